@@ -0,0 +1,258 @@
+// Package agent implements a key agent which keeps unlocked accounts in memory and serves signing
+// requests of other CLI invocations over a unix socket.
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/rpc"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	coreSignature "github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
+
+	"github.com/oasisprotocol/cli/wallet"
+)
+
+const (
+	// SocketDirName is the name of the private directory holding the agent socket in the
+	// configuration directory.
+	SocketDirName = "agent"
+	// SocketFilename is the name of the agent socket.
+	SocketFilename = "agent.sock"
+
+	// serviceName is the name of the RPC service exposed by the agent.
+	serviceName = "Agent"
+)
+
+// ErrAccountNotFound is the error returned when the agent does not hold the requested account.
+var ErrAccountNotFound = errors.New("account not held by the agent")
+
+// SocketPath returns the path of the agent socket in the given configuration directory.
+func SocketPath(dir string) string {
+	return filepath.Join(dir, SocketDirName, SocketFilename)
+}
+
+// Empty is the empty RPC request or response.
+type Empty struct{}
+
+// Status is the status of a running agent.
+type Status struct {
+	// Accounts are the sorted names of the accounts held by the agent.
+	Accounts []string
+	// ExpiresAt is the time at which the agent forgets the accounts and exits.
+	ExpiresAt time.Time
+}
+
+// AccountInfo is the public information about an account held by the agent.
+type AccountInfo struct {
+	// SignatureAddressSpec is the CBOR-encoded signature address specification of the account.
+	SignatureAddressSpec []byte
+	// EthAddress is the Ethereum address of the account, if any.
+	EthAddress []byte
+	// ConsensusPublicKey is the public key of the consensus layer signer, if any.
+	ConsensusPublicKey *coreSignature.PublicKey
+}
+
+// SignRequest is a request to sign a message with the given account.
+type SignRequest struct {
+	// Account is the name of the account.
+	Account string
+	// Context is the raw domain separation context.
+	Context []byte
+	// Message is the message to sign.
+	Message []byte
+}
+
+// Server is a key agent server.
+type Server struct {
+	mu        sync.Mutex
+	accounts  map[string]wallet.Account
+	expiresAt time.Time
+
+	listener net.Listener
+	stopOnce sync.Once
+	stopCh   chan struct{}
+}
+
+// NewServer creates a new agent holding the given accounts by name for the given duration.
+func NewServer(accounts map[string]wallet.Account, ttl time.Duration) *Server {
+	// The domain separation is entirely handled on the client side.
+	coreSignature.UnsafeAllowUnregisteredContexts()
+
+	return &Server{
+		accounts:  accounts,
+		expiresAt: time.Now().Add(ttl),
+		stopCh:    make(chan struct{}),
+	}
+}
+
+// ExpiresAt returns the time at which the agent forgets the accounts and exits.
+func (s *Server) ExpiresAt() time.Time {
+	return s.expiresAt
+}
+
+// Serve listens on the unix socket with the given path and serves requests until the agent
+// expires or is stopped.
+func (s *Server) Serve(path string) error {
+	srv := rpc.NewServer()
+	if err := srv.RegisterName(serviceName, &service{s: s}); err != nil {
+		return err
+	}
+
+	// Refuse to replace the socket of an agent which is still running.
+	if c, err := Dial(path); err == nil {
+		_ = c.Close()
+		return fmt.Errorf("agent is already running at '%s'", path)
+	}
+	_ = os.Remove(path)
+
+	// Create the socket inside a directory only accessible by the current user so that other users
+	// cannot connect to it, not even before its permissions are restricted.
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create agent directory: %w", err)
+	}
+	if err := os.Chmod(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to restrict agent directory permissions: %w", err)
+	}
+
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		return fmt.Errorf("failed to listen on '%s': %w", path, err)
+	}
+	defer os.Remove(path)
+	if err = os.Chmod(path, 0o600); err != nil {
+		_ = l.Close()
+		return err
+	}
+	s.listener = l
+
+	timer := time.AfterFunc(time.Until(s.expiresAt), s.Stop)
+	defer timer.Stop()
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			select {
+			case <-s.stopCh:
+				return nil
+			default:
+				return err
+			}
+		}
+		go srv.ServeConn(conn)
+	}
+}
+
+// Stop stops the agent and wipes the held accounts.
+func (s *Server) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopCh)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		for name, acc := range s.accounts {
+			if signer := acc.Signer(); signer != nil {
+				signer.Reset()
+			}
+			delete(s.accounts, name)
+		}
+		if s.listener != nil {
+			_ = s.listener.Close()
+		}
+	})
+}
+
+func (s *Server) account(name string) (wallet.Account, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	acc, ok := s.accounts[name]
+	if !ok {
+		return nil, ErrAccountNotFound
+	}
+	return acc, nil
+}
+
+// service is the RPC service of the agent.
+type service struct {
+	s *Server
+}
+
+// Status returns the status of the agent.
+func (svc *service) Status(_ Empty, rsp *Status) error {
+	svc.s.mu.Lock()
+	defer svc.s.mu.Unlock()
+
+	rsp.Accounts = make([]string, 0, len(svc.s.accounts))
+	for name := range svc.s.accounts {
+		rsp.Accounts = append(rsp.Accounts, name)
+	}
+	sort.Strings(rsp.Accounts)
+	rsp.ExpiresAt = svc.s.expiresAt
+	return nil
+}
+
+// Stop stops the agent.
+func (svc *service) Stop(_ Empty, _ *Empty) error {
+	// Stop asynchronously so that the response can still be sent.
+	go svc.s.Stop()
+	return nil
+}
+
+// Account returns the public information about the given account.
+func (svc *service) Account(name string, rsp *AccountInfo) error {
+	acc, err := svc.s.account(name)
+	if err != nil {
+		return err
+	}
+
+	rsp.SignatureAddressSpec = cbor.Marshal(acc.SignatureAddressSpec())
+	if ethAddr := acc.EthAddress(); ethAddr != nil {
+		rsp.EthAddress = ethAddr.Bytes()
+	}
+	if signer := acc.ConsensusSigner(); signer != nil {
+		pk := signer.Public()
+		rsp.ConsensusPublicKey = &pk
+	}
+	return nil
+}
+
+// Sign signs the message with the ParaTime signer of the given account.
+func (svc *service) Sign(req SignRequest, rsp *[]byte) error {
+	acc, err := svc.s.account(req.Account)
+	if err != nil {
+		return err
+	}
+
+	signer := acc.Signer()
+	if signer == nil {
+		return fmt.Errorf("account not compatible with ParaTime signing")
+	}
+
+	*rsp, err = signer.ContextSign(signature.RawContext(req.Context), req.Message)
+	return err
+}
+
+// ConsensusSign signs the message with the consensus layer signer of the given account.
+func (svc *service) ConsensusSign(req SignRequest, rsp *[]byte) error {
+	acc, err := svc.s.account(req.Account)
+	if err != nil {
+		return err
+	}
+	signer := acc.ConsensusSigner()
+	if signer == nil {
+		return fmt.Errorf("account not compatible with consensus layer usage")
+	}
+
+	*rsp, err = signer.ContextSign(coreSignature.Context(req.Context), req.Message)
+	return err
+}
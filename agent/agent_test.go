@@ -0,0 +1,114 @@
+package agent
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	coreSignature "github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
+	sdkTesting "github.com/oasisprotocol/oasis-sdk/client-sdk/go/testing"
+
+	"github.com/oasisprotocol/cli/wallet"
+	"github.com/oasisprotocol/cli/wallet/multisig"
+	"github.com/oasisprotocol/cli/wallet/test"
+)
+
+var testContext = coreSignature.NewContext("oasis-cli/agent: test")
+
+func TestAgent(t *testing.T) {
+	require := require.New(t)
+
+	alice, err := test.NewTestAccount(sdkTesting.Alice)
+	require.NoError(err)
+	dave, err := test.NewTestAccount(sdkTesting.Dave)
+	require.NoError(err)
+
+	path := SocketPath(t.TempDir())
+	srv := NewServer(map[string]wallet.Account{"alice": alice, "dave": dave}, time.Minute)
+	errCh := make(chan error)
+	go func() { errCh <- srv.Serve(path) }()
+	require.Eventually(func() bool {
+		c, err := Dial(path)
+		if err == nil {
+			c.Close()
+		}
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	c, err := Dial(path)
+	require.NoError(err)
+	defer c.Close()
+
+	status, err := c.Status()
+	require.NoError(err)
+	require.Equal([]string{"alice", "dave"}, status.Accounts)
+
+	// The socket is only accessible by the current user.
+	fi, err := os.Stat(filepath.Dir(path))
+	require.NoError(err)
+	require.Equal(os.FileMode(0o700), fi.Mode().Perm())
+
+	_, err = c.Account("bob")
+	require.ErrorIs(err, ErrAccountNotFound)
+
+	// ParaTime signing. Accounts remain usable after the client is closed.
+	c2, err := Dial(path)
+	require.NoError(err)
+	acc, err := c2.Account("dave")
+	require.NoError(err)
+	require.NoError(c2.Close())
+	require.Equal(dave.Address(), acc.Address())
+	require.Equal(dave.EthAddress(), acc.EthAddress())
+	require.Nil(acc.ConsensusSigner())
+
+	ctx := signature.RawContext("test context")
+	sig, err := acc.Signer().ContextSign(ctx, []byte("message"))
+	require.NoError(err)
+	require.True(dave.Signer().Public().Verify(ctx, []byte("message"), sig))
+
+	// Consensus layer signing.
+	acc, err = c.Account("alice")
+	require.NoError(err)
+	require.Equal(alice.Address(), acc.Address())
+	require.NotNil(acc.ConsensusSigner())
+
+	sig, err = acc.ConsensusSigner().ContextSign(testContext, []byte("message"))
+	require.NoError(err)
+	require.True(alice.ConsensusSigner().Public().Verify(testContext, []byte("message"), sig))
+
+	// Stopping the agent wipes the accounts.
+	require.NoError(c.Stop())
+	require.NoError(<-errCh)
+	_, err = Dial(path)
+	require.Error(err)
+}
+
+func TestAgentMultisig(t *testing.T) {
+	require := require.New(t)
+
+	af, err := wallet.Load(multisig.Kind)
+	require.NoError(err)
+	ms, err := af.Load("ms", "", map[string]interface{}{
+		"threshold": 1,
+		"signers": []map[string]interface{}{
+			{"public_key": "ed25519:" + sdkTesting.Alice.Signer.Public().String(), "weight": 1},
+		},
+	})
+	require.NoError(err)
+
+	srv := NewServer(map[string]wallet.Account{"ms": ms}, time.Minute)
+	svc := &service{s: srv}
+
+	// Multisig accounts have no signer, so signing must fail instead of panicking.
+	var sig []byte
+	err = svc.Sign(SignRequest{Account: "ms", Message: []byte("message")}, &sig)
+	require.ErrorContains(err, "not compatible with ParaTime signing")
+	err = svc.ConsensusSign(SignRequest{Account: "ms", Message: []byte("message")}, &sig)
+	require.ErrorContains(err, "not compatible with consensus layer usage")
+
+	require.NotPanics(srv.Stop)
+}
@@ -0,0 +1,191 @@
+package agent
+
+import (
+	"fmt"
+	"net"
+	"net/rpc"
+	"time"
+
+	ethCommon "github.com/ethereum/go-ethereum/common"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	coreSignature "github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+
+	"github.com/oasisprotocol/cli/wallet"
+)
+
+// dialTimeout is the timeout for connecting to the agent.
+const dialTimeout = time.Second
+
+// Client is a key agent client.
+type Client struct {
+	path string
+	rpc  *rpc.Client
+}
+
+// Dial connects to the agent listening on the unix socket with the given path.
+func Dial(path string) (*Client, error) {
+	conn, err := net.DialTimeout("unix", path, dialTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{path: path, rpc: rpc.NewClient(conn)}, nil
+}
+
+// Close closes the connection to the agent.
+func (c *Client) Close() error {
+	return c.rpc.Close()
+}
+
+// Status returns the status of the agent.
+func (c *Client) Status() (*Status, error) {
+	var rsp Status
+	if err := c.rpc.Call(serviceName+".Status", Empty{}, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+// Stop stops the agent.
+func (c *Client) Stop() error {
+	return c.rpc.Call(serviceName+".Stop", Empty{}, &Empty{})
+}
+
+// Account returns the given account held by the agent. Signing requests of the returned account
+// are forwarded to the agent over a new connection, so the client may be closed afterwards.
+func (c *Client) Account(name string) (wallet.Account, error) {
+	var info AccountInfo
+	if err := c.rpc.Call(serviceName+".Account", name, &info); err != nil {
+		if err.Error() == ErrAccountNotFound.Error() {
+			return nil, ErrAccountNotFound
+		}
+		return nil, err
+	}
+
+	acc := &agentAccount{
+		path: c.path,
+		name: name,
+	}
+	if err := cbor.Unmarshal(info.SignatureAddressSpec, &acc.spec); err != nil {
+		return nil, fmt.Errorf("malformed signature address specification: %w", err)
+	}
+	if acc.spec.PublicKey() == nil {
+		return nil, fmt.Errorf("unsupported signature address specification")
+	}
+	if info.EthAddress != nil {
+		ethAddr := ethCommon.BytesToAddress(info.EthAddress)
+		acc.ethAddress = &ethAddr
+	}
+	if info.ConsensusPublicKey != nil {
+		acc.consensusSigner = &agentCoreSigner{account: acc, pk: *info.ConsensusPublicKey}
+	}
+	return acc, nil
+}
+
+func (c *Client) sign(method, account string, context, message []byte) ([]byte, error) {
+	var sig []byte
+	req := SignRequest{
+		Account: account,
+		Context: context,
+		Message: message,
+	}
+	if err := c.rpc.Call(serviceName+"."+method, req, &sig); err != nil {
+		return nil, fmt.Errorf("agent: %w", err)
+	}
+	return sig, nil
+}
+
+// agentAccount is an account held by the agent.
+type agentAccount struct {
+	path string
+	name string
+
+	spec            types.SignatureAddressSpec
+	ethAddress      *ethCommon.Address
+	consensusSigner coreSignature.Signer
+}
+
+// sign forwards the given signing request to the agent using a new connection.
+func (a *agentAccount) sign(method string, context, message []byte) ([]byte, error) {
+	c, err := Dial(a.path)
+	if err != nil {
+		return nil, fmt.Errorf("agent: %w", err)
+	}
+	defer c.Close()
+
+	return c.sign(method, a.name, context, message)
+}
+
+func (a *agentAccount) ConsensusSigner() coreSignature.Signer {
+	return a.consensusSigner
+}
+
+func (a *agentAccount) Signer() signature.Signer {
+	return &agentSigner{account: a}
+}
+
+func (a *agentAccount) Address() types.Address {
+	return types.NewAddress(a.spec)
+}
+
+func (a *agentAccount) EthAddress() *ethCommon.Address {
+	return a.ethAddress
+}
+
+func (a *agentAccount) SignatureAddressSpec() types.SignatureAddressSpec {
+	return a.spec
+}
+
+func (a *agentAccount) UnsafeExport() (string, string) {
+	// Secret key material never leaves the agent.
+	return "", ""
+}
+
+// agentSigner is a ParaTime signer forwarding signing requests to the agent.
+type agentSigner struct {
+	account *agentAccount
+}
+
+func (s *agentSigner) Public() signature.PublicKey {
+	return s.account.spec.PublicKey()
+}
+
+func (s *agentSigner) ContextSign(context signature.Context, message []byte) ([]byte, error) {
+	return s.account.sign("Sign", context.Derive(), message)
+}
+
+func (s *agentSigner) Sign(_ []byte) ([]byte, error) {
+	return nil, fmt.Errorf("agent: signing without context not supported")
+}
+
+func (s *agentSigner) String() string {
+	return fmt.Sprintf("[agent runtime signer: %s]", s.Public())
+}
+
+func (s *agentSigner) Reset() {}
+
+// agentCoreSigner is a consensus layer signer forwarding signing requests to the agent.
+type agentCoreSigner struct {
+	account *agentAccount
+	pk      coreSignature.PublicKey
+}
+
+func (s *agentCoreSigner) Public() coreSignature.PublicKey {
+	return s.pk
+}
+
+func (s *agentCoreSigner) ContextSign(context coreSignature.Context, message []byte) ([]byte, error) {
+	rawContext, err := coreSignature.PrepareSignerContext(context)
+	if err != nil {
+		return nil, fmt.Errorf("agent: failed to prepare signing context: %w", err)
+	}
+	return s.account.sign("ConsensusSign", rawContext, message)
+}
+
+func (s *agentCoreSigner) String() string {
+	return fmt.Sprintf("[agent consensus signer: %s]", s.pk)
+}
+
+func (s *agentCoreSigner) Reset() {}
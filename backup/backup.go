@@ -0,0 +1,350 @@
+// Package backup implements passphrase-encrypted backups of the CLI configuration and wallet.
+package backup
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/spf13/viper"
+	"golang.org/x/crypto/argon2"
+
+	"github.com/oasisprotocol/deoxysii"
+
+	"github.com/oasisprotocol/cli/config"
+	"github.com/oasisprotocol/cli/wallet/file"
+)
+
+const (
+	// Format is the format identifier of backup files.
+	Format = "oasis-cli-backup"
+	// Version is the version of the backup format.
+	Version = 1
+
+	// ConfigFilename is the name under which the configuration file is stored in the backup.
+	ConfigFilename = "cli.toml"
+
+	keySize   = 32
+	saltSize  = 32
+	nonceSize = 32
+
+	// maxKDFTime is the maximum accepted number of argon2 passes.
+	maxKDFTime = 16
+	// maxKDFMemory is the maximum accepted argon2 memory size in KiB (1 GiB).
+	maxKDFMemory = 1024 * 1024
+)
+
+// Options are the backup creation options.
+type Options struct {
+	// ExcludePrivateKeys omits the key files of file-based accounts. Such accounts are converted
+	// into address book entries so that their addresses are kept.
+	ExcludePrivateKeys bool
+}
+
+// Manifest describes the content of a backup.
+type Manifest struct {
+	// Version is the version of the backup format.
+	Version int `json:"version"`
+	// CLIVersion is the version of the CLI that created the backup.
+	CLIVersion string `json:"cli_version"`
+	// ConfigVersion is the migration version of the backed up configuration.
+	ConfigVersion int `json:"config_version"`
+	// CreatedAt is the time the backup was created.
+	CreatedAt time.Time `json:"created_at"`
+	// PrivateKeys is true iff the backup contains the key files of file-based accounts.
+	PrivateKeys bool `json:"private_keys"`
+	// Checksums are the hex-encoded SHA-256 checksums of the files by file name.
+	Checksums map[string]string `json:"checksums"`
+}
+
+// Archive is the decrypted content of a backup.
+type Archive struct {
+	// Manifest describes the content of the backup.
+	Manifest Manifest `json:"manifest"`
+	// Files are the contents of the backed up files by file name.
+	Files map[string][]byte `json:"files"`
+}
+
+// FileNames returns the sorted names of the files in the archive.
+func (a *Archive) FileNames() []string {
+	names := make([]string, 0, len(a.Files))
+	for name := range a.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Validate checks the integrity of the archive and whether it can be restored by this version of
+// the CLI.
+func (a *Archive) Validate() error {
+	if a.Manifest.Version != Version {
+		return fmt.Errorf("unsupported backup version %d (expected %d)", a.Manifest.Version, Version)
+	}
+	if a.Manifest.ConfigVersion > config.LatestMigrationVersion {
+		return fmt.Errorf("backup was created by a newer CLI version (%s), upgrade the CLI to restore it", a.Manifest.CLIVersion)
+	}
+	if _, ok := a.Files[ConfigFilename]; !ok {
+		return fmt.Errorf("backup does not contain the configuration file")
+	}
+	if len(a.Files) != len(a.Manifest.Checksums) {
+		return fmt.Errorf("backup manifest does not match the backed up files")
+	}
+	for name, data := range a.Files {
+		if name == "" || filepath.Base(name) != name {
+			return fmt.Errorf("malformed file name '%s'", name)
+		}
+		if checksum(data) != a.Manifest.Checksums[name] {
+			return fmt.Errorf("checksum mismatch for '%s'", name)
+		}
+	}
+	return nil
+}
+
+// addFile adds a file to the archive.
+func (a *Archive) addFile(name string, data []byte) {
+	a.Files[name] = data
+	a.Manifest.Checksums[name] = checksum(data)
+}
+
+func checksum(data []byte) string {
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:])
+}
+
+// Create creates a backup archive of the given configuration and the wallet files in its
+// configuration directory.
+func Create(cfg *config.Config, cliVersion string, opts *Options) (*Archive, error) {
+	a := &Archive{
+		Manifest: Manifest{
+			Version:       Version,
+			CLIVersion:    cliVersion,
+			ConfigVersion: cfg.LastMigration,
+			CreatedAt:     time.Now().UTC(),
+			PrivateKeys:   !opts.ExcludePrivateKeys,
+			Checksums:     make(map[string]string),
+		},
+		Files: make(map[string][]byte),
+	}
+
+	rawCfg, err := os.ReadFile(cfg.File())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration file: %w", err)
+	}
+	if opts.ExcludePrivateKeys {
+		if rawCfg, err = stripFileAccounts(rawCfg); err != nil {
+			return nil, fmt.Errorf("failed to remove file-based accounts from configuration: %w", err)
+		}
+	}
+	a.addFile(ConfigFilename, rawCfg)
+
+	if !opts.ExcludePrivateKeys {
+		for name, acc := range cfg.Wallet.All {
+			if acc.Kind != file.Kind {
+				continue
+			}
+			fn := name + ".wallet"
+			data, err := os.ReadFile(filepath.Join(cfg.Directory(), fn))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read key file of account '%s': %w", name, err)
+			}
+			a.addFile(fn, data)
+		}
+	}
+
+	switch data, err := os.ReadFile(filepath.Join(cfg.Directory(), config.UsageFilename)); {
+	case err == nil:
+		a.addFile(config.UsageFilename, data)
+	case errors.Is(err, fs.ErrNotExist):
+	default:
+		return nil, fmt.Errorf("failed to read usage statistics: %w", err)
+	}
+
+	return a, nil
+}
+
+// stripFileAccounts converts file-based accounts in the given raw configuration into address book
+// entries and returns the updated raw configuration.
+func stripFileAccounts(rawCfg []byte) ([]byte, error) {
+	// Use a private temporary directory as the configuration is written back through viper.
+	dir, err := os.MkdirTemp("", "oasis-cli-backup")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	fn := filepath.Join(dir, ConfigFilename)
+
+	v := viper.New()
+	v.SetConfigFile(fn)
+	if err := v.ReadConfig(bytes.NewReader(rawCfg)); err != nil {
+		return nil, err
+	}
+
+	var cfg config.Config
+	if err := cfg.Load(v); err != nil {
+		return nil, err
+	}
+	for name, acc := range cfg.Wallet.All {
+		if acc.Kind != file.Kind {
+			continue
+		}
+
+		if cfg.AddressBook.All == nil {
+			cfg.AddressBook.All = make(map[string]*config.AddressBookEntry)
+		}
+		cfg.AddressBook.All[name] = &config.AddressBookEntry{
+			Description: acc.Description,
+			Address:     acc.Address,
+		}
+		delete(cfg.Wallet.All, name)
+		if cfg.Wallet.Default == name {
+			cfg.Wallet.Default = ""
+		}
+		cfg.DefaultAccounts.RemoveAccount(name)
+	}
+	if err := cfg.Save(); err != nil {
+		return nil, err
+	}
+	return os.ReadFile(fn)
+}
+
+// Restore writes the files of the archive into the directory of the given configuration,
+// replacing the configuration file.
+func Restore(a *Archive, cfg *config.Config) error {
+	if err := a.Validate(); err != nil {
+		return err
+	}
+
+	dir := cfg.Directory()
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	for _, name := range a.FileNames() {
+		fn := filepath.Join(dir, name)
+		if name == ConfigFilename {
+			fn = cfg.File()
+		}
+		if err := os.WriteFile(fn, a.Files[name], 0o600); err != nil {
+			return fmt.Errorf("failed to restore '%s': %w", name, err)
+		}
+	}
+	return nil
+}
+
+// envelope is the encrypted backup file.
+type envelope struct {
+	Format  string    `json:"format"`
+	Version int       `json:"version"`
+	KDF     kdfArgon2 `json:"kdf"`
+	Nonce   []byte    `json:"nonce"`
+	Data    []byte    `json:"data"`
+}
+
+type kdfArgon2 struct {
+	Salt    []byte `json:"salt"`
+	Time    uint32 `json:"time"`
+	Memory  uint32 `json:"memory"`
+	Threads uint8  `json:"threads"`
+}
+
+// validate checks that the KDF parameters are sane so that a malformed or malicious backup file
+// cannot crash the CLI or exhaust the available memory.
+func (k *kdfArgon2) validate() error {
+	switch {
+	case len(k.Salt) < saltSize:
+		return fmt.Errorf("malformed backup KDF salt")
+	case k.Time == 0 || k.Time > maxKDFTime:
+		return fmt.Errorf("malformed backup KDF time parameter %d", k.Time)
+	case k.Threads == 0:
+		return fmt.Errorf("malformed backup KDF threads parameter %d", k.Threads)
+	case k.Memory < 8*uint32(k.Threads) || k.Memory > maxKDFMemory:
+		return fmt.Errorf("malformed backup KDF memory parameter %d", k.Memory)
+	}
+	return nil
+}
+
+func (k *kdfArgon2) deriveKey(passphrase string) []byte {
+	return argon2.IDKey([]byte(passphrase), k.Salt, k.Time, k.Memory, k.Threads, keySize)
+}
+
+// additionalData returns the authenticated header of the envelope.
+func (e *envelope) additionalData() []byte {
+	return []byte(fmt.Sprintf("%s/%d", e.Format, e.Version))
+}
+
+// Seal encrypts the archive with the given passphrase.
+func Seal(a *Archive, passphrase string) ([]byte, error) {
+	e := envelope{
+		Format:  Format,
+		Version: Version,
+		KDF: kdfArgon2{
+			Salt:    make([]byte, saltSize),
+			Time:    1,
+			Memory:  64 * 1024,
+			Threads: 4,
+		},
+		Nonce: make([]byte, nonceSize),
+	}
+	if _, err := rand.Read(e.KDF.Salt); err != nil {
+		return nil, err
+	}
+	if _, err := rand.Read(e.Nonce); err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := deoxysii.New(e.KDF.deriveKey(passphrase))
+	if err != nil {
+		return nil, err
+	}
+	e.Data = aead.Seal(nil, e.Nonce[:aead.NonceSize()], data, e.additionalData())
+
+	return json.MarshalIndent(&e, "", "  ")
+}
+
+// Open decrypts the backup with the given passphrase and validates its content.
+func Open(data []byte, passphrase string) (*Archive, error) {
+	var e envelope
+	if err := json.Unmarshal(data, &e); err != nil || e.Format != Format {
+		return nil, fmt.Errorf("not an Oasis CLI backup file")
+	}
+	if e.Version != Version {
+		return nil, fmt.Errorf("unsupported backup version %d (expected %d)", e.Version, Version)
+	}
+	if err := e.KDF.validate(); err != nil {
+		return nil, err
+	}
+
+	aead, err := deoxysii.New(e.KDF.deriveKey(passphrase))
+	if err != nil {
+		return nil, err
+	}
+	if len(e.Nonce) < aead.NonceSize() {
+		return nil, fmt.Errorf("malformed backup nonce")
+	}
+	pt, err := aead.Open(nil, e.Nonce[:aead.NonceSize()], e.Data, e.additionalData())
+	if err != nil {
+		return nil, fmt.Errorf("wrong passphrase or corrupted backup")
+	}
+
+	var a Archive
+	if err = json.Unmarshal(pt, &a); err != nil {
+		return nil, fmt.Errorf("malformed backup content: %w", err)
+	}
+	if err = a.Validate(); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
@@ -0,0 +1,148 @@
+package backup
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/cli/config"
+)
+
+const testConfig = `
+last_migration = 2
+
+[wallets]
+default = 'alice'
+
+[wallets.alice]
+address = 'oasis1qp87hflmelnpqhzcqcw8rhzakq4elj7jzv090p3e'
+description = 'Primary'
+kind = 'file'
+
+[wallets.alice.config]
+algorithm = 'ed25519-adr8'
+number = 0
+
+[address_book.bob]
+address = 'oasis1qrvzxld9rz83wv92lvnkpmr30c77kj2tvg0pednz'
+description = ''
+`
+
+func loadTestConfig(t *testing.T, dir string) *config.Config {
+	fn := filepath.Join(dir, ConfigFilename)
+	require.NoError(t, os.WriteFile(fn, []byte(testConfig), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "alice.wallet"), []byte("secret"), 0o600))
+
+	v := viper.New()
+	v.SetConfigFile(fn)
+	require.NoError(t, v.ReadInConfig())
+
+	var cfg config.Config
+	require.NoError(t, cfg.Load(v))
+	return &cfg
+}
+
+func TestBackupRoundTrip(t *testing.T) {
+	require := require.New(t)
+
+	cfg := loadTestConfig(t, t.TempDir())
+	a, err := Create(cfg, "1.0.0", &Options{})
+	require.NoError(err)
+	require.Equal([]string{"alice.wallet", ConfigFilename}, a.FileNames())
+	require.True(a.Manifest.PrivateKeys)
+
+	data, err := Seal(a, "passphrase")
+	require.NoError(err)
+	require.NotContains(string(data), "secret")
+
+	_, err = Open(data, "wrong")
+	require.ErrorContains(err, "wrong passphrase")
+	_, err = Open([]byte("{}"), "passphrase")
+	require.ErrorContains(err, "not an Oasis CLI backup file")
+
+	restored, err := Open(data, "passphrase")
+	require.NoError(err)
+	require.Equal(a.Files, restored.Files)
+
+	// Restore into a fresh directory.
+	dstCfg := loadTestConfig(t, t.TempDir())
+	require.NoError(os.WriteFile(filepath.Join(dstCfg.Directory(), "alice.wallet"), []byte("other"), 0o600))
+	require.NoError(Restore(restored, dstCfg))
+	wallet, err := os.ReadFile(filepath.Join(dstCfg.Directory(), "alice.wallet"))
+	require.NoError(err)
+	require.Equal("secret", string(wallet))
+}
+
+func TestBackupValidate(t *testing.T) {
+	require := require.New(t)
+
+	cfg := loadTestConfig(t, t.TempDir())
+	a, err := Create(cfg, "1.0.0", &Options{})
+	require.NoError(err)
+	require.NoError(a.Validate())
+
+	a.Files["alice.wallet"] = []byte("tampered")
+	require.ErrorContains(a.Validate(), "checksum mismatch")
+	a.Files["alice.wallet"] = []byte("secret")
+
+	a.Manifest.ConfigVersion = config.LatestMigrationVersion + 1
+	require.ErrorContains(a.Validate(), "newer CLI version")
+	a.Manifest.ConfigVersion = config.LatestMigrationVersion
+
+	a.addFile("../evil", []byte{})
+	require.ErrorContains(a.Validate(), "malformed file name")
+}
+
+func TestBackupMalformedHeader(t *testing.T) {
+	require := require.New(t)
+
+	cfg := loadTestConfig(t, t.TempDir())
+	a, err := Create(cfg, "1.0.0", &Options{})
+	require.NoError(err)
+	data, err := Seal(a, "passphrase")
+	require.NoError(err)
+
+	for _, tc := range []struct {
+		name   string
+		modify func(e *envelope)
+		err    string
+	}{
+		{"ZeroTime", func(e *envelope) { e.KDF.Time = 0 }, "time parameter"},
+		{"HugeTime", func(e *envelope) { e.KDF.Time = 1 << 20 }, "time parameter"},
+		{"ZeroThreads", func(e *envelope) { e.KDF.Threads = 0 }, "threads parameter"},
+		{"ZeroMemory", func(e *envelope) { e.KDF.Memory = 0 }, "memory parameter"},
+		{"HugeMemory", func(e *envelope) { e.KDF.Memory = 1 << 31 }, "memory parameter"},
+		{"ShortSalt", func(e *envelope) { e.KDF.Salt = e.KDF.Salt[:1] }, "salt"},
+		{"ShortNonce", func(e *envelope) { e.Nonce = e.Nonce[:1] }, "nonce"},
+	} {
+		t.Run(tc.name, func(_ *testing.T) {
+			var e envelope
+			require.NoError(json.Unmarshal(data, &e))
+			tc.modify(&e)
+			malformed, err := json.Marshal(&e)
+			require.NoError(err)
+
+			_, err = Open(malformed, "passphrase")
+			require.ErrorContains(err, tc.err)
+		})
+	}
+}
+
+func TestBackupExcludePrivateKeys(t *testing.T) {
+	require := require.New(t)
+
+	cfg := loadTestConfig(t, t.TempDir())
+	a, err := Create(cfg, "1.0.0", &Options{ExcludePrivateKeys: true})
+	require.NoError(err)
+	require.Equal([]string{ConfigFilename}, a.FileNames())
+	require.False(a.Manifest.PrivateKeys)
+
+	rawCfg := string(a.Files[ConfigFilename])
+	require.NotContains(rawCfg, "[wallets.alice]")
+	require.Contains(rawCfg, "[address_book.alice]")
+	require.Contains(rawCfg, "oasis1qp87hflmelnpqhzcqcw8rhzakq4elj7jzv090p3e")
+}
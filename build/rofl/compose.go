@@ -0,0 +1,193 @@
+package rofl
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// composeVarRefRe matches variable references in compose files (`${NAME}`, `${NAME:-default}`,
+// `$NAME`). Escaped references (`$$NAME`) are handled separately.
+var composeVarRefRe = regexp.MustCompile(`\$(?:\{([A-Za-z_][A-Za-z0-9_]*)((?::?[-?+])[^}]*)?\}|([A-Za-z_][A-Za-z0-9_]*))`)
+
+// ComposeVarRef is a variable reference found in a compose file.
+type ComposeVarRef struct {
+	// Name is the name of the referenced variable.
+	Name string
+	// HasDefault is true iff the reference specifies a default value.
+	HasDefault bool
+}
+
+// ComposeService describes the configuration of a compose service relevant to ROFL apps.
+type ComposeService struct {
+	// Name is the service name.
+	Name string
+	// Image is the image reference of the service or empty if the service has no image.
+	Image string
+	// Environment are the names of the environment variables passed to the service.
+	Environment []string
+	// Refs are the variable references used in the service definition.
+	Refs []ComposeVarRef
+}
+
+// LoadComposeServices parses the given compose file and returns the configuration of all
+// services, sorted by service name.
+func LoadComposeServices(fn string) ([]*ComposeService, error) {
+	data, err := os.ReadFile(fn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read compose file: %w", err)
+	}
+	return ParseComposeServices(data)
+}
+
+// ParseComposeServices parses the given compose file content and returns the configuration of all
+// services, sorted by service name.
+func ParseComposeServices(data []byte) ([]*ComposeService, error) {
+	var project struct {
+		Services map[string]map[string]interface{} `yaml:"services"`
+	}
+	if err := yaml.Unmarshal(data, &project); err != nil {
+		return nil, fmt.Errorf("malformed compose file: %w", err)
+	}
+
+	services := make([]*ComposeService, 0, len(project.Services))
+	for name, def := range project.Services {
+		svc := &ComposeService{Name: name}
+		svc.Image, _ = def["image"].(string)
+
+		switch env := def["environment"].(type) {
+		case []interface{}:
+			for _, e := range env {
+				key, _, _ := strings.Cut(fmt.Sprintf("%v", e), "=")
+				svc.Environment = append(svc.Environment, key)
+			}
+		case map[string]interface{}:
+			for key := range env {
+				svc.Environment = append(svc.Environment, key)
+			}
+		}
+		sort.Strings(svc.Environment)
+
+		// A variable only has a default when all of its references specify one.
+		hasDefault := make(map[string]bool)
+		walkComposeStrings(def, func(s string) {
+			for _, ref := range findComposeVarRefs(s) {
+				prev, seen := hasDefault[ref.Name]
+				hasDefault[ref.Name] = ref.HasDefault && (!seen || prev)
+			}
+		})
+		for ref, withDefault := range hasDefault {
+			svc.Refs = append(svc.Refs, ComposeVarRef{Name: ref, HasDefault: withDefault})
+		}
+		sort.Slice(svc.Refs, func(i, j int) bool {
+			return svc.Refs[i].Name < svc.Refs[j].Name
+		})
+
+		services = append(services, svc)
+	}
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].Name < services[j].Name
+	})
+	return services, nil
+}
+
+// ComposeImages returns the unique image references of the given compose services, sorted.
+func ComposeImages(services []*ComposeService) []string {
+	seen := make(map[string]struct{})
+	var images []string
+	for _, svc := range services {
+		if svc.Image == "" {
+			continue
+		}
+		if _, ok := seen[svc.Image]; ok {
+			continue
+		}
+		seen[svc.Image] = struct{}{}
+		images = append(images, svc.Image)
+	}
+	sort.Strings(images)
+	return images
+}
+
+// walkComposeStrings calls fn for every string value (and map key) in the given value.
+func walkComposeStrings(v interface{}, fn func(string)) {
+	switch vv := v.(type) {
+	case string:
+		fn(vv)
+	case []interface{}:
+		for _, e := range vv {
+			walkComposeStrings(e, fn)
+		}
+	case map[string]interface{}:
+		for k, e := range vv {
+			fn(k)
+			walkComposeStrings(e, fn)
+		}
+	}
+}
+
+// findComposeVarRefs returns all variable references in the given string.
+func findComposeVarRefs(s string) []ComposeVarRef {
+	// Drop escaped dollar signs.
+	s = strings.ReplaceAll(s, "$$", "")
+
+	var refs []ComposeVarRef
+	for _, m := range composeVarRefRe.FindAllStringSubmatch(s, -1) {
+		switch {
+		case m[1] != "":
+			hasDefault := strings.HasPrefix(m[2], "-") || strings.HasPrefix(m[2], ":-")
+			refs = append(refs, ComposeVarRef{Name: m[1], HasDefault: hasDefault})
+		case m[3] != "":
+			refs = append(refs, ComposeVarRef{Name: m[3]})
+		}
+	}
+	return refs
+}
+
+// SecretsCheckResult is the result of cross-referencing secrets with a compose file.
+type SecretsCheckResult struct {
+	// Unused are the names of the secrets which are never referenced.
+	Unused []string
+	// Undefined are the names of the referenced variables without a default value that are not
+	// defined as secrets.
+	Undefined []string
+}
+
+// CheckComposeSecrets cross-references the given secrets with the variable references in the
+// given compose services.
+func CheckComposeSecrets(secrets []*SecretConfig, services []*ComposeService) *SecretsCheckResult {
+	defined := make(map[string]bool)
+	for _, sc := range secrets {
+		defined[sc.Name] = false
+	}
+
+	undefined := make(map[string]struct{})
+	for _, svc := range services {
+		for _, ref := range svc.Refs {
+			if _, ok := defined[ref.Name]; ok {
+				defined[ref.Name] = true
+				continue
+			}
+			if !ref.HasDefault {
+				undefined[ref.Name] = struct{}{}
+			}
+		}
+	}
+
+	var result SecretsCheckResult
+	for name, used := range defined {
+		if !used {
+			result.Unused = append(result.Unused, name)
+		}
+	}
+	for name := range undefined {
+		result.Undefined = append(result.Undefined, name)
+	}
+	sort.Strings(result.Unused)
+	sort.Strings(result.Undefined)
+	return &result
+}
@@ -0,0 +1,61 @@
+package rofl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testComposeFile = `
+services:
+  oracle:
+    image: docker.io/example/oracle@sha256:0000
+    environment:
+      - API_KEY=${API_KEY}
+      - LOG_LEVEL=${LOG_LEVEL:-info}
+      - STATIC=value
+    command: ["--token", "$TOKEN", "--price", "$$5"]
+  web:
+    image: docker.io/example/web
+    environment:
+      DB_PASSWORD: ${DB_PASSWORD:?missing}
+`
+
+func TestParseComposeServices(t *testing.T) {
+	require := require.New(t)
+
+	services, err := ParseComposeServices([]byte(testComposeFile))
+	require.NoError(err)
+	require.Len(services, 2)
+
+	require.Equal("oracle", services[0].Name)
+	require.Equal([]string{"API_KEY", "LOG_LEVEL", "STATIC"}, services[0].Environment)
+	require.Equal([]ComposeVarRef{
+		{Name: "API_KEY"},
+		{Name: "LOG_LEVEL", HasDefault: true},
+		{Name: "TOKEN"},
+	}, services[0].Refs)
+
+	require.Equal("web", services[1].Name)
+	require.Equal([]string{"DB_PASSWORD"}, services[1].Environment)
+	require.Equal([]ComposeVarRef{{Name: "DB_PASSWORD"}}, services[1].Refs)
+
+	_, err = ParseComposeServices([]byte("services: ["))
+	require.ErrorContains(err, "malformed compose file")
+}
+
+func TestCheckComposeSecrets(t *testing.T) {
+	require := require.New(t)
+
+	services, err := ParseComposeServices([]byte(testComposeFile))
+	require.NoError(err)
+
+	secrets := []*SecretConfig{
+		{Name: "API_KEY", Value: "AA=="},
+		{Name: "UNUSED", Value: "AA=="},
+		{Name: "DB_PASSWORD", Value: "AA=="},
+	}
+	result := CheckComposeSecrets(secrets, services)
+	require.Equal([]string{"UNUSED"}, result.Unused)
+	require.Equal([]string{"TOKEN"}, result.Undefined)
+}
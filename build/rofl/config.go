@@ -0,0 +1,57 @@
+package rofl
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/oasisprotocol/curve25519-voi/primitives/x25519"
+)
+
+// ValidateConfig validates the plain-text configuration of a deployment. Configuration values are
+// delivered to the app as secrets, so they share the limits of the rofl module with the given
+// secrets and their keys must not collide with the secret keys.
+func ValidateConfig(cfg map[string]string, secrets []*SecretConfig) error {
+	if len(cfg) == 0 {
+		return nil
+	}
+	if len(cfg)+len(secrets) > MaxSecrets {
+		return fmt.Errorf("too many secrets and configuration values (%d, maximum is %d)", len(cfg)+len(secrets), MaxSecrets)
+	}
+	secretKeys := PrepareSecrets(secrets)
+	for key, value := range cfg {
+		if key == "" {
+			return fmt.Errorf("configuration key cannot be empty")
+		}
+		if len(key) > MaxSecretKeySize {
+			return fmt.Errorf("configuration key '%s' too large (%d bytes, maximum is %d)", key, len(key), MaxSecretKeySize)
+		}
+		if len(value) > MaxSecretValueSize {
+			return fmt.Errorf("configuration value '%s' too large (%d bytes, maximum is %d)", key, len(value), MaxSecretValueSize)
+		}
+		if _, ok := secretKeys[key]; ok {
+			return fmt.Errorf("configuration key '%s' conflicts with a secret of the same name", key)
+		}
+	}
+	return nil
+}
+
+// EncryptConfig encrypts the plain-text configuration values with the secrets encryption key (SEK)
+// of the app, so they are delivered to the app the same way as secrets. The manifest keeps the
+// values in plain text where they can be reviewed.
+func EncryptConfig(cfg map[string]string, sek x25519.PublicKey) ([]*SecretConfig, error) {
+	keys := make([]string, 0, len(cfg))
+	for key := range cfg {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	secrets := make([]*SecretConfig, 0, len(keys))
+	for _, key := range keys {
+		encValue, err := EncryptSecret(key, []byte(cfg[key]), sek)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt configuration value '%s': %w", key, err)
+		}
+		secrets = append(secrets, &SecretConfig{Name: key, Value: encValue})
+	}
+	return secrets, nil
+}
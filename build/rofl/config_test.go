@@ -0,0 +1,43 @@
+package rofl
+
+import (
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/curve25519-voi/primitives/x25519"
+)
+
+func TestValidateConfig(t *testing.T) {
+	require := require.New(t)
+
+	secrets := []*SecretConfig{{Name: "API_KEY", Value: "AA=="}}
+	require.NoError(ValidateConfig(nil, secrets))
+	require.NoError(ValidateConfig(map[string]string{"LOG_LEVEL": "debug"}, secrets))
+	require.ErrorContains(ValidateConfig(map[string]string{"": "x"}, secrets), "empty")
+	require.ErrorContains(ValidateConfig(map[string]string{"API_KEY": "x"}, secrets), "conflicts")
+
+	cfg := make(map[string]string)
+	for i := 0; i < MaxSecrets; i++ {
+		cfg[fmt.Sprintf("c%d", i)] = "x"
+	}
+	require.ErrorContains(ValidateConfig(cfg, secrets), "too many")
+}
+
+func TestEncryptConfig(t *testing.T) {
+	require := require.New(t)
+
+	sek, _, err := x25519.GenerateKey(rand.Reader)
+	require.NoError(err)
+
+	secrets, err := EncryptConfig(map[string]string{"b": "2", "a": "1"}, *sek)
+	require.NoError(err)
+	require.Len(secrets, 2)
+	require.Equal("a", secrets[0].Name)
+	require.Equal("b", secrets[1].Name)
+	for _, sc := range secrets {
+		require.NoError(sc.Validate())
+	}
+}
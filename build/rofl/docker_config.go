@@ -0,0 +1,88 @@
+package rofl
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// dockerHubAuthKey is the key of the Docker Hub credentials in the Docker config.json.
+const dockerHubAuthKey = "https://index.docker.io/v1/"
+
+// dockerConfig is the subset of the Docker config.json holding registry credentials.
+type dockerConfig struct {
+	Auths map[string]struct {
+		Auth     string `json:"auth"`
+		Username string `json:"username"`
+		Password string `json:"password"`
+	} `json:"auths"`
+	CredsStore  string            `json:"credsStore"`
+	CredHelpers map[string]string `json:"credHelpers"`
+}
+
+// DockerConfigPath returns the path of the Docker config.json honoring the DOCKER_CONFIG
+// environment variable.
+func DockerConfigPath() (string, error) {
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return filepath.Join(dir, "config.json"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".docker", "config.json"), nil
+}
+
+// DockerConfigAuth returns the credentials of the registry with the given domain stored in the
+// given Docker config.json or nil if there are none. Credentials kept by credential helpers are not
+// supported.
+func DockerConfigAuth(fn, domain string) (*RegistryAuth, error) {
+	data, err := os.ReadFile(fn)
+	switch {
+	case err == nil:
+	case errors.Is(err, os.ErrNotExist):
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("failed to read Docker config: %w", err)
+	}
+	var cfg dockerConfig
+	if err = json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("malformed Docker config '%s': %w", fn, err)
+	}
+
+	keys := []string{domain, "https://" + domain, "http://" + domain}
+	if domain == "docker.io" {
+		keys = append([]string{dockerHubAuthKey}, keys...)
+	}
+	for _, key := range keys {
+		entry, ok := cfg.Auths[key]
+		if !ok {
+			continue
+		}
+		if entry.Auth == "" {
+			if entry.Username != "" {
+				return &RegistryAuth{Username: entry.Username, Password: entry.Password}, nil
+			}
+			// Empty entries are written for registries whose credentials are kept by a helper.
+			break
+		}
+		raw, err := base64.StdEncoding.DecodeString(entry.Auth)
+		if err != nil {
+			return nil, fmt.Errorf("malformed credentials of registry '%s' in Docker config: %w", domain, err)
+		}
+		username, password, ok := strings.Cut(string(raw), ":")
+		if !ok {
+			return nil, fmt.Errorf("malformed credentials of registry '%s' in Docker config", domain)
+		}
+		return &RegistryAuth{Username: username, Password: password}, nil
+	}
+
+	if cfg.CredHelpers[domain] != "" || cfg.CredsStore != "" {
+		return nil, fmt.Errorf("credentials of registry '%s' are kept by a Docker credential helper which is not supported, use `oasis rofl registry login` instead", domain)
+	}
+	return nil, nil
+}
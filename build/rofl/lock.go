@@ -0,0 +1,189 @@
+package rofl
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/distribution/reference"
+	"gopkg.in/yaml.v3"
+)
+
+// LockFileName is the name of the file recording the pinned container image digests. It is stored
+// next to the app manifest.
+const LockFileName = "rofl.lock"
+
+// Lock records the digests which the container image references of an app resolved to, so a later
+// build can detect that a reference now resolves to a different image.
+type Lock struct {
+	// Images maps image references as used in the compose file to their digests.
+	Images map[string]string `yaml:"images"`
+
+	sourceFn string
+}
+
+// LockFileFor returns the path of the lock file belonging to the given manifest.
+func LockFileFor(m *Manifest) string {
+	return filepath.Join(filepath.Dir(m.SourceFileName()), LockFileName)
+}
+
+// LoadLock loads the lock from the given file. In case the file does not exist, an empty lock is
+// returned.
+func LoadLock(fn string) (*Lock, error) {
+	lock := Lock{
+		Images:   make(map[string]string),
+		sourceFn: fn,
+	}
+
+	data, err := os.ReadFile(fn)
+	switch {
+	case err == nil:
+	case errors.Is(err, os.ErrNotExist):
+		return &lock, nil
+	default:
+		return nil, fmt.Errorf("failed to load lock file: %w", err)
+	}
+	if err = yaml.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("malformed lock file '%s': %w", fn, err)
+	}
+	if lock.Images == nil {
+		lock.Images = make(map[string]string)
+	}
+	return &lock, nil
+}
+
+// Save writes the lock to the file it was loaded from.
+func (l *Lock) Save() error {
+	f, err := os.Create(l.sourceFn)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	enc := yaml.NewEncoder(f)
+	enc.SetIndent(2)
+	return enc.Encode(l)
+}
+
+// ImageDigestChange is a container image whose resolved digest differs from the locked one.
+type ImageDigestChange struct {
+	// Image is the image reference.
+	Image string
+	// Locked is the digest recorded in the lock.
+	Locked string
+	// Resolved is the digest the reference resolves to now.
+	Resolved string
+}
+
+// Check compares the given resolved image digests against the lock. It returns the images whose
+// digests changed and the images not yet recorded in the lock, both sorted by reference.
+func (l *Lock) Check(resolved map[string]string) ([]ImageDigestChange, []string) {
+	var (
+		changed  []ImageDigestChange
+		unlocked []string
+	)
+	for image, digest := range resolved {
+		locked, ok := l.Images[image]
+		switch {
+		case !ok:
+			unlocked = append(unlocked, image)
+		case locked != digest:
+			changed = append(changed, ImageDigestChange{Image: image, Locked: locked, Resolved: digest})
+		}
+	}
+	sort.Slice(changed, func(i, j int) bool {
+		return changed[i].Image < changed[j].Image
+	})
+	sort.Strings(unlocked)
+	return changed, unlocked
+}
+
+// PinnedImage returns the given image reference pinned to the given digest (e.g.
+// `nginx@sha256:...`). References that already include a digest are returned unchanged.
+func PinnedImage(image, digest string) (string, error) {
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return "", fmt.Errorf("malformed image reference '%s': %w", image, err)
+	}
+	if _, ok := named.(reference.Digested); ok {
+		return image, nil
+	}
+
+	pinned := reference.FamiliarName(named) + "@" + digest
+	if _, err = reference.ParseNormalizedNamed(pinned); err != nil {
+		return "", fmt.Errorf("malformed digest '%s' of image '%s': %w", digest, image, err)
+	}
+	return pinned, nil
+}
+
+// PinComposeImages rewrites the image references of all services in the given compose file
+// content to include the given digests, keyed by the original image references. Images without a
+// digest are left unchanged. In case no image needs to be rewritten, the content is returned as is.
+func PinComposeImages(data []byte, digests map[string]string) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("malformed compose file: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return data, nil
+	}
+
+	var changed bool
+	services := yamlMappingValue(doc.Content[0], "services")
+	if services == nil || services.Kind != yaml.MappingNode {
+		return data, nil
+	}
+	for i := 1; i < len(services.Content); i += 2 {
+		image := yamlMappingValue(services.Content[i], "image")
+		if image == nil || image.Kind != yaml.ScalarNode {
+			continue
+		}
+		digest, ok := digests[image.Value]
+		if !ok {
+			continue
+		}
+		pinned, err := PinnedImage(image.Value, digest)
+		if err != nil {
+			return nil, err
+		}
+		if pinned != image.Value {
+			image.Value = pinned
+			changed = true
+		}
+	}
+	if !changed {
+		return data, nil
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to encode compose file: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// yamlMappingValue returns the value of the given key in the given YAML mapping node or nil if
+// there is no such key.
+func yamlMappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// IsImagePinned returns true iff the given image reference includes a digest and is therefore
+// immutable.
+func IsImagePinned(image string) bool {
+	return strings.Contains(image, "@sha256:")
+}
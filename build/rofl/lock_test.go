@@ -0,0 +1,100 @@
+package rofl
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testImagesComposeFile = `services:
+  web:
+    image: docker.io/library/nginx:1.27
+    ports:
+      - "80:80"
+  worker:
+    image: ghcr.io/oasisprotocol/demo@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef
+  other:
+    image: docker.io/library/nginx:1.27
+  local:
+    build: .
+`
+
+func TestComposeImages(t *testing.T) {
+	require := require.New(t)
+
+	services, err := ParseComposeServices([]byte(testImagesComposeFile))
+	require.NoError(err)
+	images := ComposeImages(services)
+	require.Equal([]string{
+		"docker.io/library/nginx:1.27",
+		"ghcr.io/oasisprotocol/demo@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+	}, images)
+	require.False(IsImagePinned(images[0]))
+	require.True(IsImagePinned(images[1]))
+}
+
+func TestPinComposeImages(t *testing.T) {
+	require := require.New(t)
+
+	const (
+		nginxDigest = "sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+		demoDigest  = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	)
+
+	pinned, err := PinComposeImages([]byte(testImagesComposeFile), map[string]string{
+		"docker.io/library/nginx:1.27": nginxDigest,
+		"ghcr.io/oasisprotocol/demo@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef": demoDigest,
+	})
+	require.NoError(err)
+
+	services, err := ParseComposeServices(pinned)
+	require.NoError(err)
+	require.Len(services, 4)
+	images := make(map[string]string)
+	for _, svc := range services {
+		images[svc.Name] = svc.Image
+	}
+	require.Equal(map[string]string{
+		"local":  "",
+		"other":  "nginx@" + nginxDigest,
+		"web":    "nginx@" + nginxDigest,
+		"worker": "ghcr.io/oasisprotocol/demo@" + demoDigest,
+	}, images)
+	require.Contains(string(pinned), `"80:80"`, "other configuration must be preserved")
+
+	// Without digests the compose file is left unchanged.
+	unchanged, err := PinComposeImages([]byte(testImagesComposeFile), nil)
+	require.NoError(err)
+	require.Equal(testImagesComposeFile, string(unchanged))
+
+	_, err = PinComposeImages([]byte(testImagesComposeFile), map[string]string{"docker.io/library/nginx:1.27": "bad"})
+	require.Error(err)
+	_, err = PinComposeImages([]byte("services: ["), nil)
+	require.Error(err)
+}
+
+func TestLock(t *testing.T) {
+	require := require.New(t)
+
+	fn := filepath.Join(t.TempDir(), LockFileName)
+	lock, err := LoadLock(fn)
+	require.NoError(err)
+	require.Empty(lock.Images)
+
+	lock.Images["nginx:1.27"] = "sha256:aaaa"
+	lock.Images["redis:7"] = "sha256:bbbb"
+	require.NoError(lock.Save())
+
+	lock, err = LoadLock(fn)
+	require.NoError(err)
+	require.Equal(map[string]string{"nginx:1.27": "sha256:aaaa", "redis:7": "sha256:bbbb"}, lock.Images)
+
+	changed, unlocked := lock.Check(map[string]string{
+		"nginx:1.27":  "sha256:aaaa",
+		"redis:7":     "sha256:cccc",
+		"postgres:16": "sha256:dddd",
+	})
+	require.Equal([]ImageDigestChange{{Image: "redis:7", Locked: "sha256:bbbb", Resolved: "sha256:cccc"}}, changed)
+	require.Equal([]string{"postgres:16"}, unlocked)
+}
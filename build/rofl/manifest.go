@@ -53,6 +53,9 @@ type Manifest struct {
 	Resources ResourcesConfig `yaml:"resources" json:"resources"`
 	// Artifacts are the optional artifact location overrides.
 	Artifacts *ArtifactsConfig `yaml:"artifacts,omitempty" json:"artifacts,omitempty"`
+	// KernelOptions are the extra kernel command line options (TDX only). Note that they are part
+	// of the measurement, so changing them changes the enclave identity.
+	KernelOptions []string `yaml:"kernel_options,omitempty" json:"kernel_options,omitempty"`
 
 	// Deployments are the ROFL app deployments.
 	Deployments map[string]*Deployment `yaml:"deployments" json:"deployments"`
@@ -82,31 +85,60 @@ func ManifestExists() bool {
 // LoadManifest attempts to find and load the ROFL app manifest from a local file.
 func LoadManifest() (*Manifest, error) {
 	for _, fn := range ManifestFileNames {
-		f, err := os.Open(fn)
+		m, err := loadManifestFile(fn, false)
 		switch {
 		case err == nil:
+			return m, nil
 		case errors.Is(err, os.ErrNotExist):
 			continue
 		default:
-			return nil, fmt.Errorf("failed to load manifest from '%s': %w", fn, err)
+			return nil, err
 		}
+	}
+	return nil, fmt.Errorf("no ROFL app manifest found (tried: %s)", strings.Join(ManifestFileNames, ", "))
+}
 
-		var m Manifest
-		dec := yaml.NewDecoder(f)
-		if err = dec.Decode(&m); err != nil {
-			f.Close()
-			return nil, fmt.Errorf("malformed manifest '%s': %w", fn, err)
-		}
-		if err = m.Validate(); err != nil {
-			f.Close()
-			return nil, fmt.Errorf("invalid manifest '%s': %w", fn, err)
+// ValidateManifestFile loads the ROFL app manifest from the given file and performs all of the
+// validation that does not require network access. Contrary to LoadManifest, unknown fields are
+// rejected.
+func ValidateManifestFile(fn string) (*Manifest, error) {
+	m, err := loadManifestFile(fn, true)
+	if err != nil {
+		return nil, err
+	}
+	for name, d := range m.Deployments {
+		if err = ValidateSecretLimits(d.Secrets); err != nil {
+			return nil, fmt.Errorf("invalid manifest '%s': bad deployment '%s': %w", fn, name, err)
 		}
-		m.sourceFn, _ = filepath.Abs(f.Name()) // Record source filename.
+	}
+	return m, nil
+}
 
-		f.Close()
-		return &m, nil
+// loadManifestFile loads and validates the ROFL app manifest from the given file. In strict mode,
+// unknown fields are rejected.
+func loadManifestFile(fn string, strict bool) (*Manifest, error) {
+	f, err := os.Open(fn)
+	switch {
+	case err == nil:
+	case errors.Is(err, os.ErrNotExist):
+		return nil, err
+	default:
+		return nil, fmt.Errorf("failed to load manifest from '%s': %w", fn, err)
 	}
-	return nil, fmt.Errorf("no ROFL app manifest found (tried: %s)", strings.Join(ManifestFileNames, ", "))
+	defer f.Close()
+
+	var m Manifest
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(strict)
+	if err = dec.Decode(&m); err != nil {
+		return nil, fmt.Errorf("malformed manifest '%s': %w", fn, err)
+	}
+	if err = m.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid manifest '%s': %w", fn, err)
+	}
+	m.sourceFn, _ = filepath.Abs(f.Name()) // Record source filename.
+
+	return &m, nil
 }
 
 // Validate validates the manifest for correctness.
@@ -142,6 +174,15 @@ func (m *Manifest) Validate() error {
 		return fmt.Errorf("bad resources config: %w", err)
 	}
 
+	if len(m.KernelOptions) > 0 && m.TEE != TEETypeTDX {
+		return fmt.Errorf("kernel options are only supported under TDX")
+	}
+	for _, opt := range m.KernelOptions {
+		if err := ValidateKernelOption(opt); err != nil {
+			return fmt.Errorf("bad kernel option '%s': %w", opt, err)
+		}
+	}
+
 	for name, d := range m.Deployments {
 		if d == nil {
 			return fmt.Errorf("bad deployment: %s", name)
@@ -157,6 +198,53 @@ func (m *Manifest) Validate() error {
 	return nil
 }
 
+var (
+	// reservedKernelOptionKeys are the kernel options which are configured by the build system or
+	// which would weaken the isolation of the app.
+	reservedKernelOptionKeys = map[string]bool{
+		"console":            true,
+		"debug":              true,
+		"init":               true,
+		"lockdown":           true,
+		"mitigations":        true,
+		"module.sig_enforce": true,
+		"nokaslr":            true,
+		"rdinit":             true,
+		"root":               true,
+	}
+	// reservedKernelOptionPrefixes are the kernel option key prefixes used by the build system.
+	reservedKernelOptionPrefixes = []string{"oasis.", "ROFL_"}
+)
+
+// ValidateKernelOption validates a single extra kernel command line option. Options must have the
+// form key or key=value without whitespace or quotes and must not override the options configured
+// by the build system.
+func ValidateKernelOption(opt string) error {
+	key, value, _ := strings.Cut(opt, "=")
+	if key == "" {
+		return fmt.Errorf("option key cannot be empty")
+	}
+	for _, c := range key {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '_', c == '-', c == '.':
+		default:
+			return fmt.Errorf("invalid character '%c' in option key", c)
+		}
+	}
+	if strings.ContainsAny(value, " \t\n\"'") {
+		return fmt.Errorf("option value cannot contain whitespace or quotes")
+	}
+	if reservedKernelOptionKeys[key] {
+		return fmt.Errorf("option is reserved")
+	}
+	for _, prefix := range reservedKernelOptionPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return fmt.Errorf("option is reserved")
+		}
+	}
+	return nil
+}
+
 // SourceFileName returns the filename of the manifest file from which the manifest was loaded or
 // an empty string in case the filename is not available.
 func (m *Manifest) SourceFileName() string {
@@ -207,6 +295,9 @@ type Deployment struct {
 	Metadata map[string]string `yaml:"metadata,omitempty" json:"metadata,omitempty"`
 	// Secrets contains encrypted secrets.
 	Secrets []*SecretConfig `yaml:"secrets,omitempty" json:"secrets,omitempty"`
+	// Config contains non-sensitive plain-text configuration delivered to the app alongside the
+	// secrets.
+	Config map[string]string `yaml:"config,omitempty" json:"config,omitempty"`
 }
 
 // Validate validates the manifest for correctness.
@@ -223,11 +314,19 @@ func (d *Deployment) Validate() error {
 	if d.ParaTime == "" {
 		return fmt.Errorf("paratime cannot be empty")
 	}
+	for key := range d.Metadata {
+		if err := ValidateMetadataKey(key); err != nil {
+			return fmt.Errorf("bad metadata: %w", err)
+		}
+	}
 	for _, s := range d.Secrets {
 		if err := s.Validate(); err != nil {
 			return fmt.Errorf("bad secret: %w", err)
 		}
 	}
+	if err := ValidateConfig(d.Config, d.Secrets); err != nil {
+		return fmt.Errorf("bad config: %w", err)
+	}
 	return nil
 }
 
@@ -236,6 +335,48 @@ func (d *Deployment) HasAppID() bool {
 	return len(d.AppID) > 0
 }
 
+// MetadataReservedPrefix is the metadata key prefix reserved for well-known keys.
+const MetadataReservedPrefix = "net.oasis.rofl."
+
+// Well-known metadata keys.
+const (
+	MetadataKeyName        = MetadataReservedPrefix + "name"
+	MetadataKeyVersion     = MetadataReservedPrefix + "version"
+	MetadataKeyDescription = MetadataReservedPrefix + "description"
+	MetadataKeyAuthor      = MetadataReservedPrefix + "author"
+	MetadataKeyLicense     = MetadataReservedPrefix + "license"
+	MetadataKeyHomepage    = MetadataReservedPrefix + "homepage"
+	MetadataKeyRepository  = MetadataReservedPrefix + "repository"
+)
+
+// WellKnownMetadataKeys are the metadata keys that may be used in the reserved namespace.
+var WellKnownMetadataKeys = []string{
+	MetadataKeyName,
+	MetadataKeyVersion,
+	MetadataKeyDescription,
+	MetadataKeyAuthor,
+	MetadataKeyLicense,
+	MetadataKeyHomepage,
+	MetadataKeyRepository,
+}
+
+// ValidateMetadataKey validates the given metadata key. Keys in the reserved namespace must be one
+// of the well-known keys.
+func ValidateMetadataKey(key string) error {
+	if key == "" {
+		return fmt.Errorf("key cannot be empty")
+	}
+	if !strings.HasPrefix(key, MetadataReservedPrefix) {
+		return nil
+	}
+	for _, wk := range WellKnownMetadataKeys {
+		if key == wk {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown key '%s' in reserved namespace '%s*'", key, MetadataReservedPrefix)
+}
+
 // TrustRootConfig is the trust root configuration.
 type TrustRootConfig struct {
 	// Height is the consensus layer block height where to take the trust root.
@@ -83,6 +83,21 @@ func TestManifestValidation(t *testing.T) {
 	err = m.Validate()
 	require.NoError(err)
 
+	// Unknown key in the reserved metadata namespace.
+	m.Deployments["default"].Metadata = map[string]string{
+		"net.oasis.rofl.licence": "Apache-2.0",
+	}
+	err = m.Validate()
+	require.ErrorContains(err, "bad deployment 'default': bad metadata: unknown key 'net.oasis.rofl.licence'")
+
+	m.Deployments["default"].Metadata = map[string]string{
+		"net.oasis.rofl.license": "Apache-2.0",
+		"custom.key":             "value",
+	}
+	err = m.Validate()
+	require.NoError(err)
+	m.Deployments["default"].Metadata = nil
+
 	// Add ephemeral storage configuration.
 	m.Resources.Storage = &StorageConfig{}
 	err = m.Validate()
@@ -95,6 +110,50 @@ func TestManifestValidation(t *testing.T) {
 	m.Resources.Storage.Size = 16
 	err = m.Validate()
 	require.NoError(err)
+
+	// Custom kernel options.
+	m.KernelOptions = []string{"foo=bar", "quiet"}
+	err = m.Validate()
+	require.NoError(err)
+
+	m.KernelOptions = []string{"console=ttyS1"}
+	err = m.Validate()
+	require.ErrorContains(err, "bad kernel option 'console=ttyS1': option is reserved")
+
+	m.TEE = "sgx"
+	m.Kind = "raw"
+	m.KernelOptions = []string{"foo=bar"}
+	err = m.Validate()
+	require.ErrorContains(err, "kernel options are only supported under TDX")
+}
+
+func TestValidateKernelOption(t *testing.T) {
+	require := require.New(t)
+
+	for _, opt := range []string{
+		"quiet",
+		"foo=bar",
+		"net.ifnames=0",
+		"transparent_hugepage=never",
+	} {
+		require.NoError(ValidateKernelOption(opt), opt)
+	}
+
+	for _, opt := range []string{
+		"",
+		"=bar",
+		"a b",
+		"foo=a b",
+		"foo=\"bar\"",
+		"console=ttyS1",
+		"init=/bin/sh",
+		"oasis.stage2.roothash=00",
+		"ROFL_FOO=1",
+		"nokaslr",
+		"debug",
+	} {
+		require.Error(ValidateKernelOption(opt), opt)
+	}
 }
 
 const serializedYamlManifest = `
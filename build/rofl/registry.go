@@ -0,0 +1,250 @@
+package rofl
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/distribution/reference"
+)
+
+// manifestMediaTypes are the accepted media types of image manifests and indices.
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// registryHost returns the host of the registry API for the given reference domain.
+func registryHost(domain string) string {
+	if domain == "docker.io" {
+		return "registry-1.docker.io"
+	}
+	return domain
+}
+
+// registryScheme returns the URL scheme used to access the given registry host. Local registries
+// are accessed over plain HTTP.
+func registryScheme(host string) string {
+	hostname, _, _ := strings.Cut(host, ":")
+	switch hostname {
+	case "localhost", "127.0.0.1":
+		return "http"
+	default:
+		return "https"
+	}
+}
+
+// RegistryAuth are the credentials and custom headers used to access a registry.
+type RegistryAuth struct {
+	// Username is the username used for basic authentication.
+	Username string
+	// Password is the password used for basic authentication.
+	Password string
+	// Token is the bearer token used for authentication.
+	Token string
+	// Headers are custom HTTP headers sent with each registry request.
+	Headers map[string]string
+}
+
+// hasBasic returns true iff basic authentication credentials are configured.
+func (a *RegistryAuth) hasBasic() bool {
+	return a != nil && a.Username != ""
+}
+
+// apply sets the custom headers and the configured credentials on the given registry request.
+func (a *RegistryAuth) apply(req *http.Request) {
+	if a == nil {
+		return
+	}
+	for name, value := range a.Headers {
+		req.Header.Set(name, value)
+	}
+	switch {
+	case a.Token != "":
+		req.Header.Set("Authorization", "Bearer "+a.Token)
+	case a.hasBasic():
+		req.SetBasicAuth(a.Username, a.Password)
+	}
+}
+
+// RegistryAuthFunc returns the credentials and custom headers of the registry with the given
+// domain (e.g. docker.io or ghcr.io) or nil to access the registry anonymously.
+type RegistryAuthFunc func(domain string) *RegistryAuth
+
+// ResolveImageDigest resolves the given container image reference to the digest of the image
+// manifest (or index) by querying the registry. The registry is accessed with the credentials
+// returned by auth or anonymously if there are none. References that already include a digest are
+// returned unchanged.
+func ResolveImageDigest(ctx context.Context, image string, auth RegistryAuthFunc) (string, error) {
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return "", fmt.Errorf("malformed image reference '%s': %w", image, err)
+	}
+	if digested, ok := named.(reference.Digested); ok {
+		return digested.Digest().String(), nil
+	}
+	tagged, _ := reference.TagNameOnly(named).(reference.Tagged)
+
+	var regAuth *RegistryAuth
+	if auth != nil {
+		regAuth = auth(reference.Domain(named))
+	}
+
+	host := registryHost(reference.Domain(named))
+	manifestURL := fmt.Sprintf("%s://%s/v2/%s/manifests/%s", registryScheme(host), host, reference.Path(named), tagged.Tag())
+
+	rsp, err := fetchManifest(ctx, manifestURL, regAuth, "")
+	if err != nil {
+		return "", err
+	}
+	if rsp.StatusCode == http.StatusUnauthorized {
+		// Obtain a token (using basic authentication credentials if any) and retry.
+		rsp.Body.Close()
+		token, err := fetchRegistryToken(ctx, rsp.Header.Get("WWW-Authenticate"), regAuth)
+		if err != nil {
+			return "", fmt.Errorf("failed to authenticate to registry '%s': %w", host, err)
+		}
+		if rsp, err = fetchManifest(ctx, manifestURL, regAuth, token); err != nil {
+			return "", err
+		}
+	}
+	defer rsp.Body.Close()
+	if rsp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to resolve image '%s': %s", image, rsp.Status)
+	}
+
+	if digest := rsp.Header.Get("Docker-Content-Digest"); digest != "" {
+		return digest, nil
+	}
+	// Not all registries return the digest header, compute the digest from the manifest.
+	data, err := io.ReadAll(rsp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read manifest of image '%s': %w", image, err)
+	}
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// fetchManifest requests the image manifest at the given URL, optionally with a bearer token
+// which takes precedence over the configured credentials.
+func fetchManifest(ctx context.Context, manifestURL string, auth *RegistryAuth, token string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, manifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	auth.apply(req)
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rsp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query registry: %w", err)
+	}
+	return rsp, nil
+}
+
+// fetchRegistryToken obtains a bearer token as requested by the given WWW-Authenticate challenge.
+// The token is requested with the basic authentication credentials if configured and anonymously
+// otherwise.
+func fetchRegistryToken(ctx context.Context, challenge string, auth *RegistryAuth) (string, error) {
+	params, ok := parseBearerChallenge(challenge)
+	if !ok || params["realm"] == "" {
+		return "", fmt.Errorf("unsupported authentication challenge '%s'", challenge)
+	}
+
+	tokenURL, err := url.Parse(params["realm"])
+	if err != nil {
+		return "", fmt.Errorf("malformed authentication realm: %w", err)
+	}
+	query := tokenURL.Query()
+	for _, key := range []string{"service", "scope"} {
+		if v := params[key]; v != "" {
+			query.Set(key, v)
+		}
+	}
+	tokenURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tokenURL.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if auth.hasBasic() {
+		req.SetBasicAuth(auth.Username, auth.Password)
+	}
+	rsp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer rsp.Body.Close()
+	if rsp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token request failed: %s", rsp.Status)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err = json.NewDecoder(rsp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("malformed token response: %w", err)
+	}
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	return body.AccessToken, nil
+}
+
+// parseBearerChallenge parses the parameters of a Bearer WWW-Authenticate challenge.
+func parseBearerChallenge(challenge string) (map[string]string, bool) {
+	scheme, rest, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return nil, false
+	}
+
+	params := make(map[string]string)
+	for rest = strings.TrimSpace(rest); rest != ""; {
+		var key string
+		key, rest, _ = strings.Cut(rest, "=")
+		key = strings.ToLower(strings.TrimSpace(key))
+
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			var ok bool
+			value, rest, ok = strings.Cut(rest[1:], `"`)
+			if !ok {
+				return nil, false
+			}
+			rest = strings.TrimPrefix(strings.TrimSpace(rest), ",")
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+		params[key] = value
+		rest = strings.TrimSpace(rest)
+	}
+	return params, true
+}
+
+// ResolveImageDigests resolves the digests of all given image references using the given registry
+// credentials. Images which could not be resolved are returned together with the resolution
+// errors.
+func ResolveImageDigests(ctx context.Context, images []string, auth RegistryAuthFunc) (map[string]string, map[string]error) {
+	resolved := make(map[string]string)
+	failed := make(map[string]error)
+	for _, image := range images {
+		digest, err := ResolveImageDigest(ctx, image, auth)
+		if err != nil {
+			failed[image] = err
+			continue
+		}
+		resolved[image] = digest
+	}
+	return resolved, failed
+}
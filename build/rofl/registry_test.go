@@ -0,0 +1,144 @@
+package rofl
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveImageDigest(t *testing.T) {
+	require := require.New(t)
+
+	const digest = "sha256:4d8b5f3e5b7d5b0c9e8f1a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d5e6f"
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			require.Equal("repository:demo/app:pull", r.URL.Query().Get("scope"))
+			_, _ = w.Write([]byte(`{"token":"secret"}`))
+		case "/v2/demo/app/manifests/v1":
+			if r.Header.Get("Authorization") != "Bearer secret" {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="`+srv.URL+`/token",service="test",scope="repository:demo/app:pull"`)
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			require.Contains(r.Header.Get("Accept"), "application/vnd.oci.image.index.v1+json")
+			w.Header().Set("Docker-Content-Digest", digest)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	host := strings.TrimPrefix(srv.URL, "http://")
+
+	resolved, err := ResolveImageDigest(context.Background(), host+"/demo/app:v1", nil)
+	require.NoError(err)
+	require.Equal(digest, resolved)
+
+	_, err = ResolveImageDigest(context.Background(), host+"/demo/app:v2", nil)
+	require.Error(err)
+
+	resolved, err = ResolveImageDigest(context.Background(), "nginx@"+digest, nil)
+	require.NoError(err)
+	require.Equal(digest, resolved)
+
+	_, err = ResolveImageDigest(context.Background(), "Invalid Reference", nil)
+	require.Error(err)
+}
+
+func TestResolveImageDigestAuth(t *testing.T) {
+	require := require.New(t)
+
+	const digest = "sha256:4d8b5f3e5b7d5b0c9e8f1a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d5e6f"
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			if user, pass, ok := r.BasicAuth(); !ok || user != "user" || pass != "pass" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"access_token":"private"}`))
+		case "/v2/private/app/manifests/v1":
+			require.Equal("tenant", r.Header.Get("X-Tenant"))
+			switch r.Header.Get("Authorization") {
+			case "Bearer private", "Bearer static":
+				w.Header().Set("Docker-Content-Digest", digest)
+			default:
+				w.Header().Set("WWW-Authenticate", `Bearer realm="`+srv.URL+`/token",service="test"`)
+				w.WriteHeader(http.StatusUnauthorized)
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	host := strings.TrimPrefix(srv.URL, "http://")
+
+	headers := map[string]string{"X-Tenant": "tenant"}
+	authFor := func(auth *RegistryAuth) RegistryAuthFunc {
+		return func(domain string) *RegistryAuth {
+			require.Equal(host, domain)
+			return auth
+		}
+	}
+
+	resolved, err := ResolveImageDigest(context.Background(), host+"/private/app:v1", authFor(&RegistryAuth{Username: "user", Password: "pass", Headers: headers}))
+	require.NoError(err)
+	require.Equal(digest, resolved)
+
+	resolved, err = ResolveImageDigest(context.Background(), host+"/private/app:v1", authFor(&RegistryAuth{Token: "static", Headers: headers}))
+	require.NoError(err)
+	require.Equal(digest, resolved)
+
+	_, err = ResolveImageDigest(context.Background(), host+"/private/app:v1", authFor(&RegistryAuth{Username: "user", Password: "wrong", Headers: headers}))
+	require.Error(err)
+}
+
+func TestDockerConfigAuth(t *testing.T) {
+	require := require.New(t)
+
+	fn := filepath.Join(t.TempDir(), "config.json")
+	auth, err := DockerConfigAuth(fn, "ghcr.io")
+	require.NoError(err)
+	require.Nil(auth)
+
+	require.NoError(os.WriteFile(fn, []byte(`{
+  "auths": {
+    "https://index.docker.io/v1/": {"auth": "aHViOnNlY3JldA=="},
+    "ghcr.io": {"username": "gh", "password": "pat"},
+    "quay.io": {}
+  },
+  "credsStore": "desktop"
+}`), 0o600))
+
+	auth, err = DockerConfigAuth(fn, "docker.io")
+	require.NoError(err)
+	require.Equal(&RegistryAuth{Username: "hub", Password: "secret"}, auth)
+
+	auth, err = DockerConfigAuth(fn, "ghcr.io")
+	require.NoError(err)
+	require.Equal(&RegistryAuth{Username: "gh", Password: "pat"}, auth)
+
+	_, err = DockerConfigAuth(fn, "quay.io")
+	require.ErrorContains(err, "credential helper")
+}
+
+func TestParseBearerChallenge(t *testing.T) {
+	require := require.New(t)
+
+	params, ok := parseBearerChallenge(`Bearer realm="https://ghcr.io/token",service="ghcr.io",scope="repository:oasisprotocol/demo:pull"`)
+	require.True(ok)
+	require.Equal("https://ghcr.io/token", params["realm"])
+	require.Equal("ghcr.io", params["service"])
+	require.Equal("repository:oasisprotocol/demo:pull", params["scope"])
+
+	_, ok = parseBearerChallenge(`Basic realm="registry"`)
+	require.False(ok)
+}
@@ -0,0 +1,150 @@
+package rofl
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Toolchain is a language toolchain that can be detected in an application directory.
+type Toolchain struct {
+	// Name is the human readable toolchain name.
+	Name string
+	// Marker is the file whose presence identifies the toolchain.
+	Marker string
+
+	// dockerfile returns the Dockerfile used to build a container image for the given app.
+	dockerfile func(dir, appName string) string
+}
+
+// Dockerfile returns a multi-stage Dockerfile that builds a container image for the app in the
+// given directory.
+func (t *Toolchain) Dockerfile(dir, appName string) string {
+	return t.dockerfile(dir, appName)
+}
+
+var (
+	// cargoPackageNameRe matches the package name in a Cargo manifest.
+	cargoPackageNameRe = regexp.MustCompile(`(?m)^\s*name\s*=\s*"([^"]+)"`)
+	// composeServiceNameInvalidRe matches characters not allowed in compose service names.
+	composeServiceNameInvalidRe = regexp.MustCompile(`[^a-z0-9_.-]+`)
+)
+
+// Toolchains are the supported toolchains in detection order.
+var Toolchains = []*Toolchain{
+	{
+		Name:   "Node.js",
+		Marker: "package.json",
+		dockerfile: func(_, _ string) string {
+			return `FROM node:22-alpine AS build
+WORKDIR /app
+COPY package*.json ./
+RUN npm ci
+COPY . .
+RUN npm run build --if-present
+
+FROM node:22-alpine
+WORKDIR /app
+ENV NODE_ENV=production
+COPY --from=build /app ./
+CMD ["npm", "start"]
+`
+		},
+	},
+	{
+		Name:   "Python",
+		Marker: "pyproject.toml",
+		dockerfile: func(_, _ string) string {
+			return `FROM python:3.12-slim AS build
+WORKDIR /app
+COPY . .
+RUN pip install --no-cache-dir --prefix=/install .
+
+FROM python:3.12-slim
+WORKDIR /app
+COPY --from=build /install /usr/local
+COPY . .
+CMD ["python", "main.py"]
+`
+		},
+	},
+	{
+		Name:   "Go",
+		Marker: "go.mod",
+		dockerfile: func(_, _ string) string {
+			return `FROM golang:1.23 AS build
+WORKDIR /src
+COPY go.mod go.sum* ./
+RUN go mod download
+COPY . .
+RUN CGO_ENABLED=0 go build -o /out/app .
+
+FROM gcr.io/distroless/static-debian12
+COPY --from=build /out/app /app
+ENTRYPOINT ["/app"]
+`
+		},
+	},
+	{
+		Name:   "Rust",
+		Marker: "Cargo.toml",
+		dockerfile: func(dir, appName string) string {
+			binName := appName
+			if data, err := os.ReadFile(filepath.Join(dir, "Cargo.toml")); err == nil {
+				if m := cargoPackageNameRe.FindSubmatch(data); m != nil {
+					binName = string(m[1])
+				}
+			}
+			return fmt.Sprintf(`FROM rust:1.83 AS build
+WORKDIR /src
+COPY . .
+RUN cargo build --release
+
+FROM debian:bookworm-slim
+COPY --from=build /src/target/release/%s /usr/local/bin/app
+ENTRYPOINT ["/usr/local/bin/app"]
+`, binName)
+		},
+	},
+}
+
+// DetectToolchain returns the toolchain used by the application in the given directory or nil
+// in case no supported toolchain has been detected.
+func DetectToolchain(dir string) *Toolchain {
+	for _, tc := range Toolchains {
+		if _, err := os.Stat(filepath.Join(dir, tc.Marker)); err == nil {
+			return tc
+		}
+	}
+	return nil
+}
+
+// ComposeServiceName returns a valid compose service name derived from the given app name.
+func ComposeServiceName(appName string) string {
+	name := strings.ToLower(appName)
+	name = composeServiceNameInvalidRe.ReplaceAllString(name, "-")
+	name = strings.Trim(name, "-_.")
+	if name == "" {
+		return "app"
+	}
+	return name
+}
+
+// GenerateCompose returns a compose file with a single service running the given image which is
+// built from the Dockerfile in the application directory.
+func GenerateCompose(serviceName, image string) string {
+	return fmt.Sprintf(`services:
+  %s:
+    image: %s
+    build: .
+    platform: linux/amd64
+    restart: always
+`, serviceName, image)
+}
+
+// ImageBuildScript returns the script which builds the container image of the app.
+func ImageBuildScript(image string) string {
+	return fmt.Sprintf("docker build --platform linux/amd64 -t %s .", image)
+}
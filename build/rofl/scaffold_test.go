@@ -0,0 +1,43 @@
+package rofl
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectToolchain(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	require.Nil(DetectToolchain(dir))
+
+	err := os.WriteFile(filepath.Join(dir, "Cargo.toml"), []byte("[package]\nname = \"my-app\"\nversion = \"0.1.0\"\n"), 0o644)
+	require.NoError(err)
+	tc := DetectToolchain(dir)
+	require.NotNil(tc)
+	require.Equal("Rust", tc.Name)
+	require.Contains(tc.Dockerfile(dir, "other"), "/src/target/release/my-app ")
+
+	// Node.js takes precedence over Rust.
+	err = os.WriteFile(filepath.Join(dir, "package.json"), []byte("{}"), 0o644)
+	require.NoError(err)
+	tc = DetectToolchain(dir)
+	require.NotNil(tc)
+	require.Equal("Node.js", tc.Name)
+}
+
+func TestComposeServiceName(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal("my-app", ComposeServiceName("My App"))
+	require.Equal("app", ComposeServiceName("!!!"))
+	require.Equal("hello_world.v2", ComposeServiceName("hello_world.v2"))
+
+	services, err := ParseComposeServices([]byte(GenerateCompose("my-app", "my-app:latest")))
+	require.NoError(err)
+	require.Len(services, 1)
+	require.Equal("my-app", services[0].Name)
+}
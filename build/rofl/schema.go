@@ -0,0 +1,128 @@
+package rofl
+
+import (
+	"encoding"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/rofl"
+)
+
+// ManifestSchemaID is the identifier of the manifest JSON Schema.
+const ManifestSchemaID = "https://oasis.io/schemas/rofl.yaml.json"
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	manifestPkgPath     = reflect.TypeOf(Manifest{}).PkgPath()
+
+	// schemaTypeOverrides are the schemas of types which have a custom YAML representation.
+	schemaTypeOverrides = map[reflect.Type]map[string]interface{}{
+		reflect.TypeOf(rofl.FeePolicy(0)): {
+			"type": "string",
+			"enum": []string{"instance", "endorsing_node"},
+		},
+	}
+
+	// schemaFieldEnums are the allowed values of string fields, keyed by type and field name.
+	schemaFieldEnums = map[reflect.Type]map[string][]string{
+		reflect.TypeOf(Manifest{}): {
+			"TEE":  {TEETypeSGX, TEETypeTDX},
+			"Kind": {AppKindRaw, AppKindContainer},
+		},
+		reflect.TypeOf(StorageConfig{}): {
+			"Kind": {StorageKindNone, StorageKindDiskEphemeral, StorageKindDiskPersistent, StorageKindRAM},
+		},
+	}
+)
+
+// ManifestSchema returns the JSON Schema of the ROFL app manifest, generated from the manifest
+// structures. Fields of the manifest structures without omitempty are required.
+func ManifestSchema() map[string]interface{} {
+	schema := typeSchema(reflect.TypeOf(Manifest{}))
+	schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	schema["$id"] = ManifestSchemaID
+	schema["title"] = "ROFL app manifest"
+
+	// Scripts may only be defined for the well-known build stages.
+	props := schema["properties"].(map[string]interface{})
+	props["scripts"].(map[string]interface{})["propertyNames"] = map[string]interface{}{
+		"enum": []string{ScriptBuildPre, ScriptBuildPost, ScriptBundlePost},
+	}
+	return schema
+}
+
+// typeSchema returns the JSON Schema of the given type.
+func typeSchema(t reflect.Type) map[string]interface{} {
+	if s, ok := schemaTypeOverrides[t]; ok {
+		return s
+	}
+	if t.Kind() != reflect.Ptr && reflect.PtrTo(t).Implements(textUnmarshalerType) {
+		return map[string]interface{}{"type": "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return typeSchema(t.Elem())
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "minimum": 0}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// Byte slices are encoded as strings.
+			return map[string]interface{}{"type": "string"}
+		}
+		return map[string]interface{}{"type": "array", "items": typeSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": typeSchema(t.Elem())}
+	case reflect.Struct:
+		return structSchema(t)
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// structSchema returns the JSON Schema of the given struct type.
+func structSchema(t reflect.Type) map[string]interface{} {
+	props := make(map[string]interface{})
+	var required []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+
+		fs := typeSchema(f.Type)
+		if values, ok := schemaFieldEnums[t][f.Name]; ok {
+			fs = map[string]interface{}{"type": "string", "enum": values}
+		}
+		props[name] = fs
+
+		if t.PkgPath() == manifestPkgPath && !strings.Contains(opts, "omitempty") {
+			required = append(required, name)
+		}
+	}
+
+	s := map[string]interface{}{
+		"type":                 "object",
+		"properties":           props,
+		"additionalProperties": false,
+	}
+	if len(required) > 0 {
+		sort.Strings(required)
+		s["required"] = required
+	}
+	return s
+}
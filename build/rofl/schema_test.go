@@ -0,0 +1,29 @@
+package rofl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestManifestSchema(t *testing.T) {
+	require := require.New(t)
+
+	schema := ManifestSchema()
+	require.Equal(ManifestSchemaID, schema["$id"])
+	require.Equal([]string{"deployments", "kind", "name", "resources", "tee", "version"}, schema["required"])
+
+	props := schema["properties"].(map[string]interface{})
+	require.Equal([]string{TEETypeSGX, TEETypeTDX}, props["tee"].(map[string]interface{})["enum"])
+
+	deployment := props["deployments"].(map[string]interface{})["additionalProperties"].(map[string]interface{})
+	require.Equal([]string{"network", "paratime"}, deployment["required"])
+
+	// Fields of external types are never required.
+	dprops := deployment["properties"].(map[string]interface{})
+	policy := dprops["policy"].(map[string]interface{})
+	require.NotContains(policy, "required")
+	pprops := policy["properties"].(map[string]interface{})
+	require.Equal("string", pprops["fees"].(map[string]interface{})["type"])
+	require.Equal("string", pprops["enclaves"].(map[string]interface{})["items"].(map[string]interface{})["type"])
+}
@@ -11,6 +11,17 @@ import (
 	mraeDeoxysii "github.com/oasisprotocol/oasis-core/go/common/crypto/mrae/deoxysii"
 )
 
+const (
+	// MaxSecrets is the maximum number of secrets accepted by the rofl module.
+	MaxSecrets = 64
+	// MaxSecretKeySize is the maximum size of the on-chain secret key (the public name if set or
+	// the name otherwise) accepted by the rofl module.
+	MaxSecretKeySize = 1024
+	// MaxSecretValueSize is the maximum size of the encrypted secret envelope accepted by the rofl
+	// module.
+	MaxSecretValueSize = 16 * 1024
+)
+
 // SecretConfig is the configuration of a given secret.
 type SecretConfig struct {
 	// Name is the name of the secret.
@@ -39,6 +50,33 @@ func (s *SecretConfig) Validate() error {
 	return nil
 }
 
+// ValidateSecretLimits checks that the given secrets do not exceed the limits enforced by the rofl
+// module.
+func ValidateSecretLimits(cfg []*SecretConfig) error {
+	if len(cfg) > MaxSecrets {
+		return fmt.Errorf("too many secrets (%d, maximum is %d)", len(cfg), MaxSecrets)
+	}
+	for name, value := range PrepareSecrets(cfg) {
+		if len(name) > MaxSecretKeySize {
+			return fmt.Errorf("secret key '%s' too large (%d bytes, maximum is %d)", name, len(name), MaxSecretKeySize)
+		}
+		if len(value) > MaxSecretValueSize {
+			return fmt.Errorf("secret '%s' too large (%d bytes encrypted, maximum is %d)", name, len(value), MaxSecretValueSize)
+		}
+	}
+	return nil
+}
+
+// SecretsPayloadSize returns the number of bytes the given secrets contribute to the app
+// configuration update transaction.
+func SecretsPayloadSize(cfg []*SecretConfig) int {
+	var size int
+	for name, value := range PrepareSecrets(cfg) {
+		size += len(name) + len(value)
+	}
+	return size
+}
+
 // SecretEnvelope is the envelope used for storing encrypted secrets.
 type SecretEnvelope struct {
 	// Pk is the ephemeral public key used for X25519.
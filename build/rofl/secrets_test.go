@@ -0,0 +1,42 @@
+package rofl
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSecretLimits(t *testing.T) {
+	require := require.New(t)
+
+	secret := func(name string, valueSize int) *SecretConfig {
+		return &SecretConfig{
+			Name:  name,
+			Value: base64.StdEncoding.EncodeToString(make([]byte, valueSize)),
+		}
+	}
+
+	require.NoError(ValidateSecretLimits(nil))
+	require.NoError(ValidateSecretLimits([]*SecretConfig{secret("a", MaxSecretValueSize)}))
+	require.Equal(len("a")+MaxSecretValueSize, SecretsPayloadSize([]*SecretConfig{secret("a", MaxSecretValueSize)}))
+
+	err := ValidateSecretLimits([]*SecretConfig{secret("a", MaxSecretValueSize+1)})
+	require.ErrorContains(err, "too large")
+
+	err = ValidateSecretLimits([]*SecretConfig{secret(strings.Repeat("a", MaxSecretKeySize+1), 1)})
+	require.ErrorContains(err, "key")
+
+	// Public names are used as keys when set.
+	sc := secret(strings.Repeat("a", MaxSecretKeySize+1), 1)
+	sc.PublicName = "short"
+	require.NoError(ValidateSecretLimits([]*SecretConfig{sc}))
+
+	var many []*SecretConfig
+	for i := 0; i <= MaxSecrets; i++ {
+		many = append(many, secret(fmt.Sprintf("s%d", i), 1))
+	}
+	require.ErrorContains(ValidateSecretLimits(many), "too many secrets")
+}
@@ -0,0 +1,166 @@
+package rofl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	coreSignature "github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/runtime/bundle"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/ed25519"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/secp256k1"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/sr25519"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+const (
+	// BundleSignerFileName is the name of the file within a bundle identifying its signer.
+	BundleSignerFileName = "signer.json"
+	// BundleSignatureSuffix is the suffix of the detached signature file of a bundle.
+	BundleSignatureSuffix = ".sig"
+)
+
+// BundleSignatureContext is the domain separation context of bundle signatures.
+var BundleSignatureContext = signature.RawContext(coreSignature.NewContext("oasis-cli/rofl: bundle signature"))
+
+// BundleSigner identifies the signer of a bundle.
+type BundleSigner struct {
+	// PublicKey is the public key of the signer.
+	PublicKey types.PublicKey `json:"public_key"`
+}
+
+// Address returns the address of the signer.
+func (s *BundleSigner) Address() (types.Address, error) {
+	switch pk := s.PublicKey.PublicKey.(type) {
+	case ed25519.PublicKey:
+		return types.NewAddress(types.NewSignatureAddressSpecEd25519(pk)), nil
+	case *ed25519.PublicKey:
+		return types.NewAddress(types.NewSignatureAddressSpecEd25519(*pk)), nil
+	case secp256k1.PublicKey:
+		return types.NewAddress(types.NewSignatureAddressSpecSecp256k1Eth(pk)), nil
+	case *secp256k1.PublicKey:
+		return types.NewAddress(types.NewSignatureAddressSpecSecp256k1Eth(*pk)), nil
+	case sr25519.PublicKey:
+		return types.NewAddress(types.NewSignatureAddressSpecSr25519(pk)), nil
+	case *sr25519.PublicKey:
+		return types.NewAddress(types.NewSignatureAddressSpecSr25519(*pk)), nil
+	default:
+		return types.Address{}, fmt.Errorf("unsupported public key type: %T", pk)
+	}
+}
+
+// BundleSignature is a detached signature of a bundle.
+type BundleSignature struct {
+	// Signer is the signer of the bundle.
+	Signer BundleSigner `json:"signer"`
+	// ManifestHash is the hash of the signed bundle manifest which covers all files of the bundle.
+	ManifestHash hash.Hash `json:"manifest_hash"`
+	// Signature is the signature of the manifest hash.
+	Signature []byte `json:"signature"`
+}
+
+// BundleSignatureFileFor returns the path of the detached signature of the given bundle.
+func BundleSignatureFileFor(bundleFn string) string {
+	return bundleFn + BundleSignatureSuffix
+}
+
+// EmbedBundleSigner embeds the identity of the given signer into the bundle. It must be called
+// before the bundle is written and signed.
+func EmbedBundleSigner(bnd *bundle.Bundle, pk signature.PublicKey) error {
+	data, err := json.Marshal(&BundleSigner{PublicKey: types.PublicKey{PublicKey: pk}})
+	if err != nil {
+		return fmt.Errorf("failed to serialize bundle signer: %w", err)
+	}
+	return bnd.Add(BundleSignerFileName, bundle.NewBytesData(data))
+}
+
+// EmbeddedBundleSigner returns the signer identity embedded in the bundle or nil if there is none.
+func EmbeddedBundleSigner(bnd *bundle.Bundle) (*BundleSigner, error) {
+	d, ok := bnd.Data[BundleSignerFileName]
+	if !ok {
+		return nil, nil
+	}
+	data, err := bundle.ReadAllData(d)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bundle signer: %w", err)
+	}
+	var signer BundleSigner
+	if err = json.Unmarshal(data, &signer); err != nil {
+		return nil, fmt.Errorf("malformed bundle signer: %w", err)
+	}
+	return &signer, nil
+}
+
+// SignBundle signs the manifest of the given bundle which must already be written.
+func SignBundle(bnd *bundle.Bundle, signer signature.Signer) (*BundleSignature, error) {
+	manifestHash := bnd.Manifest.Hash()
+	sig, err := signer.ContextSign(BundleSignatureContext, manifestHash[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign bundle: %w", err)
+	}
+	return &BundleSignature{
+		Signer:       BundleSigner{PublicKey: types.PublicKey{PublicKey: signer.Public()}},
+		ManifestHash: manifestHash,
+		Signature:    sig,
+	}, nil
+}
+
+// Verify verifies that the signature was produced over the given bundle by the expected signer
+// whose identity must also be embedded in the bundle. As anyone can re-sign a bundle after
+// embedding their own identity, the expected signer must come from a trusted source.
+func (s *BundleSignature) Verify(bnd *bundle.Bundle, expected types.Address) error {
+	if s.Signer.PublicKey.PublicKey == nil {
+		return errors.New("signature is missing the signer")
+	}
+	signerAddr, err := s.Signer.Address()
+	if err != nil {
+		return err
+	}
+	if !signerAddr.Equal(expected) {
+		return fmt.Errorf("bundle was signed by %s instead of %s", signerAddr, expected)
+	}
+	manifestHash := bnd.Manifest.Hash()
+	if !manifestHash.Equal(&s.ManifestHash) {
+		return fmt.Errorf("signature is for a different bundle (manifest hash %s, expected %s)", s.ManifestHash, manifestHash)
+	}
+	if !s.Signer.PublicKey.Verify(BundleSignatureContext, manifestHash[:], s.Signature) {
+		return errors.New("invalid signature")
+	}
+
+	embedded, err := EmbeddedBundleSigner(bnd)
+	if err != nil {
+		return err
+	}
+	if embedded == nil {
+		return errors.New("bundle has no embedded signer")
+	}
+	if embedded.PublicKey.PublicKey == nil || !embedded.PublicKey.Equal(s.Signer.PublicKey.PublicKey) {
+		return errors.New("signer does not match the signer embedded in the bundle")
+	}
+	return nil
+}
+
+// LoadBundleSignature loads a detached bundle signature from the given file.
+func LoadBundleSignature(fn string) (*BundleSignature, error) {
+	data, err := os.ReadFile(fn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load bundle signature: %w", err)
+	}
+	var sig BundleSignature
+	if err = json.Unmarshal(data, &sig); err != nil {
+		return nil, fmt.Errorf("malformed bundle signature '%s': %w", fn, err)
+	}
+	return &sig, nil
+}
+
+// Save writes the detached bundle signature to the given file.
+func (s *BundleSignature) Save(fn string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(fn, data, 0o644) //nolint: gosec
+}
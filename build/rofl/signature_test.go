@@ -0,0 +1,79 @@
+package rofl
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/runtime/bundle"
+	"github.com/oasisprotocol/oasis-core/go/runtime/bundle/component"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/ed25519"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+func TestBundleSignature(t *testing.T) {
+	require := require.New(t)
+
+	signer := ed25519.WrapSigner(memory.NewTestSigner("rofl bundle signer"))
+	other := ed25519.WrapSigner(memory.NewTestSigner("other bundle signer"))
+
+	signerAddr := types.NewAddress(types.NewSignatureAddressSpecEd25519(signer.Public().(ed25519.PublicKey)))
+
+	writeBundle := func(fn string, app string, embedded ed25519.PublicKey) *bundle.Bundle {
+		bnd := &bundle.Bundle{
+			Manifest: &bundle.Manifest{
+				Name: "test",
+				Components: []*bundle.Component{
+					{Kind: component.ROFL, Name: "app", Executable: "app"},
+				},
+			},
+		}
+		require.NoError(bnd.Add("app", bundle.NewBytesData([]byte(app))))
+		require.NoError(EmbedBundleSigner(bnd, embedded))
+		require.NoError(bnd.Write(fn))
+
+		opened, err := bundle.Open(fn)
+		require.NoError(err)
+		t.Cleanup(func() { opened.Close() })
+		return opened
+	}
+
+	dir := t.TempDir()
+	bnd := writeBundle(filepath.Join(dir, "app.orc"), "app binary", signer.Public().(ed25519.PublicKey))
+	tampered := writeBundle(filepath.Join(dir, "tampered.orc"), "tampered app binary", signer.Public().(ed25519.PublicKey))
+
+	sig, err := SignBundle(bnd, signer)
+	require.NoError(err)
+	sigFn := BundleSignatureFileFor(filepath.Join(dir, "app.orc"))
+	require.NoError(sig.Save(sigFn))
+
+	loaded, err := LoadBundleSignature(sigFn)
+	require.NoError(err)
+	require.NoError(loaded.Verify(bnd, signerAddr))
+	addr, err := loaded.Signer.Address()
+	require.NoError(err)
+	require.Equal(signerAddr, addr)
+
+	// The signature does not cover other bundles.
+	require.ErrorContains(loaded.Verify(tampered, signerAddr), "different bundle")
+
+	// Corrupted signatures are rejected.
+	loaded.Signature[0] ^= 0xff
+	require.ErrorContains(loaded.Verify(bnd, signerAddr), "invalid signature")
+
+	// Signatures by a signer other than the embedded one are rejected.
+	otherSig, err := SignBundle(bnd, other)
+	require.NoError(err)
+	otherAddr, err := otherSig.Signer.Address()
+	require.NoError(err)
+	require.ErrorContains(otherSig.Verify(bnd, otherAddr), "does not match")
+
+	// Bundles re-signed by someone else are rejected even when they embed the new signer.
+	resigned := writeBundle(filepath.Join(dir, "resigned.orc"), "app binary", other.Public().(ed25519.PublicKey))
+	resignedSig, err := SignBundle(resigned, other)
+	require.NoError(err)
+	require.NoError(resignedSig.Verify(resigned, otherAddr))
+	require.ErrorContains(resignedSig.Verify(resigned, signerAddr), "instead of")
+}
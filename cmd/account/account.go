@@ -27,11 +27,14 @@ func init() {
 	Cmd.AddCommand(amendCommissionScheduleCmd)
 	Cmd.AddCommand(burnCmd)
 	Cmd.AddCommand(delegateCmd)
+	Cmd.AddCommand(delegateSplitCmd)
 	Cmd.AddCommand(depositCmd)
 	Cmd.AddCommand(entityCmd)
+	Cmd.AddCommand(faucetRequestCmd)
 	Cmd.AddCommand(fromPublicKeyCmd)
 	Cmd.AddCommand(nodeUnfreezeCmd)
 	Cmd.AddCommand(show.Cmd)
+	Cmd.AddCommand(show.RuntimeDelegationsCmd)
 	Cmd.AddCommand(transferCmd)
 	Cmd.AddCommand(undelegateCmd)
 	Cmd.AddCommand(withdrawCmd)
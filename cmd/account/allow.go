@@ -2,9 +2,13 @@ package account
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
 
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/connection"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/helpers"
@@ -13,58 +17,127 @@ import (
 	cliConfig "github.com/oasisprotocol/cli/config"
 )
 
-var allowCmd = &cobra.Command{
-	Use:   "allow <beneficiary> <amount>",
-	Short: "Configure beneficiary allowance",
-	Args:  cobra.ExactArgs(2),
-	Run: func(_ *cobra.Command, args []string) {
-		cfg := cliConfig.Global()
-		npa := common.GetNPASelection(cfg)
-		txCfg := common.GetTransactionConfig()
-		beneficiary, amount := args[0], args[1]
-
-		if npa.Account == nil {
-			cobra.CheckErr("no accounts configured in your wallet")
-		}
-
-		// When not in offline mode, connect to the given network endpoint.
-		ctx := context.Background()
-		var conn connection.Connection
-		if !txCfg.Offline {
-			var err error
-			conn, err = connection.Connect(ctx, npa.Network)
+var (
+	allowBump   string
+	allowRevoke bool
+
+	allowCmd = &cobra.Command{
+		Use:   "allow { <beneficiary> | --paratime <paratime> } { <amount> | --bump <amount> | --revoke }",
+		Short: "Configure beneficiary allowance",
+		Args:  cobra.RangeArgs(0, 2),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+			npa := common.GetNPASelection(cfg)
+			txCfg := common.GetTransactionConfig()
+
+			if npa.Account == nil {
+				cobra.CheckErr("no accounts configured in your wallet")
+			}
+			if allowRevoke && allowBump != "" {
+				cobra.CheckErr("--bump and --revoke are mutually exclusive")
+			}
+			if (allowRevoke || allowBump != "") && txCfg.Offline {
+				cobra.CheckErr("--bump and --revoke are not supported in offline mode")
+			}
+
+			// When the ParaTime is explicitly selected, its deposit address is the beneficiary.
+			var beneficiary, amount string
+			expectedArgs := 2
+			if allowRevoke || allowBump != "" {
+				expectedArgs--
+			}
+			if cmd.Flags().Changed("paratime") {
+				if npa.ParaTime == nil {
+					cobra.CheckErr("no ParaTime selected")
+				}
+				beneficiary = "paratime:" + npa.ParaTimeName
+				expectedArgs--
+			}
+			if len(args) != expectedArgs {
+				cobra.CheckErr(fmt.Errorf("accepts %d arg(s), received %d", expectedArgs, len(args)))
+			}
+			if beneficiary == "" {
+				beneficiary, args = args[0], args[1:]
+			}
+			if len(args) > 0 {
+				amount = args[0]
+			}
+
+			// When not in offline mode, connect to the given network endpoint.
+			ctx := context.Background()
+			var conn connection.Connection
+			if !txCfg.Offline {
+				var err error
+				conn, err = common.Connect(ctx, npa.Network)
+				cobra.CheckErr(err)
+			}
+
+			// Resolve beneficiary address.
+			benAddr, _, err := common.ResolveLocalAccountOrAddress(npa.Network, beneficiary)
 			cobra.CheckErr(err)
-		}
-
-		// Resolve beneficiary address.
-		benAddr, _, err := common.ResolveLocalAccountOrAddress(npa.Network, beneficiary)
-		cobra.CheckErr(err)
-
-		// Parse amount.
-		var negative bool
-		if amount[0] == '-' {
-			negative = true
-			amount = amount[1:]
-		}
-		amountChange, err := helpers.ParseConsensusDenomination(npa.Network, amount)
-		cobra.CheckErr(err)
-
-		// Prepare transaction.
-		tx := staking.NewAllowTx(0, nil, &staking.Allow{
-			Beneficiary:  benAddr.ConsensusAddress(),
-			Negative:     negative,
-			AmountChange: *amountChange,
-		})
-
-		acc := common.LoadAccount(cfg, npa.AccountName)
-		sigTx, err := common.SignConsensusTransaction(ctx, npa, acc, conn, tx)
-		cobra.CheckErr(err)
-
-		common.BroadcastOrExportTransaction(ctx, npa.ParaTime, conn, sigTx, nil, nil)
-	},
-}
+
+			allow := staking.Allow{
+				Beneficiary: benAddr.ConsensusAddress(),
+			}
+			switch {
+			case allowRevoke, allowBump != "":
+				current, err := conn.Consensus().Staking().Allowance(ctx, &staking.AllowanceQuery{
+					Height:      consensus.HeightLatest,
+					Owner:       npa.Account.GetAddress().ConsensusAddress(),
+					Beneficiary: allow.Beneficiary,
+				})
+				cobra.CheckErr(err)
+				fmt.Printf("Current allowance: %s\n", helpers.FormatConsensusDenomination(npa.Network, *current))
+
+				if allowRevoke {
+					if current.IsZero() {
+						fmt.Printf("No allowance to revoke.\n")
+						return
+					}
+					allow.Negative = true
+					allow.AmountChange = *current
+					fmt.Printf("New allowance:     %s\n", helpers.FormatConsensusDenomination(npa.Network, quantity.Quantity{}))
+					break
+				}
+
+				delta, err := common.ParseConsensusAmount(npa.Network, allowBump)
+				cobra.CheckErr(err)
+				allow.AmountChange = *delta
+
+				newAllowance := current.Clone()
+				err = newAllowance.Add(delta)
+				cobra.CheckErr(err)
+				fmt.Printf("New allowance:     %s\n", helpers.FormatConsensusDenomination(npa.Network, *newAllowance))
+			default:
+				// Parse amount.
+				if amount[0] == '-' {
+					allow.Negative = true
+					amount = amount[1:]
+				}
+				amountChange, err := common.ParseConsensusAmount(npa.Network, amount)
+				cobra.CheckErr(err)
+				allow.AmountChange = *amountChange
+			}
+
+			// Prepare transaction.
+			tx := staking.NewAllowTx(0, nil, &allow)
+
+			acc := common.LoadAccount(cfg, npa.AccountName)
+			sigTx, err := common.SignConsensusTransaction(ctx, npa, acc, conn, tx)
+			cobra.CheckErr(err)
+
+			common.BroadcastOrExportTransaction(ctx, npa.ParaTime, conn, sigTx, nil, nil)
+		},
+	}
+)
 
 func init() {
-	allowCmd.Flags().AddFlagSet(common.SelectorNAFlags)
+	f := flag.NewFlagSet("", flag.ContinueOnError)
+	f.StringVar(&allowBump, "bump", "", "increase the current allowance by the given amount")
+	f.BoolVar(&allowRevoke, "revoke", false, "revoke the current allowance")
+
+	allowCmd.Flags().AddFlagSet(common.SelectorFlags)
 	allowCmd.Flags().AddFlagSet(common.TxFlags)
+	allowCmd.Flags().AddFlagSet(common.AmountFlags)
+	allowCmd.Flags().AddFlagSet(f)
 }
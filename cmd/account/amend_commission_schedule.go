@@ -44,7 +44,7 @@ var (
 			)
 			if !txCfg.Offline {
 				var err error
-				conn, err = connection.Connect(ctx, npa.Network)
+				conn, err = common.Connect(ctx, npa.Network)
 				cobra.CheckErr(err)
 
 				// And also query the various dynamic values required
@@ -7,7 +7,6 @@ import (
 
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/connection"
-	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/helpers"
 
 	"github.com/oasisprotocol/cli/cmd/common"
 	cliConfig "github.com/oasisprotocol/cli/config"
@@ -32,14 +31,14 @@ var burnCmd = &cobra.Command{
 		var conn connection.Connection
 		if !txCfg.Offline {
 			var err error
-			conn, err = connection.Connect(ctx, npa.Network)
+			conn, err = common.Connect(ctx, npa.Network)
 			cobra.CheckErr(err)
 		}
 
 		acc := common.LoadAccount(cfg, npa.AccountName)
 
 		// Consensus layer transfer.
-		amount, err := helpers.ParseConsensusDenomination(npa.Network, amountStr)
+		amount, err := common.ParseConsensusAmount(npa.Network, amountStr)
 		cobra.CheckErr(err)
 
 		// Prepare transaction.
@@ -57,4 +56,5 @@ var burnCmd = &cobra.Command{
 func init() {
 	burnCmd.Flags().AddFlagSet(common.SelectorNAFlags)
 	burnCmd.Flags().AddFlagSet(common.TxFlags)
+	burnCmd.Flags().AddFlagSet(common.AmountFlags)
 }
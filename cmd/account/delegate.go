@@ -10,7 +10,6 @@ import (
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/connection"
 	sdkSignature "github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
-	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/helpers"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/consensusaccounts"
 
 	"github.com/oasisprotocol/cli/cmd/common"
@@ -36,7 +35,7 @@ var delegateCmd = &cobra.Command{
 		var conn connection.Connection
 		if !txCfg.Offline {
 			var err error
-			conn, err = connection.Connect(ctx, npa.Network)
+			conn, err = common.Connect(ctx, npa.Network)
 			cobra.CheckErr(err)
 		}
 
@@ -53,7 +52,7 @@ var delegateCmd = &cobra.Command{
 		switch npa.ParaTime {
 		case nil:
 			// Consensus layer delegation.
-			amount, err := helpers.ParseConsensusDenomination(npa.Network, amount)
+			amount, err := common.ParseConsensusAmount(npa.Network, amount)
 			cobra.CheckErr(err)
 
 			// Prepare transaction.
@@ -66,7 +65,7 @@ var delegateCmd = &cobra.Command{
 			cobra.CheckErr(err)
 		default:
 			// ParaTime delegation.
-			amountBaseUnits, err := helpers.ParseParaTimeDenomination(npa.ParaTime, amount, npa.ConsensusDenomination())
+			amountBaseUnits, err := common.ParseParaTimeAmount(npa.ParaTime, amount, npa.ConsensusDenomination())
 			cobra.CheckErr(err)
 
 			// Prepare transaction.
@@ -126,4 +125,5 @@ var delegateCmd = &cobra.Command{
 func init() {
 	delegateCmd.Flags().AddFlagSet(common.SelectorFlags)
 	delegateCmd.Flags().AddFlagSet(common.RuntimeTxFlags)
+	delegateCmd.Flags().AddFlagSet(common.AmountFlags)
 }
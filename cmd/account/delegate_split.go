@@ -0,0 +1,297 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/connection"
+	sdkSignature "github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/helpers"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/consensusaccounts"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+
+	"github.com/oasisprotocol/cli/cmd/common"
+	cliConfig "github.com/oasisprotocol/cli/config"
+	"github.com/oasisprotocol/cli/wallet"
+)
+
+// basisPointsTotal is the number of basis points in 100%.
+const basisPointsTotal = 10_000
+
+var (
+	splitTotal string
+	splitTo    []string
+
+	delegateSplitCmd = &cobra.Command{
+		Use:   "delegate-split --total <amount> --to <to>=<percent>%,...",
+		Short: "Split the given amount of tokens among several entities and delegate it",
+		Long: `Split the given total amount of tokens among several entities by the given
+percentages and delegate the resulting amounts one after another. The
+percentages must add up to 100%. Any rounding remainder is delegated to the last
+entity.`,
+		Args: cobra.NoArgs,
+		Run: func(_ *cobra.Command, _ []string) {
+			cfg := cliConfig.Global()
+			npa := common.GetNPASelection(cfg)
+			txCfg := common.GetTransactionConfig()
+
+			if npa.Account == nil {
+				cobra.CheckErr("no accounts configured in your wallet")
+			}
+
+			if splitTotal == "" {
+				cobra.CheckErr("no total amount given, use --total")
+			}
+			shares, err := parseDelegationShares(splitTo)
+			cobra.CheckErr(err)
+
+			// Split the total amount in base units of the selected layer.
+			var total types.BaseUnits
+			switch npa.ParaTime {
+			case nil:
+				amount, err := common.ParseConsensusAmount(npa.Network, splitTotal)
+				cobra.CheckErr(err)
+				total = types.NewBaseUnits(*amount, types.NativeDenomination)
+			default:
+				amount, err := common.ParseParaTimeAmount(npa.ParaTime, splitTotal, npa.ConsensusDenomination())
+				cobra.CheckErr(err)
+				total = *amount
+			}
+			amounts, err := splitDelegation(total.Amount, shares)
+			cobra.CheckErr(err)
+			formatAmount := func(amount quantity.Quantity) string {
+				if npa.ParaTime == nil {
+					return helpers.FormatConsensusDenomination(npa.Network, amount)
+				}
+				return helpers.FormatParaTimeDenomination(npa.ParaTime, types.NewBaseUnits(amount, total.Denomination))
+			}
+
+			// Resolve all destinations before signing anything.
+			addrs := make([]*types.Address, len(shares))
+			ethAddrs := make([]*ethCommon.Address, len(shares))
+			for i, s := range shares {
+				addrs[i], ethAddrs[i], err = common.ResolveLocalAccountOrAddress(npa.Network, s.to)
+				cobra.CheckErr(err)
+			}
+
+			fmt.Printf("Delegating %s to %d entities:\n", formatAmount(total.Amount), len(shares))
+			for i, s := range shares {
+				fmt.Printf("  %s (%s%%): %s\n", s.to, formatBasisPoints(s.basisPoints), formatAmount(amounts[i]))
+			}
+
+			// When not in offline mode, connect to the given network endpoint.
+			ctx := context.Background()
+			var conn connection.Connection
+			if !txCfg.Offline {
+				conn, err = common.Connect(ctx, npa.Network)
+				cobra.CheckErr(err)
+			}
+
+			acc := common.LoadAccount(cfg, npa.AccountName)
+			// Subsequent delegations use consecutive nonces, so they can all be submitted even when
+			// they are exported instead of broadcast.
+			var (
+				nonce     uint64
+				broadcast bool
+			)
+			for i, s := range shares {
+				fmt.Printf("\n=== Delegation %d/%d: %s ===\n", i+1, len(shares), s.to)
+
+				switch npa.ParaTime {
+				case nil:
+					// Consensus layer delegation.
+					tx := staking.NewAddEscrowTx(0, nil, &staking.Escrow{
+						Account: addrs[i].ConsensusAddress(),
+						Amount:  amounts[i],
+					})
+					if i > 0 {
+						tx.Nonce = nonce + uint64(i)
+					}
+					sigTx, err := common.SignConsensusTransaction(ctx, npa, acc, conn, tx)
+					cobra.CheckErr(err)
+					if i == 0 {
+						nonce = tx.Nonce
+					}
+
+					broadcast = common.BroadcastOrExportTransactionPart(ctx, nil, conn, sigTx, nil, i, len(shares))
+				default:
+					// ParaTime delegation.
+					tx := consensusaccounts.NewDelegateTx(nil, &consensusaccounts.Delegate{
+						To:     *addrs[i],
+						Amount: types.NewBaseUnits(amounts[i], total.Denomination),
+					})
+					if i > 0 {
+						appendAuth(tx, acc, nonce+uint64(i))
+					}
+					txDetails := sdkSignature.TxDetails{OrigTo: ethAddrs[i]}
+					sigTx, meta, err := common.SignParaTimeTransaction(ctx, npa, acc, conn, tx, &txDetails)
+					cobra.CheckErr(err)
+					if i == 0 {
+						nonce = tx.AuthInfo.SignerInfo[0].Nonce
+					}
+
+					var waitCh <-chan interface{}
+					if !txCfg.Offline {
+						waitCh = waitForDelegation(ctx, npa, conn, acc.Address(), tx.AuthInfo.SignerInfo[0].Nonce)
+					}
+					if broadcast = common.BroadcastOrExportTransactionPart(ctx, npa.ParaTime, conn, sigTx, meta, i, len(shares)); broadcast {
+						checkDelegationResult(waitCh)
+					}
+				}
+			}
+
+			if broadcast {
+				fmt.Printf("\nDelegated to %d entities.\n", len(shares))
+			}
+		},
+	}
+)
+
+// appendAuth appends the authentication information of the given account with the given nonce to
+// the ParaTime transaction.
+func appendAuth(tx *types.Transaction, acc wallet.Account, nonce uint64) {
+	if msAcc, isMultisig := acc.(wallet.MultisigAccount); isMultisig {
+		tx.AppendAuthMultisig(msAcc.MultisigConfig(), nonce)
+		return
+	}
+	tx.AppendAuthSignature(acc.SignatureAddressSpec(), nonce)
+}
+
+// waitForDelegation waits for the delegation event emitted by the delegation transaction of the
+// given account with the given nonce.
+func waitForDelegation(
+	ctx context.Context,
+	npa *common.NPASelection,
+	conn connection.Connection,
+	from types.Address,
+	nonce uint64,
+) <-chan interface{} {
+	decoder := conn.Runtime(npa.ParaTime).ConsensusAccounts
+	return common.WaitForEvent(ctx, npa.ParaTime, conn, decoder, func(ev client.DecodedEvent) interface{} {
+		ce, ok := ev.(*consensusaccounts.Event)
+		if !ok || ce.Delegate == nil {
+			return nil
+		}
+		if !ce.Delegate.From.Equal(from) || ce.Delegate.Nonce != nonce {
+			return nil
+		}
+		return ce.Delegate
+	})
+}
+
+// checkDelegationResult waits for the result of a ParaTime delegation.
+func checkDelegationResult(waitCh <-chan interface{}) {
+	if waitCh == nil {
+		return
+	}
+
+	fmt.Printf("Waiting for delegation result...\n")
+
+	ev := <-waitCh
+	if ev == nil {
+		cobra.CheckErr("Failed to wait for event.")
+	}
+	if we := ev.(*consensusaccounts.DelegateEvent); !we.IsSuccess() {
+		cobra.CheckErr(fmt.Errorf("delegation failed with error code %d from module %s",
+			we.Error.Code,
+			we.Error.Module,
+		))
+	}
+	fmt.Printf("Delegation succeeded.\n")
+}
+
+// delegationShare is the share of a split delegation going to a single entity.
+type delegationShare struct {
+	to          string
+	basisPoints uint64
+}
+
+// parseDelegationShares parses delegation shares in the <to>=<percent>% form. Percentages may have
+// at most two decimal places and must add up to 100%.
+func parseDelegationShares(raw []string) ([]delegationShare, error) {
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("no entities to delegate to given, use --to")
+	}
+
+	shares := make([]delegationShare, 0, len(raw))
+	var sum uint64
+	for _, r := range raw {
+		to, pct, ok := strings.Cut(r, "=")
+		if !ok || to == "" {
+			return nil, fmt.Errorf("malformed share '%s' (expected <to>=<percent>%%)", r)
+		}
+		// Only accept plain decimal numbers, big.Rat also parses fractions and exponents.
+		num := strings.TrimSuffix(strings.TrimSpace(pct), "%")
+		v, ok := new(big.Rat).SetString(num)
+		if !ok || strings.ContainsAny(num, "/eE") {
+			return nil, fmt.Errorf("malformed percentage of '%s'", to)
+		}
+		bp := v.Mul(v, big.NewRat(100, 1))
+		if !bp.IsInt() || bp.Sign() <= 0 || bp.Num().Cmp(big.NewInt(basisPointsTotal)) > 0 {
+			return nil, fmt.Errorf("percentage of '%s' must be positive with at most two decimal places", to)
+		}
+		shares = append(shares, delegationShare{to: to, basisPoints: bp.Num().Uint64()})
+		sum += bp.Num().Uint64()
+	}
+	if sum != basisPointsTotal {
+		return nil, fmt.Errorf("percentages add up to %s%% instead of 100%%", formatBasisPoints(sum))
+	}
+	return shares, nil
+}
+
+// formatBasisPoints formats the given number of basis points as a percentage without the trailing
+// zeros.
+func formatBasisPoints(bp uint64) string {
+	return strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%d.%02d", bp/100, bp%100), "0"), ".")
+}
+
+// splitDelegation splits the total amount by the given shares. The rounding remainder is added to
+// the last share.
+func splitDelegation(total quantity.Quantity, shares []delegationShare) ([]quantity.Quantity, error) {
+	amounts := make([]quantity.Quantity, len(shares))
+	remaining := total.Clone()
+	for i, s := range shares {
+		if i == len(shares)-1 {
+			amounts[i] = *remaining
+			break
+		}
+
+		amount := total.Clone()
+		if err := amount.Mul(quantity.NewFromUint64(s.basisPoints)); err != nil {
+			return nil, err
+		}
+		if err := amount.Quo(quantity.NewFromUint64(basisPointsTotal)); err != nil {
+			return nil, err
+		}
+		if err := remaining.Sub(amount); err != nil {
+			return nil, err
+		}
+		amounts[i] = *amount
+	}
+	for i, a := range amounts {
+		if a.IsZero() {
+			return nil, fmt.Errorf("share of '%s' is zero", shares[i].to)
+		}
+	}
+	return amounts, nil
+}
+
+func init() {
+	splitFlags := flag.NewFlagSet("", flag.ContinueOnError)
+	splitFlags.StringVar(&splitTotal, "total", "", "total amount of tokens to delegate")
+	splitFlags.StringSliceVar(&splitTo, "to", nil, "entities and their percentages (e.g. val1=40%,val2=60%)")
+
+	delegateSplitCmd.Flags().AddFlagSet(common.SelectorFlags)
+	delegateSplitCmd.Flags().AddFlagSet(common.RuntimeTxFlags)
+	delegateSplitCmd.Flags().AddFlagSet(common.AmountFlags)
+	delegateSplitCmd.Flags().AddFlagSet(splitFlags)
+}
@@ -0,0 +1,48 @@
+package account
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+)
+
+func TestSplitDelegation(t *testing.T) {
+	require := require.New(t)
+
+	shares, err := parseDelegationShares([]string{"val1=40%", "val2=33.33%", "val3=26.67"})
+	require.NoError(err)
+	require.Len(shares, 3)
+	require.EqualValues(4000, shares[0].basisPoints)
+	require.EqualValues(3333, shares[1].basisPoints)
+
+	amounts, err := splitDelegation(*quantity.NewFromUint64(1_000_000_001), shares)
+	require.NoError(err)
+	require.Equal("400000000", amounts[0].String())
+	require.Equal("333300000", amounts[1].String())
+	require.Equal("266700001", amounts[2].String()) // Remainder goes to the last share.
+
+	for _, raw := range [][]string{
+		nil,
+		{"val1=50%", "val2=40%"},
+		{"val1=100.001%"},
+		{"val1=-50%", "val2=150%"},
+		{"=100%"},
+		{"val1"},
+		{"val1=1/2%", "val2=99.5%"},
+		{"val1=1e30%"},
+	} {
+		_, err = parseDelegationShares(raw)
+		require.Error(err, raw)
+	}
+
+	require.Equal("40", formatBasisPoints(4000))
+	require.Equal("33.3", formatBasisPoints(3330))
+	require.Equal("0.01", formatBasisPoints(1))
+
+	shares, err = parseDelegationShares([]string{"val1=0.01%", "val2=99.99%"})
+	require.NoError(err)
+	_, err = splitDelegation(*quantity.NewFromUint64(100), shares)
+	require.Error(err)
+}
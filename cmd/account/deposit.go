@@ -3,21 +3,25 @@ package account
 import (
 	"context"
 	"fmt"
+	"os"
 
 	ethCommon "github.com/ethereum/go-ethereum/common"
 	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
 
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/connection"
 	sdkSignature "github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
-	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/helpers"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/consensusaccounts"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
 
 	"github.com/oasisprotocol/cli/cmd/common"
 	cliConfig "github.com/oasisprotocol/cli/config"
+	"github.com/oasisprotocol/cli/wallet"
 )
 
+var depositTo string
+
 var depositCmd = &cobra.Command{
 	Use:   "deposit <amount> [to]",
 	Short: "Deposit tokens into ParaTime",
@@ -31,6 +35,12 @@ var depositCmd = &cobra.Command{
 		if len(args) >= 2 {
 			to = args[1]
 		}
+		if depositTo != "" {
+			if to != "" {
+				cobra.CheckErr("destination specified both as an argument and with --to")
+			}
+			to = depositTo
+		}
 
 		if npa.Account == nil {
 			cobra.CheckErr("no accounts configured in your wallet")
@@ -44,7 +54,7 @@ var depositCmd = &cobra.Command{
 		var conn connection.Connection
 		if !txCfg.Offline {
 			var err error
-			conn, err = connection.Connect(ctx, npa.Network)
+			conn, err = common.Connect(ctx, npa.Network)
 			cobra.CheckErr(err)
 		}
 
@@ -52,18 +62,19 @@ var depositCmd = &cobra.Command{
 		var toAddr *types.Address
 		var toEthAddr *ethCommon.Address
 		if to != "" {
+			cobra.CheckErr(common.CheckEthAddressChecksum(to))
+
 			var err error
 			toAddr, toEthAddr, err = common.ResolveLocalAccountOrAddress(npa.Network, to)
 			cobra.CheckErr(err)
+			common.OfferAddressVerification(cfg, to)
 		}
-
-		// Check, if to address is known to be unspendable.
-		if toAddr != nil {
-			common.CheckForceErr(common.CheckAddressNotReserved(cfg, toAddr.String()))
+		if toEthAddr != nil && !txCfg.Offline {
+			warnIfContract(ctx, npa, conn, *toEthAddr)
 		}
 
 		// Parse amount.
-		amountBaseUnits, err := helpers.ParseParaTimeDenomination(npa.ParaTime, amount, npa.ConsensusDenomination())
+		amountBaseUnits, err := common.ParseParaTimeAmount(npa.ParaTime, amount, npa.ConsensusDenomination())
 		cobra.CheckErr(err)
 
 		// Prepare transaction.
@@ -82,42 +93,70 @@ var depositCmd = &cobra.Command{
 			return
 		}
 
-		decoder := conn.Runtime(npa.ParaTime).ConsensusAccounts
-		waitCh := common.WaitForEvent(ctx, npa.ParaTime, conn, decoder, func(ev client.DecodedEvent) interface{} {
-			ce, ok := ev.(*consensusaccounts.Event)
-			if !ok || ce.Deposit == nil {
-				return nil
-			}
-			if !ce.Deposit.From.Equal(acc.Address()) || ce.Deposit.Nonce != tx.AuthInfo.SignerInfo[0].Nonce {
-				return nil
-			}
-			return ce.Deposit
-		})
-
-		common.BroadcastTransaction(ctx, npa.ParaTime, conn, sigTx, meta, nil)
+		broadcastDeposit(ctx, npa, conn, acc, tx, sigTx, meta)
+	},
+}
 
-		fmt.Printf("Waiting for deposit result...\n")
+// warnIfContract warns when the given Ethereum address is a contract on the selected ParaTime, as
+// the deposited tokens are only accessible if the contract can handle them.
+func warnIfContract(ctx context.Context, npa *common.NPASelection, conn connection.Connection, ethAddr ethCommon.Address) {
+	// ParaTimes without the EVM module fail the query and have no contracts to warn about.
+	code, err := conn.Runtime(npa.ParaTime).Evm.Code(ctx, client.RoundLatest, ethAddr.Bytes())
+	if err != nil || len(code) == 0 {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Warning: Destination %s is a contract. Make sure it can handle the deposited tokens, otherwise they may be lost.\n", ethAddr.Hex())
+}
 
-		ev := <-waitCh
-		if ev == nil {
-			cobra.CheckErr("Failed to wait for event.")
+// broadcastDeposit broadcasts the signed deposit transaction and waits for the deposit result.
+func broadcastDeposit(
+	ctx context.Context,
+	npa *common.NPASelection,
+	conn connection.Connection,
+	acc wallet.Account,
+	tx *types.Transaction,
+	sigTx, meta interface{},
+) {
+	decoder := conn.Runtime(npa.ParaTime).ConsensusAccounts
+	waitCh := common.WaitForEvent(ctx, npa.ParaTime, conn, decoder, func(ev client.DecodedEvent) interface{} {
+		ce, ok := ev.(*consensusaccounts.Event)
+		if !ok || ce.Deposit == nil {
+			return nil
 		}
-
-		// Check for result.
-		switch we := ev.(*consensusaccounts.DepositEvent); we.IsSuccess() {
-		case true:
-			fmt.Printf("Deposit succeeded.\n")
-		case false:
-			cobra.CheckErr(fmt.Errorf("deposit failed with error code %d from module %s",
-				we.Error.Code,
-				we.Error.Module,
-			))
+		if !ce.Deposit.From.Equal(acc.Address()) || ce.Deposit.Nonce != tx.AuthInfo.SignerInfo[0].Nonce {
+			return nil
 		}
-	},
+		return ce.Deposit
+	})
+
+	common.BroadcastTransaction(ctx, npa.ParaTime, conn, sigTx, meta, nil)
+
+	fmt.Printf("Waiting for deposit result...\n")
+
+	ev := <-waitCh
+	if ev == nil {
+		cobra.CheckErr("Failed to wait for event.")
+	}
+
+	// Check for result.
+	switch we := ev.(*consensusaccounts.DepositEvent); we.IsSuccess() {
+	case true:
+		fmt.Printf("Deposit succeeded.\n")
+	case false:
+		cobra.CheckErr(fmt.Errorf("deposit failed with error code %d from module %s",
+			we.Error.Code,
+			we.Error.Module,
+		))
+	}
 }
 
 func init() {
+	depositFlags := flag.NewFlagSet("", flag.ContinueOnError)
+	depositFlags.StringVar(&depositTo, "to", "", "destination account, address book entry, Oasis or Ethereum address")
+
+	depositCmd.Flags().AddFlagSet(depositFlags)
 	depositCmd.Flags().AddFlagSet(common.SelectorFlags)
 	depositCmd.Flags().AddFlagSet(common.RuntimeTxFlags)
 	depositCmd.Flags().AddFlagSet(common.ForceFlag)
+	depositCmd.Flags().AddFlagSet(common.AmountFlags)
 }
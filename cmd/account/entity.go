@@ -90,12 +90,12 @@ var (
 			var conn connection.Connection
 			if !txCfg.Offline {
 				var err error
-				conn, err = connection.Connect(ctx, npa.Network)
+				conn, err = common.Connect(ctx, npa.Network)
 				cobra.CheckErr(err)
 			}
 
 			// Load entity descriptor.
-			rawDescriptor, err := os.ReadFile(filename)
+			rawDescriptor, err := common.ReadFile(filename)
 			cobra.CheckErr(err)
 
 			// Parse entity descriptor.
@@ -116,6 +116,7 @@ var (
 			}
 
 			// Sign entity descriptor.
+			cobra.CheckErr(common.CheckReadOnly())
 			fmt.Println("Signing the entity descriptor...")
 			fmt.Println("(In case you are using a hardware-based signer you may need to confirm on device.)")
 			sigDescriptor, err := entity.SignEntity(signer, registry.RegisterEntitySignatureContext, &descriptor)
@@ -149,7 +150,7 @@ var (
 			var conn connection.Connection
 			if !txCfg.Offline {
 				var err error
-				conn, err = connection.Connect(ctx, npa.Network)
+				conn, err = common.Connect(ctx, npa.Network)
 				cobra.CheckErr(err)
 			}
 
@@ -201,7 +202,7 @@ var (
 			}
 
 			// Open and parse the passed entity metadata file.
-			rawMetadata, err := os.ReadFile(args[0])
+			rawMetadata, err := common.ReadFile(args[0])
 			if err != nil {
 				cobra.CheckErr(fmt.Errorf("failed to read entity metadata file: %w", err))
 			}
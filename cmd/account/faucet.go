@@ -0,0 +1,195 @@
+package account
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/oasisprotocol/oasis-core/go/common/prettyprint"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/connection"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/helpers"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/consensusaccounts"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+
+	"github.com/oasisprotocol/cli/cmd/common"
+	cliConfig "github.com/oasisprotocol/cli/config"
+)
+
+const (
+	// faucetRequestTimeout is the timeout of the faucet API request.
+	faucetRequestTimeout = 30 * time.Second
+	// faucetPollInterval is the interval between balance checks while waiting for the funds.
+	faucetPollInterval = 3 * time.Second
+)
+
+var (
+	faucetURL     string
+	faucetWait    time.Duration
+	faucetDeposit bool
+
+	faucetRequestCmd = &cobra.Command{
+		Use:   "faucet-request [<address>]",
+		Short: "Request test tokens from the network faucet",
+		Long:  "Request test tokens from the network faucet for the given address (default: the selected account) and wait for them to arrive on the consensus layer.",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+			npa := common.GetNPASelection(cfg)
+
+			target := npa.AccountName
+			if len(args) > 0 {
+				target = args[0]
+			}
+			if target == "" {
+				cobra.CheckErr("no address given and no accounts configured in your wallet")
+			}
+			addr, _, err := common.ResolveLocalAccountOrAddress(npa.Network, target)
+			cobra.CheckErr(err)
+
+			if faucetDeposit {
+				if npa.ParaTime == nil {
+					cobra.CheckErr("no ParaTimes to deposit into")
+				}
+				if npa.Account == nil || !npa.Account.GetAddress().Equal(*addr) {
+					cobra.CheckErr("--deposit requires the funded address to be the selected account")
+				}
+			}
+
+			url := faucetURL
+			if url == "" {
+				url = cfg.Faucets.Lookup(npa.NetworkName, npa.Network)
+			}
+			if url == "" {
+				cobra.CheckErr(fmt.Errorf("no faucet available for network '%s', configure one in the faucets section or pass --faucet-url", npa.NetworkName))
+			}
+
+			ctx := context.Background()
+			conn, err := common.Connect(ctx, npa.Network)
+			cobra.CheckErr(err)
+
+			before := consensusBalance(ctx, conn, addr)
+
+			fmt.Printf("Requesting tokens for %s from %s...\n", addr, url)
+			cobra.CheckErr(requestFaucetFunds(ctx, url, addr))
+
+			fmt.Printf("Waiting for the tokens to arrive...\n")
+			received := waitForFaucetFunds(ctx, conn, addr, before)
+			fmt.Printf("Received %s.\n", helpers.FormatConsensusDenomination(npa.Network, *received))
+
+			if !faucetDeposit {
+				return
+			}
+
+			// Deposit the received tokens into the ParaTime. The amount is expressed in ParaTime
+			// base units which may differ from the consensus layer ones.
+			amount, err := helpers.ParseParaTimeDenomination(
+				npa.ParaTime,
+				prettyprint.QuantityFrac(*received, npa.Network.Denomination.Decimals),
+				npa.ConsensusDenomination(),
+			)
+			cobra.CheckErr(err)
+			tx := consensusaccounts.NewDepositTx(nil, &consensusaccounts.Deposit{
+				Amount: *amount,
+			})
+
+			acc := common.LoadAccount(cfg, npa.AccountName)
+			sigTx, meta, err := common.SignParaTimeTransaction(ctx, npa, acc, conn, tx, nil)
+			cobra.CheckErr(err)
+
+			if common.GetTransactionConfig().Export {
+				common.ExportTransaction(sigTx)
+				return
+			}
+
+			broadcastDeposit(ctx, npa, conn, acc, tx, sigTx, meta)
+		},
+	}
+)
+
+// faucetRequest is the body of the faucet API request.
+type faucetRequest struct {
+	Address string `json:"address"`
+}
+
+// requestFaucetFunds asks the faucet at the given URL to fund the given address.
+func requestFaucetFunds(ctx context.Context, url string, addr *types.Address) error {
+	body, err := json.Marshal(&faucetRequest{Address: addr.String()})
+	if err != nil {
+		return err
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, faucetRequestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("faucet request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("faucet request failed with status %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	}
+	return nil
+}
+
+// consensusBalance returns the general balance of the given address on the consensus layer.
+func consensusBalance(ctx context.Context, conn connection.Connection, addr *types.Address) *quantity.Quantity {
+	account, err := conn.Consensus().Staking().Account(ctx, &staking.OwnerQuery{
+		Height: consensus.HeightLatest,
+		Owner:  addr.ConsensusAddress(),
+	})
+	cobra.CheckErr(err)
+	return &account.General.Balance
+}
+
+// waitForFaucetFunds polls the consensus balance of the given address until it exceeds the given
+// balance and returns the received amount.
+func waitForFaucetFunds(ctx context.Context, conn connection.Connection, addr *types.Address, before *quantity.Quantity) *quantity.Quantity {
+	ctx, cancel := context.WithTimeout(ctx, faucetWait)
+	defer cancel()
+
+	ticker := time.NewTicker(faucetPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			cobra.CheckErr(fmt.Errorf("tokens did not arrive within %s, check the balance later with `oasis account show`", faucetWait))
+		case <-ticker.C:
+		}
+
+		balance := consensusBalance(ctx, conn, addr)
+		if balance.Cmp(before) > 0 {
+			received := balance.Clone()
+			cobra.CheckErr(received.Sub(before))
+			return received
+		}
+	}
+}
+
+func init() {
+	faucetFlags := flag.NewFlagSet("", flag.ContinueOnError)
+	faucetFlags.StringVar(&faucetURL, "faucet-url", "", "faucet API URL (default: from config or the Testnet faucet)")
+	faucetFlags.DurationVar(&faucetWait, "wait", 2*time.Minute, "how long to wait for the tokens to arrive")
+	faucetFlags.BoolVar(&faucetDeposit, "deposit", false, "deposit the received tokens into the selected ParaTime")
+
+	faucetRequestCmd.Flags().AddFlagSet(common.SelectorFlags)
+	faucetRequestCmd.Flags().AddFlagSet(common.RuntimeTxFlags)
+	faucetRequestCmd.Flags().AddFlagSet(faucetFlags)
+}
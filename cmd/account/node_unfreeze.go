@@ -32,7 +32,7 @@ var nodeUnfreezeCmd = &cobra.Command{
 		var conn connection.Connection
 		if !txCfg.Offline {
 			var err error
-			conn, err = connection.Connect(ctx, npa.Network)
+			conn, err = common.Connect(ctx, npa.Network)
 			cobra.CheckErr(err)
 		}
 
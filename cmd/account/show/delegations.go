@@ -169,7 +169,8 @@ func prettyPrintAccountBalanceAndDelegationsFrom(
 	_ = totalAmount.Add(&totalDebDelegationsAmount)
 
 	fmt.Fprintf(w, "%sTotal: ", prefix)
-	fmt.Fprintf(w, "%s\n", helpers.FormatConsensusDenomination(network, *totalAmount))
+	fmt.Fprintf(w, "%s", helpers.FormatConsensusDenomination(network, *totalAmount))
+	fmt.Fprintf(w, "%s\n", common.FiatValueSuffix(common.ConsensusFiatValue(context.Background(), network, *totalAmount)))
 	fmt.Fprintf(w, "%sAvailable: ", prefix)
 	fmt.Fprintf(w, "%s\n", helpers.FormatConsensusDenomination(network, availableAmount))
 	fmt.Fprintln(w)
@@ -255,19 +256,17 @@ func prettyPrintDelegationsTo(
 	prettyPrintDelegationDescriptions(network, delDescs, addressFieldName, prefix, w)
 }
 
-func prettyPrintParaTimeDelegations(
+// paraTimeDelegationDescriptions computes the descriptions of the given delegations and
+// undelegations made by a ParaTime account and returns them together with their total amounts.
+func paraTimeDelegationDescriptions(
 	ctx context.Context,
 	c connection.Connection,
 	height int64,
-	npa *common.NPASelection,
 	addr *types.Address,
 	rtDelegations []*consensusaccounts.ExtendedDelegationInfo,
 	rtUndelegations []*consensusaccounts.UndelegationInfo,
-	prefix string,
-	w io.Writer,
-) {
-	var totalDeg quantity.Quantity
-	delegations := make([]delegationDescription, 0, len(rtDelegations))
+) (delegations, undelegations []delegationDescription, totalDeg, totalUndeg quantity.Quantity) {
+	delegations = make([]delegationDescription, 0, len(rtDelegations))
 	for _, di := range rtDelegations {
 		// For each destination we need to fetch the pool.
 		destAccount, err := c.Consensus().Staking().Account(ctx, &staking.OwnerQuery{
@@ -289,8 +288,7 @@ func prettyPrintParaTimeDelegations(
 		})
 	}
 
-	var totalUndeg quantity.Quantity
-	undelegations := make([]delegationDescription, 0, len(rtUndelegations))
+	undelegations = make([]delegationDescription, 0, len(rtUndelegations))
 	for _, udi := range rtUndelegations {
 		// For each destination we need to fetch the pool.
 		destAccount, err := c.Consensus().Staking().Account(ctx, &staking.OwnerQuery{
@@ -312,6 +310,24 @@ func prettyPrintParaTimeDelegations(
 		})
 	}
 
+	sort.Sort(byEndTimeAmountAddress(delegations))
+	sort.Sort(byEndTimeAmountAddress(undelegations))
+	return
+}
+
+func prettyPrintParaTimeDelegations(
+	ctx context.Context,
+	c connection.Connection,
+	height int64,
+	npa *common.NPASelection,
+	addr *types.Address,
+	rtDelegations []*consensusaccounts.ExtendedDelegationInfo,
+	rtUndelegations []*consensusaccounts.UndelegationInfo,
+	prefix string,
+	w io.Writer,
+) {
+	delegations, undelegations, totalDeg, totalUndeg := paraTimeDelegationDescriptions(ctx, c, height, addr, rtDelegations, rtUndelegations)
+
 	innerPrefix := prefix + "  "
 
 	if len(delegations) > 0 {
@@ -319,7 +335,6 @@ func prettyPrintParaTimeDelegations(
 		fmt.Fprintf(w, "%sTotal: %s\n", innerPrefix, helpers.FormatConsensusDenomination(npa.Network, totalDeg))
 		fmt.Fprintln(w)
 
-		sort.Sort(byEndTimeAmountAddress(delegations))
 		prettyPrintDelegationDescriptions(npa.Network, delegations, "To:", innerPrefix, w)
 		fmt.Fprintln(w)
 	}
@@ -329,7 +344,6 @@ func prettyPrintParaTimeDelegations(
 		fmt.Fprintf(w, "%sTotal: %s\n", innerPrefix, helpers.FormatConsensusDenomination(npa.Network, totalUndeg))
 		fmt.Fprintln(w)
 
-		sort.Sort(byEndTimeAmountAddress(undelegations))
 		prettyPrintDelegationDescriptions(npa.Network, undelegations, "To:", innerPrefix, w)
 		fmt.Fprintln(w)
 	}
@@ -0,0 +1,163 @@
+package show
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/helpers"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/consensusaccounts"
+
+	"github.com/oasisprotocol/cli/cmd/common"
+	cliConfig "github.com/oasisprotocol/cli/config"
+)
+
+// runtimeDelegationsOutput is the JSON output of the runtime-delegations command.
+type runtimeDelegationsOutput struct {
+	Address       string                    `json:"address"`
+	ParaTime      string                    `json:"paratime"`
+	Height        int64                     `json:"height"`
+	Epoch         beacon.EpochTime          `json:"epoch"`
+	Delegations   []runtimeDelegationOutput `json:"delegations"`
+	Undelegations []runtimeDelegationOutput `json:"undelegations"`
+	Total         runtimeDelegationsTotal   `json:"total"`
+}
+
+// runtimeDelegationOutput is a single (debonding) delegation in the JSON output.
+type runtimeDelegationOutput struct {
+	To     string            `json:"to,omitempty"`
+	From   string            `json:"from,omitempty"`
+	Shares quantity.Quantity `json:"shares"`
+	Amount quantity.Quantity `json:"amount"`
+	Epoch  beacon.EpochTime  `json:"epoch,omitempty"`
+}
+
+// runtimeDelegationsTotal are the total amounts of the delegations in the JSON output.
+type runtimeDelegationsTotal struct {
+	Delegations   quantity.Quantity `json:"delegations"`
+	Undelegations quantity.Quantity `json:"undelegations"`
+}
+
+// RuntimeDelegationsCmd shows the delegations made from a ParaTime account.
+var RuntimeDelegationsCmd = &cobra.Command{
+	Use:   "runtime-delegations [address]",
+	Short: "Show delegations made from a ParaTime account",
+	Long: `Show the consensus layer delegations and pending undelegations made from a ParaTime account
+via the consensus accounts module.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(_ *cobra.Command, args []string) {
+		cfg := cliConfig.Global()
+		npa := common.GetNPASelection(cfg)
+
+		if npa.ParaTime == nil {
+			cobra.CheckErr("no ParaTime configured")
+		}
+
+		var targetAddress string
+		switch {
+		case len(args) >= 1:
+			targetAddress = args[0]
+		case npa.Account != nil:
+			targetAddress = npa.AccountName
+		default:
+			cobra.CheckErr("no address given and no wallet configured")
+		}
+
+		ctx := context.Background()
+		c, err := common.Connect(ctx, npa.Network)
+		cobra.CheckErr(err)
+
+		addr, _, err := common.ResolveLocalAccountOrAddress(npa.Network, targetAddress)
+		cobra.CheckErr(err)
+
+		height, err := common.GetActualHeight(ctx, c.Consensus())
+		cobra.CheckErr(err)
+		epoch, err := c.Consensus().Beacon().GetEpoch(ctx, height)
+		cobra.CheckErr(err)
+		round := paraTimeRound(ctx, c, npa, height)
+
+		rt := c.Runtime(npa.ParaTime)
+		rtDelegations, err := rt.ConsensusAccounts.Delegations(ctx, round, &consensusaccounts.DelegationsQuery{
+			From: *addr,
+		})
+		cobra.CheckErr(err)
+		rtUndelegations, err := rt.ConsensusAccounts.Undelegations(ctx, round, &consensusaccounts.UndelegationsQuery{
+			To: *addr,
+		})
+		cobra.CheckErr(err)
+
+		delegations, undelegations, totalDeg, totalUndeg := paraTimeDelegationDescriptions(ctx, c, height, addr, rtDelegations, rtUndelegations)
+
+		if common.IsJSONOutput() {
+			out := runtimeDelegationsOutput{
+				Address:       addr.String(),
+				ParaTime:      npa.ParaTimeName,
+				Height:        height,
+				Epoch:         epoch,
+				Delegations:   make([]runtimeDelegationOutput, 0, len(delegations)),
+				Undelegations: make([]runtimeDelegationOutput, 0, len(undelegations)),
+				Total: runtimeDelegationsTotal{
+					Delegations:   totalDeg,
+					Undelegations: totalUndeg,
+				},
+			}
+			for _, d := range delegations {
+				out.Delegations = append(out.Delegations, runtimeDelegationOutput{
+					To:     d.address.String(),
+					Shares: d.shares,
+					Amount: d.amount,
+				})
+			}
+			for _, d := range undelegations {
+				out.Undelegations = append(out.Undelegations, runtimeDelegationOutput{
+					From:   d.address.String(),
+					Shares: d.shares,
+					Amount: d.amount,
+					Epoch:  d.endTime,
+				})
+			}
+			data, err := common.JSONMarshalOutput(out)
+			cobra.CheckErr(err)
+			fmt.Printf("%s\n", data)
+			return
+		}
+
+		fmt.Printf("Address:       %s\n", addr)
+		fmt.Printf("ParaTime:      %s\n", npa.ParaTimeName)
+		fmt.Printf("Current epoch: %d\n", epoch)
+		fmt.Println()
+
+		if len(delegations) == 0 && len(undelegations) == 0 {
+			fmt.Println("No delegations from this account.")
+			return
+		}
+
+		if len(delegations) > 0 {
+			fmt.Println("Active Delegations from this Account:")
+			fmt.Printf("  Total: %s\n", helpers.FormatConsensusDenomination(npa.Network, totalDeg))
+			fmt.Println()
+			prettyPrintDelegationDescriptions(npa.Network, delegations, "To:", "  ", os.Stdout)
+			fmt.Println()
+		}
+
+		if len(undelegations) > 0 {
+			fmt.Println("Pending Undelegations to this Account:")
+			fmt.Printf("  Total: %s\n", helpers.FormatConsensusDenomination(npa.Network, totalUndeg))
+			fmt.Println()
+			prettyPrintDelegationDescriptions(npa.Network, undelegations, "From:", "  ", os.Stdout)
+			fmt.Println()
+			fmt.Println("Undelegated tokens are returned to the ParaTime account once the debonding")
+			fmt.Println("period ends at the given epoch.")
+		}
+	},
+}
+
+func init() {
+	RuntimeDelegationsCmd.Flags().AddFlagSet(common.SelectorFlags)
+	RuntimeDelegationsCmd.Flags().AddFlagSet(common.HeightFlag)
+	RuntimeDelegationsCmd.Flags().AddFlagSet(common.FormatFlag)
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/connection"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/helpers"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/consensusaccounts"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/evm"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
 
 	"github.com/oasisprotocol/cli/cmd/common"
@@ -43,7 +44,7 @@ var (
 				targetAddress = args[0]
 			case npa.Account != nil:
 				// Default account is selected.
-				targetAddress = npa.Account.Address
+				targetAddress = npa.AccountName
 			default:
 				// No address given and no wallet configured.
 				cobra.CheckErr("no address given and no wallet configured")
@@ -51,11 +52,15 @@ var (
 
 			// Establish connection with the target network.
 			ctx := context.Background()
-			c, err := connection.Connect(ctx, npa.Network)
+			c, err := common.Connect(ctx, npa.Network)
 			cobra.CheckErr(err)
 
-			addr, _, err := common.ResolveLocalAccountOrAddress(npa.Network, targetAddress)
+			addr, ethAddr, err := common.ResolveLocalAccountOrAddress(npa.Network, targetAddress)
 			cobra.CheckErr(err)
+			if acfg, ok := cfg.Wallet.All[targetAddress]; ok && ethAddr == nil && acfg.HasEthAddress() && !common.IsNonInteractive() {
+				// Unlock the secp256k1 account to derive its Ethereum address.
+				ethAddr = common.LoadAccount(cfg, targetAddress).EthAddress()
+			}
 
 			height, err := common.GetActualHeight(
 				ctx,
@@ -75,6 +80,12 @@ var (
 			cobra.CheckErr(err)
 
 			fmt.Printf("Address: %s\n", addr)
+			if ethAddr != nil {
+				fmt.Printf("Ethereum address: %s\n", ethAddr.Hex())
+			}
+			if common.IsQRRequested() {
+				cobra.CheckErr(common.PrintAddressQRs(addr, ethAddr))
+			}
 			fmt.Println()
 			fmt.Printf("=== CONSENSUS LAYER (%s) ===\n", npa.NetworkName)
 			fmt.Printf("  Nonce: %d\n", consensusAccount.General.Nonce)
@@ -160,21 +171,7 @@ var (
 			}
 
 			if npa.ParaTime != nil {
-				// Make an effort to support the height query.
-				//
-				// Note: Public gRPC endpoints do not allow this method.
-				round := client.RoundLatest
-				if h := common.GetHeight(); h != consensus.HeightLatest {
-					blk, err := c.Consensus().RootHash().GetLatestBlock(
-						ctx,
-						&roothash.RuntimeRequest{
-							RuntimeID: npa.ParaTime.Namespace(),
-							Height:    height,
-						},
-					)
-					cobra.CheckErr(err)
-					round = blk.Header.Round
-				}
+				round := paraTimeRound(ctx, c, npa, height)
 
 				// Query runtime account when a ParaTime has been configured.
 				rtBalances, err := c.Runtime(npa.ParaTime).Accounts.Balances(ctx, round, *addr)
@@ -196,6 +193,16 @@ var (
 					fmt.Printf("  Nonce: %d\n", nonce)
 					fmt.Println()
 
+					if ethAddr != nil && hasEVMModule(ctx, c, npa) {
+						evmBalance, err := c.Runtime(npa.ParaTime).Evm.Balance(ctx, round, ethAddr.Bytes())
+						cobra.CheckErr(err)
+
+						fmt.Printf("  EVM balance: %s\n", helpers.FormatParaTimeDenomination(npa.ParaTime, types.NewBaseUnits(*evmBalance, types.NativeDenomination)))
+						fmt.Printf("  The ParaTime stores the account state under the native address %s.\n", addr)
+						fmt.Printf("  EVM contracts and Ethereum tooling refer to it as %s.\n", ethAddr.Hex())
+						fmt.Println()
+					}
+
 					if hasNonZeroBalance {
 						fmt.Printf("  Balances for all denominations:\n")
 						for denom, balance := range rtBalances.Balances {
@@ -204,6 +211,9 @@ var (
 
 							fmt.Printf("  - Amount: %s\n", amnt)
 							fmt.Printf("    Symbol: %s\n", symbol)
+							if value := common.ParaTimeFiatValue(ctx, npa.ParaTime, types.NewBaseUnits(balance, denom)); value != "" {
+								fmt.Printf("    Value:  %s (estimate)\n", value)
+							}
 						}
 
 						fmt.Println()
@@ -232,10 +242,41 @@ var (
 	}
 )
 
+// paraTimeRound returns the latest ParaTime round at the given consensus height if the height was
+// explicitly requested or the latest round otherwise.
+func paraTimeRound(ctx context.Context, c connection.Connection, npa *common.NPASelection, height int64) uint64 {
+	// Make an effort to support the height query.
+	//
+	// Note: Public gRPC endpoints do not allow this method.
+	if common.GetHeight() == consensus.HeightLatest {
+		return client.RoundLatest
+	}
+	blk, err := c.Consensus().RootHash().GetLatestBlock(
+		ctx,
+		&roothash.RuntimeRequest{
+			RuntimeID: npa.ParaTime.Namespace(),
+			Height:    height,
+		},
+	)
+	cobra.CheckErr(err)
+	return blk.Header.Round
+}
+
+// hasEVMModule returns true, iff the selected ParaTime contains the EVM module.
+func hasEVMModule(ctx context.Context, c connection.Connection, npa *common.NPASelection) bool {
+	info, err := c.Runtime(npa.ParaTime).Core.RuntimeInfo(ctx)
+	if err != nil {
+		return false
+	}
+	_, ok := info.Modules[evm.ModuleName]
+	return ok
+}
+
 func init() {
 	f := flag.NewFlagSet("", flag.ContinueOnError)
 	f.BoolVar(&showDelegations, "show-delegations", false, "show incoming and outgoing delegations")
 	Cmd.Flags().AddFlagSet(common.SelectorFlags)
 	Cmd.Flags().AddFlagSet(common.HeightFlag)
+	Cmd.Flags().AddFlagSet(common.QRFlags)
 	Cmd.Flags().AddFlagSet(f)
 }
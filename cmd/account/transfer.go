@@ -2,14 +2,15 @@ package account
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
 
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/connection"
 	sdkSignature "github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
-	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/helpers"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/accounts"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
 
@@ -17,6 +18,8 @@ import (
 	cliConfig "github.com/oasisprotocol/cli/config"
 )
 
+var iKnowWhatIAmDoing bool
+
 var transferCmd = &cobra.Command{
 	Use:     "transfer <amount> [<denom>] <to>",
 	Short:   "Transfer given amount of tokens",
@@ -45,16 +48,24 @@ var transferCmd = &cobra.Command{
 		var conn connection.Connection
 		if !txCfg.Offline {
 			var err error
-			conn, err = connection.Connect(ctx, npa.Network)
+			conn, err = common.Connect(ctx, npa.Network)
 			cobra.CheckErr(err)
 		}
 
 		// Resolve destination address.
 		toAddr, toEthAddr, err := common.ResolveLocalAccountOrAddress(npa.Network, to)
 		cobra.CheckErr(err)
+		common.OfferAddressVerification(cfg, to)
 
-		// Check, if to address is known to be unspendable.
-		common.CheckForceErr(common.CheckAddressNotReserved(cfg, toAddr.String()))
+		// Check, if to address is known to be unspendable. Transfers to ParaTime addresses lose
+		// the funds, so they need to be explicitly allowed.
+		if err = common.CheckAddressNotParaTime(cfg, toAddr.String()); err != nil {
+			if !iKnowWhatIAmDoing {
+				cobra.CheckErr(fmt.Errorf("%w\nUse 'oasis account deposit' to move tokens into a ParaTime or --i-know-what-i-am-doing to transfer anyway", err))
+			}
+			fmt.Printf("Warning: %s\nProceeding as requested\n", err)
+			common.AcknowledgeReservedAddress(*toAddr)
+		}
 
 		acc := common.LoadAccount(cfg, npa.AccountName)
 
@@ -67,11 +78,12 @@ var transferCmd = &cobra.Command{
 				common.CheckForceErr(common.CheckAddressIsConsensusCapable(cfg, toEthAddr.Hex()))
 			}
 
+			// The denomination argument may only repeat the consensus layer token symbol.
 			if denom != "" {
-				cobra.CheckErr("consensus layer only supports the native denomination")
+				amount += " " + denom
 			}
 
-			amt, err := helpers.ParseConsensusDenomination(npa.Network, amount)
+			amt, err := common.ParseConsensusAmount(npa.Network, amount)
 			cobra.CheckErr(err)
 
 			// Prepare transaction.
@@ -93,7 +105,7 @@ var transferCmd = &cobra.Command{
 			cobra.CheckErr(err)
 		default:
 			// ParaTime transfer.
-			amtBaseUnits, err := helpers.ParseParaTimeDenomination(npa.ParaTime, amount, types.Denomination(denom))
+			amtBaseUnits, err := common.ParseParaTimeAmount(npa.ParaTime, amount, types.Denomination(denom))
 			cobra.CheckErr(err)
 
 			// Prepare transaction.
@@ -121,8 +133,13 @@ var transferCmd = &cobra.Command{
 }
 
 func init() {
+	transferFlags := flag.NewFlagSet("", flag.ContinueOnError)
+	transferFlags.BoolVar(&iKnowWhatIAmDoing, "i-know-what-i-am-doing", false, "allow transferring to the address of a ParaTime (the funds will be lost)")
+
 	transferCmd.Flags().AddFlagSet(SubtractFeeFlags)
 	transferCmd.Flags().AddFlagSet(common.SelectorFlags)
 	transferCmd.Flags().AddFlagSet(common.RuntimeTxFlags)
 	transferCmd.Flags().AddFlagSet(common.ForceFlag)
+	transferCmd.Flags().AddFlagSet(common.AmountFlags)
+	transferCmd.Flags().AddFlagSet(transferFlags)
 }
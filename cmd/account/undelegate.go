@@ -5,38 +5,77 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
 
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/connection"
 	sdkSignature "github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/helpers"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/consensusaccounts"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
 
 	"github.com/oasisprotocol/cli/cmd/common"
 	cliConfig "github.com/oasisprotocol/cli/config"
 )
 
+// undelegateAll is the special --shares and --amount value denoting all delegated shares.
+const undelegateAll = "all"
+
+var (
+	undelegateShares string
+	undelegateAmount string
+	maxSharesPerTx   string
+)
+
 var undelegateCmd = &cobra.Command{
-	Use:   "undelegate <shares> <from>",
+	Use:   "undelegate [<shares>] <from> [--shares <shares>|all] [--amount <amount>|all]",
 	Short: "Undelegate given amount of shares from an entity",
-	Args:  cobra.ExactArgs(2),
+	Args:  cobra.RangeArgs(1, 2),
 	Run: func(_ *cobra.Command, args []string) {
 		cfg := cliConfig.Global()
 		npa := common.GetNPASelection(cfg)
 		txCfg := common.GetTransactionConfig()
-		amount, from := args[0], args[1]
+
+		var rawShares, from string
+		switch len(args) {
+		case 2:
+			rawShares, from = args[0], args[1]
+		default:
+			from = args[0]
+		}
+		var specified int
+		for _, v := range []string{rawShares, undelegateShares, undelegateAmount} {
+			if v != "" {
+				specified++
+			}
+		}
+		if specified != 1 {
+			cobra.CheckErr("specify what to undelegate either by the number of shares, --shares or --amount")
+		}
+		if rawShares == undelegateAll {
+			cobra.CheckErr("use --shares all to undelegate all shares")
+		}
+		if undelegateShares != "" {
+			rawShares = undelegateShares
+		}
+		all := rawShares == undelegateAll || undelegateAmount == undelegateAll
 
 		if npa.Account == nil {
 			cobra.CheckErr("no accounts configured in your wallet")
 		}
+		if (all || undelegateAmount != "") && txCfg.Offline {
+			cobra.CheckErr("--shares all and --amount are not supported in offline mode")
+		}
 
 		// When not in offline mode, connect to the given network endpoint.
 		ctx := context.Background()
 		var conn connection.Connection
 		if !txCfg.Offline {
 			var err error
-			conn, err = connection.Connect(ctx, npa.Network)
+			conn, err = common.Connect(ctx, npa.Network)
 			cobra.CheckErr(err)
 		}
 
@@ -47,8 +86,42 @@ var undelegateCmd = &cobra.Command{
 		acc := common.LoadAccount(cfg, npa.AccountName)
 
 		var shares quantity.Quantity
-		err = shares.UnmarshalText([]byte(amount))
-		cobra.CheckErr(err)
+		switch {
+		case all:
+			owner := acc.Address()
+			shares = delegatedShares(ctx, npa, conn, &owner, fromAddr)
+			printReclaimTimeline(ctx, npa, conn, fromAddr, shares)
+		case undelegateAmount != "":
+			amount, err := common.ParseConsensusAmount(npa.Network, undelegateAmount)
+			cobra.CheckErr(err)
+			owner := acc.Address()
+			delegated := delegatedShares(ctx, npa, conn, &owner, fromAddr)
+			shares = sharesForAmount(ctx, conn, fromAddr, amount)
+			if shares.Cmp(&delegated) > 0 {
+				cobra.CheckErr(fmt.Errorf("amount exceeds the delegation to %s", fromAddr))
+			}
+			printReclaimTimeline(ctx, npa, conn, fromAddr, shares)
+		default:
+			err = shares.UnmarshalText([]byte(rawShares))
+			cobra.CheckErr(err)
+		}
+
+		// Split the reclamation into multiple transactions if requested.
+		chunks := []quantity.Quantity{shares}
+		if maxSharesPerTx != "" {
+			var maxShares quantity.Quantity
+			if err = maxShares.UnmarshalText([]byte(maxSharesPerTx)); err != nil {
+				cobra.CheckErr(fmt.Errorf("bad maximum shares per transaction: %w", err))
+			}
+			if npa.ParaTime != nil {
+				cobra.CheckErr("--max-shares-per-tx is only supported on the consensus layer")
+			}
+			chunks, err = splitShares(shares, maxShares)
+			cobra.CheckErr(err)
+			if len(chunks) > 1 {
+				fmt.Printf("Reclamation will be split into %d transactions.\n", len(chunks))
+			}
+		}
 
 		var (
 			sigTx, meta interface{}
@@ -57,13 +130,30 @@ var undelegateCmd = &cobra.Command{
 		switch npa.ParaTime {
 		case nil:
 			// Consensus layer delegation.
-			tx := staking.NewReclaimEscrowTx(0, nil, &staking.ReclaimEscrow{
-				Account: fromAddr.ConsensusAddress(),
-				Shares:  shares,
-			})
+			var nonce uint64
+			for i, chunk := range chunks {
+				if len(chunks) > 1 {
+					fmt.Printf("Transaction %d of %d:\n", i+1, len(chunks))
+				}
+				tx := staking.NewReclaimEscrowTx(0, nil, &staking.ReclaimEscrow{
+					Account: fromAddr.ConsensusAddress(),
+					Shares:  chunk,
+				})
+				// Subsequent chunks use consecutive nonces, so they can all be submitted even
+				// when they are exported instead of broadcast.
+				if i > 0 {
+					tx.Nonce = nonce + uint64(i)
+				}
 
-			sigTx, err = common.SignConsensusTransaction(ctx, npa, acc, conn, tx)
-			cobra.CheckErr(err)
+				sigTx, err = common.SignConsensusTransaction(ctx, npa, acc, conn, tx)
+				cobra.CheckErr(err)
+				if i == 0 {
+					nonce = tx.Nonce
+				}
+
+				common.BroadcastOrExportTransactionPart(ctx, npa.ParaTime, conn, sigTx, nil, i, len(chunks))
+			}
+			return
 		default:
 			// ParaTime delegation.
 			tx := consensusaccounts.NewUndelegateTx(nil, &consensusaccounts.Undelegate{
@@ -119,7 +209,128 @@ var undelegateCmd = &cobra.Command{
 	},
 }
 
+// delegatedShares returns the number of shares the given account has delegated to the given
+// escrow account either on the consensus layer or from the selected ParaTime.
+func delegatedShares(
+	ctx context.Context,
+	npa *common.NPASelection,
+	conn connection.Connection,
+	owner *types.Address,
+	escrow *types.Address,
+) quantity.Quantity {
+	switch npa.ParaTime {
+	case nil:
+		delegations, err := conn.Consensus().Staking().DelegationsFor(ctx, &staking.OwnerQuery{
+			Owner:  owner.ConsensusAddress(),
+			Height: consensus.HeightLatest,
+		})
+		cobra.CheckErr(err)
+
+		d, ok := delegations[escrow.ConsensusAddress()]
+		if !ok || d.Shares.IsZero() {
+			cobra.CheckErr(fmt.Errorf("no active delegation to %s", escrow))
+		}
+		return d.Shares
+	default:
+		di, err := conn.Runtime(npa.ParaTime).ConsensusAccounts.Delegation(ctx, client.RoundLatest, &consensusaccounts.DelegationQuery{
+			From: *owner,
+			To:   *escrow,
+		})
+		cobra.CheckErr(err)
+		if di.Shares.IsZero() {
+			cobra.CheckErr(fmt.Errorf("no active delegation to %s", escrow))
+		}
+		return di.Shares
+	}
+}
+
+// printReclaimTimeline prints the amount of tokens the given shares currently represent together
+// with the expected end of the debonding period.
+func printReclaimTimeline(
+	ctx context.Context,
+	npa *common.NPASelection,
+	conn connection.Connection,
+	escrow *types.Address,
+	shares quantity.Quantity,
+) {
+	consensusConn := conn.Consensus()
+	escrowAcc, err := consensusConn.Staking().Account(ctx, &staking.OwnerQuery{
+		Owner:  escrow.ConsensusAddress(),
+		Height: consensus.HeightLatest,
+	})
+	cobra.CheckErr(err)
+
+	amount, err := escrowAcc.Escrow.Active.StakeForShares(&shares)
+	cobra.CheckErr(err)
+
+	fmt.Printf("Reclaiming %s shares (~%s) from %s.\n",
+		shares,
+		helpers.FormatConsensusDenomination(npa.Network, *amount),
+		escrow,
+	)
+
+	params, err := consensusConn.Staking().ConsensusParameters(ctx, consensus.HeightLatest)
+	cobra.CheckErr(err)
+	epoch, err := consensusConn.Beacon().GetEpoch(ctx, consensus.HeightLatest)
+	cobra.CheckErr(err)
+
+	endEpoch := epoch + params.DebondingInterval
+	fmt.Printf("Debonding period ends at epoch %d", endEpoch)
+	if endTime, err := common.EstimateEpochTime(ctx, consensusConn, endEpoch); err == nil {
+		fmt.Printf(" (approximately %s)", endTime.Local().Format("2006-01-02 15:04 MST"))
+	}
+	fmt.Println(".")
+}
+
+// sharesForAmount returns the number of shares of the given escrow account currently representing
+// the given amount of tokens.
+func sharesForAmount(
+	ctx context.Context,
+	conn connection.Connection,
+	escrow *types.Address,
+	amount *quantity.Quantity,
+) quantity.Quantity {
+	escrowAcc, err := conn.Consensus().Staking().Account(ctx, &staking.OwnerQuery{
+		Owner:  escrow.ConsensusAddress(),
+		Height: consensus.HeightLatest,
+	})
+	cobra.CheckErr(err)
+
+	pool := escrowAcc.Escrow.Active
+	if pool.Balance.IsZero() || pool.TotalShares.IsZero() {
+		cobra.CheckErr(fmt.Errorf("no active escrow of %s", escrow))
+	}
+	shares := amount.Clone()
+	cobra.CheckErr(shares.Mul(&pool.TotalShares))
+	cobra.CheckErr(shares.Quo(&pool.Balance))
+	return *shares
+}
+
+// splitShares splits the given shares into chunks of at most maxShares shares.
+func splitShares(shares, maxShares quantity.Quantity) ([]quantity.Quantity, error) {
+	if maxShares.IsZero() {
+		return nil, fmt.Errorf("maximum shares per transaction must be positive")
+	}
+
+	var chunks []quantity.Quantity
+	remaining := shares.Clone()
+	for remaining.Cmp(&maxShares) > 0 {
+		chunks = append(chunks, *maxShares.Clone())
+		if err := remaining.Sub(&maxShares); err != nil {
+			return nil, err
+		}
+	}
+	return append(chunks, *remaining), nil
+}
+
 func init() {
+	f := flag.NewFlagSet("", flag.ContinueOnError)
+	f.StringVar(&undelegateShares, "shares", "", "number of shares to undelegate or 'all' for all delegated shares")
+	f.StringVar(&undelegateAmount, "amount", "", "amount of tokens (e.g. '100 ROSE') to undelegate or 'all' for all delegated shares")
+	f.StringVar(&maxSharesPerTx, "max-shares-per-tx", "", "split reclamation into transactions of at most the given number of shares")
+
 	undelegateCmd.Flags().AddFlagSet(common.SelectorFlags)
 	undelegateCmd.Flags().AddFlagSet(common.RuntimeTxFlags)
+	undelegateCmd.Flags().AddFlagSet(common.AmountFlags)
+	undelegateCmd.Flags().AddFlagSet(f)
 }
@@ -0,0 +1,15 @@
+package account
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUndelegateAmountFlags(t *testing.T) {
+	require := require.New(t)
+
+	// The --amount flag is parsed like all other consensus amounts.
+	require.NotNil(undelegateCmd.Flags().Lookup("amount"))
+	require.NotNil(undelegateCmd.Flags().Lookup("base-units"))
+}
@@ -10,7 +10,6 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/connection"
-	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/helpers"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/consensusaccounts"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
 
@@ -44,7 +43,7 @@ var withdrawCmd = &cobra.Command{
 		var conn connection.Connection
 		if !txCfg.Offline {
 			var err error
-			conn, err = connection.Connect(ctx, npa.Network)
+			conn, err = common.Connect(ctx, npa.Network)
 			cobra.CheckErr(err)
 		}
 
@@ -56,6 +55,7 @@ var withdrawCmd = &cobra.Command{
 			var err error
 			toAddr, ethAddr, err = common.ResolveLocalAccountOrAddress(npa.Network, to)
 			cobra.CheckErr(err)
+			common.OfferAddressVerification(cfg, to)
 			addrToCheck = toAddr.String()
 			if ethAddr != nil {
 				addrToCheck = ethAddr.Hex()
@@ -69,10 +69,9 @@ var withdrawCmd = &cobra.Command{
 
 		// Check, if to address is known to be unspendable.
 		common.CheckForceErr(common.CheckAddressIsConsensusCapable(cfg, addrToCheck))
-		common.CheckForceErr(common.CheckAddressNotReserved(cfg, addrToCheck))
 
 		// Parse amount.
-		amountBaseUnits, err := helpers.ParseParaTimeDenomination(npa.ParaTime, amount, npa.ConsensusDenomination())
+		amountBaseUnits, err := common.ParseParaTimeAmount(npa.ParaTime, amount, npa.ConsensusDenomination())
 		cobra.CheckErr(err)
 
 		// Prepare transaction.
@@ -140,4 +139,5 @@ func init() {
 	withdrawCmd.Flags().AddFlagSet(common.SelectorFlags)
 	withdrawCmd.Flags().AddFlagSet(common.RuntimeTxFlags)
 	withdrawCmd.Flags().AddFlagSet(common.ForceFlag)
+	withdrawCmd.Flags().AddFlagSet(common.AmountFlags)
 }
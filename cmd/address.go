@@ -0,0 +1,248 @@
+package cmd
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+
+	coreCommon "github.com/oasisprotocol/oasis-core/go/common"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/ed25519"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/secp256k1"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/sr25519"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/helpers"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+
+	"github.com/oasisprotocol/cli/cmd/common"
+	cliConfig "github.com/oasisprotocol/cli/config"
+	"github.com/oasisprotocol/cli/wallet/multisig"
+)
+
+// Kinds of address conversion inputs.
+const (
+	addressKindNative    = "native address"
+	addressKindEth       = "Ethereum address"
+	addressKindPublicKey = "public key"
+	addressKindRuntimeID = "runtime ID"
+	addressKindName      = "name"
+)
+
+// addressInfo contains the representations of an address.
+type addressInfo struct {
+	// Kind is the kind of the input.
+	Kind string `json:"kind"`
+	// Native is the Oasis native address.
+	Native string `json:"native"`
+	// Eth is the Ethereum address, if it is known.
+	Eth string `json:"ethereum,omitempty"`
+	// PublicKey is the public key in the <algorithm>:<base64 public key> form, if it is known.
+	PublicKey string `json:"public_key,omitempty"`
+	// Notes are remarks about the conversion, e.g. which representations cannot be derived.
+	Notes []string `json:"notes,omitempty"`
+}
+
+var (
+	addressCmd = &cobra.Command{
+		Use:   "address",
+		Short: "Convert and validate addresses",
+	}
+
+	addressConvertCmd = &cobra.Command{
+		Use:   "convert <input>",
+		Short: "Convert an address, public key or runtime ID to all known address formats",
+		Long: `Convert the input to the Oasis native address and, where it can be derived, the
+Ethereum address. The input can be an Oasis native address, an Ethereum address,
+a public key (<algorithm>:<base64>, Base64-encoded Ed25519 or Secp256k1 key or
+hex-encoded Secp256k1 key), a runtime ID or the name of an account, address book
+entry or special address (e.g. test:alice, paratime:sapphire).`,
+		Args: cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+			npa := common.GetNPASelection(cfg)
+
+			info, err := convertAddress(cfg, npa.Network, args[0])
+			cobra.CheckErr(err)
+
+			if common.IsJSONOutput() {
+				data, err := common.JSONMarshalOutput(info)
+				cobra.CheckErr(err)
+				fmt.Printf("%s\n", data)
+				return
+			}
+
+			fmt.Printf("Input kind:       %s\n", info.Kind)
+			if info.PublicKey != "" {
+				fmt.Printf("Public key:       %s\n", info.PublicKey)
+			}
+			fmt.Printf("Native address:   %s\n", info.Native)
+			if info.Eth != "" {
+				fmt.Printf("Ethereum address: %s\n", info.Eth)
+			}
+			for _, note := range info.Notes {
+				fmt.Printf("Note: %s\n", note)
+			}
+		},
+	}
+
+	addressValidateCmd = &cobra.Command{
+		Use:   "validate <input>",
+		Short: "Validate an address, public key or runtime ID",
+		Long: `Validate the input and print its kind and native address. The command fails with
+a non-zero exit code if the input is not valid, so it can be used in scripts.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+			npa := common.GetNPASelection(cfg)
+
+			info, err := convertAddress(cfg, npa.Network, args[0])
+			cobra.CheckErr(err)
+			fmt.Printf("%s: %s\n", info.Kind, info.Native)
+		},
+	}
+)
+
+// convertAddress converts the given input to all address representations which can be derived
+// from it.
+func convertAddress(cfg *cliConfig.Config, net *config.Network, input string) (*addressInfo, error) {
+	var info *addressInfo
+	switch {
+	case strings.HasPrefix(input, "oasis1"):
+		var addr types.Address
+		if err := addr.UnmarshalText([]byte(input)); err != nil {
+			return nil, fmt.Errorf("malformed native address: %w", err)
+		}
+		info = &addressInfo{
+			Kind:   addressKindNative,
+			Native: addr.String(),
+			Notes:  []string{"The Ethereum address and the public key cannot be derived from the native address."},
+		}
+	case strings.HasPrefix(input, "0x") && len(input) == 2+2*ethCommon.AddressLength:
+		if err := common.CheckEthAddressChecksum(input); err != nil {
+			return nil, err
+		}
+		addr, ethAddr, err := helpers.ResolveEthOrOasisAddress(input)
+		if err != nil {
+			return nil, err
+		}
+		info = &addressInfo{
+			Kind:   addressKindEth,
+			Native: addr.String(),
+			Eth:    ethAddr.Hex(),
+			Notes:  []string{"The conversion is one-way, the Ethereum address cannot be derived from the native address."},
+		}
+	case isRuntimeID(input):
+		var id coreCommon.Namespace
+		if err := id.UnmarshalHex(input); err != nil {
+			return nil, fmt.Errorf("malformed runtime ID: %w", err)
+		}
+		addr := types.NewAddressFromConsensus(staking.NewRuntimeAddress(id))
+		info = &addressInfo{
+			Kind:   addressKindRuntimeID,
+			Native: addr.String(),
+			Notes:  []string{"This is the consensus layer address of the runtime. Tokens transferred to it directly are lost."},
+		}
+	default:
+		if pk, ok := parseAddressPublicKey(input); ok {
+			info = publicKeyAddressInfo(pk)
+			break
+		}
+
+		addr, ethAddr, err := common.ResolveLocalAccountOrAddress(net, input)
+		if err != nil {
+			return nil, fmt.Errorf("unrecognized address '%s': %w", input, err)
+		}
+		info = &addressInfo{
+			Kind:   addressKindName,
+			Native: addr.String(),
+		}
+		if ethAddr != nil {
+			info.Eth = ethAddr.Hex()
+		}
+	}
+
+	// Point out the native addresses of the configured ParaTimes.
+	for netName, net := range cfg.Networks.All {
+		for ptName, pt := range net.ParaTimes.All {
+			if types.NewAddressFromConsensus(staking.NewRuntimeAddress(pt.Namespace())).String() == info.Native {
+				info.Notes = append(info.Notes, fmt.Sprintf("This is the native address of paratime:%s on %s.", ptName, netName))
+			}
+		}
+	}
+	return info, nil
+}
+
+// isRuntimeID returns true iff the given input looks like a hex-encoded runtime ID.
+func isRuntimeID(input string) bool {
+	if len(input) != 2*coreCommon.NamespaceSize {
+		return false
+	}
+	_, err := hex.DecodeString(input)
+	return err == nil
+}
+
+// parseAddressPublicKey parses the given public key in the <algorithm>:<base64 public key> form or
+// as a bare Base64-encoded Ed25519 or Secp256k1 key or hex-encoded Secp256k1 key.
+func parseAddressPublicKey(input string) (types.PublicKey, bool) {
+	if pk, err := multisig.ParsePublicKey(input); err == nil {
+		return pk, true
+	}
+
+	var raw []byte
+	switch data, err := base64.StdEncoding.DecodeString(input); {
+	case err == nil:
+		raw = data
+	case strings.HasPrefix(input, "0x"):
+		if raw, err = hex.DecodeString(input[2:]); err != nil {
+			return types.PublicKey{}, false
+		}
+	default:
+		return types.PublicKey{}, false
+	}
+
+	switch len(raw) {
+	case 32:
+		var pk ed25519.PublicKey
+		if err := pk.UnmarshalBinary(raw); err == nil {
+			return types.PublicKey{PublicKey: pk}, true
+		}
+	case 33:
+		var pk secp256k1.PublicKey
+		if err := pk.UnmarshalBinary(raw); err == nil {
+			return types.PublicKey{PublicKey: pk}, true
+		}
+	}
+	return types.PublicKey{}, false
+}
+
+// publicKeyAddressInfo returns the address representations of the given public key.
+func publicKeyAddressInfo(pk types.PublicKey) *addressInfo {
+	info := &addressInfo{
+		Kind:   addressKindPublicKey,
+		Native: multisig.SignerAddress(pk).String(),
+		Notes:  []string{"The conversion is one-way, the public key cannot be derived from the addresses."},
+	}
+	switch inner := pk.PublicKey.(type) {
+	case ed25519.PublicKey:
+		info.PublicKey = "ed25519:" + inner.String()
+	case secp256k1.PublicKey:
+		info.PublicKey = "secp256k1:" + inner.String()
+		info.Eth = helpers.EthAddressFromPubKey(inner).Hex()
+	case sr25519.PublicKey:
+		info.PublicKey = "sr25519:" + inner.String()
+	}
+	return info
+}
+
+func init() {
+	addressConvertCmd.Flags().AddFlagSet(common.SelectorNFlags)
+	addressConvertCmd.Flags().AddFlagSet(common.FormatFlag)
+	addressValidateCmd.Flags().AddFlagSet(common.SelectorNFlags)
+
+	addressCmd.AddCommand(addressConvertCmd)
+	addressCmd.AddCommand(addressValidateCmd)
+}
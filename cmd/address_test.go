@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdkTesting "github.com/oasisprotocol/oasis-sdk/client-sdk/go/testing"
+
+	cliConfig "github.com/oasisprotocol/cli/config"
+)
+
+func TestConvertAddress(t *testing.T) {
+	require := require.New(t)
+
+	cfg := &cliConfig.Default
+	net := cfg.Networks.All["mainnet"]
+
+	for _, tc := range []struct {
+		input  string
+		kind   string
+		native string
+		eth    string
+	}{
+		{sdkTesting.Alice.Address.String(), addressKindNative, sdkTesting.Alice.Address.String(), ""},
+		{sdkTesting.Dave.EthAddress.Hex(), addressKindEth, sdkTesting.Dave.Address.String(), sdkTesting.Dave.EthAddress.Hex()},
+		{"ed25519:NcPzNW3YU2T+ugNUtUWtoQnRvbOL9dYSaBfbjHLP1pE=", addressKindPublicKey, sdkTesting.Alice.Address.String(), ""},
+		{"NcPzNW3YU2T+ugNUtUWtoQnRvbOL9dYSaBfbjHLP1pE=", addressKindPublicKey, sdkTesting.Alice.Address.String(), ""},
+		{"secp256k1:AsHO8iMsQJSXbq2SYwD4WZDMhU/pVpK8ZhdRCrxxFzXq", addressKindPublicKey, "oasis1qqhejhwpvuv2zr3uzngqctrdtgt9sm6p7g4xx488", "0x3956FCbcA7aeb4A4eD4c11c9F9199b1427C66E43"},
+		{"000000000000000000000000000000000000000000000000f80306c9858e7279", addressKindRuntimeID, "oasis1qrd3mnzhhgst26hsp96uf45yhq6zlax0cuzdgcfc", ""},
+		{"test:dave", addressKindName, sdkTesting.Dave.Address.String(), sdkTesting.Dave.EthAddress.Hex()},
+	} {
+		info, err := convertAddress(cfg, net, tc.input)
+		require.NoError(err, tc.input)
+		require.Equal(tc.kind, info.Kind, tc.input)
+		require.Equal(tc.native, info.Native, tc.input)
+		require.Equal(tc.eth, info.Eth, tc.input)
+	}
+
+	info, err := convertAddress(cfg, net, "000000000000000000000000000000000000000000000000f80306c9858e7279")
+	require.NoError(err)
+	require.Contains(info.Notes, "This is the native address of paratime:sapphire on mainnet.")
+
+	for _, input := range []string{
+		"oasis1qrvzxld9rz83wv92lvnkpmr30c77kj2tvg0pednx",
+		"0x90adE3B7065fa715c7a150313877dF1d33e777d5",
+		"ed25519:invalid",
+		"foo",
+	} {
+		_, err = convertAddress(cfg, net, input)
+		require.Error(err, input)
+	}
+}
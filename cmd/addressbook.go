@@ -6,6 +6,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/oasisprotocol/cli/cmd/common"
 	"github.com/oasisprotocol/cli/config"
 	"github.com/oasisprotocol/cli/table"
 )
@@ -24,28 +25,34 @@ var (
 		Args:    cobra.NoArgs,
 		Run: func(_ *cobra.Command, _ []string) {
 			cfg := config.Global()
-			table := table.New()
-			table.SetHeader([]string{"Name", "Address"})
+			listing := table.NewListing(
+				table.Column{Name: "Name"},
+				table.Column{Name: "Address"},
+				table.Column{Name: "Description", Wide: true},
+			)
 
-			var output [][]string
-			for name, acc := range cfg.AddressBook.All {
+			// Sort output by name.
+			names := make([]string, 0, len(cfg.AddressBook.All))
+			for name := range cfg.AddressBook.All {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			for _, name := range names {
+				acc := cfg.AddressBook.All[name]
 				addrStr := acc.Address
 				if ethAddr := acc.GetEthAddress(); ethAddr != nil {
 					addrStr = ethAddr.Hex()
 				}
-				output = append(output, []string{
+				listing.Append(
 					name,
 					addrStr,
-				})
+					acc.Description,
+				)
 			}
 
-			// Sort output by name.
-			sort.Slice(output, func(i, j int) bool {
-				return output[i][0] < output[j][0]
-			})
-
-			table.AppendBulk(output)
-			table.Render()
+			err := listing.Render(common.GetListingOptions())
+			cobra.CheckErr(err)
 		},
 	}
 
@@ -132,4 +139,6 @@ func init() {
 	addressBookCmd.AddCommand(abRenameCmd)
 	addressBookCmd.AddCommand(abRmCmd)
 	addressBookCmd.AddCommand(abShowCmd)
+
+	abListCmd.Flags().AddFlagSet(common.ListingFlags)
 }
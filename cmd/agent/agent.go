@@ -0,0 +1,16 @@
+package agent
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "agent",
+	Short: "Keep unlocked accounts in memory for subsequent invocations",
+}
+
+func init() {
+	Cmd.AddCommand(startCmd)
+	Cmd.AddCommand(statusCmd)
+	Cmd.AddCommand(stopCmd)
+}
@@ -0,0 +1,77 @@
+package agent
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oasisprotocol/cli/agent"
+	"github.com/oasisprotocol/cli/cmd/common"
+	"github.com/oasisprotocol/cli/config"
+	"github.com/oasisprotocol/cli/wallet"
+)
+
+var agentTTL time.Duration
+
+var startCmd = &cobra.Command{
+	Use:   "start [<account>...]",
+	Short: "Unlock the given accounts and serve signing requests until the agent expires",
+	Long: `Unlock the given accounts, or the default account if none are given, and keep them in
+memory. Until the agent expires or is stopped, subsequent invocations of the CLI sign with these
+accounts without asking for their passphrases.`,
+	Run: func(_ *cobra.Command, args []string) {
+		cfg := config.Global()
+		npa := common.GetNPASelection(cfg)
+
+		names := args
+		if len(names) == 0 {
+			if npa.AccountName == "" {
+				cobra.CheckErr("no accounts configured in your wallet")
+			}
+			names = []string{npa.AccountName}
+		}
+		if agentTTL <= 0 {
+			cobra.CheckErr("agent time to live must be positive")
+		}
+
+		accounts := make(map[string]wallet.Account)
+		for _, name := range names {
+			fmt.Printf("Unlocking account '%s'.\n", name)
+			acc := common.LoadAccountDirect(cfg, name)
+			if _, isMultisig := acc.(wallet.MultisigAccount); isMultisig {
+				cobra.CheckErr(fmt.Errorf("account '%s' is a multisig account which has no signer to hold", name))
+			}
+			accounts[name] = acc
+		}
+
+		path := agent.SocketPath(cfg.Directory())
+		srv := agent.NewServer(accounts, agentTTL)
+
+		// Wipe the accounts on interruption.
+		sigCh := make(chan os.Signal, 1)
+		signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+		go func() {
+			<-sigCh
+			srv.Stop()
+		}()
+
+		fmt.Printf("Accounts:   %s\n", strings.Join(names, ", "))
+		fmt.Printf("Socket:     %s\n", path)
+		fmt.Printf("Expires at: %s\n", srv.ExpiresAt().Local().Format("2006-01-02 15:04:05"))
+		fmt.Printf("\n*** AGENT READY ***\n")
+
+		err := srv.Serve(path)
+		cobra.CheckErr(err)
+
+		fmt.Println("Agent stopped.")
+	},
+}
+
+func init() {
+	startCmd.Flags().DurationVar(&agentTTL, "ttl", time.Hour, "how long the accounts are kept unlocked")
+}
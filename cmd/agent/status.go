@@ -0,0 +1,33 @@
+package agent
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oasisprotocol/cli/agent"
+	"github.com/oasisprotocol/cli/config"
+)
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the accounts held by the running agent",
+	Args:  cobra.NoArgs,
+	Run: func(_ *cobra.Command, _ []string) {
+		cfg := config.Global()
+
+		client, err := agent.Dial(agent.SocketPath(cfg.Directory()))
+		if err != nil {
+			fmt.Println("Agent is not running.")
+			return
+		}
+		defer client.Close()
+
+		status, err := client.Status()
+		cobra.CheckErr(err)
+
+		fmt.Printf("Accounts:   %s\n", strings.Join(status.Accounts, ", "))
+		fmt.Printf("Expires at: %s\n", status.ExpiresAt.Local().Format("2006-01-02 15:04:05"))
+	},
+}
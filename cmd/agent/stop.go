@@ -0,0 +1,30 @@
+package agent
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oasisprotocol/cli/agent"
+	"github.com/oasisprotocol/cli/config"
+)
+
+var stopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop the running agent and forget the unlocked accounts",
+	Args:  cobra.NoArgs,
+	Run: func(_ *cobra.Command, _ []string) {
+		cfg := config.Global()
+
+		client, err := agent.Dial(agent.SocketPath(cfg.Directory()))
+		if err != nil {
+			cobra.CheckErr("agent is not running")
+		}
+		defer client.Close()
+
+		err = client.Stop()
+		cobra.CheckErr(err)
+
+		fmt.Println("Agent stopped.")
+	},
+}
@@ -0,0 +1,15 @@
+package backup
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Back up and restore the CLI configuration and wallet",
+}
+
+func init() {
+	Cmd.AddCommand(createCmd)
+	Cmd.AddCommand(restoreCmd)
+}
@@ -0,0 +1,52 @@
+package backup
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oasisprotocol/cli/backup"
+	"github.com/oasisprotocol/cli/cmd/common"
+	"github.com/oasisprotocol/cli/config"
+	"github.com/oasisprotocol/cli/version"
+)
+
+var excludePrivateKeys bool
+
+var createCmd = &cobra.Command{
+	Use:   "create <file>",
+	Short: "Create an encrypted backup of the configuration, address book and wallet",
+	Args:  cobra.ExactArgs(1),
+	Run: func(_ *cobra.Command, args []string) {
+		cfg := config.Global()
+		fn := args[0]
+
+		if _, err := os.Stat(fn); err == nil {
+			common.Confirm(fmt.Sprintf("File '%s' already exists. Overwrite?", fn), "backup aborted")
+		}
+
+		a, err := backup.Create(cfg, version.Software, &backup.Options{
+			ExcludePrivateKeys: excludePrivateKeys,
+		})
+		cobra.CheckErr(err)
+
+		fmt.Println("Choose a passphrase to encrypt the backup with.")
+		passphrase := common.AskNewPassphrase()
+
+		data, err := backup.Seal(a, passphrase)
+		cobra.CheckErr(err)
+		err = os.WriteFile(fn, data, 0o600)
+		cobra.CheckErr(err)
+
+		fmt.Printf("Backed up %d files into '%s'.\n", len(a.Files), fn)
+		if excludePrivateKeys {
+			fmt.Println("Private keys were excluded, file-based accounts are stored as address book entries.")
+		}
+	},
+}
+
+func init() {
+	createCmd.Flags().BoolVar(&excludePrivateKeys, "exclude-private-keys", false, "do not include private keys of file-based accounts")
+	createCmd.Flags().AddFlagSet(common.AnswerYesFlag)
+}
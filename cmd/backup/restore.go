@@ -0,0 +1,52 @@
+package backup
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/oasisprotocol/cli/backup"
+	"github.com/oasisprotocol/cli/cmd/common"
+	"github.com/oasisprotocol/cli/config"
+)
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore <file>",
+	Short: "Restore the configuration, address book and wallet from an encrypted backup",
+	Args:  cobra.ExactArgs(1),
+	Run: func(_ *cobra.Command, args []string) {
+		cfg := config.Global()
+
+		data, err := os.ReadFile(args[0])
+		cobra.CheckErr(err)
+
+		common.CheckInteractive()
+		var passphrase string
+		err = survey.AskOne(common.PromptPassphrase, &passphrase)
+		cobra.CheckErr(err)
+
+		a, err := backup.Open(data, passphrase)
+		cobra.CheckErr(err)
+
+		fmt.Printf("Created at:   %s\n", a.Manifest.CreatedAt.Local().Format("2006-01-02 15:04:05"))
+		fmt.Printf("CLI version:  %s\n", a.Manifest.CLIVersion)
+		fmt.Printf("Private keys: %t\n", a.Manifest.PrivateKeys)
+		fmt.Println("Files:")
+		for _, name := range a.FileNames() {
+			fmt.Printf("  - %s\n", name)
+		}
+
+		common.Confirm("Restoring replaces your current configuration and wallet files with the same names. Proceed?", "restore aborted")
+
+		err = backup.Restore(a, cfg)
+		cobra.CheckErr(err)
+
+		fmt.Printf("Restored %d files into '%s'.\n", len(a.Files), cfg.Directory())
+	},
+}
+
+func init() {
+	restoreCmd.Flags().AddFlagSet(common.AnswerYesFlag)
+}
@@ -0,0 +1,121 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/helpers"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+var (
+	amountBaseUnits bool
+
+	// AmountFlags configure how token amounts are parsed.
+	AmountFlags *flag.FlagSet
+)
+
+// splitAmount splits the given amount into the value and the optional token symbol following it
+// (e.g. "100 ROSE").
+func splitAmount(amount string) (string, string, error) {
+	fields := strings.Fields(amount)
+	switch len(fields) {
+	case 1:
+		return fields[0], "", nil
+	case 2:
+		return fields[0], fields[1], nil
+	default:
+		return "", "", fmt.Errorf("malformed amount '%s'", amount)
+	}
+}
+
+// parseBaseUnits parses an integer amount of base units.
+func parseBaseUnits(value string) (*quantity.Quantity, error) {
+	var q quantity.Quantity
+	if err := q.UnmarshalText([]byte(value)); err != nil {
+		return nil, fmt.Errorf("malformed amount of base units '%s': %w", value, err)
+	}
+	return &q, nil
+}
+
+// ParseConsensusAmount parses an amount of the consensus layer denomination. The amount is given
+// in tokens and may be followed by the token symbol (e.g. "100 ROSE"). When --base-units is set,
+// the amount is given in base units instead.
+func ParseConsensusAmount(net *config.Network, amount string) (*quantity.Quantity, error) {
+	value, symbol, err := splitAmount(amount)
+	if err != nil {
+		return nil, err
+	}
+	if symbol != "" && !strings.EqualFold(symbol, net.Denomination.Symbol) {
+		return nil, fmt.Errorf("consensus layer only supports the native denomination %s, got '%s'", net.Denomination.Symbol, symbol)
+	}
+	if amountBaseUnits {
+		return parseBaseUnits(value)
+	}
+	return helpers.ParseConsensusDenomination(net, value)
+}
+
+// ParseParaTimeAmount parses an amount of the given ParaTime denomination. The amount is given in
+// tokens and may be followed by the token symbol (e.g. "100 TEST"). When no denomination is given,
+// it is determined from the symbol, defaulting to the native denomination. When --base-units is
+// set, the amount is given in base units instead.
+func ParseParaTimeAmount(pt *config.ParaTime, amount string, denom types.Denomination) (*types.BaseUnits, error) {
+	value, symbol, err := splitAmount(amount)
+	if err != nil {
+		return nil, err
+	}
+	if symbol != "" {
+		switch denom {
+		case types.NativeDenomination:
+			if denom, err = resolveParaTimeDenomination(pt, symbol); err != nil {
+				return nil, err
+			}
+		default:
+			if !matchesDenomination(pt, denom, symbol) {
+				return nil, fmt.Errorf("amount symbol '%s' does not match denomination '%s'", symbol, denom)
+			}
+		}
+	}
+	if amountBaseUnits {
+		q, err := parseBaseUnits(value)
+		if err != nil {
+			return nil, err
+		}
+		bu := types.NewBaseUnits(*q, denom)
+		return &bu, nil
+	}
+	return helpers.ParseParaTimeDenomination(pt, value, denom)
+}
+
+// matchesDenomination returns true iff the given symbol refers to the given ParaTime denomination.
+func matchesDenomination(pt *config.ParaTime, denom types.Denomination, symbol string) bool {
+	if strings.EqualFold(symbol, string(denom)) {
+		return true
+	}
+	return strings.EqualFold(symbol, pt.GetDenominationInfo(string(denom)).Symbol)
+}
+
+// resolveParaTimeDenomination returns the ParaTime denomination with the given symbol.
+func resolveParaTimeDenomination(pt *config.ParaTime, symbol string) (types.Denomination, error) {
+	if matchesDenomination(pt, types.NativeDenomination, symbol) {
+		return types.NativeDenomination, nil
+	}
+	for name := range pt.Denominations {
+		if name == config.NativeDenominationKey {
+			continue
+		}
+		if matchesDenomination(pt, types.Denomination(name), symbol) {
+			return types.Denomination(name), nil
+		}
+	}
+	return "", fmt.Errorf("unknown denomination '%s'", symbol)
+}
+
+func init() {
+	AmountFlags = flag.NewFlagSet("", flag.ContinueOnError)
+	AmountFlags.BoolVar(&amountBaseUnits, "base-units", false, "amounts are given in base units instead of tokens")
+}
@@ -0,0 +1,66 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+func TestParseAmounts(t *testing.T) {
+	require := require.New(t)
+
+	net := config.DefaultNetworks.All["mainnet"]
+	pt := &config.ParaTime{
+		Denominations: map[string]*config.DenominationInfo{
+			config.NativeDenominationKey: {Symbol: "ROSE", Decimals: 18},
+			"USDC":                       {Symbol: "USDC", Decimals: 6},
+		},
+	}
+
+	for _, amount := range []string{"1.5", "1.5 ROSE", " 1.5  rose "} {
+		q, err := ParseConsensusAmount(net, amount)
+		require.NoError(err, amount)
+		require.Equal("1500000000", q.String())
+	}
+	_, err := ParseConsensusAmount(net, "1.5 TEST")
+	require.Error(err)
+	_, err = ParseConsensusAmount(net, "1.5 ROSE extra")
+	require.Error(err)
+
+	// Amounts given to account undelegate --amount.
+	q, err := ParseConsensusAmount(net, "100 ROSE")
+	require.NoError(err)
+	require.Equal("100000000000", q.String())
+
+	bu, err := ParseParaTimeAmount(pt, "2 ROSE", types.NativeDenomination)
+	require.NoError(err)
+	require.Equal(types.NativeDenomination, bu.Denomination)
+	require.Equal("2000000000000000000", bu.Amount.String())
+
+	bu, err = ParseParaTimeAmount(pt, "2 USDC", types.NativeDenomination)
+	require.NoError(err)
+	require.Equal(types.Denomination("USDC"), bu.Denomination)
+	require.Equal("2000000", bu.Amount.String())
+
+	_, err = ParseParaTimeAmount(pt, "2 ROSE", "USDC")
+	require.Error(err)
+	_, err = ParseParaTimeAmount(pt, "2 FOO", types.NativeDenomination)
+	require.Error(err)
+
+	amountBaseUnits = true
+	defer func() { amountBaseUnits = false }()
+
+	q, err = ParseConsensusAmount(net, "1500 ROSE")
+	require.NoError(err)
+	require.Equal("1500", q.String())
+	_, err = ParseConsensusAmount(net, "1.5")
+	require.Error(err)
+
+	bu, err = ParseParaTimeAmount(pt, "7 USDC", types.NativeDenomination)
+	require.NoError(err)
+	require.Equal(types.Denomination("USDC"), bu.Denomination)
+	require.Equal("7", bu.Amount.String())
+}
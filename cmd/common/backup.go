@@ -0,0 +1,39 @@
+package common
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+
+	"github.com/oasisprotocol/cli/config"
+	"github.com/oasisprotocol/cli/wallet"
+	walletFile "github.com/oasisprotocol/cli/wallet/file"
+)
+
+// HasRecoveryPhrase returns true iff the given account is a file account whose key is derived from
+// a recovery phrase (mnemonic) kept in the wallet.
+func HasRecoveryPhrase(acfg *config.Account) bool {
+	if acfg == nil || acfg.Kind != walletFile.Kind {
+		return false
+	}
+	algorithm, _ := acfg.Config["algorithm"].(string)
+	switch algorithm {
+	case wallet.AlgorithmEd25519Adr8, wallet.AlgorithmSecp256k1Bip44, wallet.AlgorithmSr25519Adr8:
+		return true
+	default:
+		return false
+	}
+}
+
+// warnUnverifiedBackup warns when the wallet account with the given address has a recovery phrase
+// whose backup has never been verified.
+func warnUnverifiedBackup(address types.Address) {
+	for name, acfg := range config.Global().Wallet.All {
+		if acfg.Address != address.String() || !HasRecoveryPhrase(acfg) || acfg.BackupVerified != "" {
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "Warning: The backup of the recovery phrase of account '%s' has never been verified. Run `oasis wallet verify-backup %s` to verify it.\n", name, name)
+		return
+	}
+}
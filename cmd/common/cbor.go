@@ -0,0 +1,115 @@
+package common
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/rofl"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+const (
+	// cborBytesHexPrefix is the prefix of hex-encoded byte strings in JSON.
+	cborBytesHexPrefix = "0x"
+	// cborBytesBase64Prefix is the prefix of Base64-encoded byte strings in JSON.
+	cborBytesBase64Prefix = "base64:"
+)
+
+// ParseCBORJSON parses the given JSON into a value suitable for CBOR encoding. Strings starting
+// with "0x" or "base64:" are converted into byte strings, as are Oasis and ROFL app addresses.
+func ParseCBORJSON(raw string) (interface{}, error) {
+	dec := json.NewDecoder(strings.NewReader(raw))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("malformed JSON: %w", err)
+	}
+	return convertCBORJSONValue(v)
+}
+
+// convertCBORJSONValue converts a JSON-decoded value into a value suitable for CBOR encoding.
+func convertCBORJSONValue(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			ce, err := convertCBORJSONValue(e)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", k, err)
+			}
+			m[k] = ce
+		}
+		return m, nil
+	case []interface{}:
+		a := make([]interface{}, 0, len(v))
+		for i, e := range v {
+			ce, err := convertCBORJSONValue(e)
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			a = append(a, ce)
+		}
+		return a, nil
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			if n >= 0 {
+				return uint64(n), nil
+			}
+			return n, nil
+		}
+		if u, err := strconv.ParseUint(v.String(), 10, 64); err == nil {
+			return u, nil
+		}
+		return v.Float64()
+	case string:
+		switch {
+		case strings.HasPrefix(v, cborBytesHexPrefix):
+			return hex.DecodeString(strings.TrimPrefix(v, cborBytesHexPrefix))
+		case strings.HasPrefix(v, cborBytesBase64Prefix):
+			return base64.StdEncoding.DecodeString(strings.TrimPrefix(v, cborBytesBase64Prefix))
+		case strings.HasPrefix(v, "oasis1"):
+			var addr types.Address
+			if err := addr.UnmarshalText([]byte(v)); err != nil {
+				return nil, err
+			}
+			return addr.MarshalBinary()
+		case strings.HasPrefix(v, "rofl1"):
+			var appID rofl.AppID
+			if err := appID.UnmarshalText([]byte(v)); err != nil {
+				return nil, err
+			}
+			return appID.MarshalBinary()
+		}
+		return v, nil
+	default:
+		return v, nil
+	}
+}
+
+// HumanizeCBORValue converts byte strings in the given decoded CBOR value into hex so that the
+// value can be encoded as JSON.
+func HumanizeCBORValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case []byte:
+		return cborBytesHexPrefix + hex.EncodeToString(v)
+	case []interface{}:
+		a := make([]interface{}, 0, len(v))
+		for _, e := range v {
+			a = append(a, HumanizeCBORValue(e))
+		}
+		return a
+	case map[interface{}]interface{}:
+		m := make(map[interface{}]interface{}, len(v))
+		for k, e := range v {
+			m[k] = HumanizeCBORValue(e)
+		}
+		return m
+	default:
+		return v
+	}
+}
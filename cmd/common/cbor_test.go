@@ -0,0 +1,35 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCBORJSON(t *testing.T) {
+	require := require.New(t)
+
+	v, err := ParseCBORJSON(`{"id": "0x0102", "raw": "base64:AwQ=", "n": 18446744073709551615, "m": -1, "f": 1.5, "s": "foo", "a": [1, "oasis1qp87hflmelnpqhzcqcw8rhzakq4elj7jzv090p3e"]}`)
+	require.NoError(err)
+
+	m := v.(map[string]interface{})
+	require.Equal([]byte{1, 2}, m["id"])
+	require.Equal([]byte{3, 4}, m["raw"])
+	require.Equal(uint64(18446744073709551615), m["n"])
+	require.Equal(int64(-1), m["m"])
+	require.Equal(1.5, m["f"])
+	require.Equal("foo", m["s"])
+	a := m["a"].([]interface{})
+	require.Equal(uint64(1), a[0])
+	require.Len(a[1], 21)
+
+	_, err = ParseCBORJSON(`{"id": "0xzz"}`)
+	require.Error(err)
+	_, err = ParseCBORJSON(`{`)
+	require.Error(err)
+
+	require.Equal(
+		map[interface{}]interface{}{"id": "0x0102", "list": []interface{}{"0x03", uint64(4)}},
+		HumanizeCBORValue(map[interface{}]interface{}{"id": []byte{1, 2}, "list": []interface{}{[]byte{3}, uint64(4)}}),
+	)
+}
@@ -0,0 +1,118 @@
+package common
+
+import (
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	consensusTx "github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/helpers"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/accounts"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/consensusaccounts"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+
+	"github.com/oasisprotocol/cli/config"
+)
+
+// TransactionConfirmationRequired returns true iff the user should be asked to confirm signing the
+// given transaction based on the configured confirmation policy.
+func TransactionConfirmationRequired(npa *NPASelection, tx interface{}) bool {
+	cfg := config.Global()
+	switch cfg.Confirmations {
+	case config.ConfirmationsNever:
+		return false
+	case config.ConfirmationsLargeAmounts:
+		amount, threshold, ok := transactionAmount(npa, tx, cfg.ConfirmationThreshold)
+		if !ok {
+			// Confirm when the amount cannot be determined.
+			return true
+		}
+		return amount.Cmp(threshold) >= 0
+	default:
+		return true
+	}
+}
+
+// transactionAmount returns the amount moved by the given transaction together with the given
+// threshold converted into the same denomination.
+func transactionAmount(npa *NPASelection, tx interface{}, threshold string) (*quantity.Quantity, *quantity.Quantity, bool) {
+	switch tx := tx.(type) {
+	case *consensusTx.Transaction:
+		var amount quantity.Quantity
+		switch tx.Method {
+		case staking.MethodTransfer:
+			var body staking.Transfer
+			if cbor.Unmarshal(tx.Body, &body) != nil {
+				return nil, nil, false
+			}
+			amount = body.Amount
+		case staking.MethodAddEscrow:
+			var body staking.Escrow
+			if cbor.Unmarshal(tx.Body, &body) != nil {
+				return nil, nil, false
+			}
+			amount = body.Amount
+		case staking.MethodBurn:
+			var body staking.Burn
+			if cbor.Unmarshal(tx.Body, &body) != nil {
+				return nil, nil, false
+			}
+			amount = body.Amount
+		case staking.MethodAllow:
+			var body staking.Allow
+			if cbor.Unmarshal(tx.Body, &body) != nil {
+				return nil, nil, false
+			}
+			amount = body.AmountChange
+		default:
+			return nil, nil, false
+		}
+
+		limit, err := helpers.ParseConsensusDenomination(npa.Network, threshold)
+		if err != nil {
+			return nil, nil, false
+		}
+		return &amount, limit, true
+	case *types.Transaction:
+		if npa.ParaTime == nil || tx.Call.Format != types.CallFormatPlain {
+			return nil, nil, false
+		}
+
+		var amount types.BaseUnits
+		switch tx.Call.Method {
+		case "accounts.Transfer":
+			var body accounts.Transfer
+			if cbor.Unmarshal(tx.Call.Body, &body) != nil {
+				return nil, nil, false
+			}
+			amount = body.Amount
+		case "consensus.Deposit":
+			var body consensusaccounts.Deposit
+			if cbor.Unmarshal(tx.Call.Body, &body) != nil {
+				return nil, nil, false
+			}
+			amount = body.Amount
+		case "consensus.Withdraw":
+			var body consensusaccounts.Withdraw
+			if cbor.Unmarshal(tx.Call.Body, &body) != nil {
+				return nil, nil, false
+			}
+			amount = body.Amount
+		case "consensus.Delegate":
+			var body consensusaccounts.Delegate
+			if cbor.Unmarshal(tx.Call.Body, &body) != nil {
+				return nil, nil, false
+			}
+			amount = body.Amount
+		default:
+			return nil, nil, false
+		}
+
+		limit, err := helpers.ParseParaTimeDenomination(npa.ParaTime, threshold, amount.Denomination)
+		if err != nil {
+			return nil, nil, false
+		}
+		return &amount.Amount, &limit.Amount, true
+	default:
+		return nil, nil, false
+	}
+}
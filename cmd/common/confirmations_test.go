@@ -0,0 +1,51 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/accounts"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+
+	"github.com/oasisprotocol/cli/config"
+)
+
+func TestTransactionConfirmationRequired(t *testing.T) {
+	require := require.New(t)
+
+	config.ResetDefaults()
+	defer config.ResetDefaults()
+	cfg := config.Global()
+
+	net := cfg.Networks.All["mainnet"]
+	npa := &NPASelection{
+		Network:  net,
+		ParaTime: net.ParaTimes.All["sapphire"],
+	}
+
+	consensusTransfer := func(amount uint64) interface{} {
+		return staking.NewTransferTx(0, nil, &staking.Transfer{Amount: *quantity.NewFromUint64(amount)})
+	}
+	runtimeTransfer := func(amount uint64) interface{} {
+		return accounts.NewTransferTx(nil, &accounts.Transfer{Amount: types.NewBaseUnits(*quantity.NewFromUint64(amount), types.NativeDenomination)})
+	}
+	reclaim := staking.NewReclaimEscrowTx(0, nil, &staking.ReclaimEscrow{})
+
+	// Always confirm by default.
+	require.True(TransactionConfirmationRequired(npa, consensusTransfer(1)))
+
+	cfg.Confirmations = config.ConfirmationsNever
+	require.False(TransactionConfirmationRequired(npa, consensusTransfer(1_000_000_000_000_000)))
+	require.False(TransactionConfirmationRequired(npa, reclaim))
+
+	cfg.Confirmations = config.ConfirmationsLargeAmounts
+	cfg.ConfirmationThreshold = "10"
+	require.False(TransactionConfirmationRequired(npa, consensusTransfer(9_999_999_999)))           // 9.999999999 ROSE
+	require.True(TransactionConfirmationRequired(npa, consensusTransfer(10_000_000_000)))           // 10 ROSE
+	require.False(TransactionConfirmationRequired(npa, runtimeTransfer(1_000_000_000_000)))         // 0.000001 ROSE
+	require.True(TransactionConfirmationRequired(npa, runtimeTransfer(10_000_000_000_000_000_000))) // 10 ROSE
+	require.True(TransactionConfirmationRequired(npa, reclaim))
+}
@@ -0,0 +1,222 @@
+package common
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	coreCommon "github.com/oasisprotocol/oasis-core/go/common"
+	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	control "github.com/oasisprotocol/oasis-core/go/control/api"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/connection"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/accounts"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/consensusaccounts"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/contracts"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/core"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/evm"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/rewards"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/rofl"
+
+	cliConfig "github.com/oasisprotocol/cli/config"
+)
+
+const (
+	// initialRetryBackoff is the delay before the first retry of a failed request.
+	initialRetryBackoff = 500 * time.Millisecond
+	// maxRetryBackoff is the maximum delay between retries of a failed request.
+	maxRetryBackoff = 10 * time.Second
+)
+
+var (
+	requestTimeout time.Duration
+	requestRetries uint
+
+	rpcLogger = NewLogger("rpc")
+)
+
+// requestPolicy returns the request timeout and number of retries, taking the global flags and
+// the configuration into account.
+func requestPolicy() (time.Duration, uint, error) {
+	policy := cliConfig.Global().Requests
+	timeout, err := policy.TimeoutDuration()
+	if err != nil {
+		return 0, 0, err
+	}
+	retries := policy.Retries
+
+	if RequestFlags.Changed("timeout") {
+		timeout = requestTimeout
+	}
+	if RequestFlags.Changed("retries") {
+		retries = requestRetries
+	}
+	return timeout, retries, nil
+}
+
+// isIdempotentMethod returns true iff repeating the given gRPC method has no additional effect.
+//
+// Submissions (e.g. SubmitTx, SubmitTxNoWait, SubmitEvidence) are not idempotent as the first
+// attempt may have been accepted even if it appears to have failed.
+func isIdempotentMethod(method string) bool {
+	name := method[strings.LastIndex(method, "/")+1:]
+	return !strings.HasPrefix(name, "Submit")
+}
+
+// isRetryableError returns true if the request failed in a way that makes it safe to retry.
+func isRetryableError(method string, err error) bool {
+	if !isIdempotentMethod(method) {
+		return false
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
+
+// requestInterceptor returns a gRPC interceptor that applies the given timeout to each request and
+// retries failed requests with exponential backoff.
+func requestInterceptor(timeout time.Duration, retries uint) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, rsp interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		start := time.Now()
+		rpcLogger.Debug("request started", "method", method, "timeout", timeout)
+
+		backoff := initialRetryBackoff
+		for attempt := uint(0); ; attempt++ {
+			callCtx, cancel := ctx, context.CancelFunc(func() {})
+			if timeout > 0 {
+				callCtx, cancel = context.WithTimeout(ctx, timeout)
+			}
+			err := invoker(callCtx, method, req, rsp, cc, opts...)
+			timedOut := errors.Is(callCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil
+			cancel()
+
+			switch {
+			case err == nil:
+				rpcLogger.Info("request finished", "method", method, "duration", time.Since(start), "attempts", attempt+1)
+				return nil
+			case ctx.Err() != nil, !isRetryableError(method, err), attempt >= retries:
+				rpcLogger.Info("request failed", "method", method, "duration", time.Since(start), "attempts", attempt+1, "err", err)
+				if timedOut {
+					return fmt.Errorf("request %s timed out after %s (use --timeout to change the timeout): %w", method, timeout, err)
+				}
+				return err
+			}
+
+			rpcLogger.Info("retrying request", "method", method, "attempt", attempt+1, "backoff", backoff, "err", err)
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return err
+			}
+			backoff = min(2*backoff, maxRetryBackoff)
+		}
+	}
+}
+
+// nodeConnection is a connection.Connection that applies the configured request policy.
+//
+// The SDK's connection.Connect does not accept additional dial options, so the connection is
+// dialed here the same way with the request interceptor added.
+type nodeConnection struct {
+	conn *grpc.ClientConn
+	net  *config.Network
+}
+
+// Consensus implements connection.Connection.
+func (c *nodeConnection) Consensus() consensus.ClientBackend {
+	return consensus.NewConsensusClient(c.conn)
+}
+
+// Control implements connection.Connection.
+func (c *nodeConnection) Control() control.NodeController {
+	return control.NewNodeControllerClient(c.conn)
+}
+
+// Runtime implements connection.Connection.
+func (c *nodeConnection) Runtime(pt *config.ParaTime) connection.RuntimeClient {
+	var runtimeID coreCommon.Namespace
+	if err := runtimeID.UnmarshalHex(pt.ID); err != nil {
+		panic(err)
+	}
+	cli := client.New(c.conn, runtimeID)
+	return connection.RuntimeClient{
+		RuntimeClient:     cli,
+		Core:              core.NewV1(cli),
+		Accounts:          accounts.NewV1(cli),
+		Rewards:           rewards.NewV1(cli),
+		ConsensusAccounts: consensusaccounts.NewV1(cli),
+		Contracts:         contracts.NewV1(cli),
+		Evm:               evm.NewV1(cli),
+		ROFL:              rofl.NewV1(cli),
+	}
+}
+
+// Connect establishes a connection with the target network. All unary requests performed over the
+// connection honor the configured request timeout and are retried on transient failures.
+func Connect(ctx context.Context, net *config.Network) (connection.Connection, error) {
+	conn, err := ConnectNoVerify(ctx, net)
+	if err != nil {
+		return nil, err
+	}
+
+	// Request the chain domain separation context from the node and compare with local
+	// configuration to reject mismatches early.
+	chainContext, err := conn.Consensus().GetChainContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve remote node's chain context: %w", err)
+	}
+	if chainContext != net.ChainContext {
+		return nil, fmt.Errorf("remote node's chain context mismatch (expected: %s got: %s)", net.ChainContext, chainContext)
+	}
+
+	return conn, nil
+}
+
+// ConnectNoVerify establishes a connection with the target network, omitting the chain context
+// check.
+func ConnectNoVerify(_ context.Context, net *config.Network) (connection.Connection, error) {
+	timeout, retries, err := requestPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	dialOpts := []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(requestInterceptor(timeout, retries)),
+	}
+	switch cmnGrpc.IsLocalAddress(net.RPC) {
+	case true:
+		// No TLS needed for local nodes.
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	case false:
+		// Configure TLS for non-local nodes.
+		creds := credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(creds))
+	}
+
+	conn, err := cmnGrpc.Dial(net.RPC, dialOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return &nodeConnection{conn: conn, net: net}, nil
+}
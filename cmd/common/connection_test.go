@@ -0,0 +1,63 @@
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRequestInterceptor(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	invokeWith := func(method string, interceptor grpc.UnaryClientInterceptor, errs ...error) (int, error) {
+		var calls int
+		err := interceptor(ctx, method, nil, nil, nil, func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+			calls++
+			if calls <= len(errs) {
+				return errs[calls-1]
+			}
+			return nil
+		})
+		return calls, err
+	}
+	unavailable := status.Error(codes.Unavailable, "unavailable")
+
+	// Transient failures are retried.
+	calls, err := invokeWith("/test/Query", requestInterceptor(0, 2), unavailable, unavailable)
+	require.NoError(err)
+	require.Equal(3, calls)
+
+	// Retries are limited.
+	calls, err = invokeWith("/test/Query", requestInterceptor(0, 1), unavailable, unavailable)
+	require.Error(err)
+	require.Equal(2, calls)
+
+	// Other failures are not retried.
+	calls, err = invokeWith("/test/Query", requestInterceptor(0, 2), status.Error(codes.InvalidArgument, "bad"))
+	require.Error(err)
+	require.Equal(1, calls)
+
+	// Submissions are never retried, not even on transient failures.
+	calls, err = invokeWith("/oasis-core.Consensus/SubmitTx", requestInterceptor(0, 2), unavailable)
+	require.Error(err)
+	require.Equal(1, calls)
+	calls, err = invokeWith("/oasis-core.RuntimeClient/SubmitTxNoWait", requestInterceptor(0, 2), unavailable)
+	require.Error(err)
+	require.Equal(1, calls)
+
+	// Timed out submissions are not retried.
+	var submitCalls int
+	err = requestInterceptor(time.Millisecond, 2)(ctx, "/test/SubmitTx", nil, nil, nil, func(ctx context.Context, _ string, _ interface{}, _ interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		submitCalls++
+		<-ctx.Done()
+		return status.Error(codes.DeadlineExceeded, "deadline exceeded")
+	})
+	require.ErrorContains(err, "timed out")
+	require.Equal(1, submitCalls)
+}
@@ -0,0 +1,72 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+)
+
+// blockTimeSampleSize is the number of recent blocks used to estimate the average block time.
+const blockTimeSampleSize = 100
+
+// EstimateEpochTime returns the approximate wall clock time at which the given epoch starts,
+// extrapolated from the average block time of the recent blocks.
+func EstimateEpochTime(
+	ctx context.Context,
+	consensusConn consensus.ClientBackend,
+	epoch beacon.EpochTime,
+) (time.Time, error) {
+	latest, err := consensusConn.GetBlock(ctx, consensus.HeightLatest)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to query latest block: %w", err)
+	}
+
+	current, err := consensusConn.Beacon().GetEpoch(ctx, latest.Height)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to query current epoch: %w", err)
+	}
+	if epoch <= current {
+		return latest.Time, nil
+	}
+
+	params, err := consensusConn.Beacon().ConsensusParameters(ctx, latest.Height)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to query beacon parameters: %w", err)
+	}
+	epochStart, err := consensusConn.Beacon().GetEpochBlock(ctx, current)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to query epoch start height: %w", err)
+	}
+
+	blockTime, err := AverageBlockTime(ctx, consensusConn, latest)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	remainingBlocks := int64(epoch-current)*params.Interval() - (latest.Height - epochStart)
+	return latest.Time.Add(time.Duration(remainingBlocks) * blockTime), nil
+}
+
+// AverageBlockTime returns the average consensus block time based on the recent blocks before
+// the given one.
+func AverageBlockTime(
+	ctx context.Context,
+	consensusConn consensus.ClientBackend,
+	latest *consensus.Block,
+) (time.Duration, error) {
+	sampleHeight := latest.Height - blockTimeSampleSize
+	if sampleHeight < 1 {
+		sampleHeight = 1
+	}
+	if sampleHeight == latest.Height {
+		return 0, fmt.Errorf("not enough blocks to estimate block time")
+	}
+	sample, err := consensusConn.GetBlock(ctx, sampleHeight)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query block %d: %w", sampleHeight, err)
+	}
+	return latest.Time.Sub(sample.Time) / time.Duration(latest.Height-sampleHeight), nil
+}
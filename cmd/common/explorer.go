@@ -0,0 +1,79 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/connection"
+
+	cliConfig "github.com/oasisprotocol/cli/config"
+)
+
+// ExplorerKind is the kind of entity shown in a block explorer.
+type ExplorerKind string
+
+const (
+	// ExplorerTx is a transaction.
+	ExplorerTx ExplorerKind = "tx"
+	// ExplorerAddress is an account address.
+	ExplorerAddress ExplorerKind = "address"
+	// ExplorerBlock is a consensus block or a ParaTime round.
+	ExplorerBlock ExplorerKind = "block"
+)
+
+// ExplorerURL returns the block explorer URL of the given entity on the selected network and
+// ParaTime (nil for the consensus layer).
+func ExplorerURL(netName string, net *config.Network, ptName string, kind ExplorerKind, id string) (string, error) {
+	explorer := cliConfig.Global().Explorers.Lookup(netName, net, ptName)
+	if explorer == nil {
+		layer := "consensus layer"
+		if ptName != "" {
+			layer = "ParaTime " + ptName
+		}
+		return "", fmt.Errorf("no block explorer configured for the %s of network %s", layer, netName)
+	}
+
+	var tmpl string
+	switch kind {
+	case ExplorerTx:
+		tmpl = explorer.Tx
+	case ExplorerAddress:
+		tmpl = explorer.Address
+	case ExplorerBlock:
+		tmpl = explorer.Block
+	}
+	if tmpl == "" {
+		return "", fmt.Errorf("no block explorer %s URL template configured", kind)
+	}
+	return strings.ReplaceAll(tmpl, cliConfig.ExplorerPlaceholder, id), nil
+}
+
+// printExplorerURL prints the block explorer URL of the given transaction, if a block explorer is
+// available for the network of the given connection.
+func printExplorerURL(conn connection.Connection, pt *config.ParaTime, txHash string) {
+	nc, ok := conn.(*nodeConnection)
+	if !ok || nc.net == nil {
+		return
+	}
+
+	var netName, ptName string
+	for name, net := range cliConfig.Global().Networks.All {
+		if net != nc.net {
+			continue
+		}
+		netName = name
+		for name, p := range net.ParaTimes.All {
+			if p == pt {
+				ptName = name
+			}
+		}
+	}
+	if netName == "" || (pt != nil && ptName == "") {
+		return
+	}
+
+	if url, err := ExplorerURL(netName, nc.net, ptName, ExplorerTx, txHash); err == nil {
+		fmt.Printf("Explorer:         %s\n", url)
+	}
+}
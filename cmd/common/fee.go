@@ -0,0 +1,106 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/connection"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/helpers"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// selectFeeDenomination checks whether the account can pay the fee in the native denomination
+// and otherwise selects an alternative accepted fee denomination held by the account.
+//
+// Returns the gas price in the selected denomination.
+func selectFeeDenomination(
+	ctx context.Context,
+	npa *NPASelection,
+	conn connection.Connection,
+	address types.Address,
+	gas uint64,
+	minGasPrices map[types.Denomination]types.Quantity,
+) (*types.BaseUnits, error) {
+	balances, err := conn.Runtime(npa.ParaTime).Accounts.Balances(ctx, client.RoundLatest, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query balances: %w", err)
+	}
+
+	denom, gasPrice, err := pickFeeDenomination(npa.ParaTime, gas, minGasPrices, balances.Balances)
+	if err != nil {
+		return nil, err
+	}
+	if denom != types.NativeDenomination {
+		fmt.Printf("Note: Insufficient native balance to pay the fee, paying it in %s instead.\n", denomDisplayName(npa.ParaTime, denom))
+	}
+	return gasPrice, nil
+}
+
+// pickFeeDenomination returns the denomination the fee should be paid in given the account
+// balances. The native denomination is preferred, followed by the alternatives in lexicographic
+// order.
+func pickFeeDenomination(
+	pt *config.ParaTime,
+	gas uint64,
+	minGasPrices map[types.Denomination]types.Quantity,
+	balances map[types.Denomination]types.Quantity,
+) (types.Denomination, *types.BaseUnits, error) {
+	denoms := make([]types.Denomination, 0, len(minGasPrices))
+	for denom := range minGasPrices {
+		if denom != types.NativeDenomination {
+			denoms = append(denoms, denom)
+		}
+	}
+	sort.Slice(denoms, func(i, j int) bool { return denoms[i] < denoms[j] })
+	denoms = append([]types.Denomination{types.NativeDenomination}, denoms...)
+
+	feeFor := func(denom types.Denomination) *quantity.Quantity {
+		mgp := minGasPrices[denom]
+		fee := mgp.Clone()
+		_ = fee.Mul(quantity.NewFromUint64(gas))
+		return fee
+	}
+
+	// Zero fees can always be paid.
+	if fee := feeFor(types.NativeDenomination); fee.IsZero() {
+		gasPrice := types.NewBaseUnits(minGasPrices[types.NativeDenomination], types.NativeDenomination)
+		return types.NativeDenomination, &gasPrice, nil
+	}
+
+	for _, denom := range denoms {
+		balance := balances[denom]
+		if balance.Cmp(feeFor(denom)) >= 0 {
+			gasPrice := types.NewBaseUnits(minGasPrices[denom], denom)
+			return denom, &gasPrice, nil
+		}
+	}
+
+	// No denomination is sufficient, list the options.
+	options := make([]string, 0, len(denoms))
+	for _, denom := range denoms {
+		options = append(options, fmt.Sprintf("  - %s: fee %s, balance %s",
+			denomDisplayName(pt, denom),
+			helpers.FormatParaTimeDenomination(pt, types.NewBaseUnits(*feeFor(denom), denom)),
+			helpers.FormatParaTimeDenomination(pt, types.NewBaseUnits(balances[denom], denom)),
+		))
+	}
+	return "", nil, fmt.Errorf("insufficient balance to pay the transaction fee in any accepted denomination:\n%s\nfund the account or use --fee-denom and --gas-price to choose the fee denomination", strings.Join(options, "\n"))
+}
+
+// denomDisplayName returns the human readable name of the given denomination.
+func denomDisplayName(pt *config.ParaTime, denom types.Denomination) string {
+	if denom.IsNative() {
+		if pt != nil {
+			if d, ok := pt.Denominations[config.NativeDenominationKey]; ok && d.Symbol != "" {
+				return d.Symbol
+			}
+		}
+		return "native denomination"
+	}
+	return string(denom)
+}
@@ -0,0 +1,55 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+func TestPickFeeDenomination(t *testing.T) {
+	require := require.New(t)
+
+	pt := &config.ParaTime{
+		Denominations: map[string]*config.DenominationInfo{
+			config.NativeDenominationKey: {Symbol: "TEST", Decimals: 18},
+			"USDC":                       {Symbol: "USDC", Decimals: 6},
+		},
+	}
+	mgp := map[types.Denomination]types.Quantity{
+		types.NativeDenomination: *quantity.NewFromUint64(100),
+		"USDC":                   *quantity.NewFromUint64(1),
+	}
+	const gas = 1000
+
+	// Native balance is preferred.
+	denom, gasPrice, err := pickFeeDenomination(pt, gas, mgp, map[types.Denomination]types.Quantity{
+		types.NativeDenomination: *quantity.NewFromUint64(100_000),
+		"USDC":                   *quantity.NewFromUint64(100_000),
+	})
+	require.NoError(err)
+	require.Equal(types.NativeDenomination, denom)
+	require.EqualValues(mgp[types.NativeDenomination], gasPrice.Amount)
+
+	// Fall back to an alternative denomination.
+	denom, gasPrice, err = pickFeeDenomination(pt, gas, mgp, map[types.Denomination]types.Quantity{
+		types.NativeDenomination: *quantity.NewFromUint64(99_999),
+		"USDC":                   *quantity.NewFromUint64(1000),
+	})
+	require.NoError(err)
+	require.Equal(types.Denomination("USDC"), denom)
+	require.Equal(types.Denomination("USDC"), gasPrice.Denomination)
+
+	// Insufficient balance everywhere lists the options.
+	_, _, err = pickFeeDenomination(pt, gas, mgp, nil)
+	require.ErrorContains(err, "USDC: fee 0.001 USDC, balance 0.0 USDC")
+
+	// Zero fees do not require any balance.
+	_, gasPrice, err = pickFeeDenomination(pt, gas, map[types.Denomination]types.Quantity{}, nil)
+	require.NoError(err)
+	require.True(gasPrice.Amount.IsZero())
+}
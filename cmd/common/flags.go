@@ -8,6 +8,9 @@ import (
 	flag "github.com/spf13/pflag"
 
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+
+	cliConfig "github.com/oasisprotocol/cli/config"
+	"github.com/oasisprotocol/cli/table"
 )
 
 var (
@@ -22,6 +25,18 @@ var (
 
 	// FormatFlag specifies the command's output format (text/json).
 	FormatFlag *flag.FlagSet
+
+	// NonInteractiveFlag forbids prompting the user.
+	NonInteractiveFlag *flag.FlagSet
+
+	// ListingFlags configure the rendering of listings (columns, sorting, header).
+	ListingFlags *flag.FlagSet
+
+	// QRFlags configure rendering of addresses as QR codes.
+	QRFlags *flag.FlagSet
+
+	// RequestFlags configure the timeout and retries of network requests.
+	RequestFlags *flag.FlagSet
 )
 
 // FormatType specifies the type of format for output of commands.
@@ -33,8 +48,13 @@ const (
 	FormatText FormatType = "text"
 	// Output JSON.
 	FormatJSON FormatType = "json"
+	// Output canonical JSON with stable key ordering and no floating point numbers.
+	FormatJSONCanonical FormatType = "json-canonical"
 )
 
+// supportedFormats are the output formats accepted by the format flag.
+var supportedFormats = []string{string(FormatText), string(FormatJSON), string(FormatJSONCanonical)}
+
 // String returns a string representation of the output format type.
 func (f *FormatType) String() string {
 	return string(*f)
@@ -43,11 +63,11 @@ func (f *FormatType) String() string {
 // Set sets the value of the type to the argument given.
 func (f *FormatType) Set(v string) error {
 	switch strings.ToLower(v) {
-	case string(FormatText), string(FormatJSON):
-		*f = FormatType(v)
+	case string(FormatText), string(FormatJSON), string(FormatJSONCanonical):
+		*f = FormatType(strings.ToLower(v))
 		return nil
 	default:
-		return fmt.Errorf("unknown output format type, must be one of: %s", strings.Join([]string{string(FormatText), string(FormatJSON)}, ", "))
+		return fmt.Errorf("unknown output format type, must be one of: %s", strings.Join(supportedFormats, ", "))
 	}
 }
 
@@ -60,7 +80,11 @@ var (
 	selectedHeight int64
 	force          bool
 	answerYes      bool
+	nonInteractive bool
 	outputFormat   = FormatText
+	listingOptions table.Options
+	showQR         bool
+	qrPNGFilename  string
 )
 
 // GetHeight returns the user-selected block height.
@@ -79,11 +103,26 @@ func GetAnswerYes() bool {
 	return answerYes
 }
 
+// IsNonInteractive returns whether prompting the user is forbidden.
+func IsNonInteractive() bool {
+	return nonInteractive
+}
+
 // OutputFormat returns the format of the command's output.
 func OutputFormat() FormatType {
 	return outputFormat
 }
 
+// IsJSONOutput returns true iff the command's output should be JSON-encoded.
+func IsJSONOutput() bool {
+	return outputFormat == FormatJSON || outputFormat == FormatJSONCanonical
+}
+
+// GetListingOptions returns the user-selected listing rendering options.
+func GetListingOptions() table.Options {
+	return listingOptions
+}
+
 // GetActualHeight returns the user-selected block height if explicitly
 // specified, or the current latest height.
 func GetActualHeight(
@@ -111,6 +150,23 @@ func init() {
 	AnswerYesFlag = flag.NewFlagSet("", flag.ContinueOnError)
 	AnswerYesFlag.BoolVarP(&answerYes, "yes", "y", false, "answer yes to all questions")
 
+	NonInteractiveFlag = flag.NewFlagSet("", flag.ContinueOnError)
+	NonInteractiveFlag.BoolVar(&nonInteractive, "non-interactive", false, "fail instead of prompting for input")
+
+	RequestFlags = flag.NewFlagSet("", flag.ContinueOnError)
+	RequestFlags.DurationVar(&requestTimeout, "timeout", cliConfig.DefaultRequestTimeout, "timeout of each network request (0 disables the timeout)")
+	RequestFlags.UintVar(&requestRetries, "retries", cliConfig.DefaultRequestRetries, "number of times a failed network request is retried")
+
 	FormatFlag = flag.NewFlagSet("", flag.ContinueOnError)
-	FormatFlag.Var(&outputFormat, "format", "output format ["+strings.Join([]string{string(FormatText), string(FormatJSON)}, ",")+"]")
+	FormatFlag.Var(&outputFormat, "format", "output format ["+strings.Join(supportedFormats, ",")+"]")
+
+	ListingFlags = flag.NewFlagSet("", flag.ContinueOnError)
+	ListingFlags.StringSliceVar(&listingOptions.Columns, "columns", nil, "comma-separated list of columns to show")
+	ListingFlags.StringVar(&listingOptions.SortBy, "sort-by", "", "sort by the given column (prefix with - for descending order)")
+	ListingFlags.BoolVar(&listingOptions.NoHeader, "no-header", false, "do not print the header")
+	ListingFlags.BoolVar(&listingOptions.Wide, "wide", false, "show additional columns")
+
+	QRFlags = flag.NewFlagSet("", flag.ContinueOnError)
+	QRFlags.BoolVar(&showQR, "qr", false, "render addresses as QR codes")
+	QRFlags.StringVar(&qrPNGFilename, "qr-png", "", "also write the address QR code to the given PNG file")
 }
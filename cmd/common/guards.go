@@ -0,0 +1,310 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	consensusTx "github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/connection"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/accounts"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/consensusaccounts"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+
+	"github.com/oasisprotocol/cli/config"
+)
+
+// guardConfirmSuffixLen is the number of trailing destination address characters the user needs
+// to type to confirm a transaction tripping a guard rail.
+const guardConfirmSuffixLen = 6
+
+var (
+	// ethDerivedAddresses are the native addresses resolved from Ethereum addresses in this session.
+	ethDerivedAddresses = make(map[types.Address]bool)
+
+	// acknowledgedReservedAddresses are the reserved addresses the user explicitly agreed to send
+	// funds to in this session.
+	acknowledgedReservedAddresses = make(map[types.Address]bool)
+)
+
+// guardTransfer describes the funds moved by a transaction.
+type guardTransfer struct {
+	to     types.Address
+	amount types.BaseUnits
+
+	// fromConsensus is true when the funds are taken from the consensus layer account.
+	fromConsensus bool
+	// toConsensus is true when the funds end up in a consensus layer account.
+	toConsensus bool
+}
+
+// guardFinding is a guard rail tripped by a transaction.
+type guardFinding struct {
+	action  config.GuardAction
+	message string
+}
+
+// guardReport contains the guard rails tripped by a transaction.
+type guardReport struct {
+	to       types.Address
+	findings []*guardFinding
+}
+
+// add records the finding unless the guard rail is disabled.
+func (r *guardReport) add(action config.GuardAction, format string, args ...interface{}) {
+	if action == config.GuardOff {
+		return
+	}
+	r.findings = append(r.findings, &guardFinding{action: action, message: fmt.Sprintf(format, args...)})
+}
+
+// confirmationRequired returns true iff any of the tripped guard rails requires typed confirmation.
+func (r *guardReport) confirmationRequired() bool {
+	for _, f := range r.findings {
+		if f.action == config.GuardConfirm {
+			return true
+		}
+	}
+	return false
+}
+
+// enforce prints the tripped guard rails and asks the user to type the end of the destination
+// address when required.
+func (r *guardReport) enforce() {
+	if r == nil || len(r.findings) == 0 {
+		return
+	}
+	for _, f := range r.findings {
+		fmt.Printf("WARNING: %s\n", f.message)
+	}
+	if !r.confirmationRequired() {
+		return
+	}
+
+	if IsForce() {
+		fmt.Println("Proceeding by force as requested.")
+		return
+	}
+	if answerYes {
+		cobra.CheckErr("typed confirmation required by the guard rails\nUse --force or change the guards in the CLI config to skip it")
+	}
+	CheckInteractive()
+
+	addr := r.to.String()
+	suffix := addr[len(addr)-guardConfirmSuffixLen:]
+	var answer string
+	err := survey.AskOne(&survey.Input{
+		Message: fmt.Sprintf("Type the last %d characters of the destination address to proceed:", guardConfirmSuffixLen),
+	}, &answer)
+	cobra.CheckErr(err)
+	if strings.TrimSpace(answer) != suffix {
+		cobra.CheckErr("signing aborted")
+	}
+}
+
+// rememberEthDerivedAddress records that the given native address was derived from an Ethereum
+// address.
+func rememberEthDerivedAddress(addr types.Address) {
+	ethDerivedAddresses[addr] = true
+}
+
+// AcknowledgeReservedAddress records that the user explicitly agreed to send funds to the given
+// reserved address, so the reserved address guard rail does not trip for it again.
+func AcknowledgeReservedAddress(addr types.Address) {
+	acknowledgedReservedAddresses[addr] = true
+}
+
+// isEthDerivedAddress returns true iff the given native address is known to be derived from an
+// Ethereum address and therefore cannot sign consensus layer transactions.
+func isEthDerivedAddress(cfg *config.Config, addr types.Address) bool {
+	if ethDerivedAddresses[addr] {
+		return true
+	}
+	return CheckAddressIsConsensusCapable(cfg, addr.String()) != nil
+}
+
+// decodeGuardTransfer decodes the funds moved by the given transaction signed by the given
+// address.
+func decodeGuardTransfer(signer types.Address, tx interface{}) (*guardTransfer, bool) {
+	switch tx := tx.(type) {
+	case *consensusTx.Transaction:
+		if tx.Method != staking.MethodTransfer {
+			return nil, false
+		}
+		var body staking.Transfer
+		if cbor.Unmarshal(tx.Body, &body) != nil {
+			return nil, false
+		}
+		return &guardTransfer{
+			to:            types.NewAddressFromConsensus(body.To),
+			amount:        types.NewBaseUnits(body.Amount, types.NativeDenomination),
+			fromConsensus: true,
+			toConsensus:   true,
+		}, true
+	case *types.Transaction:
+		if tx.Call.Format != types.CallFormatPlain {
+			return nil, false
+		}
+
+		switch tx.Call.Method {
+		case "accounts.Transfer":
+			var body accounts.Transfer
+			if cbor.Unmarshal(tx.Call.Body, &body) != nil {
+				return nil, false
+			}
+			return &guardTransfer{to: body.To, amount: body.Amount}, true
+		case "consensus.Deposit":
+			var body consensusaccounts.Deposit
+			if cbor.Unmarshal(tx.Call.Body, &body) != nil {
+				return nil, false
+			}
+			t := guardTransfer{to: signer, amount: body.Amount, fromConsensus: true}
+			if body.To != nil {
+				t.to = *body.To
+			}
+			return &t, true
+		case "consensus.Withdraw":
+			var body consensusaccounts.Withdraw
+			if cbor.Unmarshal(tx.Call.Body, &body) != nil {
+				return nil, false
+			}
+			t := guardTransfer{to: signer, amount: body.Amount, toConsensus: true}
+			if body.To != nil {
+				t.to = *body.To
+			}
+			return &t, true
+		default:
+			return nil, false
+		}
+	default:
+		return nil, false
+	}
+}
+
+// exceedsBalanceShare returns true iff the amount is at or above the given percentage of the
+// balance.
+func exceedsBalanceShare(amount, balance quantity.Quantity, percent uint64) bool {
+	if balance.IsZero() {
+		return false
+	}
+	a := amount.Clone()
+	if a.Mul(quantity.NewFromUint64(100)) != nil {
+		return false
+	}
+	b := balance.Clone()
+	if b.Mul(quantity.NewFromUint64(percent)) != nil {
+		return false
+	}
+	return a.Cmp(b) >= 0
+}
+
+// checkTransactionGuards checks the given transaction against the configured guard rails. Checks
+// requiring network access are skipped in offline mode.
+func checkTransactionGuards(ctx context.Context, npa *NPASelection, signer types.Address, conn connection.Connection, tx interface{}) *guardReport {
+	t, ok := decodeGuardTransfer(signer, tx)
+	if !ok {
+		return nil
+	}
+	cfg := config.Global()
+	guards := &cfg.Guards
+	report := &guardReport{to: t.to}
+
+	if err := CheckAddressNotReserved(cfg, t.to.String()); err != nil && !acknowledgedReservedAddresses[t.to] {
+		report.add(guards.ReservedAddressAction(), "Destination is a reserved address: %s.", err)
+	}
+	if t.toConsensus && isEthDerivedAddress(cfg, t.to) {
+		report.add(guards.EthAddressAction(), "Destination %s is derived from an Ethereum address and will not be able to sign transactions on the consensus layer.", t.to)
+	}
+
+	if conn == nil {
+		return report
+	}
+
+	if action := guards.LargeTransferAction(); action != config.GuardOff {
+		if balance, ok := guardBalance(ctx, npa, conn, signer, t.fromConsensus, t.amount.Denomination); ok &&
+			exceedsBalanceShare(t.amount.Amount, balance, guards.Percent()) {
+			report.add(action, "Transaction moves %d%% or more of the available balance.", guards.Percent())
+		}
+	}
+
+	if action := guards.NewAddressAction(); action != config.GuardOff && !t.to.Equal(signer) {
+		if _, known := GenAccountNames()[t.to.String()]; !known && guardAddressUnused(ctx, npa, conn, t.to, t.toConsensus) {
+			layer := "consensus layer"
+			if !t.toConsensus {
+				layer = npa.ParaTimeName
+			}
+			report.add(action, "Destination %s has no history on %s of network '%s'.", t.to, layer, npa.NetworkName)
+		}
+	}
+
+	return report
+}
+
+// guardBalance returns the available balance of the given account in the given denomination.
+func guardBalance(ctx context.Context, npa *NPASelection, conn connection.Connection, addr types.Address, consensusLayer bool, denom types.Denomination) (quantity.Quantity, bool) {
+	if consensusLayer {
+		acc, err := conn.Consensus().Staking().Account(ctx, &staking.OwnerQuery{
+			Height: consensus.HeightLatest,
+			Owner:  addr.ConsensusAddress(),
+		})
+		if err != nil {
+			txLogger.Warn("failed to query balance for guard rails", "err", err)
+			return quantity.Quantity{}, false
+		}
+		return acc.General.Balance, true
+	}
+
+	balances, err := conn.Runtime(npa.ParaTime).Accounts.Balances(ctx, client.RoundLatest, addr)
+	if err != nil {
+		txLogger.Warn("failed to query balance for guard rails", "err", err)
+		return quantity.Quantity{}, false
+	}
+	return balances.Balances[denom], true
+}
+
+// guardAddressUnused returns true iff the given account has neither a nonce nor any funds.
+func guardAddressUnused(ctx context.Context, npa *NPASelection, conn connection.Connection, addr types.Address, consensusLayer bool) bool {
+	if consensusLayer {
+		acc, err := conn.Consensus().Staking().Account(ctx, &staking.OwnerQuery{
+			Height: consensus.HeightLatest,
+			Owner:  addr.ConsensusAddress(),
+		})
+		if err != nil {
+			txLogger.Warn("failed to query account for guard rails", "err", err)
+			return false
+		}
+		return acc.General.Nonce == 0 &&
+			acc.General.Balance.IsZero() &&
+			acc.Escrow.Active.Balance.IsZero() &&
+			acc.Escrow.Debonding.Balance.IsZero()
+	}
+
+	rt := conn.Runtime(npa.ParaTime)
+	nonce, err := rt.Accounts.Nonce(ctx, client.RoundLatest, addr)
+	if err != nil {
+		txLogger.Warn("failed to query account for guard rails", "err", err)
+		return false
+	}
+	if nonce > 0 {
+		return false
+	}
+	balances, err := rt.Accounts.Balances(ctx, client.RoundLatest, addr)
+	if err != nil {
+		txLogger.Warn("failed to query account for guard rails", "err", err)
+		return false
+	}
+	for _, b := range balances.Balances {
+		if !b.IsZero() {
+			return false
+		}
+	}
+	return true
+}
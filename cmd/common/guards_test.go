@@ -0,0 +1,79 @@
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/consensusaccounts"
+	sdkTesting "github.com/oasisprotocol/oasis-sdk/client-sdk/go/testing"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+
+	"github.com/oasisprotocol/cli/config"
+)
+
+func TestExceedsBalanceShare(t *testing.T) {
+	require := require.New(t)
+
+	balance := *quantity.NewFromUint64(1000)
+	require.False(exceedsBalanceShare(*quantity.NewFromUint64(499), balance, 50))
+	require.True(exceedsBalanceShare(*quantity.NewFromUint64(500), balance, 50))
+	require.True(exceedsBalanceShare(*quantity.NewFromUint64(2000), balance, 100))
+	require.False(exceedsBalanceShare(*quantity.NewFromUint64(1), quantity.Quantity{}, 50))
+}
+
+func TestCheckTransactionGuards(t *testing.T) {
+	require := require.New(t)
+
+	config.ResetDefaults()
+	defer config.ResetDefaults()
+	cfg := config.Global()
+
+	net := cfg.Networks.All["mainnet"]
+	npa := &NPASelection{Network: net, ParaTime: net.ParaTimes.All["sapphire"]}
+	signer := sdkTesting.Alice.Address
+
+	// Transfers to regular addresses trip no guard rails in offline mode.
+	tx := staking.NewTransferTx(0, nil, &staking.Transfer{To: sdkTesting.Bob.Address.ConsensusAddress()})
+	report := checkTransactionGuards(context.Background(), npa, signer, nil, tx)
+	require.Empty(report.findings)
+
+	// Consensus transfers to ParaTime internal addresses require confirmation.
+	sapphireAddr := staking.NewRuntimeAddress(npa.ParaTime.Namespace())
+	tx = staking.NewTransferTx(0, nil, &staking.Transfer{To: sapphireAddr})
+	report = checkTransactionGuards(context.Background(), npa, signer, nil, tx)
+	require.Len(report.findings, 1)
+	require.True(report.confirmationRequired())
+
+	// Explicitly acknowledged reserved addresses no longer trip the guard rail.
+	AcknowledgeReservedAddress(types.NewAddressFromConsensus(sapphireAddr))
+	defer delete(acknowledgedReservedAddresses, types.NewAddressFromConsensus(sapphireAddr))
+	report = checkTransactionGuards(context.Background(), npa, signer, nil, tx)
+	require.Empty(report.findings)
+
+	// Withdrawals to Ethereum-derived addresses require confirmation.
+	dave := sdkTesting.Dave.Address
+	wtx := consensusaccounts.NewWithdrawTx(nil, &consensusaccounts.Withdraw{
+		To:     &dave,
+		Amount: types.NewBaseUnits(*quantity.NewFromUint64(1), types.NativeDenomination),
+	})
+	report = checkTransactionGuards(context.Background(), npa, signer, nil, wtx)
+	require.Len(report.findings, 1)
+	require.True(report.confirmationRequired())
+
+	// Guard rails can be downgraded or disabled.
+	cfg.Guards.EthAddress = config.GuardWarn
+	report = checkTransactionGuards(context.Background(), npa, signer, nil, wtx)
+	require.Len(report.findings, 1)
+	require.False(report.confirmationRequired())
+
+	cfg.Guards.EthAddress = config.GuardOff
+	report = checkTransactionGuards(context.Background(), npa, signer, nil, wtx)
+	require.Empty(report.findings)
+
+	// Other transactions are not checked.
+	require.Nil(checkTransactionGuards(context.Background(), npa, signer, nil, staking.NewReclaimEscrowTx(0, nil, &staking.ReclaimEscrow{})))
+}
@@ -0,0 +1,196 @@
+package common
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+
+	coreErrors "github.com/oasisprotocol/oasis-core/go/common/errors"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+
+	"github.com/oasisprotocol/cli/config"
+)
+
+// HintsFilename is the name of the file in the configuration directory containing extra hints.
+const HintsFilename = "hints.yaml"
+
+// AnyCode is the error code matching any error of a module which has no more specific hint.
+const AnyCode = 0
+
+// HintContext are the variables available to hint templates.
+type HintContext struct {
+	// Module is the module which returned the error.
+	Module string
+	// Code is the error code.
+	Code uint32
+	// Message is the error message.
+	Message string
+}
+
+// Hint is an actionable hint for a failure with the given module and error code.
+type Hint struct {
+	// Module is the name of the module returning the error.
+	Module string `yaml:"module"`
+	// Code is the error code or AnyCode to match any error of the module.
+	Code uint32 `yaml:"code,omitempty"`
+	// Text is the hint text. It is a Go template with HintContext as data.
+	Text string `yaml:"hint"`
+}
+
+type hintKey struct {
+	module string
+	code   uint32
+}
+
+var (
+	hintsLock   sync.Mutex
+	hintsLoaded bool
+	hints       = make(map[hintKey]*template.Template)
+)
+
+// RegisterHint registers the given hint, replacing any existing hint for the same module and code.
+func RegisterHint(h Hint) error {
+	if h.Module == "" {
+		return fmt.Errorf("hint module must not be empty")
+	}
+	tpl, err := template.New(fmt.Sprintf("%s/%d", h.Module, h.Code)).Parse(h.Text)
+	if err != nil {
+		return fmt.Errorf("malformed hint for module '%s' code %d: %w", h.Module, h.Code, err)
+	}
+
+	hintsLock.Lock()
+	defer hintsLock.Unlock()
+	hints[hintKey{h.Module, h.Code}] = tpl
+	return nil
+}
+
+// LoadHintsFile registers the hints from the given YAML file containing a list of hints.
+func LoadHintsFile(fn string) error {
+	data, err := os.ReadFile(fn)
+	if err != nil {
+		return err
+	}
+	var extra []Hint
+	if err = yaml.Unmarshal(data, &extra); err != nil {
+		return fmt.Errorf("malformed hints file '%s': %w", fn, err)
+	}
+	for _, h := range extra {
+		if err = RegisterHint(h); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadUserHints loads the extra hints from the configuration directory, if any.
+func loadUserHints() {
+	hintsLock.Lock()
+	loaded := hintsLoaded
+	hintsLoaded = true
+	hintsLock.Unlock()
+	if loaded {
+		return
+	}
+
+	fn := filepath.Join(config.DefaultDirectory(), HintsFilename)
+	if err := LoadHintsFile(fn); err != nil && !errors.Is(err, os.ErrNotExist) {
+		fmt.Fprintf(os.Stderr, "WARNING: Failed to load hints: %s\n", err)
+	}
+}
+
+// ErrorHint returns the hint for an error with the given module and code or an empty string if
+// there is no hint.
+func ErrorHint(module string, code uint32, message string) string {
+	loadUserHints()
+
+	hintsLock.Lock()
+	tpl, ok := hints[hintKey{module, code}]
+	if !ok {
+		tpl, ok = hints[hintKey{module, AnyCode}]
+	}
+	hintsLock.Unlock()
+	if !ok {
+		return ""
+	}
+
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, &HintContext{Module: module, Code: code, Message: message}); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(buf.String())
+}
+
+// withHint appends the hint for an error with the given module and code to the message.
+func withHint(msg, module string, code uint32, message string) string {
+	if hint := ErrorHint(module, code, message); hint != "" {
+		return fmt.Sprintf("%s\nHint: %s", msg, hint)
+	}
+	return msg
+}
+
+// errorWithHint appends the hint for the given consensus layer error, if any.
+func errorWithHint(err error) error {
+	module, code := coreErrors.Code(err)
+	if hint := ErrorHint(module, code, err.Error()); hint != "" {
+		return fmt.Errorf("%w\nHint: %s", err, hint)
+	}
+	return err
+}
+
+func mustRegisterHint(h Hint) {
+	if err := RegisterHint(h); err != nil {
+		panic(err)
+	}
+}
+
+// registerCoreHint registers a hint for the given consensus layer error.
+func registerCoreHint(err error, text string) {
+	module, code := coreErrors.Code(err)
+	mustRegisterHint(Hint{Module: module, Code: code, Text: text})
+}
+
+func init() {
+	// Consensus layer.
+	registerCoreHint(transaction.ErrInvalidNonce, "Another transaction from this account may be pending. Wait for it to be included or pass the correct --nonce.")
+	registerCoreHint(transaction.ErrInsufficientFeeBalance, "The account cannot pay the transaction fee. Check its balance with `oasis account show` or lower --gas-price.")
+	registerCoreHint(transaction.ErrGasPriceTooLow, "Increase the fee with --gas-price. See `oasis network show gas-costs` for the gas costs.")
+	registerCoreHint(transaction.ErrUpgradePending, "A network upgrade is pending. Retry once the upgrade has been completed.")
+	registerCoreHint(staking.ErrInsufficientBalance, "Check the balance with `oasis account show`.")
+	registerCoreHint(staking.ErrForbidden, "The account is not allowed to perform this operation. Check the allowances with `oasis account show`.")
+	registerCoreHint(staking.ErrUnderMinDelegationAmount, "Delegate at least the minimum amount shown by `oasis network show parameters`.")
+	registerCoreHint(staking.ErrUnderMinTransferAmount, "Transfer at least the minimum amount shown by `oasis network show parameters`.")
+	registerCoreHint(staking.ErrInsufficientStake, "The escrow does not cover the stake thresholds. See `oasis network show native-token` for the thresholds.")
+	registerCoreHint(governance.ErrNotEligible, "Only entities with a node in the validator set may vote. Check `oasis network show validators`.")
+	registerCoreHint(governance.ErrVotingIsClosed, "Voting on this proposal has ended. List active proposals with `oasis network governance list`.")
+	registerCoreHint(governance.ErrNoSuchProposal, "List the proposals with `oasis network governance list`.")
+	registerCoreHint(registry.ErrNoSuchEntity, "Register the entity first with `oasis account entity register <entity.json>`.")
+	registerCoreHint(registry.ErrEntityHasNodes, "Deregister or wait for the expiration of all nodes of the entity first.")
+
+	// ParaTime modules.
+	for _, h := range []Hint{
+		{"core", 4, "Another transaction from this account may be pending. Wait for it to be included or pass the correct --nonce."},
+		{"core", 5, "The account cannot pay the transaction fee. Check its balance with `oasis account show` or pay in another denomination with --fee-denom."},
+		{"core", 12, "The transaction ran out of gas. Increase the limit with --gas-limit."},
+		{"core", 20, "Increase the fee with --gas-price. The minimum gas price is reported by `oasis paratime show parameters`."},
+		{"core", 26, "A transaction with a lower nonce is still pending. Wait for it to be included or pass the correct --nonce."},
+		{"accounts", 2, "Check the ParaTime balance with `oasis account show`. To move tokens from the consensus layer, use `oasis account deposit`."},
+		{"consensus_accounts", AnyCode, "Check the consensus and ParaTime balances with `oasis account show --show-delegations`."},
+		{"evm", 8, "The EVM call reverted. The error message contains the ABI-encoded revert reason."},
+		{"evm", AnyCode, "Check the call arguments and the account balance with `oasis account show`."},
+		{"contracts", AnyCode, "Check the contract instance with `oasis contract show <instance-id>` and the uploaded code with `oasis contract show-code <code-id>`."},
+		{"rofl", AnyCode, "Check the app configuration with `oasis rofl show` and the admin account of the deployment in the manifest."},
+		{"roflmarket", AnyCode, "Check the offer parameters and the ParaTime balance with `oasis account show`."},
+	} {
+		mustRegisterHint(h)
+	}
+}
@@ -0,0 +1,42 @@
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+func TestErrorHint(t *testing.T) {
+	require := require.New(t)
+
+	// Exact match.
+	require.Contains(ErrorHint("core", 12, "out of gas"), "--gas-limit")
+	// Module-wide fallback.
+	require.Contains(ErrorHint("rofl", 42, "whatever"), "oasis rofl show")
+	// No hint.
+	require.Empty(ErrorHint("unknown", 1, "whatever"))
+
+	// Consensus layer errors.
+	err := errorWithHint(staking.ErrInsufficientBalance)
+	require.ErrorIs(err, staking.ErrInsufficientBalance)
+	require.Contains(err.Error(), "Hint: ")
+
+	// Extra hints from file with template variables.
+	fn := filepath.Join(t.TempDir(), HintsFilename)
+	require.NoError(os.WriteFile(fn, []byte(`
+- module: mymodule
+  code: 3
+  hint: "Module {{.Module}} failed with code {{.Code}}: {{.Message}}"
+`), 0o600))
+	require.NoError(LoadHintsFile(fn))
+	require.Equal("Module mymodule failed with code 3: boom", ErrorHint("mymodule", 3, "boom"))
+
+	require.NoError(os.WriteFile(fn, []byte(`- module: mymodule
+  hint: "{{.Unknown"
+`), 0o600))
+	require.Error(LoadHintsFile(fn))
+}
@@ -1,9 +1,11 @@
 package common
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 	"unicode/utf8"
 
@@ -28,6 +30,66 @@ func PrettyJSONMarshal(v interface{}) ([]byte, error) {
 	return formatted, nil
 }
 
+// maxSafeJSONInteger is the largest integer that can be represented exactly by JSON parsers which
+// use IEEE 754 double precision numbers.
+const maxSafeJSONInteger = 1<<53 - 1
+
+// CanonicalJSONMarshal returns the canonical JSON encoding of v. Object keys are sorted, integers
+// which cannot be represented exactly as IEEE 754 doubles and all non-integer numbers are encoded
+// as strings.
+func CanonicalJSONMarshal(v interface{}) ([]byte, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal to JSON: %w", err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var generic interface{}
+	if err = dec.Decode(&generic); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON: %w", err)
+	}
+
+	// The built-in encoder sorts map keys so only numbers need to be normalized.
+	formatted, err := json.MarshalIndent(canonicalizeJSONNumbers(generic), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal to canonical JSON: %w", err)
+	}
+	return formatted, nil
+}
+
+// canonicalizeJSONNumbers converts numbers that are not safe integers into strings.
+func canonicalizeJSONNumbers(v interface{}) interface{} {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		for k, e := range vv {
+			vv[k] = canonicalizeJSONNumbers(e)
+		}
+		return vv
+	case []interface{}:
+		for i, e := range vv {
+			vv[i] = canonicalizeJSONNumbers(e)
+		}
+		return vv
+	case json.Number:
+		n, err := vv.Int64()
+		if err != nil || n > maxSafeJSONInteger || n < -maxSafeJSONInteger {
+			return vv.String()
+		}
+		return n
+	default:
+		return v
+	}
+}
+
+// JSONMarshalOutput returns the JSON encoding of v according to the selected output format.
+func JSONMarshalOutput(v interface{}) ([]byte, error) {
+	if OutputFormat() == FormatJSONCanonical {
+		return CanonicalJSONMarshal(v)
+	}
+	return PrettyJSONMarshal(v)
+}
+
 // JSONMarshalKey encodes k as UTF-8 string if valid, or Base64 otherwise.
 func JSONMarshalKey(k interface{}) (keyJSON []byte, err error) {
 	keyBytes, ok := k.([]byte)
@@ -97,6 +159,8 @@ func JSONMarshalUniversalValue(v interface{}) []byte {
 
 			e = append(e, fmt.Sprintf("%s:%s", keyJSON, valJSON))
 		}
+		// Sort entries by key so that the output is deterministic.
+		sort.Strings(e)
 		return []byte(fmt.Sprintf("{%s}", strings.Join(e, ",")))
 	}
 
@@ -136,7 +200,7 @@ func PrettyPrint(npa *NPASelection, prefix string, blob interface{}) string {
 		coreSignature.UnsafeResetChainContext()
 		coreSignature.SetChainContext(npa.Network.ChainContext)
 		var pp strings.Builder
-		rtx.PrettyPrint(ctx, prefix, &pp)
+		wrapMultisigPrettyPrinter(rtx).PrettyPrint(ctx, prefix, &pp)
 		ret = pp.String()
 	default:
 		pp, err := PrettyJSONMarshal(blob)
@@ -0,0 +1,47 @@
+package common
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalJSONMarshal(t *testing.T) {
+	require := require.New(t)
+
+	type inner struct {
+		Zeta  string `json:"zeta"`
+		Alpha uint64 `json:"alpha"`
+	}
+
+	bigInt, _ := new(big.Int).SetString("100000000000000000000", 10)
+	for _, tc := range []struct {
+		value    interface{}
+		expected string
+	}{
+		{42, "42"},
+		{uint64(1 << 60), `"1152921504606846976"`},
+		{1.5, `"1.5"`},
+		{bigInt, `"100000000000000000000"`},
+		{inner{Zeta: "z", Alpha: 1}, "{\n  \"alpha\": 1,\n  \"zeta\": \"z\"\n}"},
+		{map[string]interface{}{"b": 2, "a": []interface{}{0.25, "x"}}, "{\n  \"a\": [\n    \"0.25\",\n    \"x\"\n  ],\n  \"b\": 2\n}"},
+	} {
+		out, err := CanonicalJSONMarshal(tc.value)
+		require.NoError(err)
+		require.Equal(tc.expected, string(out))
+	}
+}
+
+func TestJSONMarshalUniversalValueDeterministic(t *testing.T) {
+	require := require.New(t)
+
+	v := map[interface{}]interface{}{
+		"c": 3,
+		"a": 1,
+		"b": map[interface{}]interface{}{"y": 2, "x": 1},
+	}
+	for i := 0; i < 10; i++ {
+		require.Equal(`{"a":1,"b":{"x":1,"y":2},"c":3}`, string(JSONMarshalUniversalValue(v)))
+	}
+}
@@ -0,0 +1,83 @@
+package common
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+)
+
+// LoggingFlags configure the diagnostic logging (verbosity and log file).
+var LoggingFlags *flag.FlagSet
+
+var (
+	logVerbose bool
+	logDebug   bool
+	logFile    string
+
+	loggingEnabled bool
+)
+
+// InitLogging initializes the logging subsystem according to the logging flags. Without any of
+// the flags, all log output is discarded.
+func InitLogging() error {
+	var lvl logging.Level
+	switch {
+	case logDebug:
+		lvl = logging.LevelDebug
+	case logVerbose, logFile != "":
+		lvl = logging.LevelInfo
+	default:
+		return nil
+	}
+
+	var w io.Writer = os.Stderr
+	if logFile != "" {
+		f, err := os.OpenFile(logFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+		if err != nil {
+			return fmt.Errorf("failed to open log file: %w", err)
+		}
+		w = f
+	}
+
+	if err := logging.Initialize(w, logging.FmtLogfmt, lvl, nil); err != nil {
+		return err
+	}
+	loggingEnabled = true
+	return nil
+}
+
+// IsLoggingEnabled returns true iff diagnostic logging was requested.
+func IsLoggingEnabled() bool {
+	return loggingEnabled
+}
+
+// NewLogger returns a logger for the given CLI module.
+func NewLogger(module string) *logging.Logger {
+	return logging.GetLogger("cli/" + module)
+}
+
+// LogStage logs the start of the given stage and returns a function which logs its completion
+// together with the elapsed time. The stage is also reported in the JSON progress mode.
+//
+// Use as `defer LogStage(logger, "stage")()`.
+func LogStage(logger *logging.Logger, stage string, keyvals ...interface{}) func() {
+	start := time.Now()
+	logger.Info("stage started", append([]interface{}{"stage", stage}, keyvals...)...)
+	p := StartProgress(stage, 0, "")
+	return func() {
+		p.Finish()
+		logger.Info("stage finished", append([]interface{}{"stage", stage, "duration", time.Since(start)}, keyvals...)...)
+	}
+}
+
+func init() {
+	LoggingFlags = flag.NewFlagSet("", flag.ContinueOnError)
+	LoggingFlags.BoolVar(&logVerbose, "verbose", false, "log requests, transaction steps and build stages to stderr")
+	LoggingFlags.BoolVar(&logDebug, "debug", false, "like --verbose, but also log debugging details")
+	LoggingFlags.StringVar(&logFile, "log-file", "", "write the log to the given file instead of stderr (implies --verbose)")
+}
@@ -0,0 +1,207 @@
+package common
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	consensusPretty "github.com/oasisprotocol/oasis-core/go/common/prettyprint"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/testing"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+
+	"github.com/oasisprotocol/cli/config"
+	"github.com/oasisprotocol/cli/wallet/multisig"
+)
+
+// multisigSignerAccounts returns the names of the local accounts (including test accounts) of
+// each signer of the given multisig configuration.
+func multisigSignerAccounts(cfg *config.Config, msCfg *types.MultisigConfig) [][]string {
+	accounts := make([][]string, len(msCfg.Signers))
+	for i, s := range msCfg.Signers {
+		addr := multisig.SignerAddress(s.PublicKey).String()
+		for name, acc := range cfg.Wallet.All {
+			if acc.Address == addr {
+				accounts[i] = append(accounts[i], name)
+			}
+		}
+		for name, acc := range testing.TestAccounts {
+			if acc.Address.String() == addr {
+				accounts[i] = append(accounts[i], "test:"+name)
+			}
+		}
+		sort.Strings(accounts[i])
+	}
+	return accounts
+}
+
+// signMultisigTransaction collects the signatures of the local accounts of the multisig signers
+// until the threshold is reached.
+func signMultisigTransaction(ts *types.TransactionSigner, sigCtx signature.Context, msCfg *types.MultisigConfig) error {
+	cfg := config.Global()
+	accounts := multisigSignerAccounts(cfg, msCfg)
+
+	fmt.Printf("Multisig signers (threshold %d):\n", msCfg.Threshold)
+	for i, s := range msCfg.Signers {
+		local := "no local account"
+		if len(accounts[i]) > 0 {
+			local = accounts[i][0]
+		}
+		fmt.Printf("  %d. %s (weight %d): %s\n", i+1, multisig.SignerAddress(s.PublicKey), s.Weight, local)
+	}
+
+	var weight uint64
+	for i, s := range msCfg.Signers {
+		if weight >= msCfg.Threshold {
+			break
+		}
+		if len(accounts[i]) == 0 {
+			continue
+		}
+		name := accounts[i][0]
+		if !confirmMultisigSigner(name, s.Weight) {
+			continue
+		}
+
+		acc := LoadAccount(cfg, name)
+		if err := ts.AppendSign(sigCtx, acc.Signer()); err != nil {
+			return fmt.Errorf("failed to sign transaction with account '%s': %w", name, err)
+		}
+		weight += s.Weight
+	}
+	if weight < msCfg.Threshold {
+		return fmt.Errorf("collected signatures of weight %d, but the multisig threshold is %d", weight, msCfg.Threshold)
+	}
+	return nil
+}
+
+// confirmMultisigSigner asks the user whether to sign with the given account.
+func confirmMultisigSigner(name string, weight uint64) bool {
+	msg := fmt.Sprintf("Sign with account '%s' (weight %d)?", name, weight)
+	if answerYes {
+		fmt.Printf("? %s Yes\n", msg)
+		return true
+	}
+	CheckInteractive()
+
+	proceed := true
+	err := survey.AskOne(&survey.Confirm{Message: msg, Default: true}, &proceed)
+	cobra.CheckErr(err)
+	return proceed
+}
+
+// hasMultisigSigner returns true iff any of the transaction signers is a multisig account.
+func hasMultisigSigner(tx *types.Transaction) bool {
+	for _, si := range tx.AuthInfo.SignerInfo {
+		if si.AddressSpec.Multisig != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// wrapMultisigPrettyPrinter wraps ParaTime transactions with multisig signers, since the SDK
+// pretty printer only supports signature address specifications.
+func wrapMultisigPrettyPrinter(pp consensusPretty.PrettyPrinter) consensusPretty.PrettyPrinter {
+	switch tx := pp.(type) {
+	case *types.Transaction:
+		if hasMultisigSigner(tx) {
+			return &multisigPrettyTransaction{tx: tx}
+		}
+	case *types.UnverifiedTransaction:
+		var inner types.Transaction
+		if err := cbor.Unmarshal(tx.Body, &inner); err == nil && hasMultisigSigner(&inner) {
+			return &multisigPrettyUnverifiedTransaction{ut: tx, tx: &inner}
+		}
+	}
+	return pp
+}
+
+// multisigPrettyTransaction pretty-prints a ParaTime transaction with multisig signers.
+type multisigPrettyTransaction struct {
+	tx *types.Transaction
+}
+
+// PrettyPrint implements consensusPretty.PrettyPrinter.
+func (p *multisigPrettyTransaction) PrettyPrint(ctx context.Context, prefix string, w io.Writer) {
+	const signersHeader = "Authorized signer(s):\n"
+
+	// Print the transaction without signers and insert them afterwards.
+	tx := *p.tx
+	tx.AuthInfo.SignerInfo = nil
+	var out strings.Builder
+	tx.PrettyPrint(ctx, prefix, &out)
+	before, after, _ := strings.Cut(out.String(), prefix+signersHeader)
+
+	fmt.Fprint(w, before)
+	fmt.Fprint(w, prefix+signersHeader)
+	for idx, si := range p.tx.AuthInfo.SignerInfo {
+		switch {
+		case si.AddressSpec.Multisig != nil:
+			ms := si.AddressSpec.Multisig
+			fmt.Fprintf(w, "%s  %d. %s (multisig, threshold %d)\n", prefix, idx+1, types.NewAddressFromMultisig(ms), ms.Threshold)
+			for _, s := range ms.Signers {
+				fmt.Fprintf(w, "%s       - %s (weight %d)\n", prefix, s.PublicKey, s.Weight)
+			}
+		case si.AddressSpec.Signature != nil:
+			fmt.Fprintf(w, "%s  %d. %s\n", prefix, idx+1, si.AddressSpec.Signature.PublicKey())
+		}
+		fmt.Fprintf(w, "%s     Nonce: %d\n", prefix, si.Nonce)
+	}
+	fmt.Fprint(w, after)
+}
+
+// PrettyType implements consensusPretty.PrettyPrinter.
+func (p *multisigPrettyTransaction) PrettyType() (interface{}, error) {
+	return p.tx, nil
+}
+
+// multisigPrettyUnverifiedTransaction pretty-prints a signed ParaTime transaction with multisig
+// signers.
+type multisigPrettyUnverifiedTransaction struct {
+	ut *types.UnverifiedTransaction
+	tx *types.Transaction
+}
+
+// PrettyPrint implements consensusPretty.PrettyPrinter.
+func (p *multisigPrettyUnverifiedTransaction) PrettyPrint(ctx context.Context, prefix string, w io.Writer) {
+	fmt.Fprintf(w, "%sHash: %s\n", prefix, p.ut.Hash())
+
+	fmt.Fprintf(w, "%sSigner(s):\n", prefix)
+	sigCtx, _ := ctx.Value(signature.ContextKeySigContext).(signature.Context)
+	var n int
+	for i, ap := range p.ut.AuthProofs {
+		if i >= len(p.tx.AuthInfo.SignerInfo) {
+			break
+		}
+		pks, sigs, err := p.tx.AuthInfo.SignerInfo[i].AddressSpec.Batch(ap)
+		if err != nil {
+			fmt.Fprintf(w, "%s  <error: %s>\n", prefix, fmt.Errorf("transaction: auth proof %d batch: %w", i, err))
+			continue
+		}
+		for j, pk := range pks {
+			n++
+			fmt.Fprintf(w, "%s  %d. %s\n", prefix, n, pk)
+			fmt.Fprintf(w, "%s     (signature: %s)\n", prefix, base64.StdEncoding.EncodeToString(sigs[j]))
+			if sigCtx != nil && !pk.Verify(sigCtx.Derive(), p.ut.Body, sigs[j]) {
+				fmt.Fprintf(w, "%s     [INVALID SIGNATURE]\n", prefix)
+			}
+		}
+	}
+
+	fmt.Fprintf(w, "%sContent:\n", prefix)
+	(&multisigPrettyTransaction{tx: p.tx}).PrettyPrint(ctx, prefix+"  ", w)
+}
+
+// PrettyType implements consensusPretty.PrettyPrinter.
+func (p *multisigPrettyUnverifiedTransaction) PrettyType() (interface{}, error) {
+	return p.ut, nil
+}
@@ -0,0 +1,173 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+
+	cliConfig "github.com/oasisprotocol/cli/config"
+)
+
+// priceRequestTimeout is the timeout of a single token price request. Prices are optional, so a
+// slow provider must not hold up the command.
+const priceRequestTimeout = 5 * time.Second
+
+var (
+	priceLogger = NewLogger("price")
+
+	priceCacheLock sync.Mutex
+	// priceCache contains the fetched token prices by token symbol. A nil price marks a token
+	// whose price is unavailable.
+	priceCache = make(map[string]*float64)
+)
+
+// TokenPrice returns the fiat price of a single token with the given symbol using the configured
+// price provider. It returns false when no provider is configured or the price is unavailable.
+func TokenPrice(ctx context.Context, symbol string) (float64, bool) {
+	prices := &cliConfig.Global().Prices
+	if !prices.Enabled() || symbol == "" {
+		return 0, false
+	}
+
+	priceCacheLock.Lock()
+	defer priceCacheLock.Unlock()
+	if price, ok := priceCache[symbol]; ok {
+		if price == nil {
+			return 0, false
+		}
+		return *price, true
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, priceRequestTimeout)
+	defer cancel()
+	price, err := fetchTokenPrice(ctx, prices, symbol)
+	if err != nil {
+		priceLogger.Debug("failed to fetch token price", "symbol", symbol, "err", err)
+		priceCache[symbol] = nil
+		return 0, false
+	}
+	priceCache[symbol] = &price
+	return price, true
+}
+
+// fetchTokenPrice queries the configured price provider for the fiat price of the token.
+func fetchTokenPrice(ctx context.Context, prices *cliConfig.Prices, symbol string) (float64, error) {
+	currency := prices.FiatCurrency()
+
+	switch prices.Provider {
+	case cliConfig.PriceProviderCoinGecko:
+		id := prices.CoinGeckoID(symbol)
+		if id == "" {
+			return 0, fmt.Errorf("unknown CoinGecko coin ID of token '%s'", symbol)
+		}
+		base := prices.URL
+		if base == "" {
+			base = cliConfig.DefaultCoinGeckoURL
+		}
+		query := url.Values{"ids": {id}, "vs_currencies": {currency}}
+
+		var rsp map[string]map[string]float64
+		if err := getPriceJSON(ctx, base+"?"+query.Encode(), &rsp); err != nil {
+			return 0, err
+		}
+		price, ok := rsp[id][currency]
+		if !ok {
+			return 0, fmt.Errorf("price of '%s' in '%s' missing in response", id, currency)
+		}
+		return price, nil
+	case cliConfig.PriceProviderCustom:
+		priceURL := strings.NewReplacer(
+			cliConfig.PricePlaceholderSymbol, url.PathEscape(symbol),
+			cliConfig.PricePlaceholderCurrency, url.PathEscape(currency),
+		).Replace(prices.URL)
+
+		var rsp struct {
+			Price *float64 `json:"price"`
+		}
+		if err := getPriceJSON(ctx, priceURL, &rsp); err != nil {
+			return 0, err
+		}
+		if rsp.Price == nil {
+			return 0, fmt.Errorf("price missing in response")
+		}
+		return *rsp.Price, nil
+	default:
+		return 0, fmt.Errorf("unsupported price provider '%s'", prices.Provider)
+	}
+}
+
+// getPriceJSON fetches the given URL and decodes the JSON response.
+func getPriceJSON(ctx context.Context, priceURL string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, priceURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	rsp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+	if rsp.StatusCode != http.StatusOK {
+		return fmt.Errorf("price request failed: %s", rsp.Status)
+	}
+	return json.NewDecoder(rsp.Body).Decode(v)
+}
+
+// FormatFiatValue formats the fiat value of the given amount of base units of a token with the
+// given number of decimals and the given price.
+func FormatFiatValue(amount quantity.Quantity, decimals uint8, price float64, currency string) string {
+	value := new(big.Float).SetInt(amount.ToBigInt())
+	scale := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil))
+	value.Quo(value, scale)
+	value.Mul(value, big.NewFloat(price))
+	return fmt.Sprintf("~%s %s", value.Text('f', 2), strings.ToUpper(currency))
+}
+
+// FiatValue returns the approximate fiat value of the given amount of base units of the token with
+// the given symbol and number of decimals or an empty string when the price is not available.
+func FiatValue(ctx context.Context, symbol string, decimals uint8, amount quantity.Quantity) string {
+	price, ok := TokenPrice(ctx, symbol)
+	if !ok {
+		return ""
+	}
+	return FormatFiatValue(amount, decimals, price, cliConfig.Global().Prices.FiatCurrency())
+}
+
+// ConsensusFiatValue returns the approximate fiat value of the given amount of the consensus layer
+// token of the network or an empty string when the price is not available.
+func ConsensusFiatValue(ctx context.Context, net *config.Network, amount quantity.Quantity) string {
+	return FiatValue(ctx, net.Denomination.Symbol, net.Denomination.Decimals, amount)
+}
+
+// ParaTimeFiatValue returns the approximate fiat value of the given amount of a ParaTime token or
+// an empty string when the price is not available.
+func ParaTimeFiatValue(ctx context.Context, pt *config.ParaTime, amount types.BaseUnits) string {
+	denom, ok := pt.Denominations[config.NativeDenominationKey]
+	if !amount.Denomination.IsNative() {
+		denom, ok = pt.Denominations[string(amount.Denomination)]
+	}
+	if !ok || denom == nil {
+		return ""
+	}
+	return FiatValue(ctx, denom.Symbol, denom.Decimals, amount.Amount)
+}
+
+// FiatValueSuffix formats the given fiat value to be appended to a token amount. An empty string
+// is returned for an empty value.
+func FiatValueSuffix(value string) string {
+	if value == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (%s, estimate)", value)
+}
@@ -0,0 +1,71 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+
+	cliConfig "github.com/oasisprotocol/cli/config"
+)
+
+func TestFormatFiatValue(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal("~12.34 USD", FormatFiatValue(*quantity.NewFromUint64(123_400_000_000), 9, 0.1, "usd"))
+	require.Equal("~0.00 EUR", FormatFiatValue(*quantity.NewFromUint64(1), 18, 2.5, "eur"))
+	require.Equal("~2.50 EUR", FormatFiatValue(*quantity.NewFromUint64(1), 0, 2.5, "eur"))
+
+	require.Empty(FiatValueSuffix(""))
+	require.Equal(" (~2.50 EUR, estimate)", FiatValueSuffix("~2.50 EUR"))
+}
+
+func TestFetchTokenPrice(t *testing.T) {
+	require := require.New(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/simple/price":
+			ids, currency := r.URL.Query().Get("ids"), r.URL.Query().Get("vs_currencies")
+			if ids != "oasis-network" {
+				fmt.Fprint(w, "{}")
+				return
+			}
+			fmt.Fprintf(w, `{"%s":{"%s":0.05}}`, ids, currency)
+		case "/price/ROSE/eur":
+			fmt.Fprint(w, `{"price":0.04}`)
+		case "/price/TEST/eur":
+			fmt.Fprint(w, `{}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	ctx := context.Background()
+
+	prices := &cliConfig.Prices{Provider: cliConfig.PriceProviderCoinGecko, URL: srv.URL + "/simple/price"}
+	price, err := fetchTokenPrice(ctx, prices, "ROSE")
+	require.NoError(err)
+	require.Equal(0.05, price)
+	_, err = fetchTokenPrice(ctx, prices, "TEST")
+	require.Error(err, "unknown coin ID")
+	prices.CoinIDs = map[string]string{"TEST": "test-token"}
+	_, err = fetchTokenPrice(ctx, prices, "TEST")
+	require.Error(err, "price missing in response")
+
+	prices = &cliConfig.Prices{Provider: cliConfig.PriceProviderCustom, URL: srv.URL + "/price/{symbol}/{currency}", Currency: "EUR"}
+	price, err = fetchTokenPrice(ctx, prices, "ROSE")
+	require.NoError(err)
+	require.Equal(0.04, price)
+	_, err = fetchTokenPrice(ctx, prices, "TEST")
+	require.Error(err, "price missing in response")
+
+	prices.URL = srv.URL + "/missing"
+	_, err = fetchTokenPrice(ctx, prices, "ROSE")
+	require.Error(err)
+}
@@ -0,0 +1,213 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	flag "github.com/spf13/pflag"
+)
+
+// ProgressMode specifies how the progress of long operations is reported.
+type ProgressMode string
+
+// Supported progress modes for the progress flag.
+const (
+	// ProgressText reports the progress of long operations as human-readable text.
+	ProgressText ProgressMode = "text"
+	// ProgressJSON reports the progress of long operations as JSON events on stderr, one per line.
+	ProgressJSON ProgressMode = "json"
+)
+
+// String returns a string representation of the progress mode.
+func (m *ProgressMode) String() string {
+	return string(*m)
+}
+
+// Set sets the value of the mode to the argument given.
+func (m *ProgressMode) Set(v string) error {
+	switch ProgressMode(strings.ToLower(v)) {
+	case ProgressText, ProgressJSON:
+		*m = ProgressMode(strings.ToLower(v))
+		return nil
+	default:
+		return fmt.Errorf("unknown progress mode, must be one of: %s, %s", ProgressText, ProgressJSON)
+	}
+}
+
+// Type returns the type of the flag.
+func (m *ProgressMode) Type() string {
+	return "ProgressMode"
+}
+
+// progressMinInterval is the minimum interval between two progress events of the same stage.
+const progressMinInterval = 500 * time.Millisecond
+
+// ProgressFlags configure how the progress of long operations is reported.
+var ProgressFlags *flag.FlagSet
+
+var (
+	progressMode = ProgressText
+
+	progressLock   sync.Mutex
+	progressOutput io.Writer = os.Stderr
+)
+
+// ProgressEvent is a machine-readable progress event emitted in the JSON progress mode.
+type ProgressEvent struct {
+	// Event is the kind of the event: stage_started, progress or stage_finished.
+	Event string `json:"event"`
+	// Stage is the name of the operation stage.
+	Stage string `json:"stage"`
+	// Done is the number of completed units of work.
+	Done uint64 `json:"done,omitempty"`
+	// Total is the total number of units of work, if known.
+	Total uint64 `json:"total,omitempty"`
+	// Unit is the unit of work (e.g. rounds, blocks or bytes).
+	Unit string `json:"unit,omitempty"`
+	// Percent is the completed percentage, if the total is known.
+	Percent *float64 `json:"percent,omitempty"`
+	// ETA is the estimated number of seconds remaining, if the total is known.
+	ETA *float64 `json:"eta_seconds,omitempty"`
+	// Elapsed is the number of seconds elapsed since the stage started.
+	Elapsed float64 `json:"elapsed_seconds"`
+}
+
+// IsJSONProgress returns true iff the progress of long operations should be reported as JSON
+// events instead of human-readable text.
+func IsJSONProgress() bool {
+	return progressMode == ProgressJSON
+}
+
+// emitProgressEvent writes the given event as a single JSON line to the progress output.
+func emitProgressEvent(ev *ProgressEvent) {
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return
+	}
+
+	progressLock.Lock()
+	defer progressLock.Unlock()
+	fmt.Fprintf(progressOutput, "%s\n", data)
+}
+
+// Progress tracks the progress of a single stage of a long operation. In the text progress mode
+// it does nothing, so callers keep printing their own human-readable progress.
+type Progress struct {
+	stage string
+	unit  string
+	total uint64
+	start time.Time
+
+	mu       sync.Mutex
+	lastEmit time.Time
+	finished bool
+}
+
+// StartProgress starts tracking the progress of the given stage. The total number of units of work
+// may be zero if unknown.
+//
+// Use as `p := StartProgress("stage", total, "rounds"); defer p.Finish()`.
+func StartProgress(stage string, total uint64, unit string) *Progress {
+	p := &Progress{
+		stage: stage,
+		unit:  unit,
+		total: total,
+		start: time.Now(),
+	}
+	if IsJSONProgress() {
+		emitProgressEvent(&ProgressEvent{Event: "stage_started", Stage: stage, Total: total, Unit: unit})
+	}
+	return p
+}
+
+// Update reports that the given number of units of work has been completed. Events are rate
+// limited, except for the one reporting completion of all work.
+func (p *Progress) Update(done uint64) {
+	if !IsJSONProgress() {
+		return
+	}
+
+	p.mu.Lock()
+	now := time.Now()
+	if p.finished || (now.Sub(p.lastEmit) < progressMinInterval && (p.total == 0 || done < p.total)) {
+		p.mu.Unlock()
+		return
+	}
+	p.lastEmit = now
+	p.mu.Unlock()
+
+	emitProgressEvent(p.event(done, now))
+}
+
+// Finish reports that the stage has finished.
+func (p *Progress) Finish() {
+	p.mu.Lock()
+	if p.finished {
+		p.mu.Unlock()
+		return
+	}
+	p.finished = true
+	p.mu.Unlock()
+
+	if IsJSONProgress() {
+		emitProgressEvent(&ProgressEvent{
+			Event:   "stage_finished",
+			Stage:   p.stage,
+			Elapsed: time.Since(p.start).Seconds(),
+		})
+	}
+}
+
+// event returns a progress event for the given number of completed units of work.
+func (p *Progress) event(done uint64, now time.Time) *ProgressEvent {
+	elapsed := now.Sub(p.start).Seconds()
+	ev := &ProgressEvent{
+		Event:   "progress",
+		Stage:   p.stage,
+		Done:    done,
+		Total:   p.total,
+		Unit:    p.unit,
+		Elapsed: elapsed,
+	}
+	if p.total > 0 {
+		percent := 100 * float64(min(done, p.total)) / float64(p.total)
+		ev.Percent = &percent
+		if done > 0 {
+			eta := elapsed / float64(done) * float64(p.total-min(done, p.total))
+			ev.ETA = &eta
+		}
+	}
+	return ev
+}
+
+// Reader wraps the given reader and reports the number of bytes read as the progress of the
+// stage.
+func (p *Progress) Reader(rd io.Reader) io.Reader {
+	if !IsJSONProgress() {
+		return rd
+	}
+	return &progressReader{rd: rd, p: p}
+}
+
+type progressReader struct {
+	rd   io.Reader
+	p    *Progress
+	done uint64
+}
+
+func (r *progressReader) Read(b []byte) (int, error) {
+	n, err := r.rd.Read(b)
+	r.done += uint64(n)
+	r.p.Update(r.done)
+	return n, err
+}
+
+func init() {
+	ProgressFlags = flag.NewFlagSet("", flag.ContinueOnError)
+	ProgressFlags.Var(&progressMode, "progress", fmt.Sprintf("progress reporting of long operations [%s, %s]", ProgressText, ProgressJSON))
+}
@@ -0,0 +1,72 @@
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgressJSON(t *testing.T) {
+	require := require.New(t)
+
+	var buf bytes.Buffer
+	progressOutput, progressMode = &buf, ProgressJSON
+	defer func() {
+		progressOutput, progressMode = os.Stderr, ProgressText
+	}()
+
+	p := StartProgress("scan rounds", 4, "rounds")
+	p.Update(1)
+	p.Update(2) // Rate limited.
+	p.Update(4) // Completion is never rate limited.
+	p.Finish()
+	p.Finish()
+
+	var events []ProgressEvent
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var ev ProgressEvent
+		require.NoError(json.Unmarshal([]byte(line), &ev))
+		events = append(events, ev)
+	}
+	require.Len(events, 4)
+	require.Equal("stage_started", events[0].Event)
+	require.EqualValues(4, events[0].Total)
+	require.Equal("progress", events[1].Event)
+	require.EqualValues(1, events[1].Done)
+	require.NotNil(events[1].Percent)
+	require.EqualValues(25, *events[1].Percent)
+	require.NotNil(events[1].ETA)
+	require.EqualValues(100, *events[2].Percent)
+	require.EqualValues(0, *events[2].ETA)
+	require.Equal("stage_finished", events[3].Event)
+	require.Equal("scan rounds", events[3].Stage)
+}
+
+func TestProgressEvent(t *testing.T) {
+	require := require.New(t)
+
+	start := time.Now()
+	p := &Progress{stage: "download", unit: "bytes", total: 100, start: start}
+	ev := p.event(25, start.Add(10*time.Second))
+	require.EqualValues(25, *ev.Percent)
+	require.InDelta(30, *ev.ETA, 0.001)
+
+	p.total = 0
+	ev = p.event(25, start.Add(10*time.Second))
+	require.Nil(ev.Percent)
+	require.Nil(ev.ETA)
+
+	// The text mode emits nothing.
+	var buf bytes.Buffer
+	progressOutput = &buf
+	defer func() { progressOutput = os.Stderr }()
+	p = StartProgress("stage", 1, "")
+	p.Update(1)
+	p.Finish()
+	require.Empty(buf.String())
+}
@@ -24,12 +24,20 @@ var (
 	}
 )
 
+// CheckInteractive aborts when user input is required, but prompting is forbidden.
+func CheckInteractive() {
+	if nonInteractive {
+		cobra.CheckErr("user input required, but running in non-interactive mode")
+	}
+}
+
 // Confirm asks the user for confirmation and aborts when rejected.
 func Confirm(msg, abortMsg string) {
 	if answerYes {
 		fmt.Printf("? %s Yes\n", msg)
 		return
 	}
+	CheckInteractive()
 
 	var proceed bool
 	err := survey.AskOne(&survey.Confirm{Message: msg}, &proceed)
@@ -41,6 +49,8 @@ func Confirm(msg, abortMsg string) {
 
 // AskNewPassphrase asks the user to create a new passphrase.
 func AskNewPassphrase() string {
+	CheckInteractive()
+
 	var answers struct {
 		Passphrase  string
 		Passphrase2 string
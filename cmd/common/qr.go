@@ -0,0 +1,131 @@
+package common
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	"rsc.io/qr"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+const (
+	// qrQuietZone is the number of light modules surrounding the QR code.
+	qrQuietZone = 4
+	// qrPNGScale is the number of PNG pixels per QR code module.
+	qrPNGScale = 8
+)
+
+// addressQR is an address which should be rendered as a QR code.
+type addressQR struct {
+	// label is the human readable label of the address (e.g. "Native address").
+	label string
+	// address is the encoded address.
+	address string
+	// suffix is appended to the PNG filename to distinguish multiple addresses.
+	suffix string
+}
+
+// IsQRRequested returns true iff the user requested addresses to be rendered as QR codes.
+func IsQRRequested() bool {
+	return showQR || qrPNGFilename != ""
+}
+
+// PrintAddressQRs renders the native and, if given, Ethereum address as QR codes in the terminal
+// in case the --qr flag has been passed and writes them as PNG images in case --qr-png has been
+// passed. The PNG file of the Ethereum address gets the "-eth" suffix.
+func PrintAddressQRs(addr *types.Address, ethAddr *ethCommon.Address) error {
+	addrs := []addressQR{{"Native address", addr.String(), ""}}
+	if ethAddr != nil {
+		addrs = append(addrs, addressQR{"Ethereum address", ethAddr.Hex(), "eth"})
+	}
+
+	for _, a := range addrs {
+		code, err := qr.Encode(a.address, qr.M)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s QR code: %w", strings.ToLower(a.label), err)
+		}
+
+		if showQR {
+			fmt.Println()
+			fmt.Printf("%s:\n", a.label)
+			fmt.Print(renderQRText(code))
+			fmt.Println(a.address)
+		}
+
+		if qrPNGFilename != "" {
+			fn := qrPNGFilename
+			if a.suffix != "" {
+				ext := filepath.Ext(fn)
+				fn = strings.TrimSuffix(fn, ext) + "-" + a.suffix + ext
+			}
+			if err = writeQRPNG(fn, code); err != nil {
+				return fmt.Errorf("failed to write %s QR code: %w", strings.ToLower(a.label), err)
+			}
+			fmt.Printf("%s QR code written to %s\n", a.label, fn)
+		}
+	}
+	return nil
+}
+
+// renderQRText renders the QR code using Unicode half blocks so that each line of text contains
+// two rows of modules. Light modules are drawn as filled blocks to render correctly on terminals
+// with a dark background.
+func renderQRText(code *qr.Code) string {
+	var sb strings.Builder
+	lo, hi := -qrQuietZone, code.Size+qrQuietZone
+	for y := lo; y < hi; y += 2 {
+		for x := lo; x < hi; x++ {
+			top := !code.Black(x, y)
+			bottom := y+1 < hi && !code.Black(x, y+1)
+			switch {
+			case top && bottom:
+				sb.WriteString("█")
+			case top:
+				sb.WriteString("▀")
+			case bottom:
+				sb.WriteString("▄")
+			default:
+				sb.WriteString(" ")
+			}
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// qrImage renders the QR code into a grayscale image including the quiet zone.
+func qrImage(code *qr.Code) *image.Gray {
+	dim := (code.Size + 2*qrQuietZone) * qrPNGScale
+	img := image.NewGray(image.Rect(0, 0, dim, dim))
+	for py := 0; py < dim; py++ {
+		for px := 0; px < dim; px++ {
+			c := color.Gray{Y: 0xff}
+			if code.Black(px/qrPNGScale-qrQuietZone, py/qrPNGScale-qrQuietZone) {
+				c = color.Gray{Y: 0x00}
+			}
+			img.SetGray(px, py, c)
+		}
+	}
+	return img
+}
+
+// writeQRPNG writes the QR code as a PNG image into the given file.
+func writeQRPNG(fn string, code *qr.Code) error {
+	f, err := os.Create(fn)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err = png.Encode(f, qrImage(code)); err != nil {
+		return err
+	}
+	return f.Close()
+}
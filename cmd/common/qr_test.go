@@ -0,0 +1,32 @@
+package common
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/require"
+	"rsc.io/qr"
+)
+
+func TestRenderQR(t *testing.T) {
+	require := require.New(t)
+
+	code, err := qr.Encode("oasis1qrvzxld9rz83wv92lvnkpmr30c77kj2tvg0pednz", qr.M)
+	require.NoError(err)
+
+	dim := code.Size + 2*qrQuietZone
+	lines := strings.Split(strings.TrimSuffix(renderQRText(code), "\n"), "\n")
+	require.Len(lines, (dim+1)/2)
+	for _, line := range lines {
+		require.Equal(dim, utf8.RuneCountInString(line))
+	}
+	// Quiet zone is rendered as light modules.
+	require.Equal(strings.Repeat("█", dim), lines[0])
+
+	img := qrImage(code)
+	require.Equal(dim*qrPNGScale, img.Bounds().Dx())
+	require.EqualValues(0xff, img.GrayAt(0, 0).Y)
+	// Top-left finder pattern is dark.
+	require.EqualValues(0x00, img.GrayAt(qrQuietZone*qrPNGScale, qrQuietZone*qrPNGScale).Y)
+}
@@ -0,0 +1,47 @@
+package common
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	cliConfig "github.com/oasisprotocol/cli/config"
+)
+
+// ReadOnlyFlag forbids signing and broadcasting transactions.
+var ReadOnlyFlag *flag.FlagSet
+
+var readOnly bool
+
+// ErrReadOnly is the error returned when signing or broadcasting is attempted in read-only mode.
+var ErrReadOnly = errors.New("refusing to sign or broadcast in read-only mode (see --read-only and the read_only configuration setting)")
+
+// IsReadOnly returns true iff signing and broadcasting transactions is forbidden either by the
+// global flag or by the configuration.
+func IsReadOnly() bool {
+	return readOnly || cliConfig.Global().ReadOnly
+}
+
+// CheckReadOnly returns ErrReadOnly in read-only mode.
+func CheckReadOnly() error {
+	if IsReadOnly() {
+		return ErrReadOnly
+	}
+	return nil
+}
+
+// CheckReadOnlyCommand aborts in read-only mode if the given command signs transactions unless it
+// is only asked to generate an unsigned transaction. It catches such commands before they unlock
+// any accounts.
+func CheckReadOnlyCommand(cmd *cobra.Command) {
+	if cmd.Flags().Lookup("unsigned") == nil || txUnsigned {
+		return
+	}
+	cobra.CheckErr(CheckReadOnly())
+}
+
+func init() {
+	ReadOnlyFlag = flag.NewFlagSet("", flag.ContinueOnError)
+	ReadOnlyFlag.BoolVar(&readOnly, "read-only", false, "refuse to sign or broadcast any transactions")
+}
@@ -0,0 +1,24 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	cliConfig "github.com/oasisprotocol/cli/config"
+)
+
+func TestReadOnly(t *testing.T) {
+	require := require.New(t)
+
+	require.NoError(CheckReadOnly())
+
+	readOnly = true
+	require.ErrorIs(CheckReadOnly(), ErrReadOnly)
+	readOnly = false
+
+	cfg := cliConfig.Global()
+	cfg.ReadOnly = true
+	defer func() { cfg.ReadOnly = false }()
+	require.ErrorIs(CheckReadOnly(), ErrReadOnly)
+}
@@ -75,7 +75,11 @@ func GetNPASelection(cfg *cliConfig.Config) *NPASelection {
 		}
 	}
 
+	// Prefer the most specific default account for the selected network and ParaTime.
 	s.AccountName = cfg.Wallet.Default
+	if name := cfg.DefaultAccounts.Resolve(s.NetworkName, s.ParaTimeName); name != "" {
+		s.AccountName = name
+	}
 	if selectedAccount != "" {
 		s.AccountName = selectedAccount
 	}
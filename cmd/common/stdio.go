@@ -0,0 +1,45 @@
+package common
+
+import (
+	"io"
+	"os"
+)
+
+// StdioFilename is the filename denoting the standard input when reading and the standard output
+// when writing.
+const StdioFilename = "-"
+
+// exportStdout is the standard output used for exporting transactions. It is kept when the human
+// readable output is redirected to standard error.
+var exportStdout = os.Stdout
+
+// ReadFile reads the given file or the standard input if the filename is StdioFilename.
+func ReadFile(fn string) ([]byte, error) {
+	if fn == StdioFilename {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(fn)
+}
+
+// ReadDataArg returns the given inline data argument or the contents of the standard input if the
+// argument is StdioFilename.
+func ReadDataArg(arg string) (string, error) {
+	if arg != StdioFilename {
+		return arg, nil
+	}
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// RedirectOutputForExport redirects the human readable output to standard error when the
+// transaction is exported to standard output so the exported transaction can be piped into other
+// commands.
+func RedirectOutputForExport() {
+	if !shouldExportTransaction() || (txOutputFile != "" && txOutputFile != StdioFilename) {
+		return
+	}
+	os.Stdout = os.Stderr
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"math"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
@@ -38,6 +41,8 @@ var (
 	txUnsigned   bool
 	txFormat     string
 	txOutputFile string
+
+	txLogger = NewLogger("tx")
 )
 
 const (
@@ -123,6 +128,7 @@ func PrepareConsensusTransaction(ctx context.Context, npa *NPASelection, signer
 		if err != nil {
 			return 0, nil, fmt.Errorf("failed to estimate gas: %w", err)
 		}
+		txLogger.Info("estimated gas", "method", tx.Method, "gas", gas)
 	}
 
 	// Compute the fee.
@@ -142,6 +148,9 @@ func SignConsensusTransaction(
 	tx *consensusTx.Transaction,
 ) (interface{}, error) {
 	// Sanity checks.
+	if err := CheckReadOnly(); err != nil && !txUnsigned {
+		return nil, err
+	}
 	signer := account.ConsensusSigner()
 	if signer == nil {
 		return nil, fmt.Errorf("account does not support signing consensus transactions")
@@ -173,6 +182,7 @@ func SignConsensusTransaction(
 		if err != nil {
 			return nil, fmt.Errorf("failed to query nonce: %w", err)
 		}
+		txLogger.Info("queried nonce", "nonce", nonce)
 		tx.Nonce = nonce
 	}
 
@@ -185,7 +195,9 @@ func SignConsensusTransaction(
 		return tx, nil
 	}
 
-	PrintTransactionBeforeSigning(npa, tx)
+	guards := checkTransactionGuards(ctx, npa, account.Address(), conn, tx)
+	warnUnverifiedBackup(account.Address())
+	printTransactionBeforeSigning(npa, tx, account.Address(), guards)
 
 	// Sign the transaction.
 	// NOTE: We build our own domain separation context here as we need to support multiple chain
@@ -195,6 +207,8 @@ func SignConsensusTransaction(
 	if err != nil {
 		return nil, err
 	}
+	txLogger.Info("signed consensus transaction", "method", tx.Method, "nonce", tx.Nonce, "gas", tx.Fee.Gas, "signer", signer)
+	recordAccountUsage(npa, account.Address())
 
 	return &consensusTx.SignedTransaction{Signed: *signed}, nil
 }
@@ -205,13 +219,10 @@ func SignConsensusTransaction(
 // Returns the estimated gas limit, total fee amount and fee denominator.
 func PrepareParatimeTransaction(ctx context.Context, npa *NPASelection, account wallet.Account, conn connection.Connection, tx *types.Transaction) (uint64, *quantity.Quantity, types.Denomination, error) {
 	// Determine whether the signer information for a transaction has already been set.
-	accountAddressSpec := account.SignatureAddressSpec()
 	var hasSignerInfo bool
 	for _, si := range tx.AuthInfo.SignerInfo {
-		if si.AddressSpec.Signature == nil {
-			continue
-		}
-		if !si.AddressSpec.Signature.PublicKey().Equal(accountAddressSpec.PublicKey()) {
+		addr, err := si.AddressSpec.Address()
+		if err != nil || !addr.Equal(account.Address()) {
 			continue
 		}
 		hasSignerInfo = true
@@ -227,6 +238,7 @@ func PrepareParatimeTransaction(ctx context.Context, npa *NPASelection, account
 			if err != nil {
 				return 0, nil, "", fmt.Errorf("failed to query nonce: %w", err)
 			}
+			txLogger.Info("queried nonce", "nonce", nonce)
 		}
 
 		if nonce == invalidNonce {
@@ -234,19 +246,23 @@ func PrepareParatimeTransaction(ctx context.Context, npa *NPASelection, account
 		}
 
 		// Prepare the transaction before (optional) gas estimation to ensure correct estimation.
-		tx.AppendAuthSignature(accountAddressSpec, nonce)
+		if msAcc, isMultisig := account.(wallet.MultisigAccount); isMultisig {
+			tx.AppendAuthMultisig(msAcc.MultisigConfig(), nonce)
+		} else {
+			tx.AppendAuthSignature(account.SignatureAddressSpec(), nonce)
+		}
 	}
 
 	// Gas price estimation if not specified.
 	gasPrice := &types.BaseUnits{}
 	feeDenom := types.Denomination(txFeeDenom)
+	var mgp map[types.Denomination]types.Quantity
 	if txGasPrice != "" {
 		gasPrice, err = helpers.ParseParaTimeDenomination(npa.ParaTime, txGasPrice, feeDenom)
 		if err != nil {
 			return 0, nil, "", fmt.Errorf("bad gas price: %w", err)
 		}
 	} else if !txOffline {
-		var mgp map[types.Denomination]types.Quantity
 		mgp, err = conn.Runtime(npa.ParaTime).Core.MinGasPrice(ctx)
 		if err != nil {
 			return 0, nil, "", fmt.Errorf("failed to query minimum gas price: %w", err)
@@ -261,6 +277,16 @@ func PrepareParatimeTransaction(ctx context.Context, npa *NPASelection, account
 		if err != nil {
 			return 0, nil, "", fmt.Errorf("failed to estimate gas: %w", err)
 		}
+		txLogger.Info("estimated gas", "method", tx.Call.Method, "gas", gas)
+	}
+
+	// Pay the fee in an alternative denomination if the account cannot pay it natively.
+	if txFeeDenom == "" && txGasPrice == "" && !txOffline {
+		gasPrice, err = selectFeeDenomination(ctx, npa, conn, account.Address(), gas, mgp)
+		if err != nil {
+			return 0, nil, "", err
+		}
+		feeDenom = gasPrice.Denomination
 	}
 
 	// Compute fee.
@@ -285,6 +311,9 @@ func SignParaTimeTransaction(
 	if npa.ParaTime == nil {
 		return nil, nil, fmt.Errorf("no ParaTime configured for ParaTime transaction signing")
 	}
+	if err := CheckReadOnly(); err != nil && !txUnsigned {
+		return nil, nil, err
+	}
 
 	gas, fee, feeDenom, err := PrepareParatimeTransaction(ctx, npa, account, conn, tx)
 	if err != nil {
@@ -302,6 +331,12 @@ func SignParaTimeTransaction(
 		return nil, nil, fmt.Errorf("gas limit must be specified in offline mode")
 	}
 
+	// Check the guard rails before the call is encrypted.
+	var guards *guardReport
+	if !txUnsigned {
+		guards = checkTransactionGuards(ctx, npa, account.Address(), conn, tx)
+	}
+
 	// Handle confidential transactions.
 	var meta interface{}
 	if txEncrypted {
@@ -333,7 +368,8 @@ func SignParaTimeTransaction(
 		return tx, meta, nil
 	}
 
-	PrintTransactionBeforeSigning(npa, tx)
+	warnUnverifiedBackup(account.Address())
+	printTransactionBeforeSigning(npa, tx, account.Address(), guards)
 
 	// Sign the transaction.
 	ts := tx.PrepareForSigning()
@@ -343,9 +379,15 @@ func SignParaTimeTransaction(
 		Base:         types.SignatureContextBase,
 		TxDetails:    txDetails,
 	}
-	if err := ts.AppendSign(sigCtx, account.Signer()); err != nil {
+	if msAcc, isMultisig := account.(wallet.MultisigAccount); isMultisig {
+		if err := signMultisigTransaction(ts, sigCtx, msAcc.MultisigConfig()); err != nil {
+			return nil, nil, err
+		}
+	} else if err := ts.AppendSign(sigCtx, account.Signer()); err != nil {
 		return nil, nil, fmt.Errorf("failed to sign transaction: %w", err)
 	}
+	txLogger.Info("signed ParaTime transaction", "method", tx.Call.Method, "gas", tx.AuthInfo.Fee.Gas, "signer", account.Address())
+	recordAccountUsage(npa, account.Address())
 	return ts.UnverifiedTransaction(), meta, nil
 }
 
@@ -381,6 +423,12 @@ func PrintTransaction(npa *NPASelection, tx interface{}) {
 
 // PrintTransactionBeforeSigning prints the transaction and asks the user for confirmation.
 func PrintTransactionBeforeSigning(npa *NPASelection, tx interface{}) {
+	printTransactionBeforeSigning(npa, tx, npa.Account.GetAddress(), nil)
+}
+
+// printTransactionBeforeSigning prints the transaction together with the tripped guard rails and
+// asks the user for confirmation. The signer is the address of the account signing the transaction.
+func printTransactionBeforeSigning(npa *NPASelection, tx interface{}, signer types.Address, guards *guardReport) {
 	fmt.Printf("You are about to sign the following transaction:\n")
 
 	PrintTransaction(npa, tx)
@@ -390,19 +438,51 @@ func PrintTransactionBeforeSigning(npa *NPASelection, tx interface{}) {
 		fmt.Printf(" (%s)", npa.Account.Description)
 	}
 	fmt.Println()
+	printTransactionFiatValue(npa, tx)
+	warnIfAccountUnused(npa, signer)
+	guards.enforce()
 
-	// Ask the user to confirm signing this transaction.
-	Confirm("Sign this transaction?", "signing aborted")
+	// Ask the user to confirm signing this transaction unless the confirmation policy allows
+	// skipping it.
+	switch TransactionConfirmationRequired(npa, tx) {
+	case true:
+		Confirm("Sign this transaction?", "signing aborted")
+	case false:
+		fmt.Println("Signing without confirmation as allowed by the confirmation policy.")
+	}
 
 	fmt.Println("(In case you are using a hardware-based signer you may need to confirm on device.)")
 }
 
+// printTransactionFiatValue prints the approximate fiat value of the tokens moved by the given
+// transaction, if a price provider is configured. Nothing is printed in offline mode.
+func printTransactionFiatValue(npa *NPASelection, tx interface{}) {
+	if txOffline {
+		return
+	}
+	t, ok := decodeGuardTransfer(types.Address{}, tx)
+	if !ok {
+		return
+	}
+
+	var value string
+	switch {
+	case isRuntimeTx(tx) && npa.ParaTime != nil:
+		value = ParaTimeFiatValue(context.Background(), npa.ParaTime, t.amount)
+	case !isRuntimeTx(tx):
+		value = ConsensusFiatValue(context.Background(), npa.Network, t.amount.Amount)
+	}
+	if value != "" {
+		fmt.Printf("Value:    %s (estimate)\n", value)
+	}
+}
+
 // ExportTransaction exports a (signed) transaction based on configuration.
 func ExportTransaction(sigTx interface{}) {
 	// Determine output destination.
 	var err error
-	outputFile := os.Stdout
-	if txOutputFile != "" {
+	outputFile := exportStdout
+	if txOutputFile != "" && txOutputFile != StdioFilename {
 		outputFile, err = os.Create(txOutputFile)
 		if err != nil {
 			cobra.CheckErr(fmt.Errorf("failed to open output file: %w", err))
@@ -410,22 +490,40 @@ func ExportTransaction(sigTx interface{}) {
 		defer outputFile.Close()
 	}
 
-	// Determine output format.
+	cobra.CheckErr(writeTransaction(outputFile, sigTx))
+}
+
+// ExportTransactionToFile writes a (signed) transaction to the given file in the configured format.
+func ExportTransactionToFile(fn string, sigTx interface{}) error {
+	f, err := os.Create(fn)
+	if err != nil {
+		return fmt.Errorf("failed to open output file: %w", err)
+	}
+	defer f.Close()
+
+	return writeTransaction(f, sigTx)
+}
+
+// writeTransaction writes the transaction to the given writer in the configured format.
+func writeTransaction(w io.Writer, sigTx interface{}) error {
 	var data []byte
 	switch txFormat {
 	case formatJSON:
+		var err error
 		data, err = json.MarshalIndent(sigTx, "", "  ")
-		cobra.CheckErr(err)
+		if err != nil {
+			return err
+		}
 	case formatCBOR:
 		data = cbor.Marshal(sigTx)
 	default:
-		cobra.CheckErr(fmt.Errorf("unknown transaction format: %s", txFormat))
+		return fmt.Errorf("unknown transaction format: %s", txFormat)
 	}
 
-	_, err = outputFile.Write(data)
-	if err != nil {
-		cobra.CheckErr(fmt.Errorf("failed to write output: %w", err))
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
 	}
+	return nil
 }
 
 // BroadcastOrExportTransaction broadcasts or exports a transaction based on configuration.
@@ -449,6 +547,39 @@ func BroadcastOrExportTransaction(
 	return true
 }
 
+// BroadcastOrExportTransactionPart broadcasts or exports the given part of a transaction sequence
+// with the given total number of parts based on configuration.
+//
+// When exporting to an output file and there is more than one part, each part is written to its own
+// file with the part number inserted before the extension of the configured output file. Returns
+// false when the transaction has been exported and true when it has been broadcast.
+func BroadcastOrExportTransactionPart(
+	ctx context.Context,
+	pt *config.ParaTime,
+	conn connection.Connection,
+	tx interface{},
+	meta interface{},
+	part, total int,
+) bool {
+	if !shouldExportTransaction() {
+		BroadcastTransaction(ctx, pt, conn, tx, meta, nil)
+		return true
+	}
+	if total <= 1 || txOutputFile == "" || txOutputFile == StdioFilename {
+		ExportTransaction(tx)
+		if total > 1 {
+			fmt.Fprintln(exportStdout)
+		}
+		return false
+	}
+
+	ext := filepath.Ext(txOutputFile)
+	fn := fmt.Sprintf("%s-%d%s", strings.TrimSuffix(txOutputFile, ext), part+1, ext)
+	cobra.CheckErr(ExportTransactionToFile(fn, tx))
+	fmt.Printf("Transaction %d of %d exported to '%s'.\n", part+1, total, fn)
+	return false
+}
+
 // BroadcastTransaction broadcasts a transaction.
 //
 // When in offline mode, it outputs the transaction instead.
@@ -460,15 +591,22 @@ func BroadcastTransaction(
 	meta interface{},
 	result interface{},
 ) {
+	cobra.CheckErr(CheckReadOnly())
+
 	switch sigTx := tx.(type) {
 	case *consensusTx.SignedTransaction:
 		// Consensus transaction.
 		fmt.Printf("Broadcasting transaction...\n")
+		done := LogStage(txLogger, "submit consensus transaction", "hash", sigTx.Hash())
 		err := conn.Consensus().SubmitTx(ctx, sigTx)
-		cobra.CheckErr(err)
+		if err != nil {
+			cobra.CheckErr(errorWithHint(err))
+		}
+		done()
 
 		fmt.Printf("Transaction executed successfully.\n")
 		fmt.Printf("Transaction hash: %s\n", sigTx.Hash())
+		printExplorerURL(conn, nil, sigTx.Hash().String())
 	case *types.UnverifiedTransaction:
 		// ParaTime transaction.
 		if pt == nil {
@@ -476,11 +614,19 @@ func BroadcastTransaction(
 		}
 
 		fmt.Printf("Broadcasting transaction...\n")
+		done := LogStage(txLogger, "submit ParaTime transaction", "hash", sigTx.Hash())
 		rawMeta, err := conn.Runtime(pt).SubmitTxRawMeta(ctx, sigTx)
 		cobra.CheckErr(err)
+		done()
+		txLogger.Info("transaction included", "hash", sigTx.Hash(), "round", rawMeta.Round)
 
 		if rawMeta.CheckTxError != nil {
-			cobra.CheckErr(fmt.Sprintf("Transaction check failed with error: module: %s code: %d message: %s",
+			cobra.CheckErr(withHint(
+				fmt.Sprintf("Transaction check failed with error: module: %s code: %d message: %s",
+					rawMeta.CheckTxError.Module,
+					rawMeta.CheckTxError.Code,
+					rawMeta.CheckTxError.Message,
+				),
 				rawMeta.CheckTxError.Module,
 				rawMeta.CheckTxError.Code,
 				rawMeta.CheckTxError.Message,
@@ -490,6 +636,7 @@ func BroadcastTransaction(
 		fmt.Printf("Transaction included in block successfully.\n")
 		fmt.Printf("Round:            %d\n", rawMeta.Round)
 		fmt.Printf("Transaction hash: %s\n", sigTx.Hash())
+		printExplorerURL(conn, pt, sigTx.Hash().String())
 
 		if rawMeta.Result.IsUnknown() {
 			fmt.Printf("                  (Transaction result is encrypted.)\n")
@@ -510,7 +657,12 @@ func BroadcastTransaction(
 				cobra.CheckErr(err)
 			}
 		default:
-			cobra.CheckErr(fmt.Sprintf("Execution failed with error: %s", decResult.Failed.Error()))
+			cobra.CheckErr(withHint(
+				fmt.Sprintf("Execution failed with error: %s", decResult.Failed.Error()),
+				decResult.Failed.Module,
+				decResult.Failed.Code,
+				decResult.Failed.Message,
+			))
 		}
 	default:
 		panic(fmt.Errorf("unsupported transaction kind: %T", tx))
@@ -572,7 +724,7 @@ func init() {
 	RuntimeTxFlags.Uint64Var(&txNonce, "nonce", invalidNonce, "override nonce to use")
 	RuntimeTxFlags.Uint64Var(&txGasLimit, "gas-limit", invalidGasLimit, "override gas limit to use (disable estimation)")
 	RuntimeTxFlags.StringVar(&txGasPrice, "gas-price", "", "override gas price to use")
-	RuntimeTxFlags.StringVar(&txFeeDenom, "fee-denom", "", "override fee denomination (defaults to native)")
+	RuntimeTxFlags.StringVar(&txFeeDenom, "fee-denom", "", "override fee denomination (defaults to native or an accepted alternative if the native balance is insufficient)")
 	RuntimeTxFlags.BoolVar(&txEncrypted, "encrypted", false, "encrypt transaction call data (requires online mode)")
 	RuntimeTxFlags.AddFlagSet(AnswerYesFlag)
 	RuntimeTxFlags.BoolVar(&txUnsigned, "unsigned", false, "do not sign transaction")
@@ -0,0 +1,42 @@
+package common
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+
+	"github.com/oasisprotocol/cli/config"
+)
+
+// warnIfAccountUnused prints a warning when the given signer has not been used to sign
+// transactions on the selected network before.
+func warnIfAccountUnused(npa *NPASelection, signer types.Address) {
+	usage, err := config.LoadUsage(config.Global().Directory())
+	if err != nil {
+		return
+	}
+	if usage.Accounts[signer.String()].UsedOn(npa.NetworkName) {
+		return
+	}
+	name := signer.String()
+	if npa.Account != nil && npa.Account.Address == name {
+		name = npa.AccountName
+	}
+	fmt.Printf("WARNING: Account '%s' has not been used on network '%s' before.\n", name, npa.NetworkName)
+}
+
+// recordAccountUsage records that the given signer signed a transaction on the selected network.
+func recordAccountUsage(npa *NPASelection, signer types.Address) {
+	dir := config.Global().Directory()
+	usage, err := config.LoadUsage(dir)
+	if err == nil {
+		usage.Record(signer.String(), npa.NetworkName, time.Now())
+		err = usage.Save(dir)
+	}
+	if err != nil {
+		// Usage statistics are informational only, so do not fail.
+		fmt.Fprintf(os.Stderr, "Warning: failed to record account usage: %v\n", err)
+	}
+}
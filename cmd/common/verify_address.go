@@ -0,0 +1,67 @@
+package common
+
+import (
+	"fmt"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/oasisprotocol/cli/config"
+	"github.com/oasisprotocol/cli/wallet"
+	"github.com/oasisprotocol/cli/wallet/ledger"
+)
+
+// VerifyAccountAddress displays the address of the given hardware wallet account on the device for
+// the user to confirm and records the successful verification in the wallet.
+func VerifyAccountAddress(cfg *config.Config, name string) error {
+	acfg, ok := cfg.Wallet.All[name]
+	if !ok {
+		return fmt.Errorf("account '%s' does not exist in the wallet", name)
+	}
+
+	acc := LoadAccount(cfg, name)
+	verifier, ok := acc.(wallet.AddressVerifier)
+	if !ok {
+		return fmt.Errorf("account '%s' is not a hardware wallet account", name)
+	}
+	// Release the device, so it can be used by subsequent operations.
+	if signer := acc.Signer(); signer != nil {
+		defer signer.Reset()
+	}
+
+	fmt.Printf("Native address:   %s\n", acc.Address())
+	if ethAddr := acc.EthAddress(); ethAddr != nil {
+		fmt.Printf("Ethereum address: %s\n", ethAddr.Hex())
+	}
+	fmt.Println("Check that your device shows the same address and confirm it on the device.")
+	if err := verifier.VerifyAddress(); err != nil {
+		return err
+	}
+
+	acfg.AddressVerified = true
+	return cfg.Save()
+}
+
+// OfferAddressVerification offers to verify the address of the given hardware wallet account on the
+// device, if it has not been verified yet. It is used when the account is the destination of a
+// transfer, so the funds are not sent to an address substituted on the host.
+func OfferAddressVerification(cfg *config.Config, name string) {
+	acfg, ok := cfg.Wallet.All[name]
+	if !ok || acfg.Kind != ledger.Kind || acfg.AddressVerified {
+		return
+	}
+	// Verification requires the user to interact with the device.
+	if answerYes || nonInteractive {
+		return
+	}
+
+	verify := true
+	msg := fmt.Sprintf("The address of hardware wallet account '%s' has not been verified on the device yet. Verify it now?", name)
+	err := survey.AskOne(&survey.Confirm{Message: msg, Default: true}, &verify)
+	cobra.CheckErr(err)
+	if !verify {
+		return
+	}
+	cobra.CheckErr(VerifyAccountAddress(cfg, name))
+	fmt.Println("Address verified.")
+}
@@ -17,11 +17,15 @@ import (
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/testing"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
 
+	"github.com/oasisprotocol/cli/agent"
 	"github.com/oasisprotocol/cli/config"
 	"github.com/oasisprotocol/cli/wallet"
 	"github.com/oasisprotocol/cli/wallet/test"
 )
 
+// subAccounts caches the sub-accounts derived during this invocation.
+var subAccounts = make(map[string]wallet.Account)
+
 const (
 	addressExplicitSeparator = ":"
 	addressExplicitParaTime  = "paratime"
@@ -43,8 +47,18 @@ const (
 	poolPendingDelegation = "pending-delegation"
 )
 
-// LoadAccount loads the given named account.
+// LoadAccount loads the given named account. Accounts held by a running key agent are used
+// without asking for the passphrase.
 func LoadAccount(cfg *config.Config, name string) wallet.Account {
+	return loadAccount(cfg, name, true)
+}
+
+// LoadAccountDirect loads the given named account from the wallet, bypassing the key agent.
+func LoadAccountDirect(cfg *config.Config, name string) wallet.Account {
+	return loadAccount(cfg, name, false)
+}
+
+func loadAccount(cfg *config.Config, name string, useAgent bool) wallet.Account {
 	// Check if the specified account is a test account.
 	if testName := ParseTestAccountAddress(name); testName != "" {
 		acc, err := LoadTestAccount(testName)
@@ -52,15 +66,31 @@ func LoadAccount(cfg *config.Config, name string) wallet.Account {
 		return acc
 	}
 
-	acfg, err := LoadAccountConfig(cfg, name)
+	// Sub-accounts are derived only once per invocation.
+	if acc, ok := subAccounts[name]; ok {
+		return acc
+	}
+	baseName, _, isSubAccount := config.ParseSubAccountName(name)
+
+	acfg, err := LoadAccountConfig(cfg, baseName)
 	cobra.CheckErr(err)
 
 	af, err := acfg.LoadFactory()
 	cobra.CheckErr(err)
 
+	if useAgent && af.RequiresPassphrase() {
+		if acc := loadAgentAccount(cfg, name, acfg); acc != nil {
+			if isSubAccount {
+				recordSubAccountAddress(cfg, name, acc)
+			}
+			return acc
+		}
+	}
+
 	var passphrase string
 	if af.RequiresPassphrase() && !answerYes {
 		// Ask for passphrase to decrypt the account.
+		CheckInteractive()
 		fmt.Printf("Unlock your account.\n")
 
 		err = survey.AskOne(PromptPassphrase, &passphrase)
@@ -70,6 +100,32 @@ func LoadAccount(cfg *config.Config, name string) wallet.Account {
 	acc, err := cfg.Wallet.Load(name, passphrase)
 	cobra.CheckErr(err)
 
+	if isSubAccount {
+		subAccounts[name] = acc
+		recordSubAccountAddress(cfg, name, acc)
+	}
+
+	return acc
+}
+
+// loadAgentAccount returns the given named account from the running key agent or nil if the agent
+// is not running or does not hold the account.
+func loadAgentAccount(cfg *config.Config, name string, acfg *config.Account) wallet.Account {
+	client, err := agent.Dial(agent.SocketPath(cfg.Directory()))
+	if err != nil {
+		return nil
+	}
+	defer client.Close()
+
+	acc, err := client.Account(name)
+	if err != nil {
+		return nil
+	}
+
+	// Ignore the agent if the account has changed since it was unlocked.
+	if _, _, isSubAccount := config.ParseSubAccountName(name); !isSubAccount && acfg.Address != acc.Address().String() {
+		return nil
+	}
 	return acc
 }
 
@@ -97,9 +153,46 @@ func LoadAccountConfig(cfg *config.Config, name string) (*config.Account, error)
 		return acfg, nil
 	}
 
+	// Sub-account addresses are only known after deriving the key.
+	if baseName, number, ok := config.ParseSubAccountName(name); ok {
+		if _, exists := cfg.Wallet.All[baseName]; exists {
+			return loadSubAccountConfig(cfg, name, number)
+		}
+	}
+
 	return nil, fmt.Errorf("account '%s' does not exist in the wallet", name)
 }
 
+// loadSubAccountConfig loads the config instance of the given sub-account. The address of the
+// sub-account is recorded in the wallet the first time its key is derived so that it can later be
+// resolved without unlocking the account.
+func loadSubAccountConfig(cfg *config.Config, name string, number uint32) (*config.Account, error) {
+	baseName, _, _ := config.ParseSubAccountName(name)
+	acfg, err := cfg.Wallet.All[baseName].SubAccount(number)
+	if err != nil {
+		return nil, fmt.Errorf("account '%s': %w", baseName, err)
+	}
+	if acfg.Address != "" {
+		return acfg, nil
+	}
+
+	acc := LoadAccount(cfg, name)
+	acfg.Address = acc.Address().String()
+	return acfg, nil
+}
+
+// recordSubAccountAddress records the address of the given derived sub-account in the wallet.
+func recordSubAccountAddress(cfg *config.Config, name string, acc wallet.Account) {
+	baseName, number, _ := config.ParseSubAccountName(name)
+	acfg, exists := cfg.Wallet.All[baseName]
+	if !exists || !acfg.RecordSubAccountAddress(number, acc.Address().String()) {
+		return
+	}
+	if err := cfg.Save(); err != nil {
+		fmt.Printf("WARNING: Failed to record address of sub-account '%s': %s\n", name, err)
+	}
+}
+
 // LoadTestAccount loads the given named test account.
 func LoadTestAccount(name string) (wallet.Account, error) {
 	if testKey, ok := testing.TestAccounts[name]; ok {
@@ -140,8 +233,22 @@ func ResolveLocalAccountOrAddress(net *configSdk.Network, address string) (*type
 	// Check if address is the account name in the wallet.
 	if acc, ok := config.Global().Wallet.All[address]; ok {
 		addr := acc.GetAddress()
-		// TODO: Implement acc.GetEthAddress()
-		return &addr, nil, nil
+		// The Ethereum address is only known once the key is unlocked, so ask the key agent.
+		var ethAddr *ethCommon.Address
+		if acc.HasEthAddress() {
+			if agentAcc := loadAgentAccount(config.Global(), address, acc); agentAcc != nil {
+				ethAddr = agentAcc.EthAddress()
+			}
+		}
+		return &addr, ethAddr, nil
+	}
+
+	// Check if address is a sub-account of an account in the wallet.
+	if _, _, ok := config.ParseSubAccountName(address); ok {
+		if acfg, err := LoadAccountConfig(config.Global(), address); err == nil {
+			addr := acfg.GetAddress()
+			return &addr, nil, nil
+		}
 	}
 
 	// Check if address is the name of an address book entry.
@@ -156,6 +263,9 @@ func ResolveLocalAccountOrAddress(net *configSdk.Network, address string) (*type
 // ResolveAddress resolves a string address into the corresponding account address.
 func ResolveAddress(net *configSdk.Network, address string) (*types.Address, *ethCommon.Address, error) {
 	if addr, ethAddr, _ := helpers.ResolveEthOrOasisAddress(address); addr != nil {
+		if ethAddr != nil {
+			rememberEthDerivedAddress(*addr)
+		}
 		return addr, ethAddr, nil
 	}
 
@@ -224,6 +334,22 @@ func ResolveAddress(net *configSdk.Network, address string) (*types.Address, *et
 	}
 }
 
+// CheckEthAddressChecksum checks that the given address, if it is a mixed-case Ethereum address,
+// has a valid EIP-55 checksum. All lower or upper case addresses carry no checksum.
+func CheckEthAddressChecksum(address string) error {
+	if !strings.HasPrefix(address, "0x") || !ethCommon.IsHexAddress(address) {
+		return nil
+	}
+	hexPart := address[2:]
+	if hexPart == strings.ToLower(hexPart) || hexPart == strings.ToUpper(hexPart) {
+		return nil
+	}
+	if expected := ethCommon.HexToAddress(address).Hex(); address != expected {
+		return fmt.Errorf("invalid checksum of Ethereum address '%s', did you mean '%s'?", address, expected)
+	}
+	return nil
+}
+
 // CheckAddressIsConsensusCapable checks whether the given address is derived from any known
 // Ethereum address and is thus unspendable on consensus layer.
 func CheckAddressIsConsensusCapable(cfg *config.Config, address string) error {
@@ -293,6 +419,12 @@ func CheckAddressNotReserved(cfg *config.Config, address string) error {
 		return fmt.Errorf("address '%s' is governance deposit address", address)
 	}
 
+	return CheckAddressNotParaTime(cfg, address)
+}
+
+// CheckAddressNotParaTime checks whether the given native address is the consensus layer address
+// of any of the configured ParaTimes. Tokens transferred to such an address directly are lost.
+func CheckAddressNotParaTime(cfg *config.Config, address string) error {
 	for netName, net := range cfg.Networks.All {
 		for ptName, pt := range net.ParaTimes.All {
 			if types.NewAddressFromConsensus(staking.NewRuntimeAddress(pt.Namespace())).String() == address {
@@ -5,7 +5,11 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+
+	cliConfig "github.com/oasisprotocol/cli/config"
 )
 
 func TestResolveAddress(t *testing.T) {
@@ -83,3 +87,36 @@ func TestParseTestAccountAddress(t *testing.T) {
 		require.EqualValues(tc.expected, testName, tc.address)
 	}
 }
+
+func TestCheckAddressNotParaTime(t *testing.T) {
+	require := require.New(t)
+
+	cfg := cliConfig.Global()
+	sapphire := cfg.Networks.All["mainnet"].ParaTimes.All["sapphire"]
+	sapphireAddr := types.NewAddressFromConsensus(staking.NewRuntimeAddress(sapphire.Namespace()))
+
+	require.Error(CheckAddressNotParaTime(cfg, sapphireAddr.String()))
+	require.Error(CheckAddressNotReserved(cfg, sapphireAddr.String()))
+	require.NoError(CheckAddressNotParaTime(cfg, staking.CommonPoolAddress.String()))
+	require.Error(CheckAddressNotReserved(cfg, staking.CommonPoolAddress.String()))
+	require.NoError(CheckAddressNotParaTime(cfg, "oasis1qrec770vrek0a9a5lcrv0zvt22504k68svq7kzve"))
+}
+
+func TestCheckEthAddressChecksum(t *testing.T) {
+	require := require.New(t)
+
+	for _, tc := range []struct {
+		address string
+		valid   bool
+	}{
+		{"0x90adE3B7065fa715c7a150313877dF1d33e777D5", true},
+		{"0x90ade3b7065fa715c7a150313877df1d33e777d5", true},
+		{"0x90ADE3B7065FA715C7A150313877DF1D33E777D5", true},
+		{"0x90adE3B7065fa715c7a150313877dF1d33e777d5", false},
+		{"oasis1qrec770vrek0a9a5lcrv0zvt22504k68svq7kzve", true},
+		{"test:dave", true},
+	} {
+		err := CheckEthAddressChecksum(tc.address)
+		require.Equal(tc.valid, err == nil, tc.address)
+	}
+}
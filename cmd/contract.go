@@ -13,15 +13,17 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/connection"
-	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/helpers"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/contracts"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/core"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
 
 	"github.com/oasisprotocol/cli/cmd/common"
 	cliConfig "github.com/oasisprotocol/cli/config"
+	"github.com/oasisprotocol/cli/table"
 )
 
 var (
@@ -31,6 +33,8 @@ var (
 	contractStorageDumpKind   string
 	contractStorageDumpLimit  uint64
 	contractStorageDumpOffset uint64
+	contractEventsFromRound   uint64
+	contractEventsToRound     uint64
 
 	contractCmd = &cobra.Command{
 		Use:     "contract",
@@ -55,7 +59,7 @@ var (
 			cobra.CheckErr(err)
 
 			ctx := context.Background()
-			conn, err := connection.Connect(ctx, npa.Network)
+			conn, err := common.Connect(ctx, npa.Network)
 			cobra.CheckErr(err)
 
 			inst, err := conn.Runtime(npa.ParaTime).Contracts.Instance(ctx, client.RoundLatest, contracts.InstanceID(instanceID))
@@ -85,7 +89,7 @@ var (
 			cobra.CheckErr(err)
 
 			ctx := context.Background()
-			conn, err := connection.Connect(ctx, npa.Network)
+			conn, err := common.Connect(ctx, npa.Network)
 			cobra.CheckErr(err)
 
 			code, err := conn.Runtime(npa.ParaTime).Contracts.Code(ctx, client.RoundLatest, contracts.CodeID(codeID))
@@ -123,7 +127,7 @@ encoded as strings, or otherwise as Base64.`,
 			cobra.CheckErr(err)
 
 			ctx := context.Background()
-			conn, err := connection.Connect(ctx, npa.Network)
+			conn, err := common.Connect(ctx, npa.Network)
 			cobra.CheckErr(err)
 
 			var storeKind contracts.StoreKind
@@ -177,7 +181,7 @@ otherwise as Base64.`,
 			}
 
 			ctx := context.Background()
-			conn, err := connection.Connect(ctx, npa.Network)
+			conn, err := common.Connect(ctx, npa.Network)
 			cobra.CheckErr(err)
 
 			res, err := conn.Runtime(npa.ParaTime).Contracts.InstanceStorage(
@@ -198,6 +202,77 @@ otherwise as Base64.`,
 		},
 	}
 
+	contractEventsCmd = &cobra.Command{
+		Use:   "events <instance-id> [--from-round A] [--to-round B]",
+		Short: "Show events emitted by contract",
+		Long: `Scan the given range of rounds for events emitted by the contract instance and decode their
+CBOR payloads. If no range is given, only the latest round is scanned.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+			npa := common.GetNPASelection(cfg)
+			strInstanceID := args[0]
+
+			if npa.ParaTime == nil {
+				cobra.CheckErr("no ParaTime configured")
+			}
+
+			instanceID, err := strconv.ParseUint(strInstanceID, 10, 64)
+			cobra.CheckErr(err)
+
+			ctx := context.Background()
+			conn, err := common.Connect(ctx, npa.Network)
+			cobra.CheckErr(err)
+			rt := conn.Runtime(npa.ParaTime)
+
+			toRound := contractEventsToRound
+			if !cmd.Flags().Changed("to-round") {
+				blk, err := rt.GetBlock(ctx, client.RoundLatest)
+				cobra.CheckErr(err)
+				toRound = blk.Header.Round
+			}
+			fromRound := contractEventsFromRound
+			if !cmd.Flags().Changed("from-round") {
+				fromRound = toRound
+			}
+			if fromRound > toRound {
+				cobra.CheckErr(fmt.Errorf("from round %d is after to round %d", fromRound, toRound))
+			}
+
+			evs, err := getContractEvents(ctx, rt, contracts.InstanceID(instanceID), fromRound, toRound)
+			cobra.CheckErr(err)
+
+			if common.IsJSONOutput() {
+				data, err := common.JSONMarshalOutput(evs)
+				cobra.CheckErr(err)
+				fmt.Printf("%s\n", data)
+				return
+			}
+
+			listing := table.NewListing(
+				table.Column{Name: "Round"},
+				table.Column{Name: "Tx Hash"},
+				table.Column{Name: "Module"},
+				table.Column{Name: "Code"},
+				table.Column{Name: "Data"},
+			)
+			for _, ev := range evs {
+				txHash := "-"
+				if ev.TxHash != nil {
+					txHash = ev.TxHash.String()
+				}
+				listing.Append(
+					strconv.FormatUint(ev.Round, 10),
+					txHash,
+					ev.Module,
+					strconv.FormatUint(uint64(ev.Code), 10),
+					string(ev.Data),
+				)
+			}
+			cobra.CheckErr(listing.Render(common.GetListingOptions()))
+		},
+	}
+
 	contractDumpCodeCmd = &cobra.Command{
 		Use:   "dump-code <code-id>",
 		Short: "Dump WebAssembly smart contract code",
@@ -215,7 +290,7 @@ otherwise as Base64.`,
 			cobra.CheckErr(err)
 
 			ctx := context.Background()
-			conn, err := connection.Connect(ctx, npa.Network)
+			conn, err := common.Connect(ctx, npa.Network)
 			cobra.CheckErr(err)
 
 			// Fetch WASM contract code, if supported.
@@ -252,7 +327,7 @@ otherwise as Base64.`,
 			var conn connection.Connection
 			if !txCfg.Offline {
 				var err error
-				conn, err = connection.Connect(ctx, npa.Network)
+				conn, err = common.Connect(ctx, npa.Network)
 				cobra.CheckErr(err)
 			}
 
@@ -264,12 +339,23 @@ otherwise as Base64.`,
 			instantiatePolicy := parsePolicy(npa.Network, npa.Account, contractInstantiatePolicy)
 
 			// Prepare transaction.
+			code := contracts.CompressCode(wasmData)
 			tx := contracts.NewUploadTx(nil, &contracts.Upload{
 				ABI:               contracts.ABIOasisV1,
 				InstantiatePolicy: *instantiatePolicy,
-				Code:              contracts.CompressCode(wasmData),
+				Code:              code,
 			})
 
+			// Make sure the code can be uploaded before signing the transaction.
+			if !txCfg.Offline {
+				contractsParams, err := conn.Runtime(npa.ParaTime).Contracts.Parameters(ctx, client.RoundLatest)
+				cobra.CheckErr(err)
+				coreParams, err := conn.Runtime(npa.ParaTime).Core.Parameters(ctx, client.RoundLatest)
+				cobra.CheckErr(err)
+				err = checkContractUploadLimits(len(wasmData), len(code), len(cbor.Marshal(tx)), contractsParams, coreParams)
+				cobra.CheckErr(err)
+			}
+
 			acc := common.LoadAccount(cfg, npa.AccountName)
 			sigTx, meta, err := common.SignParaTimeTransaction(ctx, npa, acc, conn, tx, nil)
 			cobra.CheckErr(err)
@@ -312,7 +398,7 @@ otherwise as Base64.`,
 			ctx := context.Background()
 			var conn connection.Connection
 			if !txCfg.Offline {
-				conn, err = connection.Connect(ctx, npa.Network)
+				conn, err = common.Connect(ctx, npa.Network)
 				cobra.CheckErr(err)
 			}
 
@@ -371,7 +457,7 @@ otherwise as Base64.`,
 			ctx := context.Background()
 			var conn connection.Connection
 			if !txCfg.Offline {
-				conn, err = connection.Connect(ctx, npa.Network)
+				conn, err = common.Connect(ctx, npa.Network)
 				cobra.CheckErr(err)
 			}
 
@@ -433,7 +519,7 @@ otherwise as Base64.`,
 			ctx := context.Background()
 			var conn connection.Connection
 			if !txCfg.Offline {
-				conn, err = connection.Connect(ctx, npa.Network)
+				conn, err = common.Connect(ctx, npa.Network)
 				cobra.CheckErr(err)
 			}
 
@@ -455,6 +541,74 @@ otherwise as Base64.`,
 	}
 )
 
+// contractEvent is a decoded event emitted by a contract.
+type contractEvent struct {
+	Round  uint64          `json:"round"`
+	TxHash *hash.Hash      `json:"tx_hash,omitempty"`
+	Module string          `json:"module"`
+	Code   uint32          `json:"code"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// getContractEvents returns the events emitted by the given contract instance in the given
+// (inclusive) range of rounds.
+func getContractEvents(ctx context.Context, rc client.RuntimeClient, instanceID contracts.InstanceID, fromRound, toRound uint64) ([]*contractEvent, error) {
+	evs := []*contractEvent{}
+	for round := fromRound; round <= toRound; round++ {
+		rawEvs, err := rc.GetEventsRaw(ctx, round)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch events for round %d: %w", round, err)
+		}
+		for _, rawEv := range rawEvs {
+			decEvs, err := contracts.DecodeEvent(rawEv)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode event in round %d: %w", round, err)
+			}
+			for _, decEv := range decEvs {
+				ev := decEv.(*contracts.Event)
+				if ev.ID != instanceID {
+					continue
+				}
+
+				var data interface{}
+				if err = cbor.Unmarshal(ev.Data, &data); err != nil {
+					// Data is not CBOR, use raw value instead.
+					data = ev.Data
+				}
+				evs = append(evs, &contractEvent{
+					Round:  round,
+					TxHash: rawEv.TxHash,
+					Module: rawEv.Module,
+					Code:   rawEv.Code,
+					Data:   common.JSONMarshalUniversalValue(data),
+				})
+			}
+		}
+	}
+	return evs, nil
+}
+
+// contractUploadTxOverhead is the space reserved for the signer information and signatures when
+// checking the size of the contract upload transaction.
+const contractUploadTxOverhead = 512
+
+// checkContractUploadLimits checks that a contract with the given uncompressed and compressed code
+// size can be uploaded in a single transaction with the given unsigned body size. The contracts
+// module does not support uploading code in chunks, so larger contracts need to be made smaller.
+func checkContractUploadLimits(codeSize, compressedSize, txSize int, contractsParams *contracts.Parameters, coreParams *core.Parameters) error {
+	if codeSize > int(contractsParams.MaxCodeSize) {
+		return fmt.Errorf("contract code is %d bytes, but the maximum code size is %d bytes", codeSize, contractsParams.MaxCodeSize)
+	}
+	if maxTxSize := int(coreParams.MaxTxSize); maxTxSize > 0 && txSize+contractUploadTxOverhead > maxTxSize {
+		return fmt.Errorf("compressed contract code is %d bytes, which does not fit into the maximum transaction size of %d bytes", compressedSize, maxTxSize)
+	}
+	gas := contractsParams.GasCosts.TxUpload + contractsParams.GasCosts.TxUploadPerByte*uint64(compressedSize)
+	if coreParams.MaxBatchGas > 0 && gas > coreParams.MaxBatchGas {
+		return fmt.Errorf("uploading the contract requires %d gas, but the maximum batch gas is %d", gas, coreParams.MaxBatchGas)
+	}
+	return nil
+}
+
 func formatPolicy(policy *contracts.Policy) string {
 	switch {
 	case policy.Nobody != nil:
@@ -491,9 +645,12 @@ func parsePolicy(net *config.Network, wallet *cliConfig.Account, policy string)
 }
 
 func parseData(data string) interface{} {
+	data, err := common.ReadDataArg(data)
+	cobra.CheckErr(err)
+
 	var result interface{}
 	if len(data) > 0 {
-		err := yaml.Unmarshal([]byte(data), &result)
+		err = yaml.Unmarshal([]byte(data), &result)
 		cobra.CheckErr(err)
 	}
 	return result
@@ -502,8 +659,7 @@ func parseData(data string) interface{} {
 func parseTokens(pt *config.ParaTime, tokens []string) []types.BaseUnits {
 	result := []types.BaseUnits{}
 	for _, raw := range tokens {
-		// TODO: Support parsing denominations.
-		amount, err := helpers.ParseParaTimeDenomination(pt, raw, types.NativeDenomination)
+		amount, err := common.ParseParaTimeAmount(pt, raw, types.NativeDenomination)
 		if err != nil {
 			cobra.CheckErr(fmt.Errorf("malformed token amount: %w", err))
 		}
@@ -526,7 +682,7 @@ func init() {
 	contractUploadCmd.Flags().AddFlagSet(contractsUploadFlags)
 
 	contractsCallFlags := flag.NewFlagSet("", flag.ContinueOnError)
-	contractsCallFlags.StringSliceVar(&contractTokens, "tokens", []string{}, "token amounts to send to a contract")
+	contractsCallFlags.StringSliceVar(&contractTokens, "tokens", []string{}, "token amounts to send to a contract (e.g. '10 TEST')")
 
 	contractsInstantiateFlags := flag.NewFlagSet("", flag.ContinueOnError)
 	contractsInstantiateFlags.StringVar(&contractUpgradesPolicy, "upgrades-policy", "owner", "contract upgrades policy")
@@ -535,10 +691,12 @@ func init() {
 	contractInstantiateCmd.Flags().AddFlagSet(common.RuntimeTxFlags)
 	contractInstantiateCmd.Flags().AddFlagSet(contractsInstantiateFlags)
 	contractInstantiateCmd.Flags().AddFlagSet(contractsCallFlags)
+	contractInstantiateCmd.Flags().AddFlagSet(common.AmountFlags)
 
 	contractCallCmd.Flags().AddFlagSet(common.SelectorFlags)
 	contractCallCmd.Flags().AddFlagSet(common.RuntimeTxFlags)
 	contractCallCmd.Flags().AddFlagSet(contractsCallFlags)
+	contractCallCmd.Flags().AddFlagSet(common.AmountFlags)
 
 	contractChangeUpgradePolicyCmd.Flags().AddFlagSet(common.SelectorFlags)
 	contractChangeUpgradePolicyCmd.Flags().AddFlagSet(common.RuntimeTxFlags)
@@ -557,6 +715,14 @@ func init() {
 
 	contractStorageGetCmd.Flags().AddFlagSet(common.SelectorFlags)
 
+	contractsEventsFlags := flag.NewFlagSet("", flag.ContinueOnError)
+	contractsEventsFlags.Uint64Var(&contractEventsFromRound, "from-round", 0, "first round to scan (default same as --to-round)")
+	contractsEventsFlags.Uint64Var(&contractEventsToRound, "to-round", 0, "last round to scan (default latest round)")
+	contractEventsCmd.Flags().AddFlagSet(common.SelectorFlags)
+	contractEventsCmd.Flags().AddFlagSet(common.FormatFlag)
+	contractEventsCmd.Flags().AddFlagSet(common.ListingFlags)
+	contractEventsCmd.Flags().AddFlagSet(contractsEventsFlags)
+
 	contractStorageCmd.AddCommand(contractStorageDumpCmd)
 	contractStorageCmd.AddCommand(contractStorageGetCmd)
 
@@ -564,6 +730,7 @@ func init() {
 	contractCmd.AddCommand(contractShowCodeCmd)
 	contractCmd.AddCommand(contractStorageCmd)
 	contractCmd.AddCommand(contractDumpCodeCmd)
+	contractCmd.AddCommand(contractEventsCmd)
 	contractCmd.AddCommand(contractUploadCmd)
 	contractCmd.AddCommand(contractInstantiateCmd)
 	contractCmd.AddCommand(contractCallCmd)
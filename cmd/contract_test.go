@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/contracts"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/core"
+)
+
+func TestCheckContractUploadLimits(t *testing.T) {
+	require := require.New(t)
+
+	contractsParams := &contracts.Parameters{
+		MaxCodeSize: 1000,
+		GasCosts: contracts.GasCosts{
+			TxUpload:        100,
+			TxUploadPerByte: 10,
+		},
+	}
+	coreParams := &core.Parameters{
+		MaxBatchGas: 10_000,
+		MaxTxSize:   1_024,
+	}
+
+	require.NoError(checkContractUploadLimits(1000, 400, 500, contractsParams, coreParams))
+
+	err := checkContractUploadLimits(1001, 400, 500, contractsParams, coreParams)
+	require.ErrorContains(err, "maximum code size is 1000 bytes")
+
+	err = checkContractUploadLimits(1000, 400, 600, contractsParams, coreParams)
+	require.ErrorContains(err, "maximum transaction size of 1024 bytes")
+
+	err = checkContractUploadLimits(1000, 1000, 500, contractsParams, coreParams)
+	require.ErrorContains(err, "requires 10100 gas")
+	coreParams.MaxBatchGas = 20_000
+	require.NoError(checkContractUploadLimits(1000, 1000, 500, contractsParams, coreParams))
+
+	// Old runtimes don't report the maximum transaction size.
+	coreParams.MaxTxSize = 0
+	require.NoError(checkContractUploadLimits(1000, 400, 5000, contractsParams, coreParams))
+}
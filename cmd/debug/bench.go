@@ -0,0 +1,269 @@
+package debug
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/connection"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/accounts"
+
+	"github.com/oasisprotocol/cli/cmd/common"
+	cliConfig "github.com/oasisprotocol/cli/config"
+	"github.com/oasisprotocol/cli/table"
+)
+
+var (
+	benchRequests    uint
+	benchConcurrency uint
+	benchParaTime    string
+	benchEndpoints   []string
+
+	benchCmd = &cobra.Command{
+		Use:   "bench [<network>]",
+		Short: "Benchmark the gRPC endpoints of a network",
+		Long: `Measure the latency and throughput of common queries (consensus blocks, consensus
+accounts and ParaTime accounts) against the gRPC endpoint of the given network
+or the default one. Pass --endpoint to compare additional endpoints of the same
+network, e.g. when picking a public endpoint or diagnosing your own node.
+
+Failed requests are retried according to the request policy, so pass
+--retries 0 to measure the raw endpoint performance.`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+
+			netName := cfg.Networks.Default
+			if len(args) > 0 {
+				netName = args[0]
+			}
+			net := cfg.Networks.All[netName]
+			if net == nil {
+				cobra.CheckErr(fmt.Errorf("network '%s' does not exist", netName))
+			}
+			if benchRequests == 0 || benchConcurrency == 0 {
+				cobra.CheckErr("number of requests and concurrency must be positive")
+			}
+
+			ptName := benchParaTime
+			if ptName == "" {
+				ptName = net.ParaTimes.Default
+			}
+			var pt *config.ParaTime
+			if ptName != "" {
+				if pt = net.ParaTimes.All[ptName]; pt == nil {
+					cobra.CheckErr(fmt.Errorf("ParaTime '%s' does not exist", ptName))
+				}
+			}
+
+			endpoints := append([]string{net.RPC}, benchEndpoints...)
+			ctx := context.Background()
+			var results []*benchResult
+			for _, endpoint := range endpoints {
+				if !common.IsJSONOutput() {
+					fmt.Printf("Benchmarking %s...\n", endpoint)
+				}
+
+				epNet := *net
+				epNet.RPC = endpoint
+				conn, err := common.Connect(ctx, &epNet)
+				if err != nil {
+					cobra.CheckErr(fmt.Errorf("failed to connect to %s: %w", endpoint, err))
+				}
+				for _, q := range benchQueries(conn, pt) {
+					results = append(results, runBenchQuery(ctx, endpoint, q, benchRequests, benchConcurrency))
+				}
+			}
+
+			if common.IsJSONOutput() {
+				data, err := common.JSONMarshalOutput(results)
+				cobra.CheckErr(err)
+				fmt.Printf("%s\n", data)
+				return
+			}
+
+			fmt.Println()
+			t := table.New()
+			t.SetHeader([]string{"Endpoint", "Query", "Errors", "p50", "p90", "p99", "Max", "Req/s"})
+			for _, r := range results {
+				t.Append([]string{
+					r.Endpoint,
+					r.Query,
+					fmt.Sprintf("%d/%d", r.Errors, r.Requests),
+					formatBenchLatency(r.P50),
+					formatBenchLatency(r.P90),
+					formatBenchLatency(r.P99),
+					formatBenchLatency(r.Max),
+					fmt.Sprintf("%.1f", r.throughput()),
+				})
+			}
+			t.Render()
+		},
+	}
+)
+
+// benchQuery is a query performed by the benchmark.
+type benchQuery struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// benchResult contains the measurements of a single query against a single endpoint. Durations
+// are in microseconds.
+type benchResult struct {
+	Endpoint string `json:"endpoint"`
+	Query    string `json:"query"`
+	Requests uint   `json:"requests"`
+	Errors   uint   `json:"errors"`
+	P50      int64  `json:"p50_us"`
+	P90      int64  `json:"p90_us"`
+	P99      int64  `json:"p99_us"`
+	Max      int64  `json:"max_us"`
+	Duration int64  `json:"duration_us"`
+}
+
+// throughput returns the number of successful requests per second.
+func (r *benchResult) throughput() float64 {
+	if r.Duration == 0 {
+		return 0
+	}
+	return float64(r.Requests-r.Errors) / (float64(r.Duration) / float64(time.Second/time.Microsecond))
+}
+
+// benchQueries returns the queries to benchmark. ParaTime queries are only included when a
+// ParaTime is given.
+func benchQueries(conn connection.Connection, pt *config.ParaTime) []benchQuery {
+	queries := []benchQuery{
+		{
+			name: "consensus.GetBlock",
+			fn: func(ctx context.Context) error {
+				_, err := conn.Consensus().GetBlock(ctx, consensus.HeightLatest)
+				return err
+			},
+		},
+		{
+			name: "staking.Account",
+			fn: func(ctx context.Context) error {
+				_, err := conn.Consensus().Staking().Account(ctx, &staking.OwnerQuery{
+					Height: consensus.HeightLatest,
+					Owner:  staking.CommonPoolAddress,
+				})
+				return err
+			},
+		},
+	}
+	if pt == nil {
+		return queries
+	}
+
+	rt := conn.Runtime(pt)
+	return append(queries,
+		benchQuery{
+			name: "runtime.GetBlock",
+			fn: func(ctx context.Context) error {
+				_, err := rt.GetBlock(ctx, client.RoundLatest)
+				return err
+			},
+		},
+		benchQuery{
+			name: "accounts.Balances",
+			fn: func(ctx context.Context) error {
+				_, err := rt.Accounts.Balances(ctx, client.RoundLatest, accounts.FeeAccumulatorAddress)
+				return err
+			},
+		},
+	)
+}
+
+// runBenchQuery performs the given number of requests of the query with the given concurrency and
+// returns the measurements.
+func runBenchQuery(ctx context.Context, endpoint string, q benchQuery, requests, concurrency uint) *benchResult {
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		latencies []time.Duration
+		failed    uint
+	)
+	work := make(chan struct{}, requests)
+	for i := uint(0); i < requests; i++ {
+		work <- struct{}{}
+	}
+	close(work)
+
+	start := time.Now()
+	for i := uint(0); i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range work {
+				reqStart := time.Now()
+				err := q.fn(ctx)
+				latency := time.Since(reqStart)
+
+				mu.Lock()
+				if err != nil {
+					failed++
+				} else {
+					latencies = append(latencies, latency)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	r := benchResult{
+		Endpoint: endpoint,
+		Query:    q.name,
+		Requests: requests,
+		Errors:   failed,
+		Duration: time.Since(start).Microseconds(),
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	r.P50 = percentile(latencies, 50).Microseconds()
+	r.P90 = percentile(latencies, 90).Microseconds()
+	r.P99 = percentile(latencies, 99).Microseconds()
+	r.Max = percentile(latencies, 100).Microseconds()
+	return &r
+}
+
+// percentile returns the given percentile of the sorted latencies using the nearest-rank method.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// formatBenchLatency formats the given latency in microseconds.
+func formatBenchLatency(us int64) string {
+	if us == 0 {
+		return "-"
+	}
+	d := time.Duration(us) * time.Microsecond
+	return d.Round(100 * time.Microsecond).String()
+}
+
+func init() {
+	benchFlags := flag.NewFlagSet("", flag.ContinueOnError)
+	benchFlags.UintVar(&benchRequests, "requests", 50, "number of requests of each query")
+	benchFlags.UintVar(&benchConcurrency, "concurrency", 1, "number of concurrent requests")
+	benchFlags.StringVar(&benchParaTime, "paratime", "", "ParaTime to query (default: the default ParaTime of the network)")
+	benchFlags.StringSliceVar(&benchEndpoints, "endpoint", nil, "additional gRPC endpoint of the network to benchmark")
+
+	benchCmd.Flags().AddFlagSet(benchFlags)
+	benchCmd.Flags().AddFlagSet(common.FormatFlag)
+}
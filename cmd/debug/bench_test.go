@@ -0,0 +1,27 @@
+package debug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPercentile(t *testing.T) {
+	require := require.New(t)
+
+	require.EqualValues(0, percentile(nil, 50))
+
+	var latencies []time.Duration
+	for i := 1; i <= 10; i++ {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+	require.Equal(5*time.Millisecond, percentile(latencies, 50))
+	require.Equal(9*time.Millisecond, percentile(latencies, 90))
+	require.Equal(10*time.Millisecond, percentile(latencies, 99))
+	require.Equal(10*time.Millisecond, percentile(latencies, 100))
+	require.Equal(time.Millisecond, percentile(latencies[:1], 50))
+
+	r := benchResult{Requests: 10, Errors: 2, Duration: 2_000_000}
+	require.InDelta(4.0, r.throughput(), 0.001)
+}
@@ -0,0 +1,122 @@
+package debug
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+
+	"github.com/oasisprotocol/cli/cmd/common"
+)
+
+// Supported binary encodings.
+const (
+	encodingBase64 = "base64"
+	encodingHex    = "hex"
+)
+
+var (
+	cborEncoding string
+
+	cborCmd = &cobra.Command{
+		Use:   "cbor",
+		Short: "Convert between CBOR and JSON",
+	}
+
+	cborDecodeCmd = &cobra.Command{
+		Use:   "decode [<cbor>]",
+		Short: "Decode a hex or Base64-encoded CBOR blob into JSON",
+		Long: `Decode a hex or Base64-encoded CBOR blob into JSON. If no blob is given, it is
+read from standard input. Byte strings are shown as hex with the "0x" prefix.`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			input, err := readInput(args)
+			cobra.CheckErr(err)
+
+			raw, err := decodeBinary(input)
+			cobra.CheckErr(err)
+
+			var decoded interface{}
+			if err = cbor.Unmarshal(raw, &decoded); err != nil {
+				cobra.CheckErr(fmt.Errorf("malformed CBOR: %w", err))
+			}
+
+			var out bytes.Buffer
+			err = json.Indent(&out, common.JSONMarshalUniversalValue(common.HumanizeCBORValue(decoded)), "", "  ")
+			cobra.CheckErr(err)
+			fmt.Println(out.String())
+		},
+	}
+
+	cborEncodeCmd = &cobra.Command{
+		Use:   "encode [<json>]",
+		Short: "Encode JSON into a CBOR blob",
+		Long: `Encode JSON into a CBOR blob. If no JSON is given, it is read from standard input.
+
+Strings starting with "0x" or "base64:" are encoded as byte strings, as are
+Oasis ("oasis1...") and ROFL app ("rofl1...") addresses.`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			input, err := readInput(args)
+			cobra.CheckErr(err)
+
+			v, err := common.ParseCBORJSON(input)
+			cobra.CheckErr(err)
+
+			raw := cbor.Marshal(v)
+			switch cborEncoding {
+			case encodingBase64:
+				fmt.Println(base64.StdEncoding.EncodeToString(raw))
+			case encodingHex:
+				fmt.Println(hex.EncodeToString(raw))
+			default:
+				cobra.CheckErr(fmt.Errorf("unsupported encoding: %s", cborEncoding))
+			}
+		},
+	}
+)
+
+// readInput returns the first argument or, if none is given, the standard input.
+func readInput(args []string) (string, error) {
+	if len(args) > 0 {
+		return args[0], nil
+	}
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return "", fmt.Errorf("failed to read standard input: %w", err)
+	}
+	return string(data), nil
+}
+
+// decodeBinary decodes the given hex (optionally prefixed with "0x") or Base64-encoded data.
+func decodeBinary(input string) ([]byte, error) {
+	input = strings.TrimSpace(input)
+	if data, err := hex.DecodeString(strings.TrimPrefix(input, "0x")); err == nil {
+		return data, nil
+	}
+	if data, err := base64.StdEncoding.DecodeString(input); err == nil {
+		return data, nil
+	}
+	if data, err := base64.URLEncoding.DecodeString(input); err == nil {
+		return data, nil
+	}
+	return nil, fmt.Errorf("input is neither valid hex nor Base64")
+}
+
+func init() {
+	encodeFlags := flag.NewFlagSet("", flag.ContinueOnError)
+	encodeFlags.StringVar(&cborEncoding, "encoding", encodingBase64, "output encoding [base64,hex]")
+	cborEncodeCmd.Flags().AddFlagSet(encodeFlags)
+
+	cborCmd.AddCommand(cborDecodeCmd)
+	cborCmd.AddCommand(cborEncodeCmd)
+}
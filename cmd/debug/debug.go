@@ -0,0 +1,15 @@
+package debug
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "debug",
+	Short: "Debugging utilities",
+}
+
+func init() {
+	Cmd.AddCommand(benchCmd)
+	Cmd.AddCommand(cborCmd)
+}
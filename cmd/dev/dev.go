@@ -0,0 +1,192 @@
+package dev
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
+
+	"github.com/oasisprotocol/cli/cmd/common"
+	cliConfig "github.com/oasisprotocol/cli/config"
+)
+
+const (
+	// defaultImage is the Docker image of the local testing environment.
+	defaultImage = "ghcr.io/oasisprotocol/sapphire-localnet:latest"
+	// defaultContainerName is the name of the Docker container running the local testing
+	// environment.
+	defaultContainerName = "oasis-dev"
+	// defaultNetworkName is the name under which the local network is registered.
+	defaultNetworkName = "localhost"
+
+	// localnetRPC is the gRPC endpoint of the node in the local testing environment.
+	localnetRPC = "http://localhost:8544"
+	// localnetPorts are the ports published by the local testing environment.
+	localnetPorts = "8544-8548:8544-8548"
+	// localnetParaTimeID is the ID of the ParaTime in the local testing environment.
+	localnetParaTimeID = "8000000000000000000000000000000000000000000000000000000000000000"
+	// localnetSymbol is the token symbol of the local testing environment.
+	localnetSymbol = "TEST"
+)
+
+var (
+	image         string
+	containerName string
+	networkName   string
+
+	// Cmd is the dev sub-command set root.
+	Cmd = &cobra.Command{
+		Use:   "dev",
+		Short: "Manage a local testing environment",
+	}
+
+	containerFlags = flag.NewFlagSet("", flag.ContinueOnError)
+	networkFlags   = flag.NewFlagSet("", flag.ContinueOnError)
+)
+
+// docker runs the given docker command and returns its trimmed output.
+func docker(args ...string) (string, error) {
+	if _, err := exec.LookPath("docker"); err != nil {
+		return "", fmt.Errorf("docker is required to run the local testing environment: %w", err)
+	}
+	cmd := exec.Command("docker", args...)
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	return strings.TrimSpace(string(out)), err
+}
+
+// containerStatus returns the status of the local testing environment container (e.g. running,
+// exited) or an empty string if the container does not exist.
+func containerStatus() string {
+	out, err := exec.Command("docker", "inspect", "--format", "{{.State.Status}}", containerName).Output() //nolint:gosec
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// containerImage returns the image of the local testing environment container or an empty string
+// if the container does not exist.
+func containerImage() string {
+	out, err := exec.Command("docker", "inspect", "--format", "{{.Config.Image}}", containerName).Output() //nolint:gosec
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// paraTimeName returns the name of the ParaTime run by the given local testing environment image,
+// e.g. sapphire for the sapphire-localnet image.
+func paraTimeName(image string) string {
+	name := path.Base(image)
+	name, _, _ = strings.Cut(name, ":")
+	name, _, _ = strings.Cut(name, "@")
+	if pt, ok := strings.CutSuffix(name, "-localnet"); ok && config.ValidateIdentifier(pt) == nil {
+		return pt
+	}
+	return "sapphire"
+}
+
+// localnetNetwork returns the configuration of the local testing environment network with the
+// given chain context running the given ParaTime.
+func localnetNetwork(chainContext, ptName string) *config.Network {
+	return &config.Network{
+		Description:  "Local testing environment",
+		ChainContext: chainContext,
+		RPC:          localnetRPC,
+		Denomination: config.DenominationInfo{
+			Symbol:   localnetSymbol,
+			Decimals: 9,
+		},
+		ParaTimes: config.ParaTimes{
+			Default: ptName,
+			All: map[string]*config.ParaTime{
+				ptName: {
+					Description: fmt.Sprintf("Local %s ParaTime", ptName),
+					ID:          localnetParaTimeID,
+					Denominations: map[string]*config.DenominationInfo{
+						config.NativeDenominationKey: {
+							Symbol:   localnetSymbol,
+							Decimals: 18,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// waitReady waits until the node and the ParaTime of the local testing environment are ready and
+// returns the chain context of the network.
+func waitReady(ctx context.Context, net *config.Network, timeout time.Duration) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	conn, err := common.ConnectNoVerify(ctx, net)
+	if err != nil {
+		return "", err
+	}
+	pt := net.ParaTimes.All[net.ParaTimes.Default]
+
+	for {
+		chainContext, err := conn.Consensus().GetChainContext(ctx)
+		if err == nil {
+			if _, err = conn.Runtime(pt).Core.Parameters(ctx, client.RoundLatest); err == nil {
+				return chainContext, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", fmt.Errorf("local testing environment not ready after %s: %w", timeout, err)
+		case <-time.After(2 * time.Second):
+		}
+	}
+}
+
+// registerNetwork adds the local testing environment network to the configuration or updates it,
+// since the chain context changes each time the environment is started.
+func registerNetwork(cfg *cliConfig.Config, net *config.Network) error {
+	existing := cfg.Networks.All[networkName]
+	if existing == nil {
+		if err := cfg.Networks.Add(networkName, net); err != nil {
+			return err
+		}
+	} else {
+		existing.ChainContext = net.ChainContext
+		existing.RPC = net.RPC
+		for name, pt := range net.ParaTimes.All {
+			if existing.ParaTimes.All == nil {
+				existing.ParaTimes.All = make(map[string]*config.ParaTime)
+			}
+			existing.ParaTimes.All[name] = pt
+		}
+		if existing.ParaTimes.Default == "" {
+			existing.ParaTimes.Default = net.ParaTimes.Default
+		}
+	}
+
+	// The built-in test accounts are funded in the local testing environment.
+	if cfg.DefaultAccounts.Resolve(networkName, "") == "" {
+		cfg.DefaultAccounts.Set(networkName, "", "test:alice")
+	}
+	return nil
+}
+
+func init() {
+	containerFlags.StringVar(&containerName, "name", defaultContainerName, "name of the local testing environment container")
+	networkFlags.StringVar(&networkName, "network-name", defaultNetworkName, "name of the network of the local testing environment")
+
+	Cmd.AddCommand(upCmd)
+	Cmd.AddCommand(downCmd)
+	Cmd.AddCommand(statusCmd)
+}
@@ -0,0 +1,62 @@
+package dev
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
+
+	cliConfig "github.com/oasisprotocol/cli/config"
+)
+
+func TestParaTimeName(t *testing.T) {
+	require := require.New(t)
+
+	for _, tc := range []struct {
+		image    string
+		expected string
+	}{
+		{"ghcr.io/oasisprotocol/sapphire-localnet:latest", "sapphire"},
+		{"ghcr.io/oasisprotocol/emerald-localnet:2024-09-13", "emerald"},
+		{"emerald-localnet@sha256:0123", "emerald"},
+		{"example.com/custom-image:latest", "sapphire"},
+	} {
+		require.Equal(tc.expected, paraTimeName(tc.image), tc.image)
+	}
+}
+
+func TestLocalnetNetworkDenominations(t *testing.T) {
+	require := require.New(t)
+
+	net := localnetNetwork("074b1bcf4fb0b57cef7afa8ea2e3fc1c6c6b8a4a1c3c63cb74e1a1b3f2ba0a25", "sapphire")
+	require.NoError(net.Validate())
+
+	// The consensus layer uses 9 decimals while the ParaTime uses 18.
+	require.Equal(localnetSymbol, net.Denomination.Symbol)
+	require.EqualValues(9, net.Denomination.Decimals)
+	pt := net.ParaTimes.All["sapphire"]
+	require.NotNil(pt)
+	native := pt.Denominations[config.NativeDenominationKey]
+	require.Equal(localnetSymbol, native.Symbol)
+	require.EqualValues(18, native.Decimals)
+}
+
+func TestRegisterNetwork(t *testing.T) {
+	require := require.New(t)
+
+	networkName = defaultNetworkName
+	cfg := &cliConfig.Config{}
+
+	net := localnetNetwork("074b1bcf4fb0b57cef7afa8ea2e3fc1c6c6b8a4a1c3c63cb74e1a1b3f2ba0a25", "sapphire")
+	require.NoError(net.Validate())
+	require.NoError(registerNetwork(cfg, net))
+	require.Equal("test:alice", cfg.DefaultAccounts.Resolve(networkName, ""))
+
+	// Restarting the environment changes the chain context.
+	cfg.DefaultAccounts.Set(networkName, "", "test:dave")
+	restarted := localnetNetwork("5a4bc2bb1c1d9b3bfc6ec2b1e3a6a8a5c1d0f2e4b6a8c0e2f4a6b8d0e2f4a6b8", "sapphire")
+	require.NoError(registerNetwork(cfg, restarted))
+	require.Equal(restarted.ChainContext, cfg.Networks.All[networkName].ChainContext)
+	require.Equal("test:dave", cfg.DefaultAccounts.Resolve(networkName, ""), "existing default account must be kept")
+}
@@ -0,0 +1,29 @@
+package dev
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var downCmd = &cobra.Command{
+	Use:   "down",
+	Short: "Stop and remove the local testing environment",
+	Long: `Stop and remove the local testing environment container together with its chain
+state. The network stays registered and is updated by the next 'oasis dev up'.`,
+	Args: cobra.NoArgs,
+	Run: func(_ *cobra.Command, _ []string) {
+		if containerStatus() == "" {
+			fmt.Printf("Local testing environment '%s' is not running.\n", containerName)
+			return
+		}
+
+		_, err := docker("rm", "--force", "--volumes", containerName)
+		cobra.CheckErr(err)
+		fmt.Printf("Local testing environment '%s' removed.\n", containerName)
+	},
+}
+
+func init() {
+	downCmd.Flags().AddFlagSet(containerFlags)
+}
@@ -0,0 +1,55 @@
+package dev
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oasisprotocol/cli/cmd/common"
+	cliConfig "github.com/oasisprotocol/cli/config"
+)
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the status of the local testing environment",
+	Args:  cobra.NoArgs,
+	Run: func(_ *cobra.Command, _ []string) {
+		cfg := cliConfig.Global()
+
+		status := containerStatus()
+		if status == "" {
+			status = "not created"
+		}
+		fmt.Printf("Container: %s (%s)\n", containerName, status)
+
+		net := cfg.Networks.All[networkName]
+		if net == nil {
+			fmt.Printf("Network:   %s (not registered)\n", networkName)
+			return
+		}
+		fmt.Printf("Network:   %s (%s)\n", networkName, net.RPC)
+		if status != "running" {
+			return
+		}
+
+		// Check whether the registered chain context matches the running environment.
+		ctx := context.Background()
+		conn, err := common.ConnectNoVerify(ctx, net)
+		cobra.CheckErr(err)
+		chainContext, err := conn.Consensus().GetChainContext(ctx)
+		if err != nil {
+			fmt.Println("Node:      not ready")
+			return
+		}
+		fmt.Println("Node:      ready")
+		if chainContext != net.ChainContext {
+			fmt.Println("The registered chain context is outdated, run 'oasis dev up' to update it.")
+		}
+	},
+}
+
+func init() {
+	statusCmd.Flags().AddFlagSet(containerFlags)
+	statusCmd.Flags().AddFlagSet(networkFlags)
+}
@@ -0,0 +1,80 @@
+package dev
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	cliConfig "github.com/oasisprotocol/cli/config"
+)
+
+var (
+	upTimeout time.Duration
+
+	upCmd = &cobra.Command{
+		Use:   "up",
+		Short: "Start the local testing environment and register it as a network",
+		Long: `Start the local testing environment in a Docker container, wait until it is
+ready and register it as a network. The built-in test accounts (test:alice,
+test:bob, ...) are funded and test:alice becomes the default account of the
+network. Since the chain context changes each time the environment is started,
+the network is updated if it already exists.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, _ []string) {
+			cfg := cliConfig.Global()
+
+			// An existing container keeps its image unless a different one is explicitly requested,
+			// in which case the container is recreated.
+			status := containerStatus()
+			if current := containerImage(); status != "" && current != image {
+				if !cmd.Flags().Changed("image") {
+					image = current
+				} else {
+					fmt.Printf("Removing local testing environment '%s' running %s...\n", containerName, current)
+					_, err := docker("rm", "--force", containerName)
+					cobra.CheckErr(err)
+					status = ""
+				}
+			}
+
+			switch status {
+			case "running":
+				fmt.Printf("Local testing environment '%s' is already running.\n", containerName)
+			case "":
+				fmt.Printf("Starting local testing environment '%s' from %s...\n", containerName, image)
+				_, err := docker("run", "--detach", "--name", containerName, "--publish", localnetPorts, image)
+				cobra.CheckErr(err)
+			default:
+				fmt.Printf("Restarting local testing environment '%s' (%s)...\n", containerName, status)
+				_, err := docker("start", containerName)
+				cobra.CheckErr(err)
+			}
+
+			fmt.Println("Waiting for the local testing environment to become ready...")
+			net := localnetNetwork("", paraTimeName(image))
+			chainContext, err := waitReady(context.Background(), net, upTimeout)
+			cobra.CheckErr(err)
+			net.ChainContext = chainContext
+
+			cobra.CheckErr(registerNetwork(cfg, net))
+			cobra.CheckErr(cfg.Save())
+
+			fmt.Printf("Local testing environment is ready as network '%s' (%s).\n", networkName, net.RPC)
+			fmt.Printf("Default account: %s\n", cfg.DefaultAccounts.Resolve(networkName, ""))
+			fmt.Printf("Run commands against it with --network %s.\n", networkName)
+		},
+	}
+)
+
+func init() {
+	upFlags := flag.NewFlagSet("", flag.ContinueOnError)
+	upFlags.StringVar(&image, "image", defaultImage, "Docker image of the local testing environment")
+	upFlags.DurationVar(&upTimeout, "wait-timeout", 5*time.Minute, "how long to wait for the environment to become ready")
+
+	upCmd.Flags().AddFlagSet(upFlags)
+	upCmd.Flags().AddFlagSet(containerFlags)
+	upCmd.Flags().AddFlagSet(networkFlags)
+}
@@ -0,0 +1,15 @@
+package evm
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "evm",
+	Short: "EVM ParaTime operations",
+}
+
+func init() {
+	Cmd.AddCommand(rpcCmd)
+	Cmd.AddCommand(tokenCmd)
+}
@@ -0,0 +1,92 @@
+package evm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/oasisprotocol/cli/cmd/common"
+	cliConfig "github.com/oasisprotocol/cli/config"
+)
+
+// rpcBroadcastMethods are the Ethereum JSON-RPC methods which submit transactions.
+var rpcBroadcastMethods = map[string]struct{}{
+	"eth_sendRawTransaction": {},
+	"eth_sendTransaction":    {},
+}
+
+var (
+	rpcWeb3Gateway string
+
+	rpcCmd = &cobra.Command{
+		Use:   "rpc <method> [<param>...]",
+		Short: "Call the Ethereum JSON-RPC method of the ParaTime's Web3 gateway",
+		Long: `Call the given Ethereum JSON-RPC method (e.g. eth_blockNumber or eth_getBalance) of the Web3
+gateway associated with the selected ParaTime and print the result.
+
+Parameters which are valid JSON (numbers, booleans, objects, arrays or quoted strings) are passed
+as is, any other parameter is passed as a string.`,
+		Args: cobra.MinimumNArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+			npa := common.GetNPASelection(cfg)
+			method := args[0]
+
+			if _, ok := rpcBroadcastMethods[method]; ok {
+				cobra.CheckErr(common.CheckReadOnly())
+			}
+
+			url := rpcWeb3Gateway
+			if url == "" {
+				if npa.ParaTime == nil {
+					cobra.CheckErr("no ParaTime selected")
+				}
+				url = cfg.Web3Gateways.Lookup(npa.NetworkName, npa.Network, npa.ParaTimeName)
+			}
+			if url == "" {
+				cobra.CheckErr(fmt.Errorf("no Web3 gateway associated with ParaTime '%s', use --web3-gateway or 'oasis paratime set-web3-gateway'", npa.ParaTimeName))
+			}
+
+			ctx := context.Background()
+			c, err := rpc.DialContext(ctx, url)
+			if err != nil {
+				cobra.CheckErr(fmt.Errorf("failed to connect to Web3 gateway: %w", err))
+			}
+			defer c.Close()
+
+			var result json.RawMessage
+			err = c.CallContext(ctx, &result, method, parseRPCParams(args[1:])...)
+			cobra.CheckErr(err)
+
+			data, err := common.JSONMarshalOutput(result)
+			cobra.CheckErr(err)
+			fmt.Printf("%s\n", data)
+		},
+	}
+)
+
+// parseRPCParams converts the command line arguments into JSON-RPC parameters. Arguments which
+// are valid JSON are passed as is, the rest are passed as strings.
+func parseRPCParams(args []string) []interface{} {
+	params := make([]interface{}, 0, len(args))
+	for _, arg := range args {
+		if json.Valid([]byte(arg)) {
+			params = append(params, json.RawMessage(arg))
+			continue
+		}
+		params = append(params, arg)
+	}
+	return params
+}
+
+func init() {
+	f := flag.NewFlagSet("", flag.ContinueOnError)
+	f.StringVar(&rpcWeb3Gateway, "web3-gateway", "", "Web3 gateway URL overriding the one associated with the ParaTime")
+	rpcCmd.Flags().AddFlagSet(common.SelectorNPFlags)
+	rpcCmd.Flags().AddFlagSet(common.FormatFlag)
+	rpcCmd.Flags().AddFlagSet(f)
+}
@@ -0,0 +1,17 @@
+package evm
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRPCParams(t *testing.T) {
+	require := require.New(t)
+
+	params := parseRPCParams([]string{"0x90adE3B7065fa715c7a150313877dF1d33e777D5", "latest", "1", "true", `{"to":"0x00"}`, `"quoted"`})
+	data, err := json.Marshal(params)
+	require.NoError(err)
+	require.Equal(`["0x90adE3B7065fa715c7a150313877dF1d33e777D5","latest",1,true,{"to":"0x00"},"quoted"]`, string(data))
+}
@@ -0,0 +1,336 @@
+package evm
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/evm"
+
+	"github.com/oasisprotocol/cli/cmd/common"
+	cliConfig "github.com/oasisprotocol/cli/config"
+)
+
+// tokenABIJSON is the subset of the ERC-20, ERC-721 and ERC-165 interfaces used by the token
+// commands.
+const tokenABIJSON = `[
+	{"type":"function","name":"name","stateMutability":"view","inputs":[],"outputs":[{"type":"string"}]},
+	{"type":"function","name":"symbol","stateMutability":"view","inputs":[],"outputs":[{"type":"string"}]},
+	{"type":"function","name":"decimals","stateMutability":"view","inputs":[],"outputs":[{"type":"uint8"}]},
+	{"type":"function","name":"totalSupply","stateMutability":"view","inputs":[],"outputs":[{"type":"uint256"}]},
+	{"type":"function","name":"balanceOf","stateMutability":"view","inputs":[{"type":"address"}],"outputs":[{"type":"uint256"}]},
+	{"type":"function","name":"ownerOf","stateMutability":"view","inputs":[{"type":"uint256"}],"outputs":[{"type":"address"}]},
+	{"type":"function","name":"tokenURI","stateMutability":"view","inputs":[{"type":"uint256"}],"outputs":[{"type":"string"}]},
+	{"type":"function","name":"supportsInterface","stateMutability":"view","inputs":[{"type":"bytes4"}],"outputs":[{"type":"bool"}]},
+	{"type":"function","name":"transfer","stateMutability":"nonpayable","inputs":[{"type":"address"},{"type":"uint256"}],"outputs":[{"type":"bool"}]},
+	{"type":"function","name":"transferFrom","stateMutability":"nonpayable","inputs":[{"type":"address"},{"type":"address"},{"type":"uint256"}],"outputs":[]}
+]`
+
+// erc721InterfaceID is the ERC-165 interface identifier of ERC-721.
+var erc721InterfaceID = [4]byte{0x80, 0xac, 0x58, 0xcd}
+
+// simulateCallGasLimit is the gas limit used for read-only calls.
+const simulateCallGasLimit = 10_000_000
+
+var tokenABI = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(tokenABIJSON))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+var (
+	tokenHolder string
+	tokenID     string
+
+	tokenCmd = &cobra.Command{
+		Use:   "token",
+		Short: "ERC-20 and ERC-721 token operations",
+	}
+
+	tokenShowCmd = &cobra.Command{
+		Use:   "show <contract> [--holder <address>] [--token-id <id>]",
+		Short: "Show token information",
+		Args:  cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+			npa := common.GetNPASelection(cfg)
+
+			if npa.ParaTime == nil {
+				cobra.CheckErr("no ParaTime selected")
+			}
+
+			contract := resolveEthAddress(npa, args[0])
+
+			ctx := context.Background()
+			conn, err := common.Connect(ctx, npa.Network)
+			cobra.CheckErr(err)
+
+			token := &tokenContract{
+				evm:     conn.Runtime(npa.ParaTime).Evm,
+				address: *contract,
+			}
+			isNFT := token.isERC721(ctx)
+
+			fmt.Printf("Contract:     %s\n", contract.Hex())
+			switch isNFT {
+			case true:
+				fmt.Printf("Standard:     ERC-721\n")
+			case false:
+				fmt.Printf("Standard:     ERC-20\n")
+			}
+			fmt.Printf("Name:         %s\n", token.optionalString(ctx, "name"))
+			symbol := token.optionalString(ctx, "symbol")
+			fmt.Printf("Symbol:       %s\n", symbol)
+
+			var decimals uint8
+			if !isNFT {
+				decimals, err = token.decimals(ctx)
+				cobra.CheckErr(err)
+				fmt.Printf("Decimals:     %d\n", decimals)
+			}
+
+			if supply, err := token.callBigInt(ctx, "totalSupply"); err == nil {
+				fmt.Printf("Total supply: %s\n", formatTokenAmount(supply, decimals, symbol))
+			}
+
+			if tokenID != "" {
+				if !isNFT {
+					cobra.CheckErr("--token-id is only supported for ERC-721 tokens")
+				}
+				id, ok := new(big.Int).SetString(tokenID, 10)
+				if !ok {
+					cobra.CheckErr(fmt.Errorf("malformed token ID: %s", tokenID))
+				}
+				owner, err := token.ownerOf(ctx, id)
+				cobra.CheckErr(err)
+				fmt.Printf("Token ID:     %s\n", id)
+				fmt.Printf("Owner:        %s\n", owner.Hex())
+				fmt.Printf("Token URI:    %s\n", token.optionalString(ctx, "tokenURI", id))
+			}
+
+			if tokenHolder != "" {
+				holder := resolveEthAddress(npa, tokenHolder)
+				balance, err := token.callBigInt(ctx, "balanceOf", *holder)
+				cobra.CheckErr(err)
+				fmt.Printf("Balance of %s: %s\n", holder.Hex(), formatTokenAmount(balance, decimals, symbol))
+			}
+		},
+	}
+
+	tokenTransferCmd = &cobra.Command{
+		Use:   "transfer <contract> <amount>|<token-id> <to>",
+		Short: "Transfer ERC-20 tokens or an ERC-721 token",
+		Args:  cobra.ExactArgs(3),
+		Run: func(_ *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+			npa := common.GetNPASelection(cfg)
+			txCfg := common.GetTransactionConfig()
+
+			if npa.Account == nil {
+				cobra.CheckErr("no accounts configured in your wallet")
+			}
+			if npa.ParaTime == nil {
+				cobra.CheckErr("no ParaTime selected")
+			}
+			if txCfg.Offline {
+				cobra.CheckErr("token transfers are not supported in offline mode")
+			}
+
+			contract := resolveEthAddress(npa, args[0])
+			to := resolveEthAddress(npa, args[2])
+
+			ctx := context.Background()
+			conn, err := common.Connect(ctx, npa.Network)
+			cobra.CheckErr(err)
+
+			token := &tokenContract{
+				evm:     conn.Runtime(npa.ParaTime).Evm,
+				address: *contract,
+			}
+			symbol := token.optionalString(ctx, "symbol")
+
+			var data []byte
+			switch token.isERC721(ctx) {
+			case true:
+				id, ok := new(big.Int).SetString(args[1], 10)
+				if !ok {
+					cobra.CheckErr(fmt.Errorf("malformed token ID: %s", args[1]))
+				}
+				owner, err := token.ownerOf(ctx, id)
+				cobra.CheckErr(err)
+
+				fmt.Printf("Transferring %s token #%s from %s to %s.\n", symbol, id, owner.Hex(), to.Hex())
+				data, err = tokenABI.Pack("transferFrom", owner, *to, id)
+				cobra.CheckErr(err)
+			case false:
+				decimals, err := token.decimals(ctx)
+				cobra.CheckErr(err)
+				amount, err := parseTokenAmount(args[1], decimals)
+				cobra.CheckErr(err)
+
+				fmt.Printf("Transferring %s to %s.\n", formatTokenAmount(amount, decimals, symbol), to.Hex())
+				data, err = tokenABI.Pack("transfer", *to, amount)
+				cobra.CheckErr(err)
+			}
+
+			// Prepare transaction.
+			tx := evm.NewCallTx(nil, &evm.Call{
+				Address: contract.Bytes(),
+				Value:   make([]byte, 32),
+				Data:    data,
+			})
+
+			acc := common.LoadAccount(cfg, npa.AccountName)
+			sigTx, meta, err := common.SignParaTimeTransaction(ctx, npa, acc, conn, tx, nil)
+			cobra.CheckErr(err)
+
+			common.BroadcastOrExportTransaction(ctx, npa.ParaTime, conn, sigTx, meta, nil)
+		},
+	}
+)
+
+// tokenContract performs read-only queries against a token contract.
+type tokenContract struct {
+	evm     evm.V1
+	address ethCommon.Address
+}
+
+// call simulates a call of the given contract method and returns the unpacked results.
+func (t *tokenContract) call(ctx context.Context, method string, args ...interface{}) ([]interface{}, error) {
+	data, err := tokenABI.Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+	var caller ethCommon.Address
+	res, err := t.evm.SimulateCall(ctx, client.RoundLatest, nil, simulateCallGasLimit, caller.Bytes(), t.address.Bytes(), nil, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s: %w", method, err)
+	}
+	out, err := tokenABI.Unpack(method, res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s result: %w", method, err)
+	}
+	if len(out) != 1 {
+		return nil, fmt.Errorf("unexpected number of %s results: %d", method, len(out))
+	}
+	return out, nil
+}
+
+// callBigInt calls the given method returning a single uint256.
+func (t *tokenContract) callBigInt(ctx context.Context, method string, args ...interface{}) (*big.Int, error) {
+	out, err := t.call(ctx, method, args...)
+	if err != nil {
+		return nil, err
+	}
+	return abi.ConvertType(out[0], new(big.Int)).(*big.Int), nil
+}
+
+// optionalString calls the given method returning a single string. Since such methods are
+// optional in the token standards, "<unknown>" is returned on failure.
+func (t *tokenContract) optionalString(ctx context.Context, method string, args ...interface{}) string {
+	out, err := t.call(ctx, method, args...)
+	if err != nil {
+		return "<unknown>"
+	}
+	s, _ := out[0].(string)
+	return s
+}
+
+// decimals returns the number of decimals of an ERC-20 token.
+func (t *tokenContract) decimals(ctx context.Context) (uint8, error) {
+	out, err := t.call(ctx, "decimals")
+	if err != nil {
+		return 0, err
+	}
+	return *abi.ConvertType(out[0], new(uint8)).(*uint8), nil
+}
+
+// ownerOf returns the owner of the given ERC-721 token.
+func (t *tokenContract) ownerOf(ctx context.Context, id *big.Int) (ethCommon.Address, error) {
+	out, err := t.call(ctx, "ownerOf", id)
+	if err != nil {
+		return ethCommon.Address{}, err
+	}
+	return *abi.ConvertType(out[0], new(ethCommon.Address)).(*ethCommon.Address), nil
+}
+
+// isERC721 returns true iff the contract advertises ERC-721 support via ERC-165.
+func (t *tokenContract) isERC721(ctx context.Context) bool {
+	out, err := t.call(ctx, "supportsInterface", erc721InterfaceID)
+	if err != nil {
+		return false
+	}
+	supported, _ := out[0].(bool)
+	return supported
+}
+
+// resolveEthAddress resolves the given account name, address book entry or address into an
+// Ethereum address.
+func resolveEthAddress(npa *common.NPASelection, address string) *ethCommon.Address {
+	_, ethAddr, err := common.ResolveLocalAccountOrAddress(npa.Network, address)
+	cobra.CheckErr(err)
+	if ethAddr == nil {
+		cobra.CheckErr(fmt.Errorf("address '%s' is not an Ethereum address", address))
+	}
+	return ethAddr
+}
+
+// formatTokenAmount formats the given base unit amount using the given number of decimals.
+func formatTokenAmount(amount *big.Int, decimals uint8, symbol string) string {
+	s := amount.String()
+	if decimals > 0 {
+		if len(s) <= int(decimals) {
+			s = strings.Repeat("0", int(decimals)-len(s)+1) + s
+		}
+		integer, fraction := s[:len(s)-int(decimals)], strings.TrimRight(s[len(s)-int(decimals):], "0")
+		s = integer
+		if fraction != "" {
+			s += "." + fraction
+		}
+	}
+	if symbol == "" {
+		return s
+	}
+	return s + " " + symbol
+}
+
+// parseTokenAmount parses the given decimal amount into base units.
+func parseTokenAmount(amount string, decimals uint8) (*big.Int, error) {
+	integer, fraction, _ := strings.Cut(amount, ".")
+	if integer == "" && fraction == "" {
+		return nil, fmt.Errorf("malformed amount: %s", amount)
+	}
+	if len(fraction) > int(decimals) {
+		return nil, fmt.Errorf("amount '%s' has more than %d decimals", amount, decimals)
+	}
+	fraction += strings.Repeat("0", int(decimals)-len(fraction))
+
+	v, ok := new(big.Int).SetString(integer+fraction, 10)
+	if !ok || v.Sign() < 0 {
+		return nil, fmt.Errorf("malformed amount: %s", amount)
+	}
+	return v, nil
+}
+
+func init() {
+	showFlags := flag.NewFlagSet("", flag.ContinueOnError)
+	showFlags.StringVar(&tokenHolder, "holder", "", "also show the balance of the given address")
+	showFlags.StringVar(&tokenID, "token-id", "", "also show the owner and URI of the given ERC-721 token")
+	tokenShowCmd.Flags().AddFlagSet(common.SelectorNPFlags)
+	tokenShowCmd.Flags().AddFlagSet(showFlags)
+
+	tokenTransferCmd.Flags().AddFlagSet(common.SelectorFlags)
+	tokenTransferCmd.Flags().AddFlagSet(common.RuntimeTxFlags)
+
+	tokenCmd.AddCommand(tokenShowCmd)
+	tokenCmd.AddCommand(tokenTransferCmd)
+}
@@ -0,0 +1,37 @@
+package evm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenAmount(t *testing.T) {
+	require := require.New(t)
+
+	for _, tc := range []struct {
+		amount    string
+		decimals  uint8
+		baseUnits string
+		formatted string
+	}{
+		{"1", 0, "1", "1"},
+		{"1", 18, "1000000000000000000", "1"},
+		{"1.5", 6, "1500000", "1.5"},
+		{"0.000001", 6, "1", "0.000001"},
+		{"123.456", 3, "123456", "123.456"},
+	} {
+		v, err := parseTokenAmount(tc.amount, tc.decimals)
+		require.NoError(err, tc.amount)
+		require.Equal(tc.baseUnits, v.String(), tc.amount)
+		require.Equal(tc.formatted+" TOK", formatTokenAmount(v, tc.decimals, "TOK"), tc.amount)
+	}
+
+	require.Equal("0", formatTokenAmount(big.NewInt(0), 18, ""))
+
+	for _, amount := range []string{"1.0000001", "-1", "abc", "1.2.3", ""} {
+		_, err := parseTokenAmount(amount, 6)
+		require.Error(err, amount)
+	}
+}
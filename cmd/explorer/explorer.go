@@ -0,0 +1,14 @@
+package explorer
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "explorer",
+	Short: "Block explorer utilities",
+}
+
+func init() {
+	Cmd.AddCommand(urlCmd)
+}
@@ -0,0 +1,90 @@
+package explorer
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/oasisprotocol/cli/cmd/common"
+	cliConfig "github.com/oasisprotocol/cli/config"
+)
+
+// txHashRegexp matches Oasis and Ethereum transaction hashes.
+var txHashRegexp = regexp.MustCompile(`^(0x)?[0-9a-fA-F]{64}$`)
+
+var (
+	openURL bool
+
+	urlCmd = &cobra.Command{
+		Use:   "url <tx-hash> | <address> | <round>",
+		Short: "Show the block explorer URL of a transaction, account or block",
+		Long: `Show the block explorer URL of a transaction, account or block on the selected
+network and ParaTime. Pass --no-paratime for the consensus layer.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+			npa := common.GetNPASelection(cfg)
+			id := args[0]
+
+			var kind common.ExplorerKind
+			switch {
+			case txHashRegexp.MatchString(id):
+				kind = common.ExplorerTx
+			case isNumber(id):
+				kind = common.ExplorerBlock
+			default:
+				kind = common.ExplorerAddress
+				addr, ethAddr, err := common.ResolveLocalAccountOrAddress(npa.Network, id)
+				cobra.CheckErr(err)
+				id = addr.String()
+				if ethAddr != nil && npa.ParaTime != nil {
+					id = ethAddr.Hex()
+				}
+			}
+
+			url, err := common.ExplorerURL(npa.NetworkName, npa.Network, npa.ParaTimeName, kind, id)
+			cobra.CheckErr(err)
+			fmt.Println(url)
+
+			if openURL {
+				cobra.CheckErr(openBrowser(url))
+			}
+		},
+	}
+)
+
+// isNumber returns true if the given string is a block number.
+func isNumber(s string) bool {
+	_, err := strconv.ParseUint(s, 10, 64)
+	return err == nil
+}
+
+// openBrowser opens the given URL in the default web browser.
+func openBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to open browser: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	urlFlags := flag.NewFlagSet("", flag.ContinueOnError)
+	urlFlags.BoolVar(&openURL, "open", false, "open the URL in the default web browser")
+
+	urlCmd.Flags().AddFlagSet(common.SelectorNPFlags)
+	urlCmd.Flags().AddFlagSet(urlFlags)
+}
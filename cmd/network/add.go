@@ -8,8 +8,8 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
-	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/connection"
 
+	"github.com/oasisprotocol/cli/cmd/common"
 	cliConfig "github.com/oasisprotocol/cli/config"
 )
 
@@ -51,7 +51,7 @@ var addCmd = &cobra.Command{
 				RPC: net.RPC,
 			}
 			ctx := context.Background()
-			conn, err := connection.ConnectNoVerify(ctx, &network)
+			conn, err := common.ConnectNoVerify(ctx, &network)
 			cobra.CheckErr(err)
 			chainCtx, err := conn.Consensus().GetChainContext(ctx)
 			cobra.CheckErr(err)
@@ -13,7 +13,6 @@ import (
 
 	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
-	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/connection"
 
 	"github.com/oasisprotocol/cli/cmd/common"
 	cliConfig "github.com/oasisprotocol/cli/config"
@@ -80,7 +79,7 @@ func AddLocalNetwork(name string, rpc string) {
 
 	// Connect to the network and query the chain context.
 	ctx := context.Background()
-	conn, err := connection.ConnectNoVerify(ctx, &net)
+	conn, err := common.ConnectNoVerify(ctx, &net)
 	cobra.CheckErr(err)
 
 	chainContext, err := conn.Consensus().GetChainContext(ctx)
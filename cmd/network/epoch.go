@@ -0,0 +1,266 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+
+	"github.com/oasisprotocol/cli/cmd/common"
+	cliConfig "github.com/oasisprotocol/cli/config"
+)
+
+// epochState is the state of the current epoch used for epoch and height conversions.
+type epochState struct {
+	// Height is the latest block height.
+	Height int64 `json:"height"`
+	// Time is the time of the latest block.
+	Time time.Time `json:"time"`
+	// Epoch is the current epoch.
+	Epoch beacon.EpochTime `json:"epoch"`
+	// Interval is the number of blocks in an epoch.
+	Interval int64 `json:"interval"`
+	// StartHeight is the height at which the current epoch started.
+	StartHeight int64 `json:"start_height"`
+	// EndHeight is the last height of the current epoch.
+	EndHeight int64 `json:"end_height"`
+	// ExpectedEndTime is the estimated time at which the current epoch ends.
+	ExpectedEndTime *time.Time `json:"expected_end_time,omitempty"`
+
+	blockTime time.Duration
+}
+
+// estimateEpochStart returns the estimated start height of the given future epoch.
+func (s *epochState) estimateEpochStart(epoch beacon.EpochTime) int64 {
+	return s.StartHeight + int64(epoch-s.Epoch)*s.Interval
+}
+
+// estimateEpochAt returns the estimated epoch of the given future height.
+func (s *epochState) estimateEpochAt(height int64) beacon.EpochTime {
+	return s.Epoch + beacon.EpochTime((height-s.StartHeight)/s.Interval)
+}
+
+// estimateHeightTime returns the estimated time of the given future height or nil if the block
+// time is not known.
+func (s *epochState) estimateHeightTime(height int64) *time.Time {
+	if s.blockTime <= 0 {
+		return nil
+	}
+	t := s.Time.Add(time.Duration(height-s.Height) * s.blockTime)
+	return &t
+}
+
+// fetchEpochState queries the state of the current epoch.
+func fetchEpochState(ctx context.Context, consensusConn consensus.ClientBackend) (*epochState, error) {
+	latest, err := consensusConn.GetBlock(ctx, consensus.HeightLatest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query latest block: %w", err)
+	}
+	epoch, err := consensusConn.Beacon().GetEpoch(ctx, latest.Height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query current epoch: %w", err)
+	}
+	startHeight, err := consensusConn.Beacon().GetEpochBlock(ctx, epoch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query epoch start height: %w", err)
+	}
+	params, err := consensusConn.Beacon().ConsensusParameters(ctx, latest.Height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query beacon parameters: %w", err)
+	}
+	if params.Interval() <= 0 {
+		return nil, fmt.Errorf("unsupported epoch interval: %d", params.Interval())
+	}
+
+	s := &epochState{
+		Height:      latest.Height,
+		Time:        latest.Time,
+		Epoch:       epoch,
+		Interval:    params.Interval(),
+		StartHeight: startHeight,
+		EndHeight:   startHeight + params.Interval() - 1,
+	}
+	// The block time is only needed for estimates, so failing to compute it is not fatal.
+	if s.blockTime, err = common.AverageBlockTime(ctx, consensusConn, latest); err == nil {
+		s.ExpectedEndTime = s.estimateHeightTime(s.EndHeight + 1)
+	}
+	return s, nil
+}
+
+// formatEstimate formats the given estimated time together with the duration until then.
+func formatEstimate(t *time.Time, now time.Time) string {
+	if t == nil {
+		return "unknown"
+	}
+	return fmt.Sprintf("%s (in ~%s)", t.Local().Format(time.DateTime), t.Sub(now).Round(time.Minute))
+}
+
+var (
+	epochCmd = &cobra.Command{
+		Use:   "epoch",
+		Short: "Show the current epoch and convert between epochs and heights",
+		Args:  cobra.NoArgs,
+		Run: func(_ *cobra.Command, _ []string) {
+			showCurrentEpoch()
+		},
+	}
+
+	epochShowCmd = &cobra.Command{
+		Use:   "show",
+		Short: "Show the current epoch, its start height and expected end time",
+		Args:  cobra.NoArgs,
+		Run: func(_ *cobra.Command, _ []string) {
+			showCurrentEpoch()
+		},
+	}
+
+	epochAtHeightCmd = &cobra.Command{
+		Use:   "at-height <height>",
+		Short: "Show the epoch of the given height",
+		Args:  cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			height, err := strconv.ParseInt(args[0], 10, 64)
+			cobra.CheckErr(err)
+			if height < 1 {
+				cobra.CheckErr("height must be positive")
+			}
+
+			ctx, consensusConn := connectConsensus()
+			s, err := fetchEpochState(ctx, consensusConn)
+			cobra.CheckErr(err)
+
+			var (
+				epoch     beacon.EpochTime
+				estimated bool
+			)
+			switch {
+			case height > s.Height:
+				epoch = s.estimateEpochAt(height)
+				estimated = true
+			default:
+				epoch, err = consensusConn.Beacon().GetEpoch(ctx, height)
+				cobra.CheckErr(err)
+			}
+
+			if common.IsJSONOutput() {
+				data, err := common.JSONMarshalOutput(map[string]interface{}{
+					"height":    height,
+					"epoch":     epoch,
+					"estimated": estimated,
+				})
+				cobra.CheckErr(err)
+				fmt.Printf("%s\n", data)
+				return
+			}
+
+			fmt.Printf("Height: %d\n", height)
+			if estimated {
+				fmt.Printf("Epoch:  %d (estimated, height is in %d blocks)\n", epoch, height-s.Height)
+				fmt.Printf("Time:   %s\n", formatEstimate(s.estimateHeightTime(height), s.Time))
+				return
+			}
+			fmt.Printf("Epoch:  %d\n", epoch)
+		},
+	}
+
+	epochToHeightCmd = &cobra.Command{
+		Use:   "to-height <epoch>",
+		Short: "Show the height at which the given epoch starts",
+		Args:  cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			rawEpoch, err := strconv.ParseUint(args[0], 10, 64)
+			cobra.CheckErr(err)
+			epoch := beacon.EpochTime(rawEpoch)
+
+			ctx, consensusConn := connectConsensus()
+			s, err := fetchEpochState(ctx, consensusConn)
+			cobra.CheckErr(err)
+
+			var (
+				height    int64
+				startTime *time.Time
+				estimated bool
+			)
+			switch {
+			case epoch > s.Epoch:
+				height = s.estimateEpochStart(epoch)
+				startTime = s.estimateHeightTime(height)
+				estimated = true
+			default:
+				height, err = consensusConn.Beacon().GetEpochBlock(ctx, epoch)
+				cobra.CheckErr(err)
+				blk, err := consensusConn.GetBlock(ctx, height)
+				cobra.CheckErr(err)
+				startTime = &blk.Time
+			}
+
+			if common.IsJSONOutput() {
+				data, err := common.JSONMarshalOutput(map[string]interface{}{
+					"epoch":        epoch,
+					"start_height": height,
+					"start_time":   startTime,
+					"estimated":    estimated,
+				})
+				cobra.CheckErr(err)
+				fmt.Printf("%s\n", data)
+				return
+			}
+
+			fmt.Printf("Epoch:        %d\n", epoch)
+			if estimated {
+				fmt.Printf("Start height: %d (estimated, in %d blocks)\n", height, height-s.Height)
+				fmt.Printf("Start time:   %s\n", formatEstimate(startTime, s.Time))
+				return
+			}
+			fmt.Printf("Start height: %d\n", height)
+			fmt.Printf("Start time:   %s\n", startTime.Local().Format(time.DateTime))
+		},
+	}
+)
+
+// connectConsensus connects to the consensus layer of the selected network.
+func connectConsensus() (context.Context, consensus.ClientBackend) {
+	cfg := cliConfig.Global()
+	npa := common.GetNPASelection(cfg)
+
+	ctx := context.Background()
+	conn, err := common.Connect(ctx, npa.Network)
+	cobra.CheckErr(err)
+	return ctx, conn.Consensus()
+}
+
+// showCurrentEpoch shows the current epoch, its start height and expected end time.
+func showCurrentEpoch() {
+	ctx, consensusConn := connectConsensus()
+	s, err := fetchEpochState(ctx, consensusConn)
+	cobra.CheckErr(err)
+
+	if common.IsJSONOutput() {
+		data, err := common.JSONMarshalOutput(s)
+		cobra.CheckErr(err)
+		fmt.Printf("%s\n", data)
+		return
+	}
+
+	fmt.Printf("Height:         %d\n", s.Height)
+	fmt.Printf("Epoch:          %d\n", s.Epoch)
+	fmt.Printf("Epoch interval: %d blocks\n", s.Interval)
+	fmt.Printf("Start height:   %d\n", s.StartHeight)
+	fmt.Printf("End height:     %d (next epoch in %d blocks)\n", s.EndHeight, s.EndHeight-s.Height+1)
+	fmt.Printf("Expected end:   %s\n", formatEstimate(s.ExpectedEndTime, s.Time))
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{epochCmd, epochShowCmd, epochAtHeightCmd, epochToHeightCmd} {
+		cmd.Flags().AddFlagSet(common.SelectorNFlags)
+		cmd.Flags().AddFlagSet(common.FormatFlag)
+	}
+	epochCmd.AddCommand(epochShowCmd)
+	epochCmd.AddCommand(epochAtHeightCmd)
+	epochCmd.AddCommand(epochToHeightCmd)
+}
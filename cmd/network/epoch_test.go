@@ -0,0 +1,40 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+)
+
+func TestEpochStateEstimates(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	s := &epochState{
+		Height:      1050,
+		Time:        now,
+		Epoch:       10,
+		Interval:    100,
+		StartHeight: 1000,
+		EndHeight:   1099,
+		blockTime:   6 * time.Second,
+	}
+
+	require.EqualValues(1000, s.estimateEpochStart(10))
+	require.EqualValues(1300, s.estimateEpochStart(13))
+
+	require.Equal(beacon.EpochTime(10), s.estimateEpochAt(1099))
+	require.Equal(beacon.EpochTime(11), s.estimateEpochAt(1100))
+	require.Equal(beacon.EpochTime(12), s.estimateEpochAt(1250))
+
+	end := s.estimateHeightTime(1100)
+	require.NotNil(end)
+	require.Equal(now.Add(5*time.Minute), *end)
+
+	s.blockTime = 0
+	require.Nil(s.estimateHeightTime(1100))
+	require.Equal("unknown", formatEstimate(nil, now))
+}
@@ -0,0 +1,149 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/helpers"
+
+	"github.com/oasisprotocol/cli/cmd/common"
+	cliConfig "github.com/oasisprotocol/cli/config"
+)
+
+const (
+	escrowKindAdd            = "add"
+	escrowKindDebondingStart = "debonding_start"
+	escrowKindReclaim        = "reclaim"
+	escrowKindTake           = "take"
+)
+
+// escrowWatchEvent is an escrow event affecting the watched entity.
+type escrowWatchEvent struct {
+	Height    int64              `json:"height"`
+	TxHash    string             `json:"tx_hash,omitempty"`
+	Kind      string             `json:"kind"`
+	Delegator *staking.Address   `json:"delegator,omitempty"`
+	Amount    quantity.Quantity  `json:"amount"`
+	Debonding *quantity.Quantity `json:"debonding_amount,omitempty"`
+}
+
+var (
+	escrowCmd = &cobra.Command{
+		Use:   "escrow",
+		Short: "Escrow operations",
+	}
+
+	escrowWatchCmd = &cobra.Command{
+		Use:   "watch <entity-address>",
+		Short: "Watch escrow events of an entity",
+		Long: `Stream the escrow events affecting the given entity as they happen: new
+delegations, started and completed undelegations and slashing. Slashing events
+are additionally reported on stderr.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+			npa := common.GetNPASelection(cfg)
+
+			entityAddr, err := parseEntityAddress(npa, args[0])
+			cobra.CheckErr(err)
+
+			ctx := context.Background()
+			conn, err := common.Connect(ctx, npa.Network)
+			cobra.CheckErr(err)
+
+			evCh, sub, err := conn.Consensus().Staking().WatchEvents(ctx)
+			cobra.CheckErr(err)
+			defer sub.Close()
+
+			if !common.IsJSONOutput() {
+				fmt.Printf("Watching escrow events of %s on %s...\n", entityAddr, npa.PrettyPrintNetwork())
+			}
+
+			for ev := range evCh {
+				escrowEv := entityEscrowEvent(ev, entityAddr)
+				if escrowEv == nil {
+					continue
+				}
+
+				if common.IsJSONOutput() {
+					data, err := common.JSONMarshalOutput(escrowEv)
+					cobra.CheckErr(err)
+					fmt.Printf("%s\n", data)
+				} else {
+					printEscrowWatchEvent(npa, escrowEv)
+				}
+				if escrowEv.Kind == escrowKindTake {
+					fmt.Fprintf(os.Stderr, "!!! SLASHING: %s of entity %s was slashed at height %d !!!\n",
+						helpers.FormatConsensusDenomination(npa.Network, escrowEv.Amount),
+						entityAddr,
+						escrowEv.Height,
+					)
+				}
+			}
+			cobra.CheckErr("event subscription closed")
+		},
+	}
+)
+
+// entityEscrowEvent returns the escrow event affecting the given entity or nil if the event is not
+// an escrow event of the entity.
+func entityEscrowEvent(ev *staking.Event, entityAddr staking.Address) *escrowWatchEvent {
+	if ev.Escrow == nil {
+		return nil
+	}
+	e := escrowWatchEvent{Height: ev.Height}
+	if !ev.TxHash.IsEmpty() {
+		e.TxHash = ev.TxHash.String()
+	}
+
+	switch {
+	case ev.Escrow.Add != nil && ev.Escrow.Add.Escrow.Equal(entityAddr):
+		e.Kind = escrowKindAdd
+		e.Delegator = &ev.Escrow.Add.Owner
+		e.Amount = ev.Escrow.Add.Amount
+	case ev.Escrow.DebondingStart != nil && ev.Escrow.DebondingStart.Escrow.Equal(entityAddr):
+		e.Kind = escrowKindDebondingStart
+		e.Delegator = &ev.Escrow.DebondingStart.Owner
+		e.Amount = ev.Escrow.DebondingStart.Amount
+	case ev.Escrow.Reclaim != nil && ev.Escrow.Reclaim.Escrow.Equal(entityAddr):
+		e.Kind = escrowKindReclaim
+		e.Delegator = &ev.Escrow.Reclaim.Owner
+		e.Amount = ev.Escrow.Reclaim.Amount
+	case ev.Escrow.Take != nil && ev.Escrow.Take.Owner.Equal(entityAddr):
+		e.Kind = escrowKindTake
+		e.Amount = ev.Escrow.Take.Amount
+		e.Debonding = &ev.Escrow.Take.DebondingAmount
+	default:
+		return nil
+	}
+	return &e
+}
+
+// printEscrowWatchEvent prints the given escrow event in human-readable form.
+func printEscrowWatchEvent(npa *common.NPASelection, e *escrowWatchEvent) {
+	amount := helpers.FormatConsensusDenomination(npa.Network, e.Amount)
+	switch e.Kind {
+	case escrowKindAdd:
+		fmt.Printf("[%d] Delegation:         %s from %s\n", e.Height, amount, e.Delegator)
+	case escrowKindDebondingStart:
+		fmt.Printf("[%d] Undelegation start: %s by %s\n", e.Height, amount, e.Delegator)
+	case escrowKindReclaim:
+		fmt.Printf("[%d] Undelegation end:   %s to %s\n", e.Height, amount, e.Delegator)
+	case escrowKindTake:
+		fmt.Printf("[%d] SLASHED:            %s (of which %s debonding)\n", e.Height, amount,
+			helpers.FormatConsensusDenomination(npa.Network, *e.Debonding),
+		)
+	}
+}
+
+func init() {
+	escrowWatchCmd.Flags().AddFlagSet(common.SelectorNFlags)
+	escrowWatchCmd.Flags().AddFlagSet(common.FormatFlag)
+
+	escrowCmd.AddCommand(escrowWatchCmd)
+}
@@ -0,0 +1,57 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+func TestEntityEscrowEvent(t *testing.T) {
+	require := require.New(t)
+
+	entity := staking.NewAddress(signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000001"))
+	other := staking.NewAddress(signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000002"))
+	delegator := staking.NewAddress(signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000003"))
+	amount := *quantity.NewFromUint64(1000)
+
+	// Non-escrow events are ignored.
+	require.Nil(entityEscrowEvent(&staking.Event{Transfer: &staking.TransferEvent{From: delegator, To: entity}}, entity))
+
+	// Delegations to other entities are ignored.
+	ev := &staking.Event{Height: 10, Escrow: &staking.EscrowEvent{
+		Add: &staking.AddEscrowEvent{Owner: delegator, Escrow: other, Amount: amount},
+	}}
+	require.Nil(entityEscrowEvent(ev, entity))
+
+	ev.Escrow.Add.Escrow = entity
+	e := entityEscrowEvent(ev, entity)
+	require.NotNil(e)
+	require.Equal(escrowKindAdd, e.Kind)
+	require.EqualValues(10, e.Height)
+	require.Equal(delegator, *e.Delegator)
+	require.Equal(amount, e.Amount)
+
+	ev = &staking.Event{Escrow: &staking.EscrowEvent{
+		Reclaim: &staking.ReclaimEscrowEvent{Owner: delegator, Escrow: entity, Amount: amount},
+	}}
+	e = entityEscrowEvent(ev, entity)
+	require.NotNil(e)
+	require.Equal(escrowKindReclaim, e.Kind)
+
+	// Slashing is reported for the escrow account itself.
+	ev = &staking.Event{Escrow: &staking.EscrowEvent{
+		Take: &staking.TakeEscrowEvent{Owner: entity, Amount: amount},
+	}}
+	e = entityEscrowEvent(ev, entity)
+	require.NotNil(e)
+	require.Equal(escrowKindTake, e.Kind)
+	require.Nil(e.Delegator)
+	require.NotNil(e.Debonding)
+
+	ev.Escrow.Take.Owner = other
+	require.Nil(entityEscrowEvent(ev, entity))
+}
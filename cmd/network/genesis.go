@@ -0,0 +1,246 @@
+package network
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	genesis "github.com/oasisprotocol/oasis-core/go/genesis/api"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/helpers"
+
+	"github.com/oasisprotocol/cli/cmd/common"
+	cliConfig "github.com/oasisprotocol/cli/config"
+)
+
+var (
+	genesisFile     string
+	genesisSections []string
+
+	genesisCmd = &cobra.Command{
+		Use:   "genesis",
+		Short: "Genesis document operations",
+	}
+
+	genesisShowCmd = &cobra.Command{
+		Use:   "show",
+		Short: "Show the genesis document of the network",
+		Long: `Show a summary of the genesis document of the selected network, including its
+chain context. The genesis document is obtained from the network's gRPC
+endpoint, unless --file is given. Use --section to print parts of the document,
+e.g. --section staking.params.`,
+		Args: cobra.NoArgs,
+		Run: func(_ *cobra.Command, _ []string) {
+			cfg := cliConfig.Global()
+			npa := common.GetNPASelection(cfg)
+
+			doc, err := loadGenesisDocument(context.Background(), npa, genesisFile)
+			cobra.CheckErr(err)
+
+			if len(genesisSections) > 0 {
+				for _, section := range genesisSections {
+					v, err := genesisSection(doc, section)
+					cobra.CheckErr(err)
+					data, err := common.JSONMarshalOutput(v)
+					cobra.CheckErr(err)
+					if !common.IsJSONOutput() {
+						fmt.Printf("=== %s ===\n", section)
+					}
+					fmt.Printf("%s\n", data)
+				}
+				return
+			}
+
+			summary := newGenesisSummary(doc, npa.Network.ChainContext)
+			if common.IsJSONOutput() {
+				data, err := common.JSONMarshalOutput(summary)
+				cobra.CheckErr(err)
+				fmt.Printf("%s\n", data)
+				return
+			}
+
+			fmt.Printf("Chain ID:      %s\n", summary.ChainID)
+			fmt.Printf("Height:        %d\n", summary.Height)
+			fmt.Printf("Genesis time:  %s\n", summary.Time.UTC().Format(time.RFC3339))
+			fmt.Printf("Chain context: %s", summary.ChainContext)
+			switch summary.Matches {
+			case true:
+				fmt.Printf(" (matches %s)\n", npa.PrettyPrintNetwork())
+			case false:
+				fmt.Printf(" (DOES NOT match %s)\n", npa.PrettyPrintNetwork())
+			}
+			fmt.Printf("Base epoch:    %d\n", summary.BaseEpoch)
+			fmt.Printf("Entities:      %d\n", summary.Entities)
+			fmt.Printf("Nodes:         %d\n", summary.Nodes)
+			fmt.Printf("Runtimes:      %d\n", summary.Runtimes)
+			fmt.Printf("Accounts:      %d\n", summary.Accounts)
+			fmt.Printf("Total supply:  %s\n", helpers.FormatConsensusDenomination(npa.Network, summary.TotalSupply))
+			fmt.Printf("Common pool:   %s\n", helpers.FormatConsensusDenomination(npa.Network, summary.CommonPool))
+		},
+	}
+
+	genesisVerifyCmd = &cobra.Command{
+		Use:   "verify",
+		Short: "Verify the genesis document matches the configured network",
+		Long: `Compute the chain context of the genesis document and compare it against the
+chain context configured for the selected network. The genesis document is
+obtained from the network's gRPC endpoint, unless --file is given. Documents
+loaded from a file are also sanity checked.`,
+		Args: cobra.NoArgs,
+		Run: func(_ *cobra.Command, _ []string) {
+			cfg := cliConfig.Global()
+			npa := common.GetNPASelection(cfg)
+
+			doc, err := loadGenesisDocument(context.Background(), npa, genesisFile)
+			cobra.CheckErr(err)
+			if genesisFile != "" {
+				if err = doc.SanityCheck(); err != nil {
+					cobra.CheckErr(fmt.Errorf("genesis document failed the sanity check: %w", err))
+				}
+			}
+
+			chainContext := doc.ChainContext()
+			fmt.Printf("Chain ID:               %s\n", doc.ChainID)
+			fmt.Printf("Computed chain context: %s\n", chainContext)
+			fmt.Printf("Expected chain context: %s\n", npa.Network.ChainContext)
+			if chainContext != npa.Network.ChainContext {
+				cobra.CheckErr(fmt.Errorf("genesis document does not belong to %s", npa.PrettyPrintNetwork()))
+			}
+			fmt.Printf("Genesis document matches %s.\n", npa.PrettyPrintNetwork())
+		},
+	}
+)
+
+// genesisSummary is a summary of a genesis document.
+type genesisSummary struct {
+	ChainID      string            `json:"chain_id"`
+	Height       int64             `json:"height"`
+	Time         time.Time         `json:"genesis_time"`
+	ChainContext string            `json:"chain_context"`
+	Matches      bool              `json:"matches_network"`
+	BaseEpoch    uint64            `json:"base_epoch"`
+	Entities     int               `json:"entities"`
+	Nodes        int               `json:"nodes"`
+	Runtimes     int               `json:"runtimes"`
+	Accounts     int               `json:"accounts"`
+	TotalSupply  quantity.Quantity `json:"total_supply"`
+	CommonPool   quantity.Quantity `json:"common_pool"`
+}
+
+// newGenesisSummary summarizes the given genesis document.
+func newGenesisSummary(doc *genesis.Document, chainContext string) *genesisSummary {
+	cc := doc.ChainContext()
+	return &genesisSummary{
+		ChainID:      doc.ChainID,
+		Height:       doc.Height,
+		Time:         doc.Time,
+		ChainContext: cc,
+		Matches:      cc == chainContext,
+		BaseEpoch:    uint64(doc.Beacon.Base),
+		Entities:     len(doc.Registry.Entities),
+		Nodes:        len(doc.Registry.Nodes),
+		Runtimes:     len(doc.Registry.Runtimes) + len(doc.Registry.SuspendedRuntimes),
+		Accounts:     len(doc.Staking.Ledger),
+		TotalSupply:  doc.Staking.TotalSupply,
+		CommonPool:   doc.Staking.CommonPool,
+	}
+}
+
+// loadGenesisDocument loads the genesis document from the given file or URL or, when empty, from
+// the gRPC endpoint of the selected network.
+func loadGenesisDocument(ctx context.Context, npa *common.NPASelection, fn string) (*genesis.Document, error) {
+	if fn == "" {
+		// Do not verify the chain context, so mismatching documents can be inspected.
+		conn, err := common.ConnectNoVerify(ctx, npa.Network)
+		if err != nil {
+			return nil, err
+		}
+		return conn.Consensus().GetGenesisDocument(ctx)
+	}
+
+	var raw []byte
+	var err error
+	switch {
+	case strings.HasPrefix(fn, "https://"), strings.HasPrefix(fn, "http://"):
+		raw, err = downloadGenesisDocument(ctx, fn)
+	default:
+		raw, err = os.ReadFile(fn)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var doc genesis.Document
+	if err = json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("malformed genesis document: %w", err)
+	}
+	return &doc, nil
+}
+
+// downloadGenesisDocument downloads the genesis document from the given URL.
+func downloadGenesisDocument(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	rsp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download genesis document: %w", err)
+	}
+	defer rsp.Body.Close()
+	if rsp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download genesis document: %s", rsp.Status)
+	}
+	return io.ReadAll(rsp.Body)
+}
+
+// genesisSection returns the section of the genesis document at the given dot-separated path of
+// JSON field names (e.g. "staking.params").
+func genesisSection(doc *genesis.Document, path string) (interface{}, error) {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	var v interface{}
+	if err = json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+
+	for _, key := range strings.Split(path, ".") {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("section '%s' not found", path)
+		}
+		if v, ok = m[key]; !ok {
+			return nil, fmt.Errorf("section '%s' not found", path)
+		}
+	}
+	return v, nil
+}
+
+func init() {
+	genesisFlags := flag.NewFlagSet("", flag.ContinueOnError)
+	genesisFlags.StringVar(&genesisFile, "file", "", "load the genesis document from the given file or URL")
+
+	genesisShowFlags := flag.NewFlagSet("", flag.ContinueOnError)
+	genesisShowFlags.StringSliceVar(&genesisSections, "section", nil, "print the given section of the genesis document (e.g. staking.params)")
+
+	genesisShowCmd.Flags().AddFlagSet(common.SelectorNFlags)
+	genesisShowCmd.Flags().AddFlagSet(common.FormatFlag)
+	genesisShowCmd.Flags().AddFlagSet(genesisFlags)
+	genesisShowCmd.Flags().AddFlagSet(genesisShowFlags)
+
+	genesisVerifyCmd.Flags().AddFlagSet(common.SelectorNFlags)
+	genesisVerifyCmd.Flags().AddFlagSet(genesisFlags)
+
+	genesisCmd.AddCommand(genesisShowCmd)
+	genesisCmd.AddCommand(genesisVerifyCmd)
+}
@@ -0,0 +1,42 @@
+package network
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	genesis "github.com/oasisprotocol/oasis-core/go/genesis/api"
+)
+
+func TestGenesisDocument(t *testing.T) {
+	require := require.New(t)
+
+	doc := &genesis.Document{ChainID: "test-chain", Height: 42}
+	doc.Staking.TotalSupply = *quantity.NewFromUint64(1000)
+	doc.Staking.Parameters.DebondingInterval = 14
+
+	fn := filepath.Join(t.TempDir(), "genesis.json")
+	data, err := doc.CanonicalJSON()
+	require.NoError(err)
+	require.NoError(os.WriteFile(fn, data, 0o600))
+
+	loaded, err := loadGenesisDocument(context.Background(), nil, fn)
+	require.NoError(err)
+	require.Equal(doc.ChainContext(), loaded.ChainContext())
+
+	summary := newGenesisSummary(loaded, doc.ChainContext())
+	require.True(summary.Matches)
+	require.EqualValues(42, summary.Height)
+	require.Equal("1000", summary.TotalSupply.String())
+	require.False(newGenesisSummary(loaded, "other").Matches)
+
+	v, err := genesisSection(loaded, "staking.params.debonding_interval")
+	require.NoError(err)
+	require.EqualValues(14, v)
+	_, err = genesisSection(loaded, "staking.nonexistent")
+	require.Error(err)
+}
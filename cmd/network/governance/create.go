@@ -5,10 +5,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"os"
 	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
 
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
@@ -56,6 +57,9 @@ func parseConsensusParameterChange(module string, raw []byte) (cbor.RawMessage,
 }
 
 var (
+	changeParameters []string
+	changeValues     []string
+
 	govCreateProposalCmd = &cobra.Command{
 		Use:   "create-proposal",
 		Short: "Create a governance proposal",
@@ -80,12 +84,12 @@ var (
 			var conn connection.Connection
 			if !txCfg.Offline {
 				var err error
-				conn, err = connection.Connect(ctx, npa.Network)
+				conn, err = common.Connect(ctx, npa.Network)
 				cobra.CheckErr(err)
 			}
 
 			// Load upgrade descriptor.
-			rawDescriptor, err := os.ReadFile(filename)
+			rawDescriptor, err := common.ReadFile(filename)
 			cobra.CheckErr(err)
 
 			// Parse upgrade descriptor.
@@ -113,33 +117,60 @@ var (
 	}
 
 	govCreateProposalParameterChangeCmd = &cobra.Command{
-		Use:   "parameter-change <module> <changes.json>",
+		Use:   "parameter-change <module> [<changes.json>]",
 		Short: "Create a parameter change governance proposal",
-		Args:  cobra.ExactArgs(2),
+		Long: `Create a parameter change governance proposal for the given consensus module.
+
+The changes are either read from the given JSON file, built from pairs of
+--parameter and --value flags or, if neither is given, asked for interactively.
+Unless in offline mode, the changes are validated against the live consensus
+parameters before signing.`,
+		Args: cobra.RangeArgs(1, 2),
 		Run: func(_ *cobra.Command, args []string) {
 			cfg := cliConfig.Global()
 			npa := common.GetNPASelection(cfg)
 			txCfg := common.GetTransactionConfig()
 
 			module := args[0]
-			changesFile := args[1]
 
 			if npa.Account == nil {
 				cobra.CheckErr("no accounts configured in your wallet")
 			}
+			if _, err := parameterChangeTypes(module); err != nil {
+				names := []string{governance.ModuleName, keymanager.ModuleName, registry.ModuleName, roothash.ModuleName, scheduler.ModuleName, staking.ModuleName}
+				cobra.CheckErr(fmt.Errorf("%w (supported modules: %s)", err, strings.Join(names, ", ")))
+			}
 
 			// When not in offline mode, connect to the given network endpoint.
 			ctx := context.Background()
 			var conn connection.Connection
 			if !txCfg.Offline {
 				var err error
-				conn, err = connection.Connect(ctx, npa.Network)
+				conn, err = common.Connect(ctx, npa.Network)
 				cobra.CheckErr(err)
 			}
 
-			// Load and parse changes json.
-			rawChanges, err := os.ReadFile(changesFile)
-			cobra.CheckErr(err)
+			// Load or build changes json.
+			var (
+				rawChanges []byte
+				err        error
+			)
+			switch {
+			case len(args) > 1:
+				if len(changeParameters) > 0 {
+					cobra.CheckErr("--parameter cannot be combined with a changes file")
+				}
+				rawChanges, err = common.ReadFile(args[1])
+				cobra.CheckErr(err)
+			case len(changeParameters) > 0:
+				rawChanges, err = buildParameterChanges(module, changeParameters, changeValues)
+				cobra.CheckErr(err)
+			default:
+				params, values, err := askParameterChanges(module)
+				cobra.CheckErr(err)
+				rawChanges, err = buildParameterChanges(module, params, values)
+				cobra.CheckErr(err)
+			}
 
 			changes, err := parseConsensusParameterChange(module, rawChanges)
 			if err != nil {
@@ -154,6 +185,17 @@ var (
 				cobra.CheckErr(fmt.Errorf("invalid parameter upgrade proposal: %w", err))
 			}
 
+			// Validate the changes against the live consensus parameters.
+			var current, updated interface{}
+			if !txCfg.Offline {
+				current, updated, err = checkLiveParameterChanges(ctx, conn.Consensus(), module, changes)
+				if err != nil {
+					cobra.CheckErr(fmt.Errorf("invalid parameter upgrade proposal: %w", err))
+				}
+			}
+			printParameterChanges(rawChanges, current, updated)
+			fmt.Println()
+
 			// Prepare transaction.
 			tx := governance.NewSubmitProposalTx(0, nil, &governance.ProposalContent{
 				ChangeParameters: content,
@@ -186,7 +228,7 @@ var (
 			var conn connection.Connection
 			if !txCfg.Offline {
 				var err error
-				conn, err = connection.Connect(ctx, npa.Network)
+				conn, err = common.Connect(ctx, npa.Network)
 				cobra.CheckErr(err)
 			}
 
@@ -214,8 +256,12 @@ func init() {
 	govCreateProposalUpgradeCmd.Flags().AddFlagSet(common.SelectorNAFlags)
 	govCreateProposalUpgradeCmd.Flags().AddFlagSet(common.TxFlags)
 
+	parameterChangeFlags := flag.NewFlagSet("", flag.ContinueOnError)
+	parameterChangeFlags.StringArrayVar(&changeParameters, "parameter", nil, "name of the parameter to change (repeatable)")
+	parameterChangeFlags.StringArrayVar(&changeValues, "value", nil, "new value of the preceding parameter (repeatable)")
 	govCreateProposalParameterChangeCmd.Flags().AddFlagSet(common.SelectorNAFlags)
-	govCreateProposalUpgradeCmd.Flags().AddFlagSet(common.TxFlags)
+	govCreateProposalParameterChangeCmd.Flags().AddFlagSet(common.TxFlags)
+	govCreateProposalParameterChangeCmd.Flags().AddFlagSet(parameterChangeFlags)
 
 	govCreateProposalCancelUpgradeCmd.Flags().AddFlagSet(common.SelectorNAFlags)
 	govCreateProposalCancelUpgradeCmd.Flags().AddFlagSet(common.TxFlags)
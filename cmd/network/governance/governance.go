@@ -15,4 +15,5 @@ func init() {
 	Cmd.AddCommand(govCastVoteCmd)
 	Cmd.AddCommand(govShowCmd)
 	Cmd.AddCommand(govListCmd)
+	Cmd.AddCommand(govWatchCmd)
 }
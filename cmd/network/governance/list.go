@@ -6,8 +6,6 @@ import (
 
 	"github.com/spf13/cobra"
 
-	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/connection"
-
 	"github.com/oasisprotocol/cli/cmd/common"
 	cliConfig "github.com/oasisprotocol/cli/config"
 	"github.com/oasisprotocol/cli/table"
@@ -24,18 +22,23 @@ var govListCmd = &cobra.Command{
 
 		// When not in offline mode, connect to the given network endpoint.
 		ctx := context.Background()
-		conn, err := connection.Connect(ctx, npa.Network)
+		conn, err := common.Connect(ctx, npa.Network)
 		cobra.CheckErr(err)
 
-		table := table.New()
-		table.SetHeader([]string{"ID", "Kind", "Submitter", "Created At", "Closes At", "State"})
+		listing := table.NewListing(
+			table.Column{Name: "ID"},
+			table.Column{Name: "Kind"},
+			table.Column{Name: "Submitter"},
+			table.Column{Name: "Created At"},
+			table.Column{Name: "Closes At"},
+			table.Column{Name: "State"},
+		)
 
 		proposals, err := conn.Consensus().Governance().Proposals(ctx, common.GetHeight())
 		if err != nil {
 			cobra.CheckErr(fmt.Errorf("failed to fetch proposals: %w", err))
 		}
 
-		var output [][]string
 		for _, proposal := range proposals {
 			var kind string
 			switch {
@@ -49,22 +52,23 @@ var govListCmd = &cobra.Command{
 				kind = "unknown"
 			}
 
-			output = append(output, []string{
+			listing.Append(
 				fmt.Sprintf("%d", proposal.ID),
 				kind,
 				proposal.Submitter.String(),
 				fmt.Sprintf("%d", proposal.CreatedAt),
 				fmt.Sprintf("%d", proposal.ClosesAt),
 				proposal.State.String(),
-			})
+			)
 		}
 
-		table.AppendBulk(output)
-		table.Render()
+		err = listing.Render(common.GetListingOptions())
+		cobra.CheckErr(err)
 	},
 }
 
 func init() {
 	govListCmd.Flags().AddFlagSet(common.SelectorNFlags)
 	govListCmd.Flags().AddFlagSet(common.HeightFlag)
+	govListCmd.Flags().AddFlagSet(common.ListingFlags)
 }
@@ -0,0 +1,256 @@
+package governance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+	keymanager "github.com/oasisprotocol/oasis-core/go/keymanager/api"
+	keymanagerSecrets "github.com/oasisprotocol/oasis-core/go/keymanager/secrets"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+
+	"github.com/oasisprotocol/cli/cmd/common"
+	"github.com/oasisprotocol/cli/table"
+)
+
+// consensusParameters are the consensus parameters of a module.
+type consensusParameters[P any] interface {
+	*P
+	SanityCheck() error
+}
+
+// consensusParameterChanges are the consensus parameter changes of a module.
+type consensusParameterChanges[P any] interface {
+	Apply(*P) error
+	SanityCheck() error
+}
+
+// parameterChangeTypes returns an empty instance of the parameter changes structure of the given
+// module.
+func parameterChangeTypes(module string) (interface{}, error) {
+	switch module {
+	case governance.ModuleName:
+		return &governance.ConsensusParameterChanges{}, nil
+	case keymanager.ModuleName:
+		return &keymanagerSecrets.ConsensusParameterChanges{}, nil
+	case registry.ModuleName:
+		return &registry.ConsensusParameterChanges{}, nil
+	case roothash.ModuleName:
+		return &roothash.ConsensusParameterChanges{}, nil
+	case scheduler.ModuleName:
+		return &scheduler.ConsensusParameterChanges{}, nil
+	case staking.ModuleName:
+		return &staking.ConsensusParameterChanges{}, nil
+	default:
+		return nil, fmt.Errorf("unknown module: %s", module)
+	}
+}
+
+// parameterNames returns the sorted names of the parameters that can be changed in the given
+// module.
+func parameterNames(module string) ([]string, error) {
+	changes, err := parameterChangeTypes(module)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	t := reflect.TypeOf(changes).Elem()
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// buildParameterChanges builds the JSON-encoded parameter changes of the given module from the
+// given parameter names and values. Values are interpreted as JSON and fall back to plain strings
+// in case they are not valid JSON or do not match the parameter type (e.g. token amounts).
+func buildParameterChanges(module string, params, values []string) ([]byte, error) {
+	if len(params) != len(values) {
+		return nil, fmt.Errorf("each --parameter must be followed by exactly one --value")
+	}
+
+	changes := make(map[string]json.RawMessage)
+	for i, param := range params {
+		if _, exists := changes[param]; exists {
+			return nil, fmt.Errorf("parameter '%s' given more than once", param)
+		}
+
+		var candidates []json.RawMessage
+		if json.Valid([]byte(values[i])) {
+			candidates = append(candidates, json.RawMessage(values[i]))
+		}
+		quoted, _ := json.Marshal(values[i])
+		candidates = append(candidates, quoted)
+
+		var err error
+		for _, candidate := range candidates {
+			raw, _ := json.Marshal(map[string]json.RawMessage{param: candidate})
+			if _, err = parseConsensusParameterChange(module, raw); err == nil {
+				changes[param] = candidate
+				break
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parameter '%s': %w", param, err)
+		}
+	}
+	return json.Marshal(changes)
+}
+
+// askParameterChanges interactively asks the user for the parameter changes of the given module.
+func askParameterChanges(module string) (params, values []string, err error) {
+	common.CheckInteractive()
+
+	names, err := parameterNames(module)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for {
+		var param, value string
+		if err = survey.AskOne(&survey.Select{Message: "Parameter:", Options: names}, &param); err != nil {
+			return nil, nil, err
+		}
+		if err = survey.AskOne(&survey.Input{Message: "New value:"}, &value, survey.WithValidator(survey.Required)); err != nil {
+			return nil, nil, err
+		}
+		params = append(params, param)
+		values = append(values, value)
+
+		var more bool
+		if err = survey.AskOne(&survey.Confirm{Message: "Change another parameter?"}, &more); err != nil {
+			return nil, nil, err
+		}
+		if !more {
+			return params, values, nil
+		}
+	}
+}
+
+// applyParameterChanges applies the changes to a copy of the current parameters and checks that
+// both the changes and the resulting parameters are valid.
+func applyParameterChanges[P any, PP consensusParameters[P]](rawChanges cbor.RawMessage, changes consensusParameterChanges[P], current *P) (*P, error) {
+	if err := cbor.Unmarshal(rawChanges, changes); err != nil {
+		return nil, err
+	}
+	if err := changes.SanityCheck(); err != nil {
+		return nil, fmt.Errorf("invalid changes: %w", err)
+	}
+
+	// Deep copy the current parameters so that changes do not modify them.
+	var updated P
+	if err := cbor.Unmarshal(cbor.Marshal(current), &updated); err != nil {
+		return nil, err
+	}
+	if err := changes.Apply(&updated); err != nil {
+		return nil, fmt.Errorf("failed to apply changes: %w", err)
+	}
+	if err := PP(&updated).SanityCheck(); err != nil {
+		return nil, fmt.Errorf("resulting parameters are invalid: %w", err)
+	}
+	return &updated, nil
+}
+
+// checkLiveParameterChanges validates the parameter changes of the given module against the live
+// consensus parameters and returns the current and updated parameters.
+func checkLiveParameterChanges(ctx context.Context, consensusConn consensus.ClientBackend, module string, changes cbor.RawMessage) (current, updated interface{}, err error) {
+	height := consensus.HeightLatest
+	switch module {
+	case governance.ModuleName:
+		var params *governance.ConsensusParameters
+		if params, err = consensusConn.Governance().ConsensusParameters(ctx, height); err != nil {
+			return nil, nil, err
+		}
+		updated, err = applyParameterChanges(changes, &governance.ConsensusParameterChanges{}, params)
+		return params, updated, err
+	case registry.ModuleName:
+		var params *registry.ConsensusParameters
+		if params, err = consensusConn.Registry().ConsensusParameters(ctx, height); err != nil {
+			return nil, nil, err
+		}
+		updated, err = applyParameterChanges(changes, &registry.ConsensusParameterChanges{}, params)
+		return params, updated, err
+	case roothash.ModuleName:
+		var params *roothash.ConsensusParameters
+		if params, err = consensusConn.RootHash().ConsensusParameters(ctx, height); err != nil {
+			return nil, nil, err
+		}
+		updated, err = applyParameterChanges(changes, &roothash.ConsensusParameterChanges{}, params)
+		return params, updated, err
+	case scheduler.ModuleName:
+		var params *scheduler.ConsensusParameters
+		if params, err = consensusConn.Scheduler().ConsensusParameters(ctx, height); err != nil {
+			return nil, nil, err
+		}
+		updated, err = applyParameterChanges(changes, &scheduler.ConsensusParameterChanges{}, params)
+		return params, updated, err
+	case staking.ModuleName:
+		var params *staking.ConsensusParameters
+		if params, err = consensusConn.Staking().ConsensusParameters(ctx, height); err != nil {
+			return nil, nil, err
+		}
+		updated, err = applyParameterChanges(changes, &staking.ConsensusParameterChanges{}, params)
+		return params, updated, err
+	default:
+		// Module parameters cannot be queried, only basic validation is possible.
+		return nil, nil, nil
+	}
+}
+
+// printParameterChanges prints the current and new values of the changed parameters.
+func printParameterChanges(rawChanges []byte, current, updated interface{}) {
+	var changes map[string]json.RawMessage
+	_ = json.Unmarshal(rawChanges, &changes)
+
+	toMap := func(v interface{}) map[string]json.RawMessage {
+		m := make(map[string]json.RawMessage)
+		if v == nil {
+			return m
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			return m
+		}
+		_ = json.Unmarshal(data, &m)
+		return m
+	}
+	currentMap, updatedMap := toMap(current), toMap(updated)
+
+	names := make([]string, 0, len(changes))
+	for name := range changes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	t := table.New()
+	t.SetHeader([]string{"Parameter", "Current", "New"})
+	for _, name := range names {
+		cur, ok := currentMap[name]
+		if !ok {
+			cur = json.RawMessage("-")
+		}
+		upd, ok := updatedMap[name]
+		if !ok {
+			upd = changes[name]
+		}
+		t.Append([]string{name, string(cur), string(upd)})
+	}
+	t.Render()
+}
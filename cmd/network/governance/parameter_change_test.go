@@ -0,0 +1,73 @@
+package governance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+func TestBuildParameterChanges(t *testing.T) {
+	require := require.New(t)
+
+	names, err := parameterNames(staking.ModuleName)
+	require.NoError(err)
+	require.Contains(names, "debonding_interval")
+	require.Contains(names, "min_delegation")
+
+	raw, err := buildParameterChanges(staking.ModuleName,
+		[]string{"debonding_interval", "min_delegation", "disable_transfers"},
+		[]string{"336", "100000000000", "true"},
+	)
+	require.NoError(err)
+
+	rawCBOR, err := parseConsensusParameterChange(staking.ModuleName, raw)
+	require.NoError(err)
+	var changes staking.ConsensusParameterChanges
+	require.NoError(cbor.Unmarshal(rawCBOR, &changes))
+	require.EqualValues(336, *changes.DebondingInterval)
+	require.EqualValues(*quantity.NewFromUint64(100_000_000_000), *changes.MinDelegationAmount)
+	require.True(*changes.DisableTransfers)
+
+	_, err = buildParameterChanges(staking.ModuleName, []string{"no_such_parameter"}, []string{"1"})
+	require.Error(err)
+	_, err = buildParameterChanges(staking.ModuleName, []string{"debonding_interval"}, []string{"soon"})
+	require.Error(err)
+	_, err = buildParameterChanges(staking.ModuleName, []string{"debonding_interval"}, nil)
+	require.Error(err)
+	_, err = buildParameterChanges("no_such_module", []string{"debonding_interval"}, []string{"1"})
+	require.Error(err)
+}
+
+func TestApplyParameterChanges(t *testing.T) {
+	require := require.New(t)
+
+	current := &governance.ConsensusParameters{
+		StakeThreshold:            68,
+		VotingPeriod:              100,
+		UpgradeMinEpochDiff:       300,
+		UpgradeCancelMinEpochDiff: 300,
+	}
+	apply := func(param, value string) (*governance.ConsensusParameters, error) {
+		raw, err := buildParameterChanges(governance.ModuleName, []string{param}, []string{value})
+		require.NoError(err)
+		changes, err := parseConsensusParameterChange(governance.ModuleName, raw)
+		require.NoError(err)
+		return applyParameterChanges(changes, &governance.ConsensusParameterChanges{}, current)
+	}
+
+	updated, err := apply("voting_period", "200")
+	require.NoError(err)
+	require.EqualValues(200, updated.VotingPeriod)
+	require.EqualValues(100, current.VotingPeriod, "current parameters must not be modified")
+
+	// Resulting parameters must be sane.
+	_, err = apply("voting_period", "400")
+	require.Error(err)
+	_, err = apply("stake_threshold", "50")
+	require.Error(err)
+}
@@ -12,11 +12,9 @@ import (
 	flag "github.com/spf13/pflag"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
-	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
-	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/connection"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
 
 	"github.com/oasisprotocol/cli/cmd/common"
@@ -45,7 +43,9 @@ func subShares(validatorVoteShares map[governance.Vote]quantity.Quantity, vote g
 }
 
 var (
-	showVotes bool
+	showVotes       bool
+	showFast        bool
+	showConcurrency uint
 
 	govShowCmd = &cobra.Command{
 		Use:   "show <proposal-id>",
@@ -61,15 +61,12 @@ var (
 
 			// Establish connection with the target network.
 			ctx := context.Background()
-			conn, err := connection.Connect(ctx, npa.Network)
+			conn, err := common.Connect(ctx, npa.Network)
 			cobra.CheckErr(err)
 
 			consensusConn := conn.Consensus()
 			governanceConn := consensusConn.Governance()
 			beaconConn := consensusConn.Beacon()
-			schedulerConn := consensusConn.Scheduler()
-			registryConn := consensusConn.Registry()
-			stakingConn := consensusConn.Staking()
 
 			// Figure out the height to use if "latest".
 			height, err := common.GetActualHeight(
@@ -118,157 +115,30 @@ var (
 			votes, err := governanceConn.Votes(ctx, proposalQuery)
 			cobra.CheckErr(err)
 
-			// Retrieve all the node descriptors.
-			nodeLookup, err := common.NewNodeLookup(
-				ctx,
-				consensusConn,
-				registryConn,
-				height,
+			// Tally the votes. Tallies of closed proposals never change, so they are cached.
+			cacheable := proposal.State != governance.StateActive && hasCorrectVotingPower
+			chainContext := npa.Network.ChainContext
+			var (
+				tally  *voteTally
+				cached bool
 			)
-			cobra.CheckErr(err)
-
-			// Figure out the per-validator and total voting power.
-			//
-			// Note: This also initializes the non-voter list to the entire
-			// validator set, and each validator that voted will be removed
-			// as the actual votes are examined.
-
-			totalVotingStake := quantity.NewQuantity()
-			validatorVotes := make(map[staking.Address]*governance.Vote)
-			validatorVoteShares := make(map[staking.Address]map[governance.Vote]quantity.Quantity)
-			validatorEntitiesShares := make(map[staking.Address]*staking.SharePool)
-			validatorVoters := make(map[staking.Address]quantity.Quantity)
-			validatorNonVoters := make(map[staking.Address]quantity.Quantity)
-
-			validators, err := schedulerConn.GetValidators(ctx, height)
-			cobra.CheckErr(err)
-
-			for _, validator := range validators {
-				var node *node.Node
-				node, err = nodeLookup.ByID(ctx, validator.ID)
-				cobra.CheckErr(err)
-
-				// If there are multiple nodes in the validator set belonging
-				// to the same entity, only count the entity escrow once.
-				entityAddr := staking.NewAddress(node.EntityID)
-				if validatorEntitiesShares[entityAddr] != nil {
-					continue
-				}
-
-				var account *staking.Account
-				account, err = stakingConn.Account(
-					ctx,
-					&staking.OwnerQuery{
-						Height: height,
-						Owner:  entityAddr,
-					},
-				)
-				cobra.CheckErr(err)
-
-				validatorEntitiesShares[entityAddr] = &account.Escrow.Active
-				err = totalVotingStake.Add(&account.Escrow.Active.Balance)
-				cobra.CheckErr(err)
-				validatorNonVoters[entityAddr] = account.Escrow.Active.Balance
-				validatorVoteShares[entityAddr] = make(map[governance.Vote]quantity.Quantity)
-			}
-
-			// Tally the validator votes.
-
-			var invalidVotes uint64
-			for _, vote := range votes {
-				escrow, ok := validatorEntitiesShares[vote.Voter]
-				if !ok {
-					// Non validator votes handled later.
-					continue
-				}
-
-				validatorVotes[vote.Voter] = &vote.Vote
-				if err = addShares(validatorVoteShares[vote.Voter], vote.Vote, escrow.TotalShares); err != nil {
-					cobra.CheckErr(fmt.Errorf("failed to add shares: %w", err))
-				}
-				delete(validatorNonVoters, vote.Voter)
-				validatorVoters[vote.Voter] = escrow.Balance
+			if cacheable {
+				tally, cached = loadCachedTally(chainContext, proposalID, height, showFast)
 			}
-
-			// Tally the delegator (non-validator) votes.
-			type override struct {
-				vote         governance.Vote
-				shares       quantity.Quantity
-				sharePercent *big.Float
-			}
-			validatorVoteOverrides := make(map[staking.Address]map[staking.Address]override)
-			for _, vote := range votes {
-				// Fetch outgoing delegations.
-				var delegations map[staking.Address]*staking.Delegation
-				delegations, err = stakingConn.DelegationsFor(ctx, &staking.OwnerQuery{Height: height, Owner: vote.Voter})
+			if !cached {
+				engine := newTallyEngine(consensusConn, showConcurrency)
+				tally, err = engine.tally(ctx, height, votes, showFast)
 				cobra.CheckErr(err)
 
-				var delegatesToValidator bool
-				for to, delegation := range delegations {
-					// Skip delegations to non-validators.
-					if _, ok := validatorEntitiesShares[to]; !ok {
-						continue
-					}
-					delegatesToValidator = true
-
-					validatorVote := validatorVotes[to]
-					// Nothing to do if vote matches the validator's vote.
-					if validatorVote != nil && vote.Vote == *validatorVote {
-						continue
-					}
-
-					// Vote doesn't match. Deduct shares from the validator's vote and
-					// add shares to the delegator's vote.
-					if validatorVote != nil {
-						if err = subShares(validatorVoteShares[to], *validatorVote, delegation.Shares); err != nil {
-							cobra.CheckErr(fmt.Errorf("failed to sub shares: %w", err))
-						}
-					}
-					if err = addShares(validatorVoteShares[to], vote.Vote, delegation.Shares); err != nil {
-						cobra.CheckErr(fmt.Errorf("failed to add shares: %w", err))
-					}
-
-					// Remember the validator vote overrides, so that we can display them later.
-					if validatorVoteOverrides[to] == nil {
-						validatorVoteOverrides[to] = make(map[staking.Address]override)
-					}
-					sharePercent := new(big.Float).SetInt(delegation.Shares.Clone().ToBigInt())
-					sharePercent = sharePercent.Mul(sharePercent, new(big.Float).SetInt64(100))
-					sharePercent = sharePercent.Quo(sharePercent, new(big.Float).SetInt(validatorEntitiesShares[to].TotalShares.ToBigInt()))
-					validatorVoteOverrides[to][vote.Voter] = override{
-						vote:         vote.Vote,
-						shares:       delegation.Shares,
-						sharePercent: sharePercent,
+				if cacheable {
+					if err = saveCachedTally(chainContext, proposalID, height, tally); err != nil {
+						fmt.Fprintf(os.Stderr, "Warning: failed to cache vote tally: %v\n", err)
 					}
 				}
-
-				if !delegatesToValidator {
-					// Invalid vote if delegator doesn't delegate to a validator.
-					invalidVotes++
-				}
-			}
-
-			// Finalize the voting results - convert votes in shares into results in stake.
-
-			derivedResults := make(map[governance.Vote]quantity.Quantity)
-			for validator, votes := range validatorVoteShares {
-				sharePool := validatorEntitiesShares[validator]
-				for vote, shares := range votes {
-					// Compute stake from shares.
-					var escrow *quantity.Quantity
-					escrow, err = sharePool.StakeForShares(shares.Clone())
-					if err != nil {
-						cobra.CheckErr(fmt.Errorf("failed to compute stake from shares: %w", err))
-					}
-
-					// Add stake to results.
-					currentVotes := derivedResults[vote]
-					if err = currentVotes.Add(escrow); err != nil {
-						cobra.CheckErr(fmt.Errorf("failed to add votes: %w", err))
-					}
-					derivedResults[vote] = currentVotes
-				}
 			}
+			totalVotingStake := &tally.TotalVotingStake
+			derivedResults, err := tally.results()
+			cobra.CheckErr(err)
 
 			// Display the high-level summary of the proposal status.
 
@@ -297,7 +167,11 @@ var (
 				cobra.CheckErr(err)
 
 				fmt.Printf("Closes At:       epoch %d (in %d epochs)\n", proposal.ClosesAt, proposal.ClosesAt-epoch)
-				fmt.Printf("Current Outcome: %s\n", proposal.State)
+				if tally.Fast {
+					fmt.Printf("Current Outcome: %s (ignoring delegator votes)\n", proposal.State)
+				} else {
+					fmt.Printf("Current Outcome: %s\n", proposal.State)
+				}
 			case governance.StatePassed, governance.StateFailed, governance.StateRejected:
 				fmt.Println("Results:")
 				for _, v := range []governance.Vote{governance.VoteYes, governance.VoteNo, governance.VoteAbstain} {
@@ -316,6 +190,10 @@ var (
 			proposal.Content.PrettyPrint(ctx, "", os.Stdout)
 			fmt.Println()
 
+			if proposal.Content.Upgrade != nil {
+				printUpgradeComparison(ctx, consensusConn, conn.Control(), height, &proposal.Content.Upgrade.Descriptor)
+			}
+
 			// Calculate voting percentages.
 			votedStake, err := proposal.VotedSum()
 			cobra.CheckErr(err)
@@ -393,7 +271,7 @@ var (
 
 				fmt.Println()
 				fmt.Println("=== VALIDATORS VOTED ===")
-				votersList := entitiesByDescendingStake(validatorVoters)
+				votersList := entitiesByDescendingStake(tally.voters())
 				for i, val := range votersList {
 					name := getName(val.Address)
 					stakePercentage := new(big.Float).SetInt(val.Stake.Clone().ToBigInt())
@@ -401,18 +279,18 @@ var (
 					stakePercentage = stakePercentage.Quo(stakePercentage, new(big.Float).SetInt(totalVotingStake.ToBigInt()))
 
 					if hasCorrectVotingPower {
-						fmt.Printf("  %d. %s,%s,%s (%.2f%%): %s\n", i+1, val.Address, name, val.Stake, stakePercentage, validatorVotes[val.Address])
+						fmt.Printf("  %d. %s,%s,%s (%.2f%%): %s\n", i+1, val.Address, name, val.Stake, stakePercentage, tally.ValidatorVotes[val.Address])
 					} else {
-						fmt.Printf("  %d. %s,%s: %s\n", i+1, val.Address, name, validatorVotes[val.Address])
+						fmt.Printf("  %d. %s,%s: %s\n", i+1, val.Address, name, tally.ValidatorVotes[val.Address])
 					}
 
 					// Display delegators that voted differently.
-					for voter, override := range validatorVoteOverrides[val.Address] {
+					for voter, override := range tally.Overrides[val.Address] {
 						voterName := getName(voter)
 						if hasCorrectVotingPower {
-							fmt.Printf("    - %s,%s,%s (%.2f%%) -> %s\n", voter, voterName, override.shares, override.sharePercent, override.vote)
+							fmt.Printf("    - %s,%s,%s (%.2f%%) -> %s\n", voter, voterName, override.Shares, tally.sharePercent(val.Address, override.Shares), override.Vote)
 						} else {
-							fmt.Printf("    - %s,%s -> %s\n", voter, voterName, override.vote)
+							fmt.Printf("    - %s,%s -> %s\n", voter, voterName, override.Vote)
 						}
 					}
 				}
@@ -420,7 +298,7 @@ var (
 				if hasCorrectVotingPower {
 					fmt.Println()
 					fmt.Println("=== VALIDATORS NOT VOTED ===")
-					nonVotersList := entitiesByDescendingStake(validatorNonVoters)
+					nonVotersList := entitiesByDescendingStake(tally.nonVoters())
 					for i, val := range nonVotersList {
 						name := getName(val.Address)
 						stakePercentage := new(big.Float).SetInt(val.Stake.Clone().ToBigInt())
@@ -429,9 +307,9 @@ var (
 						fmt.Printf("  %d. %s,%s,%s (%.2f%%)", i+1, val.Address, name, val.Stake, stakePercentage)
 						fmt.Println()
 						// Display delegators that voted differently.
-						for voter, override := range validatorVoteOverrides[val.Address] {
+						for voter, override := range tally.Overrides[val.Address] {
 							voterName := getName(voter)
-							fmt.Printf("    - %s,%s,%s (%.2f%%) -> %s", voter, voterName, override.shares, override.sharePercent, override.vote)
+							fmt.Printf("    - %s,%s,%s (%.2f%%) -> %s", voter, voterName, override.Shares, tally.sharePercent(val.Address, override.Shares), override.Vote)
 							fmt.Println()
 						}
 					}
@@ -467,6 +345,8 @@ func (p entityStakes) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
 func init() {
 	showVotesFlag := flag.NewFlagSet("", flag.ContinueOnError)
 	showVotesFlag.BoolVar(&showVotes, "show-votes", false, "individual entity votes")
+	showVotesFlag.BoolVar(&showFast, "fast", false, "skip delegator votes overriding their validator's vote")
+	showVotesFlag.UintVar(&showConcurrency, "concurrency", defaultTallyConcurrency, "number of staking queries performed in parallel")
 	govShowCmd.Flags().AddFlagSet(showVotesFlag)
 	govShowCmd.Flags().AddFlagSet(common.SelectorNFlags)
 	govShowCmd.Flags().AddFlagSet(common.HeightFlag)
@@ -0,0 +1,379 @@
+package governance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/adrg/xdg"
+
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+
+	"github.com/oasisprotocol/cli/cmd/common"
+)
+
+// defaultTallyConcurrency is the default number of staking queries performed in parallel when
+// tallying votes.
+const defaultTallyConcurrency = 8
+
+// voteOverride is a delegator vote overriding the vote of a validator for the delegated shares.
+type voteOverride struct {
+	Vote   governance.Vote   `json:"vote"`
+	Shares quantity.Quantity `json:"shares"`
+}
+
+// voteTally are the votes of a proposal tallied at a given height.
+type voteTally struct {
+	// Fast is true iff delegator votes overriding the vote of their validator were skipped.
+	Fast bool `json:"fast,omitempty"`
+	// TotalVotingStake is the total stake of all validator entities.
+	TotalVotingStake quantity.Quantity `json:"total_voting_stake"`
+	// SharePools are the active escrow share pools of the validator entities.
+	SharePools map[staking.Address]*staking.SharePool `json:"share_pools"`
+	// ValidatorVotes are the votes cast by validator entities.
+	ValidatorVotes map[staking.Address]governance.Vote `json:"validator_votes"`
+	// VoteShares are the escrow shares of each validator entity per vote.
+	VoteShares map[staking.Address]map[governance.Vote]quantity.Quantity `json:"vote_shares"`
+	// Overrides are the delegator votes differing from the vote of the validator by validator.
+	Overrides map[staking.Address]map[staking.Address]voteOverride `json:"overrides,omitempty"`
+	// InvalidVotes is the number of votes cast by accounts not delegating to any validator.
+	InvalidVotes uint64 `json:"invalid_votes"`
+}
+
+// newVoteTally creates a new tally of votes cast for validator entities with the given share pools.
+func newVoteTally(sharePools map[staking.Address]*staking.SharePool) (*voteTally, error) {
+	t := &voteTally{
+		SharePools:     sharePools,
+		ValidatorVotes: make(map[staking.Address]governance.Vote),
+		VoteShares:     make(map[staking.Address]map[governance.Vote]quantity.Quantity),
+		Overrides:      make(map[staking.Address]map[staking.Address]voteOverride),
+	}
+	for addr, pool := range sharePools {
+		if err := t.TotalVotingStake.Add(&pool.Balance); err != nil {
+			return nil, fmt.Errorf("failed to add voting stake: %w", err)
+		}
+		t.VoteShares[addr] = make(map[governance.Vote]quantity.Quantity)
+	}
+	return t, nil
+}
+
+// addValidatorVotes tallies the votes of validator entities which count with all of the
+// validator's shares. Votes of other accounts are ignored.
+func (t *voteTally) addValidatorVotes(votes []*governance.VoteEntry) error {
+	for _, vote := range votes {
+		pool, ok := t.SharePools[vote.Voter]
+		if !ok {
+			continue
+		}
+		t.ValidatorVotes[vote.Voter] = vote.Vote
+		if err := addShares(t.VoteShares[vote.Voter], vote.Vote, pool.TotalShares); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addDelegatorVote tallies the vote of an account with the given outgoing delegations. The vote
+// overrides the vote of each validator the account delegates to for the delegated shares.
+//
+// All validator votes must be tallied first.
+func (t *voteTally) addDelegatorVote(vote *governance.VoteEntry, delegations map[staking.Address]*staking.Delegation) error {
+	var delegatesToValidator bool
+	for to, delegation := range delegations {
+		// Skip delegations to non-validators.
+		if _, ok := t.SharePools[to]; !ok {
+			continue
+		}
+		delegatesToValidator = true
+
+		// Nothing to do if the vote matches the validator's vote.
+		validatorVote, hasVoted := t.ValidatorVotes[to]
+		if hasVoted && validatorVote == vote.Vote {
+			continue
+		}
+
+		// Deduct shares from the validator's vote and add them to the delegator's vote.
+		if hasVoted {
+			if err := subShares(t.VoteShares[to], validatorVote, delegation.Shares); err != nil {
+				return err
+			}
+		}
+		if err := addShares(t.VoteShares[to], vote.Vote, delegation.Shares); err != nil {
+			return err
+		}
+
+		if t.Overrides[to] == nil {
+			t.Overrides[to] = make(map[staking.Address]voteOverride)
+		}
+		t.Overrides[to][vote.Voter] = voteOverride{
+			Vote:   vote.Vote,
+			Shares: delegation.Shares,
+		}
+	}
+
+	if !delegatesToValidator {
+		// Invalid vote if delegator doesn't delegate to a validator.
+		t.InvalidVotes++
+	}
+	return nil
+}
+
+// results converts the tallied shares into the voting results in stake.
+func (t *voteTally) results() (map[governance.Vote]quantity.Quantity, error) {
+	results := make(map[governance.Vote]quantity.Quantity)
+	for validator, votes := range t.VoteShares {
+		for vote, shares := range votes {
+			stake, err := t.SharePools[validator].StakeForShares(shares.Clone())
+			if err != nil {
+				return nil, fmt.Errorf("failed to compute stake from shares: %w", err)
+			}
+
+			current := results[vote]
+			if err = current.Add(stake); err != nil {
+				return nil, fmt.Errorf("failed to add votes: %w", err)
+			}
+			results[vote] = current
+		}
+	}
+	return results, nil
+}
+
+// voters returns the stake of the validator entities that voted.
+func (t *voteTally) voters() map[staking.Address]quantity.Quantity {
+	voters := make(map[staking.Address]quantity.Quantity)
+	for addr := range t.ValidatorVotes {
+		voters[addr] = t.SharePools[addr].Balance
+	}
+	return voters
+}
+
+// nonVoters returns the stake of the validator entities that did not vote.
+func (t *voteTally) nonVoters() map[staking.Address]quantity.Quantity {
+	nonVoters := make(map[staking.Address]quantity.Quantity)
+	for addr, pool := range t.SharePools {
+		if _, ok := t.ValidatorVotes[addr]; !ok {
+			nonVoters[addr] = pool.Balance
+		}
+	}
+	return nonVoters
+}
+
+// sharePercent returns the percentage of the validator's shares the given shares represent.
+func (t *voteTally) sharePercent(validator staking.Address, shares quantity.Quantity) *big.Float {
+	percent := new(big.Float).SetInt(shares.Clone().ToBigInt())
+	percent = percent.Mul(percent, new(big.Float).SetInt64(100))
+	return percent.Quo(percent, new(big.Float).SetInt(t.SharePools[validator].TotalShares.ToBigInt()))
+}
+
+// tallyEngine tallies proposal votes by querying the staking state in parallel.
+type tallyEngine struct {
+	consensusConn consensus.ClientBackend
+	concurrency   uint
+
+	mu sync.Mutex
+	// sharePools caches the validator entity share pools of the last queried height.
+	sharePools       map[staking.Address]*staking.SharePool
+	sharePoolsHeight int64
+}
+
+func newTallyEngine(consensusConn consensus.ClientBackend, concurrency uint) *tallyEngine {
+	return &tallyEngine{
+		consensusConn: consensusConn,
+		concurrency:   concurrency,
+	}
+}
+
+// validatorSharePools returns the active escrow share pools of all validator entities at the
+// given height.
+func (e *tallyEngine) validatorSharePools(ctx context.Context, height int64) (map[staking.Address]*staking.SharePool, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.sharePools != nil && e.sharePoolsHeight == height {
+		return e.sharePools, nil
+	}
+
+	nodeLookup, err := common.NewNodeLookup(ctx, e.consensusConn, e.consensusConn.Registry(), height)
+	if err != nil {
+		return nil, err
+	}
+	validators, err := e.consensusConn.Scheduler().GetValidators(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch validators: %w", err)
+	}
+
+	// If there are multiple nodes in the validator set belonging to the same entity, only count
+	// the entity escrow once.
+	var entities []staking.Address
+	seen := make(map[staking.Address]bool)
+	for _, validator := range validators {
+		n, err := nodeLookup.ByID(ctx, validator.ID)
+		if err != nil {
+			return nil, err
+		}
+		addr := staking.NewAddress(n.EntityID)
+		if seen[addr] {
+			continue
+		}
+		seen[addr] = true
+		entities = append(entities, addr)
+	}
+
+	accounts := make([]*staking.Account, len(entities))
+	err = runParallel(ctx, len(entities), e.concurrency, func(ctx context.Context, i int) error {
+		account, err := e.consensusConn.Staking().Account(ctx, &staking.OwnerQuery{Height: height, Owner: entities[i]})
+		if err != nil {
+			return fmt.Errorf("failed to fetch account %s: %w", entities[i], err)
+		}
+		accounts[i] = account
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sharePools := make(map[staking.Address]*staking.SharePool, len(entities))
+	for i, addr := range entities {
+		sharePools[addr] = &accounts[i].Escrow.Active
+	}
+	e.sharePools = sharePools
+	e.sharePoolsHeight = height
+	return sharePools, nil
+}
+
+// tally tallies the given votes at the given height. In fast mode the outgoing delegations of the
+// voters are not queried, so delegator votes overriding the vote of their validator are skipped.
+func (e *tallyEngine) tally(ctx context.Context, height int64, votes []*governance.VoteEntry, fast bool) (*voteTally, error) {
+	sharePools, err := e.validatorSharePools(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	t, err := newVoteTally(sharePools)
+	if err != nil {
+		return nil, err
+	}
+	t.Fast = fast
+	if err = t.addValidatorVotes(votes); err != nil {
+		return nil, err
+	}
+	if fast {
+		return t, nil
+	}
+
+	// Fetch the outgoing delegations of all voters in parallel, but apply them in the order of
+	// votes so the tally does not depend on the order of responses.
+	delegations := make([]map[staking.Address]*staking.Delegation, len(votes))
+	err = runParallel(ctx, len(votes), e.concurrency, func(ctx context.Context, i int) error {
+		d, err := e.consensusConn.Staking().DelegationsFor(ctx, &staking.OwnerQuery{Height: height, Owner: votes[i].Voter})
+		if err != nil {
+			return fmt.Errorf("failed to fetch delegations of %s: %w", votes[i].Voter, err)
+		}
+		delegations[i] = d
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i, vote := range votes {
+		if err = t.addDelegatorVote(vote, delegations[i]); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+// runParallel calls fn for each index in [0, n) using at most the given number of workers and
+// returns the first error encountered.
+func runParallel(ctx context.Context, n int, concurrency uint, fn func(ctx context.Context, i int) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+	)
+	work := make(chan int)
+	go func() {
+		defer close(work)
+		for i := 0; i < n; i++ {
+			select {
+			case work <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for w := uint(0); w < max(concurrency, 1); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				if err := fn(ctx, i); err != nil {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = err
+						cancel()
+					}
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// tallyCachePath returns the path of the cached tally of the given proposal at the given height.
+func tallyCachePath(chainContext string, proposalID uint64, height int64, fast bool) (string, error) {
+	fn := fmt.Sprintf("%d-%d.json", proposalID, height)
+	if fast {
+		fn = fmt.Sprintf("%d-%d-fast.json", proposalID, height)
+	}
+	return xdg.CacheFile(filepath.Join("oasis", "governance_tally", chainContext, fn))
+}
+
+// loadCachedTally returns the cached tally of the given proposal at the given height. A full
+// tally is also used when a fast one is requested.
+func loadCachedTally(chainContext string, proposalID uint64, height int64, fast bool) (*voteTally, bool) {
+	modes := []bool{false}
+	if fast {
+		modes = append(modes, true)
+	}
+	for _, mode := range modes {
+		path, err := tallyCachePath(chainContext, proposalID, height, mode)
+		if err != nil {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var t voteTally
+		if err = json.Unmarshal(data, &t); err != nil {
+			continue
+		}
+		return &t, true
+	}
+	return nil, false
+}
+
+// saveCachedTally stores the tally of the given proposal at the given height.
+func saveCachedTally(chainContext string, proposalID uint64, height int64, t *voteTally) error {
+	path, err := tallyCachePath(chainContext, proposalID, height, t.Fast)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
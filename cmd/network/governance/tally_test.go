@@ -0,0 +1,87 @@
+package governance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+func TestVoteTally(t *testing.T) {
+	require := require.New(t)
+
+	validatorA := staking.NewAddress(signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000001"))
+	validatorB := staking.NewAddress(signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000002"))
+	delegator := staking.NewAddress(signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000003"))
+	stranger := staking.NewAddress(signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000004"))
+
+	tally, err := newVoteTally(map[staking.Address]*staking.SharePool{
+		validatorA: {Balance: *quantity.NewFromUint64(600), TotalShares: *quantity.NewFromUint64(300)},
+		validatorB: {Balance: *quantity.NewFromUint64(400), TotalShares: *quantity.NewFromUint64(400)},
+	})
+	require.NoError(err)
+	require.EqualValues(*quantity.NewFromUint64(1000), tally.TotalVotingStake)
+
+	votes := []*governance.VoteEntry{
+		{Voter: validatorA, Vote: governance.VoteYes},
+		{Voter: delegator, Vote: governance.VoteNo},
+		{Voter: stranger, Vote: governance.VoteNo},
+	}
+	require.NoError(tally.addValidatorVotes(votes))
+	require.NoError(tally.addDelegatorVote(votes[0], map[staking.Address]*staking.Delegation{
+		validatorA: {Shares: *quantity.NewFromUint64(300)},
+	}))
+	require.NoError(tally.addDelegatorVote(votes[1], map[staking.Address]*staking.Delegation{
+		validatorA: {Shares: *quantity.NewFromUint64(100)},
+		validatorB: {Shares: *quantity.NewFromUint64(100)},
+	}))
+	require.NoError(tally.addDelegatorVote(votes[2], nil))
+	require.EqualValues(1, tally.InvalidVotes)
+
+	results, err := tally.results()
+	require.NoError(err)
+	require.EqualValues(*quantity.NewFromUint64(400), results[governance.VoteYes])
+	require.EqualValues(*quantity.NewFromUint64(300), results[governance.VoteNo])
+	require.Len(tally.Overrides[validatorA], 1)
+	require.Len(tally.Overrides[validatorB], 1)
+	require.Equal("33.33", tally.sharePercent(validatorA, tally.Overrides[validatorA][delegator].Shares).Text('f', 2))
+	require.Contains(tally.voters(), validatorA)
+	require.Contains(tally.nonVoters(), validatorB)
+
+	// Cached tallies must produce the same results.
+	data, err := json.Marshal(tally)
+	require.NoError(err)
+	var decoded voteTally
+	require.NoError(json.Unmarshal(data, &decoded))
+	decodedResults, err := decoded.results()
+	require.NoError(err)
+	require.EqualValues(results, decodedResults)
+	require.EqualValues(tally.Overrides, decoded.Overrides)
+}
+
+func TestRunParallel(t *testing.T) {
+	require := require.New(t)
+
+	done := make([]bool, 100)
+	err := runParallel(context.Background(), len(done), 4, func(_ context.Context, i int) error {
+		done[i] = true
+		return nil
+	})
+	require.NoError(err)
+	require.NotContains(done, false)
+
+	err = runParallel(context.Background(), 100, 4, func(_ context.Context, i int) error {
+		if i == 10 {
+			return errors.New("failed")
+		}
+		return nil
+	})
+	require.EqualError(err, "failed")
+}
@@ -0,0 +1,97 @@
+package governance
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	control "github.com/oasisprotocol/oasis-core/go/control/api"
+	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
+)
+
+// upgradeTimeFormat is the format used for displaying estimated upgrade times.
+const upgradeTimeFormat = "2006-01-02 15:04 MST"
+
+// epochTime returns the time at which the given epoch started or, for future epochs, the estimated
+// time based on the duration of the previous epoch.
+func epochTime(ctx context.Context, consensusConn consensus.ClientBackend, current, epoch beacon.EpochTime) (time.Time, bool, error) {
+	blockTime := func(epoch beacon.EpochTime) (time.Time, error) {
+		height, err := consensusConn.Beacon().GetEpochBlock(ctx, epoch)
+		if err != nil {
+			return time.Time{}, err
+		}
+		blk, err := consensusConn.GetBlock(ctx, height)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return blk.Time, nil
+	}
+
+	if epoch <= current {
+		t, err := blockTime(epoch)
+		return t, false, err
+	}
+	if current == 0 {
+		return time.Time{}, false, fmt.Errorf("not enough epochs to estimate epoch duration")
+	}
+
+	currentStart, err := blockTime(current)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	previousStart, err := blockTime(current - 1)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	epochDuration := currentStart.Sub(previousStart)
+	return currentStart.Add(time.Duration(epoch-current) * epochDuration), true, nil
+}
+
+// printUpgradeComparison prints the comparison between the upgrade target and the versions
+// currently running on the network.
+func printUpgradeComparison(
+	ctx context.Context,
+	consensusConn consensus.ClientBackend,
+	controlConn control.NodeController,
+	height int64,
+	descriptor *upgrade.Descriptor,
+) {
+	fmt.Println("=== UPGRADE ===")
+	fmt.Printf("Handler:           %s\n", descriptor.Handler)
+
+	running := "unknown"
+	if status, err := consensusConn.GetStatus(ctx); err == nil {
+		running = status.Version.String()
+	}
+	fmt.Printf("Consensus version: %s (running: %s)\n", descriptor.Target.ConsensusProtocol, running)
+	fmt.Printf("Runtime host:      %s\n", descriptor.Target.RuntimeHostProtocol)
+	fmt.Printf("Runtime committee: %s\n", descriptor.Target.RuntimeCommitteeProtocol)
+	if status, err := controlConn.GetStatus(ctx); err == nil {
+		fmt.Printf("Node software:     %s\n", status.SoftwareVersion)
+	}
+
+	epoch, err := consensusConn.Beacon().GetEpoch(ctx, height)
+	if err != nil {
+		fmt.Printf("Epoch:             %d\n", descriptor.Epoch)
+	} else {
+		switch t, estimated, err := epochTime(ctx, consensusConn, epoch, descriptor.Epoch); {
+		case err != nil:
+			fmt.Printf("Epoch:             %d\n", descriptor.Epoch)
+		case estimated:
+			fmt.Printf("Epoch:             %d (in %d epochs, around %s)\n", descriptor.Epoch, descriptor.Epoch-epoch, t.Local().Format(upgradeTimeFormat))
+		default:
+			fmt.Printf("Epoch:             %d (at %s)\n", descriptor.Epoch, t.Local().Format(upgradeTimeFormat))
+		}
+	}
+
+	// Warn if the upgrade breaks compatibility with the consensus protocol supported by the CLI.
+	if descriptor.Target.ConsensusProtocol.MaskNonMajor().ToU64() > version.ConsensusProtocol.MaskNonMajor().ToU64() {
+		fmt.Println()
+		fmt.Printf("WARNING: This CLI supports consensus protocol version %s, while the upgrade targets %s.\n", version.ConsensusProtocol, descriptor.Target.ConsensusProtocol)
+		fmt.Println("         Upgrade the CLI before the upgrade epoch to keep interacting with the network.")
+	}
+	fmt.Println()
+}
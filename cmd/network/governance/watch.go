@@ -0,0 +1,272 @@
+package governance
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+
+	"github.com/oasisprotocol/cli/cmd/common"
+	cliConfig "github.com/oasisprotocol/cli/config"
+)
+
+// watchAlertExitCode is the exit code used when exiting due to an alert.
+const watchAlertExitCode = 2
+
+// webhookTimeout is the timeout for delivering a single alert to the webhook.
+const webhookTimeout = 10 * time.Second
+
+var (
+	watchInterval    time.Duration
+	watchFinalEpochs uint64
+	watchEntities    []string
+	watchWebhook     string
+	watchExitOnAlert bool
+
+	govWatchCmd = &cobra.Command{
+		Use:   "watch",
+		Short: "Watch active proposals and alert on important changes",
+		Long: `Periodically poll active governance proposals and emit an alert when the
+simulated outcome of a proposal flips, a proposal enters its final epochs or
+a watched entity has not yet voted on a proposal.`,
+		Args: cobra.NoArgs,
+		Run: func(_ *cobra.Command, _ []string) {
+			cfg := cliConfig.Global()
+			npa := common.GetNPASelection(cfg)
+
+			if watchInterval <= 0 {
+				cobra.CheckErr("poll interval must be positive")
+			}
+
+			// Resolve the watched entities.
+			entities := make(map[staking.Address]string)
+			for _, e := range watchEntities {
+				addr, _, err := common.ResolveLocalAccountOrAddress(npa.Network, e)
+				cobra.CheckErr(err)
+				entities[addr.ConsensusAddress()] = e
+			}
+
+			ctx := context.Background()
+			conn, err := common.Connect(ctx, npa.Network)
+			cobra.CheckErr(err)
+
+			w := &proposalWatcher{
+				consensusConn: conn.Consensus(),
+				tally:         newTallyEngine(conn.Consensus(), defaultTallyConcurrency),
+				entities:      entities,
+				proposals:     make(map[uint64]*watchedProposal),
+			}
+
+			fmt.Printf("Watching active proposals on %s every %s...\n", npa.PrettyPrintNetwork(), watchInterval)
+			for {
+				alerts, err := w.poll(ctx)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: failed to poll proposals: %v\n", err)
+				}
+				for _, alert := range alerts {
+					fmt.Printf("[%s] proposal %d: %s\n", alert.Time.Local().Format(time.DateTime), alert.ProposalID, alert.Message)
+					if watchWebhook != "" {
+						if err = alert.deliver(ctx, watchWebhook); err != nil {
+							fmt.Fprintf(os.Stderr, "Warning: failed to deliver alert to webhook: %v\n", err)
+						}
+					}
+				}
+				if len(alerts) > 0 && watchExitOnAlert {
+					os.Exit(watchAlertExitCode)
+				}
+
+				time.Sleep(watchInterval)
+			}
+		},
+	}
+)
+
+// watchAlert is an alert emitted by the proposal watcher.
+type watchAlert struct {
+	Time       time.Time `json:"time"`
+	ProposalID uint64    `json:"proposal_id"`
+	Kind       string    `json:"kind"`
+	Message    string    `json:"message"`
+}
+
+// deliver posts the alert as JSON to the given webhook URL.
+func (a *watchAlert) deliver(ctx context.Context, url string) error {
+	body, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, webhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("invalid response status: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// watchedProposal is the state the watcher remembers about an active proposal.
+type watchedProposal struct {
+	outcome       governance.ProposalState
+	closingAlert  bool
+	nonVoterAlert map[staking.Address]bool
+}
+
+// proposalWatcher tracks active proposals between polls.
+type proposalWatcher struct {
+	consensusConn consensus.ClientBackend
+	tally         *tallyEngine
+	entities      map[staking.Address]string
+	proposals     map[uint64]*watchedProposal
+}
+
+// poll queries the active proposals and returns any new alerts.
+func (w *proposalWatcher) poll(ctx context.Context) ([]*watchAlert, error) {
+	governanceConn := w.consensusConn.Governance()
+
+	blk, err := w.consensusConn.GetBlock(ctx, consensus.HeightLatest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query latest block: %w", err)
+	}
+	height := blk.Height
+
+	epoch, err := w.consensusConn.Beacon().GetEpoch(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query epoch: %w", err)
+	}
+	proposals, err := governanceConn.ActiveProposals(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch proposals: %w", err)
+	}
+	params, err := governanceConn.ConsensusParameters(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch governance parameters: %w", err)
+	}
+
+	var alerts []*watchAlert
+	newAlert := func(id uint64, kind, msg string, args ...interface{}) {
+		alerts = append(alerts, &watchAlert{
+			Time:       blk.Time,
+			ProposalID: id,
+			Kind:       kind,
+			Message:    fmt.Sprintf(msg, args...),
+		})
+	}
+
+	active := make(map[uint64]struct{})
+	for _, proposal := range proposals {
+		active[proposal.ID] = struct{}{}
+
+		votes, err := governanceConn.Votes(ctx, &governance.ProposalQuery{Height: height, ProposalID: proposal.ID})
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch votes for proposal %d: %w", proposal.ID, err)
+		}
+		outcome, err := simulateOutcome(ctx, w.tally, height, proposal, votes, params.StakeThreshold)
+		if err != nil {
+			return nil, fmt.Errorf("failed to tally votes for proposal %d: %w", proposal.ID, err)
+		}
+
+		wp, known := w.proposals[proposal.ID]
+		if !known {
+			wp = &watchedProposal{
+				outcome:       outcome,
+				nonVoterAlert: make(map[staking.Address]bool),
+			}
+			w.proposals[proposal.ID] = wp
+			fmt.Printf("Proposal %d: closes at epoch %d (in %d epochs), current outcome: %s\n",
+				proposal.ID, proposal.ClosesAt, proposal.ClosesAt-epoch, outcome)
+		}
+
+		if outcome != wp.outcome {
+			newAlert(proposal.ID, "outcome", "current outcome changed from %s to %s", wp.outcome, outcome)
+			wp.outcome = outcome
+		}
+
+		if !wp.closingAlert && proposal.ClosesAt-epoch <= beacon.EpochTime(watchFinalEpochs) {
+			newAlert(proposal.ID, "closing", "closes at epoch %d (in %d epochs), current outcome: %s",
+				proposal.ClosesAt, proposal.ClosesAt-epoch, outcome)
+			wp.closingAlert = true
+		}
+
+		voted := make(map[staking.Address]bool)
+		for _, vote := range votes {
+			voted[vote.Voter] = true
+		}
+		for addr, name := range w.entities {
+			if voted[addr] || wp.nonVoterAlert[addr] {
+				continue
+			}
+			newAlert(proposal.ID, "not-voted", "entity %s has not voted yet (closes in %d epochs)", name, proposal.ClosesAt-epoch)
+			wp.nonVoterAlert[addr] = true
+		}
+	}
+
+	// Forget proposals that are no longer active.
+	for id := range w.proposals {
+		if _, ok := active[id]; !ok {
+			delete(w.proposals, id)
+		}
+	}
+
+	return alerts, nil
+}
+
+// simulateOutcome tallies the current votes of an active proposal and returns the state the
+// proposal would have if it were closed at the given height.
+func simulateOutcome(
+	ctx context.Context,
+	engine *tallyEngine,
+	height int64,
+	proposal *governance.Proposal,
+	votes []*governance.VoteEntry,
+	stakeThreshold uint8,
+) (governance.ProposalState, error) {
+	tally, err := engine.tally(ctx, height, votes, false)
+	if err != nil {
+		return 0, err
+	}
+	results, err := tally.results()
+	if err != nil {
+		return 0, err
+	}
+
+	simulated := *proposal
+	simulated.Results = results
+	if err = simulated.CloseProposal(*tally.TotalVotingStake.Clone(), stakeThreshold); err != nil {
+		return 0, err
+	}
+	return simulated.State, nil
+}
+
+func init() {
+	f := flag.NewFlagSet("", flag.ContinueOnError)
+	f.DurationVar(&watchInterval, "interval", time.Minute, "poll interval")
+	f.Uint64Var(&watchFinalEpochs, "final-epochs", 2, "alert when a proposal enters its final number of epochs")
+	f.StringSliceVar(&watchEntities, "entity", nil, "alert when the given entity address or account has not voted yet")
+	f.StringVar(&watchWebhook, "webhook", "", "also POST alerts as JSON to the given URL")
+	f.BoolVar(&watchExitOnAlert, "exit-on-alert", false, fmt.Sprintf("exit with code %d after the first alerts", watchAlertExitCode))
+	govWatchCmd.Flags().AddFlagSet(f)
+	govWatchCmd.Flags().AddFlagSet(common.SelectorNFlags)
+}
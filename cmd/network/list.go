@@ -2,6 +2,7 @@ package network
 
 import (
 	"sort"
+	"strconv"
 
 	"github.com/spf13/cobra"
 
@@ -17,29 +18,44 @@ var listCmd = &cobra.Command{
 	Args:    cobra.NoArgs,
 	Run: func(_ *cobra.Command, _ []string) {
 		cfg := cliConfig.Global()
-		table := table.New()
-		table.SetHeader([]string{"Name", "Chain Context", "RPC"})
+		listing := table.NewListing(
+			table.Column{Name: "Name"},
+			table.Column{Name: "Chain Context"},
+			table.Column{Name: "RPC"},
+			table.Column{Name: "Denomination", Wide: true},
+			table.Column{Name: "ParaTimes", Wide: true},
+			table.Column{Name: "Description", Wide: true},
+		)
 
-		var output [][]string
-		for name, net := range cfg.Networks.All {
+		// Sort output by name.
+		names := make([]string, 0, len(cfg.Networks.All))
+		for name := range cfg.Networks.All {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			net := cfg.Networks.All[name]
 			displayName := name
 			if cfg.Networks.Default == name {
 				displayName += common.DefaultMarker
 			}
 
-			output = append(output, []string{
+			listing.Append(
 				displayName,
 				net.ChainContext,
 				net.RPC,
-			})
+				net.Denomination.Symbol,
+				strconv.Itoa(len(net.ParaTimes.All)),
+				net.Description,
+			)
 		}
 
-		// Sort output by name.
-		sort.Slice(output, func(i, j int) bool {
-			return output[i][0] < output[j][0]
-		})
-
-		table.AppendBulk(output)
-		table.Render()
+		err := listing.Render(common.GetListingOptions())
+		cobra.CheckErr(err)
 	},
 }
+
+func init() {
+	listCmd.Flags().AddFlagSet(common.ListingFlags)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
 
+	"github.com/oasisprotocol/cli/cmd/common"
 	"github.com/oasisprotocol/cli/cmd/network/governance"
 )
 
@@ -17,6 +18,8 @@ var Cmd = &cobra.Command{
 }
 
 func networkDetailsFromSurvey(net *config.Network) {
+	common.CheckInteractive()
+
 	// Ask user for some additional parameters.
 	questions := []*survey.Question{
 		{
@@ -52,12 +55,19 @@ func networkDetailsFromSurvey(net *config.Network) {
 func init() {
 	Cmd.AddCommand(addCmd)
 	Cmd.AddCommand(addLocalCmd)
+	Cmd.AddCommand(epochCmd)
+	Cmd.AddCommand(escrowCmd)
+	Cmd.AddCommand(genesisCmd)
 	Cmd.AddCommand(governance.Cmd)
 	Cmd.AddCommand(listCmd)
+	Cmd.AddCommand(nodeCmd)
 	Cmd.AddCommand(rmCmd)
 	Cmd.AddCommand(setChainContextCmd)
 	Cmd.AddCommand(setDefaultCmd)
+	Cmd.AddCommand(setDefaultAccountCmd)
 	Cmd.AddCommand(setRPCCmd)
 	Cmd.AddCommand(showCmd)
 	Cmd.AddCommand(statusCmd)
+	Cmd.AddCommand(syncCmd)
+	Cmd.AddCommand(validatorCmd)
 }
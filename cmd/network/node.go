@@ -0,0 +1,388 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	coreCommon "github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+
+	"github.com/oasisprotocol/cli/cmd/common"
+	cliConfig "github.com/oasisprotocol/cli/config"
+)
+
+// nodeHealth is the health verdict of a node.
+type nodeHealth string
+
+// Health verdicts of a node, from the best to the worst.
+const (
+	nodeHealthy   nodeHealth = "healthy"
+	nodeDegraded  nodeHealth = "degraded"
+	nodeUnhealthy nodeHealth = "unhealthy"
+)
+
+// Exit codes of the node check, following the conventions of monitoring plugins.
+const (
+	nodeCheckExitDegraded  = 1
+	nodeCheckExitUnhealthy = 2
+	nodeCheckExitUnknown   = 3
+)
+
+// exitCode returns the exit code of the node check reporting the verdict.
+func (h nodeHealth) exitCode() int {
+	switch h {
+	case nodeHealthy:
+		return 0
+	case nodeDegraded:
+		return nodeCheckExitDegraded
+	default:
+		return nodeCheckExitUnhealthy
+	}
+}
+
+// nodeIssue is a single problem found by the node check.
+type nodeIssue struct {
+	Severity nodeHealth `json:"severity"`
+	Message  string     `json:"message"`
+}
+
+// nodeRuntimeStatus is the status of a runtime the node is registered for.
+type nodeRuntimeStatus struct {
+	ID            coreCommon.Namespace `json:"id"`
+	Name          string               `json:"name,omitempty"`
+	Versions      []string             `json:"versions"`
+	ActiveVersion string               `json:"active_version,omitempty"`
+	Committees    []string             `json:"committees"`
+	Failures      uint8                `json:"failures,omitempty"`
+	Suspended     bool                 `json:"suspended,omitempty"`
+}
+
+// nodeCheck is the result of the node health check.
+type nodeCheck struct {
+	ID         signature.PublicKey `json:"id"`
+	EntityID   signature.PublicKey `json:"entity_id"`
+	Height     int64               `json:"height"`
+	Epoch      beacon.EpochTime    `json:"epoch"`
+	Roles      string              `json:"roles"`
+	Expiration uint64              `json:"expiration"`
+	Frozen     bool                `json:"frozen,omitempty"`
+	Validator  bool                `json:"validator"`
+	Runtimes   []nodeRuntimeStatus `json:"runtimes"`
+	Verdict    nodeHealth          `json:"verdict"`
+	Issues     []nodeIssue         `json:"issues"`
+}
+
+// addIssue records a problem and worsens the verdict accordingly.
+func (c *nodeCheck) addIssue(severity nodeHealth, format string, args ...interface{}) {
+	c.Issues = append(c.Issues, nodeIssue{Severity: severity, Message: fmt.Sprintf(format, args...)})
+	if severity == nodeUnhealthy || c.Verdict == nodeHealthy {
+		c.Verdict = severity
+	}
+}
+
+var (
+	nodeCmd = &cobra.Command{
+		Use:   "node",
+		Short: "Node operator helpers",
+	}
+
+	nodeCheckCmd = &cobra.Command{
+		Use:   "check <node-id|address>",
+		Short: "Check the health of a registered node",
+		Long: `Combine the registry status (expiration, roles and runtime versions), the committee
+memberships and the liveness faults of a node into a single health verdict.
+
+The exit code reports the verdict: 0 if the node is healthy, 1 if it is degraded, 2 if it is
+unhealthy and 3 if the check itself failed.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+			npa := common.GetNPASelection(cfg)
+
+			// Establish connection with the target network.
+			ctx := context.Background()
+			conn, err := common.Connect(ctx, npa.Network)
+			checkNodeErr(err)
+
+			consensusConn := conn.Consensus()
+			height, err := common.GetActualHeight(ctx, consensusConn)
+			checkNodeErr(err)
+
+			nodeID, err := resolveNodeID(ctx, npa, consensusConn, height, args[0])
+			checkNodeErr(err)
+
+			check, err := checkNode(ctx, cfg, consensusConn, height, nodeID)
+			checkNodeErr(err)
+
+			if common.IsJSONOutput() {
+				data, err := common.JSONMarshalOutput(check)
+				checkNodeErr(err)
+				fmt.Printf("%s\n", data)
+			} else {
+				printNodeCheck(check)
+			}
+			os.Exit(check.Verdict.exitCode())
+		},
+	}
+)
+
+// checkNodeErr aborts the node check with the unknown verdict exit code on error.
+func checkNodeErr(err error) {
+	if err == nil {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+	os.Exit(nodeCheckExitUnknown)
+}
+
+// resolveNodeID parses the given node ID or finds the registered node with the given address.
+func resolveNodeID(ctx context.Context, npa *common.NPASelection, consensusConn consensus.ClientBackend, height int64, s string) (signature.PublicKey, error) {
+	var id signature.PublicKey
+	if err := id.UnmarshalText([]byte(s)); err == nil {
+		return id, nil
+	}
+
+	addr, _, err := common.ResolveLocalAccountOrAddress(npa.Network, s)
+	if err != nil {
+		return id, fmt.Errorf("malformed node ID or address '%s': %w", s, err)
+	}
+	nodes, err := consensusConn.Registry().GetNodes(ctx, height)
+	if err != nil {
+		return id, fmt.Errorf("failed to fetch nodes: %w", err)
+	}
+	for _, n := range nodes {
+		if staking.NewAddress(n.ID).Equal(addr.ConsensusAddress()) {
+			return n.ID, nil
+		}
+	}
+	return id, fmt.Errorf("no registered node with address '%s'", addr)
+}
+
+// checkNode gathers the registry status, committee memberships and liveness faults of the given
+// node.
+func checkNode(ctx context.Context, cfg *cliConfig.Config, consensusConn consensus.ClientBackend, height int64, nodeID signature.PublicKey) (*nodeCheck, error) {
+	check := nodeCheck{
+		ID:       nodeID,
+		Height:   height,
+		Runtimes: []nodeRuntimeStatus{},
+		Verdict:  nodeHealthy,
+		Issues:   []nodeIssue{},
+	}
+
+	epoch, err := consensusConn.Beacon().GetEpoch(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	check.Epoch = epoch
+
+	n, err := consensusConn.Registry().GetNode(ctx, &registry.IDQuery{Height: height, ID: nodeID})
+	switch {
+	case errors.Is(err, registry.ErrNoSuchNode):
+		check.addIssue(nodeUnhealthy, "node is not registered")
+		return &check, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to fetch node: %w", err)
+	}
+	status, err := consensusConn.Registry().GetNodeStatus(ctx, &registry.IDQuery{Height: height, ID: nodeID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch node status: %w", err)
+	}
+
+	validators, err := consensusConn.Scheduler().GetValidators(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch validators: %w", err)
+	}
+	for _, v := range validators {
+		if v.ID.Equal(nodeID) {
+			check.Validator = true
+			break
+		}
+	}
+
+	runtimes := make(map[coreCommon.Namespace]*registry.Runtime)
+	committees := make(map[coreCommon.Namespace][]string)
+	for _, nrt := range n.Runtimes {
+		if _, ok := runtimes[nrt.ID]; ok {
+			continue
+		}
+		rt, err := consensusConn.Registry().GetRuntime(ctx, &registry.GetRuntimeQuery{Height: height, ID: nrt.ID})
+		switch {
+		case errors.Is(err, registry.ErrNoSuchRuntime):
+			runtimes[nrt.ID] = nil
+			continue
+		case err != nil:
+			return nil, fmt.Errorf("failed to fetch runtime %s: %w", nrt.ID, err)
+		}
+		runtimes[nrt.ID] = rt
+
+		rtCommittees, err := consensusConn.Scheduler().GetCommittees(ctx, &scheduler.GetCommitteesRequest{
+			Height:    height,
+			RuntimeID: nrt.ID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch committees of runtime %s: %w", nrt.ID, err)
+		}
+		for _, committee := range rtCommittees {
+			for _, member := range committee.Members {
+				if member.PublicKey.Equal(nodeID) {
+					committees[nrt.ID] = append(committees[nrt.ID], fmt.Sprintf("%s %s", committee.Kind, member.Role))
+				}
+			}
+		}
+	}
+
+	evaluateNode(cfg, &check, n, status, runtimes, committees)
+	return &check, nil
+}
+
+// evaluateNode fills in the node check from the node descriptor, its status and the registered
+// runtimes and derives the health verdict.
+func evaluateNode(
+	cfg *cliConfig.Config,
+	check *nodeCheck,
+	n *node.Node,
+	status *registry.NodeStatus,
+	runtimes map[coreCommon.Namespace]*registry.Runtime,
+	committees map[coreCommon.Namespace][]string,
+) {
+	check.EntityID = n.EntityID
+	check.Roles = n.Roles.String()
+	check.Expiration = n.Expiration
+	check.Frozen = status.IsFrozen()
+
+	// Registration.
+	switch {
+	case n.IsExpired(uint64(check.Epoch)):
+		check.addIssue(nodeUnhealthy, "registration expired in epoch %d", n.Expiration)
+	case n.Expiration == uint64(check.Epoch):
+		check.addIssue(nodeDegraded, "registration expires at the end of the current epoch")
+	}
+	if check.Frozen {
+		if status.FreezeEndTime == registry.FreezeForever {
+			check.addIssue(nodeUnhealthy, "node is frozen indefinitely")
+		} else {
+			check.addIssue(nodeUnhealthy, "node is frozen until epoch %d", status.FreezeEndTime)
+		}
+	}
+	if n.HasRoles(node.RoleValidator) && !check.Validator {
+		check.addIssue(nodeDegraded, "node has the validator role but is not in the validator set")
+	}
+	if n.HasRoles(node.RoleComputeWorker) && (status.ElectionEligibleAfter == 0 || status.ElectionEligibleAfter > check.Epoch) {
+		check.addIssue(nodeDegraded, "node is not yet eligible for committee elections")
+	}
+
+	// Runtimes.
+	rtStatuses := make(map[coreCommon.Namespace]*nodeRuntimeStatus)
+	var rtIDs []coreCommon.Namespace
+	for _, nrt := range n.Runtimes {
+		rts, ok := rtStatuses[nrt.ID]
+		if !ok {
+			rts = &nodeRuntimeStatus{
+				ID:         nrt.ID,
+				Versions:   []string{},
+				Committees: []string{},
+			}
+			if name := getParatimeName(cfg, nrt.ID.String()); name != "unknown" {
+				rts.Name = name
+			}
+			rtStatuses[nrt.ID] = rts
+			rtIDs = append(rtIDs, nrt.ID)
+		}
+		rts.Versions = append(rts.Versions, nrt.Version.String())
+	}
+	sort.Slice(rtIDs, func(i, j int) bool { return rtIDs[i].String() < rtIDs[j].String() })
+
+	for _, id := range rtIDs {
+		rts := rtStatuses[id]
+		rt := runtimes[id]
+		if rt == nil {
+			check.addIssue(nodeDegraded, "runtime %s is not registered", id)
+			check.Runtimes = append(check.Runtimes, *rts)
+			continue
+		}
+
+		if active := rt.ActiveDeployment(check.Epoch); active != nil {
+			rts.ActiveVersion = active.Version.String()
+			if n.GetRuntime(id, active.Version) == nil {
+				check.addIssue(nodeUnhealthy, "runtime %s is not registered with the active version %s", id, active.Version)
+			}
+		}
+		if c := committees[id]; c != nil {
+			rts.Committees = c
+		}
+
+		if fault, ok := status.Faults[id]; ok && fault != nil {
+			rts.Failures = fault.Failures
+			rts.Suspended = fault.IsSuspended(check.Epoch)
+			switch {
+			case rts.Suspended:
+				check.addIssue(nodeUnhealthy, "node is suspended from runtime %s committees until epoch %d after %d liveness failure(s)", id, fault.SuspendedUntil, fault.Failures)
+			case fault.Failures > 0:
+				check.addIssue(nodeDegraded, "node has %d recent liveness failure(s) in runtime %s", fault.Failures, id)
+			}
+		}
+		check.Runtimes = append(check.Runtimes, *rts)
+	}
+}
+
+// printNodeCheck prints the result of the node health check.
+func printNodeCheck(check *nodeCheck) {
+	fmt.Printf("Node:              %s\n", check.ID)
+	if check.Roles != "" {
+		fmt.Printf("Entity:            %s\n", check.EntityID)
+		fmt.Printf("Roles:             %s\n", check.Roles)
+		fmt.Printf("Expiration:        epoch %d\n", check.Expiration)
+	}
+	fmt.Printf("Height:            %d\n", check.Height)
+	fmt.Printf("Epoch:             %d\n", check.Epoch)
+	if check.Roles != "" {
+		fmt.Printf("Validator set:     %t\n", check.Validator)
+	}
+
+	for _, rt := range check.Runtimes {
+		fmt.Println()
+		name := rt.Name
+		if name == "" {
+			name = "unknown"
+		}
+		fmt.Printf("=== RUNTIME %s(%s) ===\n", name, rt.ID)
+		fmt.Printf("Versions:          %v\n", rt.Versions)
+		if rt.ActiveVersion != "" {
+			fmt.Printf("Active version:    %s\n", rt.ActiveVersion)
+		}
+		if len(rt.Committees) > 0 {
+			fmt.Printf("Committees:        %v\n", rt.Committees)
+		} else {
+			fmt.Println("Committees:        (none)")
+		}
+		fmt.Printf("Liveness failures: %d\n", rt.Failures)
+		if rt.Suspended {
+			fmt.Println("Suspended:         true")
+		}
+	}
+	fmt.Println()
+
+	fmt.Printf("Verdict:           %s\n", check.Verdict)
+	for _, issue := range check.Issues {
+		fmt.Printf("  - [%s] %s\n", issue.Severity, issue.Message)
+	}
+}
+
+func init() {
+	nodeCheckCmd.Flags().AddFlagSet(common.SelectorNFlags)
+	nodeCheckCmd.Flags().AddFlagSet(common.HeightFlag)
+	nodeCheckCmd.Flags().AddFlagSet(common.FormatFlag)
+
+	nodeCmd.AddCommand(nodeCheckCmd)
+}
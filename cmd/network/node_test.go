@@ -0,0 +1,73 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	coreCommon "github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+
+	cliConfig "github.com/oasisprotocol/cli/config"
+)
+
+func TestEvaluateNode(t *testing.T) {
+	require := require.New(t)
+
+	var rtID coreCommon.Namespace
+	rtID[31] = 1
+	rt := &registry.Runtime{
+		ID:          rtID,
+		Deployments: []*registry.VersionInfo{{Version: version.Version{Major: 1}, ValidFrom: 0}},
+	}
+	n := &node.Node{
+		Expiration: 12,
+		Roles:      node.RoleComputeWorker,
+		Runtimes:   []*node.Runtime{{ID: rtID, Version: version.Version{Major: 1}}},
+	}
+	runtimes := map[coreCommon.Namespace]*registry.Runtime{rtID: rt}
+	committees := map[coreCommon.Namespace][]string{rtID: {"executor worker"}}
+
+	evaluate := func(n *node.Node, status *registry.NodeStatus) *nodeCheck {
+		check := &nodeCheck{Epoch: 10, Verdict: nodeHealthy}
+		evaluateNode(&cliConfig.Config{}, check, n, status, runtimes, committees)
+		return check
+	}
+
+	// Healthy node.
+	check := evaluate(n, &registry.NodeStatus{ElectionEligibleAfter: 5})
+	require.Equal(nodeHealthy, check.Verdict)
+	require.Empty(check.Issues)
+	require.Len(check.Runtimes, 1)
+	require.Equal([]string{"executor worker"}, check.Runtimes[0].Committees)
+	require.Equal(0, check.Verdict.exitCode())
+
+	// Recent liveness failures degrade the node.
+	check = evaluate(n, &registry.NodeStatus{
+		ElectionEligibleAfter: 5,
+		Faults:                map[coreCommon.Namespace]*registry.Fault{rtID: {Failures: 1, SuspendedUntil: 9}},
+	})
+	require.Equal(nodeDegraded, check.Verdict)
+	require.Len(check.Issues, 1)
+	require.Equal(nodeCheckExitDegraded, check.Verdict.exitCode())
+
+	// Suspension and an outdated runtime version make it unhealthy.
+	outdated := *n
+	outdated.Runtimes = []*node.Runtime{{ID: rtID, Version: version.Version{Major: 0, Minor: 9}}}
+	check = evaluate(&outdated, &registry.NodeStatus{
+		ElectionEligibleAfter: 5,
+		Faults:                map[coreCommon.Namespace]*registry.Fault{rtID: {Failures: 2, SuspendedUntil: 12}},
+	})
+	require.Equal(nodeUnhealthy, check.Verdict)
+	require.Len(check.Issues, 2)
+	require.True(check.Runtimes[0].Suspended)
+	require.Equal(nodeCheckExitUnhealthy, check.Verdict.exitCode())
+
+	// Expired registration.
+	expired := *n
+	expired.Expiration = 9
+	check = evaluate(&expired, &registry.NodeStatus{ElectionEligibleAfter: 5})
+	require.Equal(nodeUnhealthy, check.Verdict)
+}
@@ -0,0 +1,33 @@
+package network
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	cliConfig "github.com/oasisprotocol/cli/config"
+)
+
+var setDefaultAccountCmd = &cobra.Command{
+	Use:   "set-default-account <network> [<account>]",
+	Short: "Sets the default account for the given network",
+	Long:  "Sets the default account for the given network which takes precedence over the wallet default account. Omit the account to clear it.",
+	Args:  cobra.RangeArgs(1, 2),
+	Run: func(_ *cobra.Command, args []string) {
+		cfg := cliConfig.Global()
+		network := args[0]
+		var account string
+		if len(args) > 1 {
+			account = args[1]
+		}
+
+		if _, exists := cfg.Networks.All[network]; !exists {
+			cobra.CheckErr(fmt.Errorf("network '%s' does not exist", network))
+		}
+
+		cfg.DefaultAccounts.Set(network, "", account)
+
+		err := cfg.Save()
+		cobra.CheckErr(err)
+	},
+}
@@ -2,7 +2,6 @@ package network
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"os"
 	"sort"
@@ -12,13 +11,13 @@ import (
 
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	consensusPretty "github.com/oasisprotocol/oasis-core/go/common/prettyprint"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 	"github.com/oasisprotocol/oasis-core/go/staking/api/token"
-	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/connection"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
 
 	"github.com/oasisprotocol/cli/cmd/common"
@@ -38,24 +37,37 @@ const (
 	selGasCosts
 	selCommittees
 	selParameters
+	selBlocks
+	selTx
+	selRuntime
 )
 
+var blockCount uint64
+
 var showCmd = &cobra.Command{
-	Use:     "show { <id> | committees | entities | gas-costs | native-token | nodes | parameters | paratimes | validators }",
+	Use:     "show { <id> | blocks | committees | entities | gas-costs | native-token | nodes | parameters | paratimes | runtime <id> | tx <tx-hash> | validators }",
 	Short:   "Show network properties",
 	Long:    "Show network property stored in the registry, scheduler, genesis document or chain. Query by ID, hash or a specified kind.",
-	Args:    cobra.ExactArgs(1),
+	Args:    cobra.RangeArgs(1, 2),
 	Aliases: []string{"s"},
-	Run: func(_ *cobra.Command, args []string) {
+	Run: func(cmd *cobra.Command, args []string) {
 		cfg := cliConfig.Global()
 		npa := common.GetNPASelection(cfg)
 
 		id, err := parseIdentifier(npa, args[0])
 		cobra.CheckErr(err)
+		switch {
+		case id == selTx && len(args) != 2:
+			cobra.CheckErr("missing transaction hash")
+		case id == selRuntime && len(args) != 2:
+			cobra.CheckErr("missing runtime ID")
+		case id != selTx && id != selRuntime && len(args) != 1:
+			cobra.CheckErr(fmt.Sprintf("unexpected argument: %s", args[1]))
+		}
 
 		// Establish connection with the target network.
 		ctx := context.Background()
-		conn, err := connection.Connect(ctx, npa.Network)
+		conn, err := common.Connect(ctx, npa.Network)
 		cobra.CheckErr(err)
 
 		consensusConn := conn.Consensus()
@@ -74,7 +86,7 @@ var showCmd = &cobra.Command{
 		// looking for.
 
 		prettyPrint := func(b interface{}) error {
-			data, err := json.MarshalIndent(b, "", "  ")
+			data, err := common.JSONMarshalOutput(b)
 			if err != nil {
 				return err
 			}
@@ -202,6 +214,14 @@ var showCmd = &cobra.Command{
 				}
 				return
 			case selCommittees:
+				switch {
+				case committeesRuntime != "":
+					showCommitteeHistory(ctx, cfg, npa, height, consensusConn)
+					return
+				case cmd.Flags().Changed("epochs"), cmd.Flags().Changed("entity"):
+					cobra.CheckErr("--runtime is required for the committee history and forecast")
+				}
+
 				runtimes, err := registryConn.GetRuntimes(ctx, &registry.GetRuntimesQuery{
 					Height:           height,
 					IncludeSuspended: false,
@@ -254,6 +274,20 @@ var showCmd = &cobra.Command{
 			case selParameters:
 				showParameters(ctx, npa, height, consensusConn)
 				return
+			case selBlocks:
+				// Only list ParaTime rounds when a ParaTime was explicitly requested.
+				if cmd.Flags().Changed("paratime") && npa.ParaTime != nil {
+					showParaTimeBlocks(ctx, npa, blockCount, conn)
+					return
+				}
+				showConsensusBlocks(ctx, height, blockCount, consensusConn)
+				return
+			case selTx:
+				showConsensusTx(ctx, npa, height, args[1], consensusConn)
+				return
+			case selRuntime:
+				showRuntime(ctx, cfg, npa, height, args[1], consensusConn)
+				return
 
 			default:
 				// Should never happen.
@@ -306,6 +340,12 @@ func selectorFromString(s string) propertySelector {
 		return selCommittees
 	case "parameters":
 		return selParameters
+	case "blocks":
+		return selBlocks
+	case "tx":
+		return selTx
+	case "runtime":
+		return selRuntime
 	}
 	return selInvalid
 }
@@ -335,10 +375,16 @@ func showNativeToken(ctx context.Context, height int64, npa *common.NPASelection
 	fmt.Printf("%-25s %d", "Token's base-10 exponent:", tokenValueExponent)
 	fmt.Println()
 
+	if price, ok := common.TokenPrice(ctx, tokenSymbol); ok {
+		fmt.Printf("%-25s %s (estimate)", "Token price:", common.FormatFiatValue(*quantity.NewFromUint64(1), 0, price, cliConfig.Global().Prices.FiatCurrency()))
+		fmt.Println()
+	}
+
 	totalSupply, err := stakingConn.TotalSupply(ctx, height)
 	cobra.CheckErr(err)
 	fmt.Printf("%-25s ", "Total supply:")
 	token.PrettyPrintAmount(ctx, *totalSupply, os.Stdout)
+	fmt.Print(common.FiatValueSuffix(common.FiatValue(ctx, tokenSymbol, tokenValueExponent, *totalSupply)))
 	fmt.Println()
 
 	commonPool, err := stakingConn.CommonPool(ctx, height)
@@ -427,7 +473,7 @@ func showParameters(ctx context.Context, npa *common.NPASelection, height int64,
 	doc := make(map[string]interface{})
 
 	doSection := func(name string, params interface{}) {
-		if common.OutputFormat() == common.FormatJSON {
+		if common.IsJSONOutput() {
 			doc[name] = params
 		} else {
 			fmt.Printf("=== %s PARAMETERS ===\n", strings.ToUpper(name))
@@ -445,15 +491,20 @@ func showParameters(ctx context.Context, npa *common.NPASelection, height int64,
 	doSection("beacon", beaconParams)
 	doSection("governance", governanceParams)
 
-	if common.OutputFormat() == common.FormatJSON {
-		pp, err := json.MarshalIndent(doc, "", "  ")
+	if common.IsJSONOutput() {
+		pp, err := common.JSONMarshalOutput(doc)
 		cobra.CheckErr(err)
 		fmt.Printf("%s\n", pp)
 	}
 }
 
 func init() {
-	showCmd.Flags().AddFlagSet(common.SelectorNFlags)
+	showCmd.Flags().AddFlagSet(common.SelectorNPFlags)
 	showCmd.Flags().AddFlagSet(common.HeightFlag)
 	showCmd.Flags().AddFlagSet(common.FormatFlag)
+	showCmd.Flags().Uint64Var(&blockCount, "count", 10, "number of recent blocks to show")
+	showCmd.Flags().Uint64Var(&txScanDepth, "scan-depth", 1000, "number of recent blocks to search for the transaction")
+	showCmd.Flags().StringVar(&committeesRuntime, "runtime", "", "show the committee history and election forecast of the given runtime ID or ParaTime name")
+	showCmd.Flags().Uint64Var(&committeesEpochs, "epochs", 1, "number of recent epochs of the committee history")
+	showCmd.Flags().StringVar(&committeesEntity, "entity", "", "entity ID to forecast the election chances for")
 }
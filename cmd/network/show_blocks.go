@@ -0,0 +1,193 @@
+package network
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/connection"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+
+	"github.com/oasisprotocol/cli/cmd/common"
+	"github.com/oasisprotocol/cli/metadata"
+	"github.com/oasisprotocol/cli/table"
+)
+
+// blockTimeFormat is the format used for displaying block timestamps.
+const blockTimeFormat = "2006-01-02 15:04:05"
+
+// cometBFTAddressSize is the size of the CometBFT validator address.
+const cometBFTAddressSize = 20
+
+// cometBFTBlockIDFlagAbsent is the CometBFT block ID flag of a validator that did not vote.
+const cometBFTBlockIDFlagAbsent = 1
+
+// blockMeta is the subset of the CometBFT-specific block metadata needed to determine the proposer
+// and the validators that signed the previous block.
+type blockMeta struct {
+	Header *struct {
+		ProposerAddress []byte `json:"proposer_address"`
+	} `json:"header"`
+	LastCommit *struct {
+		Signatures []struct {
+			BlockIDFlag      uint8  `json:"block_id_flag"`
+			ValidatorAddress []byte `json:"validator_address"`
+		} `json:"signatures"`
+	} `json:"last_commit"`
+}
+
+// cometBFTAddress returns the hex-encoded CometBFT validator address of the given consensus key.
+func cometBFTAddress(pk signature.PublicKey) string {
+	h := sha256.Sum256(pk[:])
+	return hex.EncodeToString(h[:cometBFTAddressSize])
+}
+
+// showConsensusBlocks lists the most recent consensus blocks up to the given height together with
+// the proposer statistics.
+func showConsensusBlocks(ctx context.Context, height int64, count uint64, consensusConn consensus.ClientBackend) {
+	proposers := proposerLookup(ctx, height, consensusConn)
+
+	var (
+		output         [][]string
+		proposedBlocks = make(map[string]uint64)
+	)
+	for h := height; h > 0 && uint64(height-h) < count; h-- {
+		blk, err := consensusConn.GetBlock(ctx, h)
+		cobra.CheckErr(err)
+
+		txs, err := consensusConn.GetTransactions(ctx, h)
+		cobra.CheckErr(err)
+
+		proposer := "unknown"
+		var meta blockMeta
+		if err = cbor.Unmarshal(blk.Meta, &meta); err == nil && meta.Header != nil {
+			addr := hex.EncodeToString(meta.Header.ProposerAddress)
+			proposer = addr
+			if name, ok := proposers[addr]; ok {
+				proposer = name
+			}
+		}
+		proposedBlocks[proposer]++
+
+		output = append(output, []string{
+			strconv.FormatInt(blk.Height, 10),
+			blk.Time.Local().Format(blockTimeFormat),
+			proposer,
+			strconv.Itoa(len(txs)),
+			strconv.FormatUint(blk.Size, 10),
+		})
+	}
+
+	t := table.New()
+	t.SetHeader([]string{"Height", "Time", "Proposer", "Txs", "Size"})
+	t.AppendBulk(output)
+	t.Render()
+
+	fmt.Println()
+	fmt.Println("=== PROPOSER STATISTICS ===")
+	names := make([]string, 0, len(proposedBlocks))
+	for name := range proposedBlocks {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if proposedBlocks[names[i]] == proposedBlocks[names[j]] {
+			return names[i] < names[j]
+		}
+		return proposedBlocks[names[i]] > proposedBlocks[names[j]]
+	})
+	t = table.New()
+	t.SetHeader([]string{"Proposer", "Blocks"})
+	for _, name := range names {
+		t.Append([]string{name, strconv.FormatUint(proposedBlocks[name], 10)})
+	}
+	t.Render()
+}
+
+// proposerLookup returns a map of hex-encoded CometBFT validator addresses to human readable entity
+// names (or entity addresses when no name is known) for the current validator set.
+func proposerLookup(ctx context.Context, height int64, consensusConn consensus.ClientBackend) map[string]string {
+	lookup := make(map[string]string)
+
+	validators, err := consensusConn.Scheduler().GetValidators(ctx, height)
+	if err != nil {
+		return lookup
+	}
+
+	entityNames, err := metadata.EntitiesFromRegistry(ctx)
+	if err != nil {
+		// Non-fatal, fall back to entity addresses.
+		entityNames = nil
+	}
+	accountNames := common.GenAccountNames()
+
+	for _, v := range validators {
+		node, err := consensusConn.Registry().GetNode(ctx, &registry.IDQuery{
+			Height: height,
+			ID:     v.ID,
+		})
+		if err != nil {
+			continue
+		}
+
+		lookup[cometBFTAddress(node.Consensus.ID)] = entityDisplayName(node.EntityID, entityNames, accountNames)
+	}
+	return lookup
+}
+
+// entityDisplayName returns the most descriptive name of the given entity.
+func entityDisplayName(id signature.PublicKey, entityNames map[types.Address]*metadata.Entity, accountNames types.AccountNames) string {
+	addr := types.NewAddressFromConsensusPublicKey(id)
+	if name, ok := accountNames[addr.String()]; ok {
+		return name
+	}
+	if entity, ok := entityNames[addr]; ok && entity.Name != "" {
+		return entity.Name
+	}
+	return addr.String()
+}
+
+// showParaTimeBlocks lists the most recent rounds of the selected ParaTime.
+func showParaTimeBlocks(ctx context.Context, npa *common.NPASelection, count uint64, conn connection.Connection) {
+	rt := conn.Runtime(npa.ParaTime)
+
+	latest, err := rt.GetBlock(ctx, client.RoundLatest)
+	cobra.CheckErr(err)
+
+	var output [][]string
+	for round := latest.Header.Round; latest.Header.Round-round < count; round-- {
+		blk, err := rt.GetBlock(ctx, round)
+		cobra.CheckErr(err)
+
+		txs, err := rt.GetTransactions(ctx, round)
+		cobra.CheckErr(err)
+
+		output = append(output, []string{
+			strconv.FormatUint(blk.Header.Round, 10),
+			time.Unix(int64(blk.Header.Timestamp), 0).Local().Format(blockTimeFormat),
+			strconv.Itoa(len(txs)),
+		})
+
+		if round == 0 {
+			break
+		}
+	}
+
+	fmt.Printf("ParaTime: %s\n", npa.ParaTimeName)
+	fmt.Println()
+
+	t := table.New()
+	t.SetHeader([]string{"Round", "Time", "Txs"})
+	t.AppendBulk(output)
+	t.Render()
+}
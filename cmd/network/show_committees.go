@@ -0,0 +1,319 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	coreCommon "github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/helpers"
+
+	"github.com/oasisprotocol/cli/cmd/common"
+	cliConfig "github.com/oasisprotocol/cli/config"
+	"github.com/oasisprotocol/cli/table"
+)
+
+var (
+	committeesRuntime string
+	committeesEpochs  uint64
+	committeesEntity  string
+)
+
+// committeeMember is a member of an executor committee in a given epoch.
+type committeeMember struct {
+	Epoch    beacon.EpochTime    `json:"epoch"`
+	Height   int64               `json:"height"`
+	EntityID signature.PublicKey `json:"entity_id"`
+	NodeID   signature.PublicKey `json:"node_id"`
+	Role     string              `json:"role"`
+}
+
+// electionForecast is the forecast of the executor committee election in the next epoch.
+type electionForecast struct {
+	Epoch         beacon.EpochTime `json:"epoch"`
+	EligibleNodes int              `json:"eligible_nodes"`
+	WorkerSeats   uint16           `json:"worker_seats"`
+	BackupSeats   uint16           `json:"backup_seats"`
+
+	EntityID            *signature.PublicKey `json:"entity_id,omitempty"`
+	EntityNodes         int                  `json:"entity_nodes,omitempty"`
+	EntityStake         *quantity.Quantity   `json:"entity_stake,omitempty"`
+	EntityRequiredStake *quantity.Quantity   `json:"entity_required_stake,omitempty"`
+	WorkerChance        float64              `json:"worker_chance"`
+	BackupChance        float64              `json:"backup_chance"`
+}
+
+// parseRuntimeID parses the given runtime ID or the name of a ParaTime of the selected network.
+func parseRuntimeID(npa *common.NPASelection, s string) (coreCommon.Namespace, error) {
+	var id coreCommon.Namespace
+	if pt, ok := npa.Network.ParaTimes.All[s]; ok {
+		return pt.Namespace(), nil
+	}
+	if err := id.UnmarshalHex(s); err != nil {
+		return id, fmt.Errorf("malformed runtime ID '%s': %w", s, err)
+	}
+	return id, nil
+}
+
+// electionChance returns the probability that at least one of the given number of nodes is
+// elected when the given number of seats is uniformly filled from the candidate pool.
+func electionChance(pool, nodes int, seats uint16) float64 {
+	if nodes <= 0 || pool <= 0 {
+		return 0
+	}
+	if int(seats) >= pool {
+		return 1
+	}
+	// Probability that none of the nodes is elected: C(pool-nodes, seats) / C(pool, seats).
+	none := 1.0
+	for i := 0; i < int(seats); i++ {
+		none *= float64(pool-nodes-i) / float64(pool-i)
+		if none <= 0 {
+			return 1
+		}
+	}
+	return 1 - none
+}
+
+// showCommitteeHistory shows the executor committees of the given runtime in the past epochs and
+// a forecast of the election in the next epoch.
+func showCommitteeHistory(
+	ctx context.Context,
+	cfg *cliConfig.Config,
+	npa *common.NPASelection,
+	height int64,
+	consensusConn consensus.ClientBackend,
+) {
+	runtimeID, err := parseRuntimeID(npa, committeesRuntime)
+	cobra.CheckErr(err)
+
+	var entityID *signature.PublicKey
+	if committeesEntity != "" {
+		var pk signature.PublicKey
+		if err = pk.UnmarshalText([]byte(committeesEntity)); err != nil {
+			cobra.CheckErr(fmt.Errorf("malformed entity ID '%s': %w", committeesEntity, err))
+		}
+		entityID = &pk
+	}
+	if committeesEpochs == 0 {
+		cobra.CheckErr("number of epochs must be at least 1")
+	}
+
+	runtime, err := consensusConn.Registry().GetRuntime(ctx, &registry.GetRuntimeQuery{
+		Height: height,
+		ID:     runtimeID,
+	})
+	cobra.CheckErr(err)
+
+	epoch, err := consensusConn.Beacon().GetEpoch(ctx, height)
+	cobra.CheckErr(err)
+
+	// Gather committee compositions of past epochs. Node registrations may have expired since, so
+	// entities are looked up at the height of each epoch.
+	firstEpoch := beacon.EpochTime(0)
+	if uint64(epoch) >= committeesEpochs {
+		firstEpoch = epoch + 1 - beacon.EpochTime(committeesEpochs)
+	}
+	var members []*committeeMember
+	for e := epoch; e >= firstEpoch; e-- {
+		epochHeight, err := consensusConn.Beacon().GetEpochBlock(ctx, e)
+		cobra.CheckErr(err)
+
+		committees, err := consensusConn.Scheduler().GetCommittees(ctx, &scheduler.GetCommitteesRequest{
+			Height:    epochHeight,
+			RuntimeID: runtimeID,
+		})
+		cobra.CheckErr(err)
+
+		for _, committee := range committees {
+			if committee.Kind != scheduler.KindComputeExecutor {
+				continue
+			}
+			for _, member := range committee.Members {
+				n, err := consensusConn.Registry().GetNode(ctx, &registry.IDQuery{
+					Height: epochHeight,
+					ID:     member.PublicKey,
+				})
+				cobra.CheckErr(err)
+
+				members = append(members, &committeeMember{
+					Epoch:    e,
+					Height:   epochHeight,
+					EntityID: n.EntityID,
+					NodeID:   member.PublicKey,
+					Role:     member.Role.String(),
+				})
+			}
+		}
+		if e == 0 {
+			break
+		}
+	}
+
+	forecast := forecastElection(ctx, height, epoch, runtime, entityID, consensusConn)
+
+	if common.IsJSONOutput() {
+		data, err := common.JSONMarshalOutput(map[string]interface{}{
+			"runtime_id": runtimeID,
+			"committees": members,
+			"forecast":   forecast,
+		})
+		cobra.CheckErr(err)
+		fmt.Printf("%s\n", data)
+		return
+	}
+
+	fmt.Println("=== COMMITTEE HISTORY ===")
+	fmt.Printf("Paratime: %s(%s)\n", getParatimeName(cfg, runtimeID.String()), runtimeID)
+	fmt.Printf("Epochs:   %d-%d\n", firstEpoch, epoch)
+	fmt.Println()
+
+	t := table.New()
+	t.SetHeader([]string{"Epoch", "Height", "Entity ID", "Node ID", "Role"})
+	for _, m := range members {
+		t.Append([]string{
+			strconv.FormatUint(uint64(m.Epoch), 10),
+			strconv.FormatInt(m.Height, 10),
+			m.EntityID.String(),
+			m.NodeID.String(),
+			m.Role,
+		})
+	}
+	t.Render()
+	fmt.Println()
+
+	fmt.Printf("=== ELECTION FORECAST (epoch %d) ===\n", forecast.Epoch)
+	fmt.Printf("Eligible nodes: %d\n", forecast.EligibleNodes)
+	fmt.Printf("Worker seats:   %d\n", forecast.WorkerSeats)
+	fmt.Printf("Backup seats:   %d\n", forecast.BackupSeats)
+	if forecast.EntityID == nil {
+		fmt.Printf("Chance of a single eligible node to be elected:\n")
+	} else {
+		fmt.Printf("Entity:         %s\n", forecast.EntityID)
+		fmt.Printf("Entity nodes:   %d eligible\n", forecast.EntityNodes)
+		fmt.Printf("Entity stake:   %s (required: %s)\n",
+			helpers.FormatConsensusDenomination(npa.Network, *forecast.EntityStake),
+			helpers.FormatConsensusDenomination(npa.Network, *forecast.EntityRequiredStake),
+		)
+		fmt.Printf("Chance of the entity to be elected:\n")
+	}
+	fmt.Printf("  Worker: %.1f%%\n", 100*forecast.WorkerChance)
+	fmt.Printf("  Backup: %.1f%%\n", 100*forecast.BackupChance)
+	fmt.Println()
+	fmt.Println("The forecast assumes a uniform election among eligible nodes with the current")
+	fmt.Println("registrations and stake, and ignores node liveness and freezing.")
+}
+
+// forecastElection computes the chances of being elected into the executor committee of the
+// given runtime in the next epoch based on current registrations, stake and scheduling parameters.
+func forecastElection(
+	ctx context.Context,
+	height int64,
+	epoch beacon.EpochTime,
+	runtime *registry.Runtime,
+	entityID *signature.PublicKey,
+	consensusConn consensus.ClientBackend,
+) *electionForecast {
+	nodes, err := consensusConn.Registry().GetNodes(ctx, height)
+	cobra.CheckErr(err)
+
+	// Per-entity limit on the number of eligible nodes.
+	var maxNodes uint16
+	if c, ok := runtime.Constraints[scheduler.KindComputeExecutor][scheduler.RoleWorker]; ok && c.MaxNodes != nil {
+		maxNodes = c.MaxNodes.Limit
+	}
+
+	perEntity := make(map[signature.PublicKey]int)
+	for _, n := range nodes {
+		if n.IsExpired(uint64(epoch)) || !n.HasRoles(node.RoleComputeWorker) {
+			continue
+		}
+		var registered bool
+		for _, rt := range n.Runtimes {
+			if rt.ID.Equal(&runtime.ID) {
+				registered = true
+				break
+			}
+		}
+		if !registered {
+			continue
+		}
+		if maxNodes > 0 && perEntity[n.EntityID] >= int(maxNodes) {
+			continue
+		}
+		perEntity[n.EntityID]++
+	}
+
+	forecast := &electionForecast{
+		Epoch:       epoch + 1,
+		WorkerSeats: runtime.Executor.GroupSize,
+		BackupSeats: runtime.Executor.GroupBackupSize,
+	}
+	for _, count := range perEntity {
+		forecast.EligibleNodes += count
+	}
+
+	candidates := 1
+	if entityID != nil {
+		forecast.EntityID = entityID
+		forecast.EntityNodes = perEntity[*entityID]
+		candidates = forecast.EntityNodes
+
+		// Nodes of entities without sufficient stake are not eligible.
+		stake, required := entityStake(ctx, height, runtime, *entityID, forecast.EntityNodes, consensusConn)
+		forecast.EntityStake = stake
+		forecast.EntityRequiredStake = required
+		if stake.Cmp(required) < 0 {
+			candidates = 0
+		}
+	}
+	forecast.WorkerChance = electionChance(forecast.EligibleNodes, candidates, forecast.WorkerSeats)
+	forecast.BackupChance = electionChance(forecast.EligibleNodes, candidates, forecast.BackupSeats)
+	return forecast
+}
+
+// entityStake returns the active escrow of the given entity and the stake required for the entity
+// to have the given number of compute nodes registered for the runtime.
+func entityStake(
+	ctx context.Context,
+	height int64,
+	runtime *registry.Runtime,
+	entityID signature.PublicKey,
+	nodes int,
+	consensusConn consensus.ClientBackend,
+) (*quantity.Quantity, *quantity.Quantity) {
+	account, err := consensusConn.Staking().Account(ctx, &staking.OwnerQuery{
+		Height: height,
+		Owner:  staking.NewAddress(entityID),
+	})
+	cobra.CheckErr(err)
+
+	threshold := func(kind staking.ThresholdKind) *quantity.Quantity {
+		q, err := consensusConn.Staking().Threshold(ctx, &staking.ThresholdQuery{
+			Height: height,
+			Kind:   kind,
+		})
+		cobra.CheckErr(err)
+		return q
+	}
+
+	required := threshold(staking.KindEntity).Clone()
+	perNode := threshold(staking.KindNodeCompute).Clone()
+	if q, ok := runtime.Staking.Thresholds[staking.KindNodeCompute]; ok {
+		cobra.CheckErr(perNode.Add(&q))
+	}
+	cobra.CheckErr(perNode.Mul(quantity.NewFromUint64(uint64(nodes))))
+	cobra.CheckErr(required.Add(perNode))
+
+	return &account.Escrow.Active.Balance, required
+}
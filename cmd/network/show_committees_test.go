@@ -0,0 +1,18 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestElectionChance(t *testing.T) {
+	require := require.New(t)
+
+	require.Zero(electionChance(10, 0, 3))
+	require.Zero(electionChance(0, 1, 3))
+	require.Equal(1.0, electionChance(3, 1, 3))
+	require.Equal(1.0, electionChance(10, 8, 3))
+	require.InDelta(0.3, electionChance(10, 1, 3), 1e-9)
+	require.InDelta(1-(8.0/10)*(7.0/9)*(6.0/8), electionChance(10, 2, 3), 1e-9)
+}
@@ -0,0 +1,332 @@
+package network
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/helpers"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+
+	"github.com/oasisprotocol/cli/cmd/common"
+	cliConfig "github.com/oasisprotocol/cli/config"
+	"github.com/oasisprotocol/cli/metadata"
+	"github.com/oasisprotocol/cli/table"
+)
+
+// runtimeDeployment is a deployment of a runtime together with its validity.
+type runtimeDeployment struct {
+	Version        string                `json:"version"`
+	ValidFrom      beacon.EpochTime      `json:"valid_from"`
+	ValidUntil     *beacon.EpochTime     `json:"valid_until,omitempty"`
+	Active         bool                  `json:"active"`
+	Enclaves       []sgx.EnclaveIdentity `json:"enclaves,omitempty"`
+	BundleChecksum string                `json:"bundle_checksum,omitempty"`
+}
+
+// runtimeEntity is an entity referenced by a runtime descriptor.
+type runtimeEntity struct {
+	ID       signature.PublicKey `json:"id"`
+	Name     string              `json:"name,omitempty"`
+	MaxNodes map[string]uint16   `json:"max_nodes,omitempty"`
+}
+
+// runtimeThreshold is a staking threshold of the nodes hosting a runtime.
+type runtimeThreshold struct {
+	Global  quantity.Quantity `json:"global"`
+	Runtime quantity.Quantity `json:"runtime"`
+	Total   quantity.Quantity `json:"total"`
+}
+
+// runtimeNode is a registered node hosting a runtime.
+type runtimeNode struct {
+	ID         signature.PublicKey `json:"id"`
+	EntityID   signature.PublicKey `json:"entity_id"`
+	EntityName string              `json:"entity_name,omitempty"`
+	Roles      string              `json:"roles"`
+	Version    string              `json:"version"`
+}
+
+// runtimeInfo is the information derived from a runtime descriptor and the registry.
+type runtimeInfo struct {
+	ID               string                       `json:"id"`
+	Name             string                       `json:"name"`
+	Kind             string                       `json:"kind"`
+	Owner            runtimeEntity                `json:"owner"`
+	GovernanceModel  string                       `json:"governance_model"`
+	TEEHardware      string                       `json:"tee_hardware"`
+	KeyManager       string                       `json:"key_manager,omitempty"`
+	Height           int64                        `json:"height"`
+	Epoch            beacon.EpochTime             `json:"epoch"`
+	Deployments      []runtimeDeployment          `json:"deployments"`
+	AdmissionPolicy  string                       `json:"admission_policy"`
+	AdmittedEntities []runtimeEntity              `json:"admitted_entities,omitempty"`
+	Thresholds       map[string]*runtimeThreshold `json:"thresholds"`
+	ExecutorSize     uint16                       `json:"executor_group_size"`
+	BackupSize       uint16                       `json:"executor_backup_size"`
+	Nodes            []runtimeNode                `json:"nodes"`
+}
+
+// showRuntime shows the runtime with the given ID or ParaTime name together with the information
+// derived from its descriptor and the registered nodes.
+func showRuntime(
+	ctx context.Context,
+	cfg *cliConfig.Config,
+	npa *common.NPASelection,
+	height int64,
+	rawID string,
+	consensusConn consensus.ClientBackend,
+) {
+	runtimeID, err := parseRuntimeID(npa, rawID)
+	cobra.CheckErr(err)
+
+	runtime, err := consensusConn.Registry().GetRuntime(ctx, &registry.GetRuntimeQuery{
+		Height:           height,
+		ID:               runtimeID,
+		IncludeSuspended: true,
+	})
+	cobra.CheckErr(err)
+
+	epoch, err := consensusConn.Beacon().GetEpoch(ctx, height)
+	cobra.CheckErr(err)
+
+	nodes, err := consensusConn.Registry().GetNodes(ctx, height)
+	cobra.CheckErr(err)
+
+	// Entity names are only a convenience, ignore failures.
+	entityNames, _ := metadata.EntitiesFromRegistry(ctx)
+	entityName := func(id signature.PublicKey) string {
+		if entity, ok := entityNames[types.NewAddressFromConsensusPublicKey(id)]; ok {
+			return entity.Name
+		}
+		return ""
+	}
+
+	info := runtimeInfo{
+		ID:              runtime.ID.String(),
+		Name:            getParatimeName(cfg, runtime.ID.String()),
+		Kind:            runtime.Kind.String(),
+		Owner:           runtimeEntity{ID: runtime.EntityID, Name: entityName(runtime.EntityID)},
+		GovernanceModel: runtime.GovernanceModel.String(),
+		TEEHardware:     runtime.TEEHardware.String(),
+		Height:          height,
+		Epoch:           epoch,
+		Deployments:     runtimeDeployments(runtime, epoch),
+		Thresholds:      make(map[string]*runtimeThreshold),
+		ExecutorSize:    runtime.Executor.GroupSize,
+		BackupSize:      runtime.Executor.GroupBackupSize,
+		Nodes:           []runtimeNode{},
+	}
+	if runtime.KeyManager != nil {
+		info.KeyManager = runtime.KeyManager.String()
+	}
+
+	// Admission policy.
+	switch {
+	case runtime.AdmissionPolicy.AnyNode != nil:
+		info.AdmissionPolicy = "any node"
+	case runtime.AdmissionPolicy.EntityWhitelist != nil:
+		info.AdmissionPolicy = "entity whitelist"
+		for id, wc := range runtime.AdmissionPolicy.EntityWhitelist.Entities {
+			e := runtimeEntity{ID: id, Name: entityName(id)}
+			if len(wc.MaxNodes) > 0 {
+				e.MaxNodes = make(map[string]uint16)
+				for role, limit := range wc.MaxNodes {
+					e.MaxNodes[role.String()] = limit
+				}
+			}
+			info.AdmittedEntities = append(info.AdmittedEntities, e)
+		}
+		sort.Slice(info.AdmittedEntities, func(i, j int) bool {
+			return info.AdmittedEntities[i].ID.String() < info.AdmittedEntities[j].ID.String()
+		})
+	}
+
+	// Staking thresholds of the nodes hosting the runtime.
+	for _, kind := range []staking.ThresholdKind{staking.KindNodeCompute, staking.KindNodeKeyManager, staking.KindNodeObserver} {
+		global, err := consensusConn.Staking().Threshold(ctx, &staking.ThresholdQuery{Height: height, Kind: kind})
+		cobra.CheckErr(err)
+
+		t := runtimeThreshold{Global: *global.Clone(), Total: *global.Clone()}
+		if q, ok := runtime.Staking.Thresholds[kind]; ok {
+			t.Runtime = q
+			cobra.CheckErr(t.Total.Add(&q))
+		}
+		info.Thresholds[kind.String()] = &t
+	}
+
+	// Nodes currently hosting the runtime.
+	for _, n := range nodes {
+		if n.IsExpired(uint64(epoch)) {
+			continue
+		}
+		for _, rt := range n.Runtimes {
+			if !rt.ID.Equal(&runtime.ID) {
+				continue
+			}
+			info.Nodes = append(info.Nodes, runtimeNode{
+				ID:         n.ID,
+				EntityID:   n.EntityID,
+				EntityName: entityName(n.EntityID),
+				Roles:      n.Roles.String(),
+				Version:    rt.Version.String(),
+			})
+		}
+	}
+	sort.Slice(info.Nodes, func(i, j int) bool {
+		if info.Nodes[i].EntityID.String() != info.Nodes[j].EntityID.String() {
+			return info.Nodes[i].EntityID.String() < info.Nodes[j].EntityID.String()
+		}
+		return info.Nodes[i].ID.String() < info.Nodes[j].ID.String()
+	})
+
+	if common.IsJSONOutput() {
+		data, err := common.JSONMarshalOutput(map[string]interface{}{
+			"descriptor": runtime,
+			"info":       info,
+		})
+		cobra.CheckErr(err)
+		fmt.Printf("%s\n", data)
+		return
+	}
+	printRuntimeInfo(npa, &info)
+}
+
+// runtimeDeployments returns the deployments of the given runtime with their validity periods.
+func runtimeDeployments(runtime *registry.Runtime, epoch beacon.EpochTime) []runtimeDeployment {
+	deployments := make([]runtimeDeployment, 0, len(runtime.Deployments))
+	active := -1
+	for i, d := range runtime.Deployments {
+		rd := runtimeDeployment{
+			Version:   d.Version.String(),
+			ValidFrom: d.ValidFrom,
+		}
+		if i+1 < len(runtime.Deployments) {
+			validUntil := runtime.Deployments[i+1].ValidFrom
+			rd.ValidUntil = &validUntil
+		}
+		if d.ValidFrom <= epoch {
+			active = i
+		}
+		if len(d.BundleChecksum) > 0 {
+			rd.BundleChecksum = hex.EncodeToString(d.BundleChecksum)
+		}
+		if runtime.TEEHardware == node.TEEHardwareIntelSGX && len(d.TEE) > 0 {
+			var sc node.SGXConstraints
+			if err := cbor.Unmarshal(d.TEE, &sc); err == nil {
+				rd.Enclaves = sc.Enclaves
+			}
+		}
+		deployments = append(deployments, rd)
+	}
+	if active >= 0 {
+		deployments[active].Active = true
+	}
+	return deployments
+}
+
+// printRuntimeInfo prints the runtime information in human-readable form.
+func printRuntimeInfo(npa *common.NPASelection, info *runtimeInfo) {
+	withName := func(id fmt.Stringer, name string) string {
+		if name == "" {
+			return id.String()
+		}
+		return fmt.Sprintf("%s (%s)", id, name)
+	}
+
+	fmt.Printf("ID:               %s\n", info.ID)
+	fmt.Printf("Name:             %s\n", info.Name)
+	fmt.Printf("Kind:             %s\n", info.Kind)
+	fmt.Printf("Owner:            %s\n", withName(info.Owner.ID, info.Owner.Name))
+	fmt.Printf("Governance model: %s\n", info.GovernanceModel)
+	fmt.Printf("TEE hardware:     %s\n", info.TEEHardware)
+	if info.KeyManager != "" {
+		fmt.Printf("Key manager:      %s\n", info.KeyManager)
+	}
+	fmt.Printf("Executor group:   %d workers, %d backups\n", info.ExecutorSize, info.BackupSize)
+	fmt.Printf("Current epoch:    %d (height %d)\n", info.Epoch, info.Height)
+	fmt.Println()
+
+	fmt.Println("=== DEPLOYMENTS ===")
+	for _, d := range info.Deployments {
+		validity := fmt.Sprintf("from epoch %d", d.ValidFrom)
+		if d.ValidUntil != nil {
+			validity += fmt.Sprintf(" until epoch %d", *d.ValidUntil)
+		}
+		status := ""
+		switch {
+		case d.Active:
+			status = " [active]"
+		case d.ValidFrom > info.Epoch:
+			status = " [scheduled]"
+		}
+		fmt.Printf("- Version %s, valid %s%s\n", d.Version, validity, status)
+		for _, e := range d.Enclaves {
+			fmt.Printf("    Enclave: %s\n", e)
+		}
+		if d.BundleChecksum != "" {
+			fmt.Printf("    Bundle checksum: %s\n", d.BundleChecksum)
+		}
+	}
+	fmt.Println()
+
+	fmt.Println("=== ADMISSION POLICY ===")
+	fmt.Printf("Policy: %s\n", info.AdmissionPolicy)
+	for _, e := range info.AdmittedEntities {
+		fmt.Printf("- %s\n", withName(e.ID, e.Name))
+		roles := make([]string, 0, len(e.MaxNodes))
+		for role := range e.MaxNodes {
+			roles = append(roles, role)
+		}
+		sort.Strings(roles)
+		for _, role := range roles {
+			fmt.Printf("    Max %s nodes: %d\n", role, e.MaxNodes[role])
+		}
+	}
+	fmt.Println()
+
+	fmt.Println("=== NODE STAKING THRESHOLDS ===")
+	kinds := make([]string, 0, len(info.Thresholds))
+	for kind := range info.Thresholds {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	for _, kind := range kinds {
+		t := info.Thresholds[kind]
+		fmt.Printf("  %-19s %s", kind+":", helpers.FormatConsensusDenomination(npa.Network, t.Total))
+		if !t.Runtime.IsZero() {
+			fmt.Printf(" (global %s + runtime %s)",
+				helpers.FormatConsensusDenomination(npa.Network, t.Global),
+				helpers.FormatConsensusDenomination(npa.Network, t.Runtime),
+			)
+		}
+		fmt.Println()
+	}
+	fmt.Println()
+
+	fmt.Printf("=== NODES (%d) ===\n", len(info.Nodes))
+	t := table.New()
+	t.SetHeader([]string{"Entity", "Node ID", "Roles", "Version"})
+	for _, n := range info.Nodes {
+		t.Append([]string{
+			withName(n.EntityID, n.EntityName),
+			n.ID.String(),
+			strings.ReplaceAll(n.Roles, ",", ", "),
+			n.Version,
+		})
+	}
+	t.Render()
+}
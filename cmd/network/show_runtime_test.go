@@ -0,0 +1,38 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+)
+
+func TestRuntimeDeployments(t *testing.T) {
+	require := require.New(t)
+
+	runtime := &registry.Runtime{
+		Deployments: []*registry.VersionInfo{
+			{Version: version.Version{Major: 1}, ValidFrom: 10},
+			{Version: version.Version{Major: 2}, ValidFrom: 20},
+			{Version: version.Version{Major: 3}, ValidFrom: 30},
+		},
+	}
+
+	deployments := runtimeDeployments(runtime, 25)
+	require.Len(deployments, 3)
+	require.Equal("1.0.0", deployments[0].Version)
+	require.False(deployments[0].Active)
+	require.EqualValues(20, *deployments[0].ValidUntil)
+	require.True(deployments[1].Active)
+	require.EqualValues(30, *deployments[1].ValidUntil)
+	require.False(deployments[2].Active)
+	require.Nil(deployments[2].ValidUntil)
+
+	// No deployment is active before the first one becomes valid.
+	deployments = runtimeDeployments(runtime, 5)
+	for _, d := range deployments {
+		require.False(d.Active)
+	}
+}
@@ -0,0 +1,120 @@
+package network
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction/results"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+
+	"github.com/oasisprotocol/cli/cmd/common"
+)
+
+// txScanDepth is the maximum number of consensus blocks scanned when looking up a transaction.
+var txScanDepth uint64
+
+// consensusTxLookup is a consensus transaction found by its hash.
+type consensusTxLookup struct {
+	Hash        hash.Hash                      `json:"hash"`
+	Height      int64                          `json:"height"`
+	Index       int                            `json:"index"`
+	Signer      staking.Address                `json:"signer"`
+	Transaction *transaction.Transaction       `json:"transaction"`
+	Signed      *transaction.SignedTransaction `json:"-"`
+	Result      *results.Result                `json:"result"`
+}
+
+// findConsensusTx scans the consensus blocks backwards starting at the given height for the
+// transaction with the given hash.
+func findConsensusTx(ctx context.Context, height int64, depth uint64, txHash hash.Hash, consensusConn consensus.ClientBackend) (*consensusTxLookup, error) {
+	for h := height; h > 0 && uint64(height-h) < depth; h-- {
+		txs, err := consensusConn.GetTransactionsWithResults(ctx, h)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch transactions at height %d: %w", h, err)
+		}
+
+		for i, rawTx := range txs.Transactions {
+			if rawHash := hash.NewFromBytes(rawTx); !rawHash.Equal(&txHash) {
+				continue
+			}
+
+			var sigTx transaction.SignedTransaction
+			if err = cbor.Unmarshal(rawTx, &sigTx); err != nil {
+				return nil, fmt.Errorf("malformed transaction: %w", err)
+			}
+			var tx transaction.Transaction
+			if err = cbor.Unmarshal(sigTx.Blob, &tx); err != nil {
+				return nil, fmt.Errorf("malformed transaction body: %w", err)
+			}
+
+			return &consensusTxLookup{
+				Hash:        txHash,
+				Height:      h,
+				Index:       i,
+				Signer:      staking.NewAddress(sigTx.Signature.PublicKey),
+				Transaction: &tx,
+				Signed:      &sigTx,
+				Result:      txs.Results[i],
+			}, nil
+		}
+	}
+	return nil, fmt.Errorf("transaction %s not found in the last %d blocks, use --scan-depth to search further", txHash, depth)
+}
+
+// showConsensusTx looks up the consensus transaction with the given hash and prints it together
+// with its result and emitted events.
+func showConsensusTx(ctx context.Context, npa *common.NPASelection, height int64, rawHash string, consensusConn consensus.ClientBackend) {
+	var txHash hash.Hash
+	if err := txHash.UnmarshalHex(rawHash); err != nil {
+		cobra.CheckErr(fmt.Errorf("malformed tx hash: %w", err))
+	}
+
+	tx, err := findConsensusTx(ctx, height, txScanDepth, txHash, consensusConn)
+	cobra.CheckErr(err)
+
+	if common.IsJSONOutput() {
+		data, err := common.JSONMarshalOutput(tx)
+		cobra.CheckErr(err)
+		fmt.Printf("%s\n", data)
+		return
+	}
+
+	fmt.Printf("Height:   %d\n", tx.Height)
+	fmt.Printf("Index:    %d\n", tx.Index)
+	fmt.Printf("Signer:   %s\n", tx.Signer)
+	fmt.Printf("Method:   %s\n", tx.Transaction.Method)
+	fmt.Println()
+
+	fmt.Println("=== Transaction ===")
+	common.PrintTransactionRaw(npa, tx.Signed)
+	fmt.Println()
+
+	fmt.Println("=== Result ===")
+	switch res := tx.Result; res.IsSuccess() {
+	case true:
+		fmt.Printf("Status:   ok\n")
+	case false:
+		fmt.Printf("Status:   failed\n")
+		fmt.Printf("Module:   %s\n", res.Error.Module)
+		fmt.Printf("Code:     %d\n", res.Error.Code)
+		fmt.Printf("Message:  %s\n", res.Error.Message)
+	}
+	fmt.Printf("Gas used: %d\n", tx.Result.GasUsed)
+	fmt.Println()
+
+	fmt.Println("=== Events ===")
+	if len(tx.Result.Events) == 0 {
+		fmt.Println("No events emitted by this transaction.")
+		return
+	}
+	for evIndex, ev := range tx.Result.Events {
+		fmt.Printf("--- Event %d ---\n", evIndex)
+		fmt.Print(common.PrettyPrint(npa, "  ", ev))
+	}
+}
@@ -11,8 +11,6 @@ import (
 
 	coreCommon "github.com/oasisprotocol/oasis-core/go/common"
 
-	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/connection"
-
 	"github.com/oasisprotocol/cli/cmd/common"
 	cliConfig "github.com/oasisprotocol/cli/config"
 )
@@ -38,7 +36,7 @@ var statusCmd = &cobra.Command{
 
 		// Establish connection with the target network.
 		ctx := context.Background()
-		conn, err := connection.Connect(ctx, npa.Network)
+		conn, err := common.Connect(ctx, npa.Network)
 		cobra.CheckErr(err)
 
 		ctrlConn := conn.Control()
@@ -46,9 +44,9 @@ var statusCmd = &cobra.Command{
 		nodeStatus, err := ctrlConn.GetStatus(ctx)
 		cobra.CheckErr(err)
 
-		switch common.OutputFormat() {
-		case common.FormatJSON:
-			nodeStr, err := common.PrettyJSONMarshal(nodeStatus)
+		switch common.IsJSONOutput() {
+		case true:
+			nodeStr, err := common.JSONMarshalOutput(nodeStatus)
 			cobra.CheckErr(err)
 
 			fmt.Println(string(nodeStr))
@@ -0,0 +1,121 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/oasisprotocol/cli/cmd/common"
+	cliConfig "github.com/oasisprotocol/cli/config"
+	"github.com/oasisprotocol/cli/update"
+	"github.com/oasisprotocol/cli/version"
+)
+
+var (
+	syncRegistry string
+
+	syncCmd = &cobra.Command{
+		Use:   "sync",
+		Short: "Update networks and ParaTimes from the network registry",
+		Long: `Fetch the registry of canonical network and ParaTime definitions maintained by
+the Oasis team, verify its signature and offer to apply each difference to the
+local configuration. New networks and ParaTimes are added, while existing ones
+are updated, e.g. with the chain context after a network upgrade. Networks and
+ParaTimes which are not part of the registry are left untouched.`,
+		Args: cobra.NoArgs,
+		Run: func(_ *cobra.Command, _ []string) {
+			cfg := cliConfig.Global()
+
+			data, err := loadRegistryFile(context.Background(), syncRegistry)
+			cobra.CheckErr(err)
+			signature, err := loadRegistryFile(context.Background(), syncRegistry+".sig")
+			cobra.CheckErr(err)
+			if err = update.VerifySignature(version.ReleaseSigningKey, update.RegistrySignatureContext, data, signature); err != nil {
+				cobra.CheckErr(fmt.Errorf("failed to verify the network registry: %w", err))
+			}
+			registry, err := cliConfig.ParseRegistry(data)
+			cobra.CheckErr(err)
+
+			changes := cfg.DiffRegistry(registry)
+			if len(changes) == 0 {
+				fmt.Println("The network configuration is up to date.")
+				return
+			}
+
+			var applied int
+			for _, c := range changes {
+				fmt.Println()
+				printRegistryChange(c)
+				if !confirmRegistryChange() {
+					continue
+				}
+				cobra.CheckErr(c.Apply(cfg))
+				applied++
+			}
+			if applied == 0 {
+				fmt.Println("\nNo changes applied.")
+				return
+			}
+
+			cobra.CheckErr(cfg.Validate())
+			cobra.CheckErr(cfg.Save())
+			fmt.Printf("\nApplied %d of %d changes.\n", applied, len(changes))
+		},
+	}
+)
+
+// loadRegistryFile loads the given network registry file from a local path or URL.
+func loadRegistryFile(ctx context.Context, fn string) ([]byte, error) {
+	if strings.HasPrefix(fn, "https://") || strings.HasPrefix(fn, "http://") {
+		return update.Download(ctx, fn)
+	}
+	return os.ReadFile(fn)
+}
+
+// printRegistryChange prints the given change proposed by the network registry.
+func printRegistryChange(c *cliConfig.RegistryChange) {
+	what := fmt.Sprintf("network '%s'", c.Network)
+	if c.ParaTime != "" {
+		what = fmt.Sprintf("ParaTime '%s' of network '%s'", c.ParaTime, c.Network)
+	}
+	if c.New {
+		fmt.Printf("Add %s.\n", what)
+		return
+	}
+
+	fmt.Printf("Update %s:\n", what)
+	for _, f := range c.Fields {
+		old := f.Old
+		if old == "" {
+			old = "(none)"
+		}
+		fmt.Printf("  %s: %s -> %s\n", f.Name, old, f.New)
+	}
+}
+
+// confirmRegistryChange asks the user whether to apply the change.
+func confirmRegistryChange() bool {
+	if common.GetAnswerYes() {
+		fmt.Println("? Apply? Yes")
+		return true
+	}
+	common.CheckInteractive()
+
+	proceed := true
+	err := survey.AskOne(&survey.Confirm{Message: "Apply?", Default: true}, &proceed)
+	cobra.CheckErr(err)
+	return proceed
+}
+
+func init() {
+	syncFlags := flag.NewFlagSet("", flag.ContinueOnError)
+	syncFlags.StringVar(&syncRegistry, "registry", cliConfig.DefaultRegistryURL, "network registry file or URL")
+
+	syncCmd.Flags().AddFlagSet(syncFlags)
+	syncCmd.Flags().AddFlagSet(common.AnswerYesFlag)
+}
@@ -0,0 +1,299 @@
+package network
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+
+	"github.com/oasisprotocol/cli/cmd/common"
+	cliConfig "github.com/oasisprotocol/cli/config"
+	"github.com/oasisprotocol/cli/metadata"
+)
+
+// validatorSampleSize is the number of recent blocks examined for validator signatures.
+var validatorSampleSize uint64
+
+// validatorNodeStatus is the status of a single validator node of an entity.
+type validatorNodeStatus struct {
+	ID          signature.PublicKey `json:"id"`
+	VotingPower int64               `json:"voting_power"`
+}
+
+// pendingProposal is an active governance proposal the entity has not voted on yet.
+type pendingProposal struct {
+	ID       uint64           `json:"id"`
+	ClosesAt beacon.EpochTime `json:"closes_at"`
+}
+
+// validatorStatus is the operational status of a validator entity.
+type validatorStatus struct {
+	Entity             staking.Address            `json:"entity"`
+	Name               string                     `json:"name,omitempty"`
+	Height             int64                      `json:"height"`
+	Epoch              beacon.EpochTime           `json:"epoch"`
+	Nodes              []validatorNodeStatus      `json:"nodes"`
+	VotingPower        int64                      `json:"voting_power"`
+	TotalVotingPower   int64                      `json:"total_voting_power"`
+	Rank               int                        `json:"rank,omitempty"`
+	Validators         int                        `json:"validators"`
+	SampledBlocks      uint64                     `json:"sampled_blocks"`
+	SignedBlocks       uint64                     `json:"signed_blocks"`
+	MissedBlocks       uint64                     `json:"missed_blocks"`
+	CommissionRate     *quantity.Quantity         `json:"commission_rate,omitempty"`
+	CommissionSchedule staking.CommissionSchedule `json:"commission_schedule"`
+	PendingVotes       []pendingProposal          `json:"pending_votes"`
+}
+
+var (
+	validatorCmd = &cobra.Command{
+		Use:   "validator",
+		Short: "Validator operations",
+	}
+
+	validatorStatusCmd = &cobra.Command{
+		Use:   "status <entity-or-address>",
+		Short: "Show the operational status of a validator",
+		Long:  "Show validator set membership, voting power rank, recently signed blocks, commission schedule and pending governance votes of a validator entity.",
+		Args:  cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+			npa := common.GetNPASelection(cfg)
+
+			entityAddr, err := parseEntityAddress(npa, args[0])
+			cobra.CheckErr(err)
+
+			// Establish connection with the target network.
+			ctx := context.Background()
+			conn, err := common.Connect(ctx, npa.Network)
+			cobra.CheckErr(err)
+
+			consensusConn := conn.Consensus()
+			height, err := common.GetActualHeight(ctx, consensusConn)
+			cobra.CheckErr(err)
+
+			status, err := getValidatorStatus(ctx, consensusConn, height, entityAddr)
+			cobra.CheckErr(err)
+
+			if common.IsJSONOutput() {
+				data, err := common.JSONMarshalOutput(status)
+				cobra.CheckErr(err)
+				fmt.Printf("%s\n", data)
+				return
+			}
+			printValidatorStatus(npa, status)
+		},
+	}
+)
+
+// parseEntityAddress parses an entity public key, an address or the name of an account or
+// address book entry into the entity address.
+func parseEntityAddress(npa *common.NPASelection, s string) (staking.Address, error) {
+	var pk signature.PublicKey
+	if err := pk.UnmarshalText([]byte(s)); err == nil {
+		return staking.NewAddress(pk), nil
+	}
+
+	addr, _, err := common.ResolveLocalAccountOrAddress(npa.Network, s)
+	if err != nil {
+		return staking.Address{}, fmt.Errorf("malformed entity ID or address '%s': %w", s, err)
+	}
+	return staking.Address(*addr), nil
+}
+
+// getValidatorStatus gathers the operational status of the given validator entity.
+func getValidatorStatus(ctx context.Context, consensusConn consensus.ClientBackend, height int64, entityAddr staking.Address) (*validatorStatus, error) {
+	status := validatorStatus{
+		Entity:       entityAddr,
+		Height:       height,
+		PendingVotes: []pendingProposal{},
+	}
+
+	epoch, err := consensusConn.Beacon().GetEpoch(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	status.Epoch = epoch
+
+	if entityNames, err := metadata.EntitiesFromRegistry(ctx); err == nil {
+		if entity, ok := entityNames[types.Address(entityAddr)]; ok {
+			status.Name = entity.Name
+		}
+	}
+
+	// Determine the validator set membership and the voting power rank.
+	validators, err := consensusConn.Scheduler().GetValidators(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch validators: %w", err)
+	}
+	entityPower := make(map[staking.Address]int64)
+	cometAddrs := make(map[string]struct{})
+	for _, v := range validators {
+		addr := staking.NewAddress(v.EntityID)
+		entityPower[addr] += v.VotingPower
+		status.TotalVotingPower += v.VotingPower
+		if !addr.Equal(entityAddr) {
+			continue
+		}
+
+		status.Nodes = append(status.Nodes, validatorNodeStatus{ID: v.ID, VotingPower: v.VotingPower})
+		node, err := consensusConn.Registry().GetNode(ctx, &registry.IDQuery{Height: height, ID: v.ID})
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch node %s: %w", v.ID, err)
+		}
+		cometAddrs[cometBFTAddress(node.Consensus.ID)] = struct{}{}
+	}
+	status.Validators = len(entityPower)
+	status.VotingPower = entityPower[entityAddr]
+	if status.VotingPower > 0 {
+		status.Rank = 1
+		for addr, power := range entityPower {
+			if power > status.VotingPower || (power == status.VotingPower && addr.String() < entityAddr.String()) {
+				status.Rank++
+			}
+		}
+	}
+
+	// Sample the recent blocks for the signatures of the validator nodes.
+	if len(cometAddrs) > 0 {
+		for h := height; h > 0 && uint64(height-h) < validatorSampleSize; h-- {
+			blk, err := consensusConn.GetBlock(ctx, h)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch block %d: %w", h, err)
+			}
+			var meta blockMeta
+			if err = cbor.Unmarshal(blk.Meta, &meta); err != nil || meta.LastCommit == nil {
+				continue
+			}
+
+			status.SampledBlocks++
+			for _, sig := range meta.LastCommit.Signatures {
+				if _, ok := cometAddrs[hex.EncodeToString(sig.ValidatorAddress)]; !ok {
+					continue
+				}
+				switch sig.BlockIDFlag {
+				case cometBFTBlockIDFlagAbsent:
+					status.MissedBlocks++
+				default:
+					status.SignedBlocks++
+				}
+			}
+		}
+	}
+
+	// Commission schedule.
+	account, err := consensusConn.Staking().Account(ctx, &staking.OwnerQuery{Height: height, Owner: entityAddr})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch account: %w", err)
+	}
+	status.CommissionSchedule = account.Escrow.CommissionSchedule
+	status.CommissionRate = status.CommissionSchedule.CurrentRate(epoch)
+
+	// Active proposals the entity has not voted on yet.
+	proposals, err := consensusConn.Governance().ActiveProposals(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch active proposals: %w", err)
+	}
+	sort.Slice(proposals, func(i, j int) bool { return proposals[i].ID < proposals[j].ID })
+	for _, proposal := range proposals {
+		votes, err := consensusConn.Governance().Votes(ctx, &governance.ProposalQuery{Height: height, ProposalID: proposal.ID})
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch votes for proposal %d: %w", proposal.ID, err)
+		}
+		var voted bool
+		for _, vote := range votes {
+			if vote.Voter.Equal(entityAddr) {
+				voted = true
+				break
+			}
+		}
+		if !voted {
+			status.PendingVotes = append(status.PendingVotes, pendingProposal{ID: proposal.ID, ClosesAt: proposal.ClosesAt})
+		}
+	}
+
+	return &status, nil
+}
+
+// formatCommissionRate formats the commission rate as a percentage.
+func formatCommissionRate(rate *quantity.Quantity) string {
+	if rate == nil {
+		return "(none)"
+	}
+	pct := new(big.Float).SetInt(rate.ToBigInt())
+	pct.Quo(pct, new(big.Float).SetInt(staking.CommissionRateDenominator.ToBigInt()))
+	pct.Mul(pct, big.NewFloat(100))
+	return pct.Text('f', 2) + "%"
+}
+
+// printValidatorStatus prints the operational status of the validator.
+func printValidatorStatus(npa *common.NPASelection, status *validatorStatus) {
+	fmt.Printf("Entity:            %s\n", status.Entity)
+	if status.Name != "" {
+		fmt.Printf("Name:              %s\n", status.Name)
+	}
+	fmt.Printf("Height:            %d\n", status.Height)
+	fmt.Printf("Epoch:             %d\n", status.Epoch)
+	fmt.Println()
+
+	fmt.Println("=== VALIDATOR SET ===")
+	switch len(status.Nodes) {
+	case 0:
+		fmt.Println("Member:            no")
+	default:
+		fmt.Println("Member:            yes")
+		fmt.Printf("Voting power:      %d (%.2f%% of %d)\n", status.VotingPower, 100*float64(status.VotingPower)/float64(status.TotalVotingPower), status.TotalVotingPower)
+		fmt.Printf("Rank:              %d/%d\n", status.Rank, status.Validators)
+		fmt.Println("Nodes:")
+		for _, node := range status.Nodes {
+			fmt.Printf("  - %s (voting power: %d)\n", node.ID, node.VotingPower)
+		}
+	}
+	fmt.Println()
+
+	fmt.Println("=== SIGNED BLOCKS ===")
+	if signatures := status.SignedBlocks + status.MissedBlocks; signatures > 0 {
+		fmt.Printf("Sampled blocks:    %d\n", status.SampledBlocks)
+		fmt.Printf("Signed:            %d (%.2f%%)\n", status.SignedBlocks, 100*float64(status.SignedBlocks)/float64(signatures))
+		fmt.Printf("Missed:            %d\n", status.MissedBlocks)
+	} else {
+		fmt.Println("No signatures expected in the sampled blocks.")
+	}
+	fmt.Println()
+
+	fmt.Println("=== COMMISSION ===")
+	fmt.Printf("Current rate:      %s\n", formatCommissionRate(status.CommissionRate))
+	fmt.Print(common.PrettyPrint(npa, "", status.CommissionSchedule))
+	fmt.Println()
+
+	fmt.Println("=== PENDING GOVERNANCE VOTES ===")
+	if len(status.PendingVotes) == 0 {
+		fmt.Println("No pending votes.")
+		return
+	}
+	for _, proposal := range status.PendingVotes {
+		fmt.Printf("Proposal %d (closes at epoch %d)\n", proposal.ID, proposal.ClosesAt)
+	}
+}
+
+func init() {
+	validatorStatusCmd.Flags().AddFlagSet(common.SelectorNFlags)
+	validatorStatusCmd.Flags().AddFlagSet(common.HeightFlag)
+	validatorStatusCmd.Flags().AddFlagSet(common.FormatFlag)
+	validatorStatusCmd.Flags().Uint64Var(&validatorSampleSize, "sample-size", 100, "number of recent blocks to examine for signatures")
+
+	validatorCmd.AddCommand(validatorStatusCmd)
+}
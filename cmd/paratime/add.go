@@ -17,6 +17,7 @@ var (
 	symbol      string
 	numDecimals uint
 	description string
+	web3URL     string
 
 	addCmd = &cobra.Command{
 		Use:   "add <network> <name> <id>",
@@ -37,6 +38,9 @@ var (
 			// Validate initial paratime configuration early.
 			cobra.CheckErr(config.ValidateIdentifier(name))
 			cobra.CheckErr(pt.Validate())
+			if web3URL != "" {
+				cobra.CheckErr(cliConfig.ValidateWeb3GatewayURL(web3URL))
+			}
 
 			paratimeInfo := struct {
 				Description string
@@ -60,6 +64,8 @@ var (
 			}
 
 			if !common.GetAnswerYes() {
+				common.CheckInteractive()
+
 				// Ask user for some additional parameters.
 				questions := []*survey.Question{
 					{
@@ -101,6 +107,9 @@ var (
 
 			err := net.ParaTimes.Add(name, &pt)
 			cobra.CheckErr(err)
+			if web3URL != "" {
+				cfg.Web3Gateways.Set(network, name, web3URL)
+			}
 
 			err = cfg.Save()
 			cobra.CheckErr(err)
@@ -122,4 +131,8 @@ func init() {
 	descriptionFlag := flag.NewFlagSet("", flag.ContinueOnError)
 	descriptionFlag.StringVar(&description, "description", "", "paratime's description")
 	addCmd.Flags().AddFlagSet(descriptionFlag)
+
+	web3Flag := flag.NewFlagSet("", flag.ContinueOnError)
+	web3Flag.StringVar(&web3URL, "web3-gateway", "", "URL of the paratime's Web3 gateway")
+	addCmd.Flags().AddFlagSet(web3Flag)
 }
@@ -0,0 +1,69 @@
+package paratime
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+
+	"github.com/oasisprotocol/cli/cmd/common"
+	cliConfig "github.com/oasisprotocol/cli/config"
+)
+
+// paraTimeAddress is the consensus layer staking address of a ParaTime.
+type paraTimeAddress struct {
+	ParaTime string        `json:"paratime"`
+	ID       string        `json:"id"`
+	Address  types.Address `json:"address"`
+}
+
+var addressCmd = &cobra.Command{
+	Use:   "address <paratime>",
+	Short: "Show the consensus layer address of the given ParaTime",
+	Long: `Show the consensus layer staking address of the given ParaTime. The address holds
+all tokens deposited into the ParaTime and must never be transferred to directly.
+Use the deposit and withdraw commands to move tokens between the consensus layer
+and the ParaTime instead.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(_ *cobra.Command, args []string) {
+		cfg := cliConfig.Global()
+		npa := common.GetNPASelection(cfg)
+		name := args[0]
+
+		pt := npa.Network.ParaTimes.All[name]
+		if pt == nil {
+			cobra.CheckErr(fmt.Errorf("paratime '%s' does not exist", name))
+		}
+
+		a := paraTimeAddress{
+			ParaTime: name,
+			ID:       pt.ID,
+			Address:  types.NewAddressFromConsensus(staking.NewRuntimeAddress(pt.Namespace())),
+		}
+		if common.IsJSONOutput() {
+			data, err := common.JSONMarshalOutput(a)
+			cobra.CheckErr(err)
+			fmt.Printf("%s\n", data)
+			return
+		}
+
+		fmt.Printf("ParaTime: %s (%s)\n", name, npa.PrettyPrintNetwork())
+		fmt.Printf("ID:       %s\n", pt.ID)
+		fmt.Printf("Address:  %s\n", a.Address)
+		fmt.Println()
+		fmt.Println("This address holds the tokens deposited into the ParaTime. Do NOT transfer")
+		fmt.Println("tokens to it directly, they would be lost. Instead:")
+		fmt.Println()
+		fmt.Println("- To move tokens from the consensus layer into the ParaTime, run:")
+		fmt.Printf("    oasis account deposit <amount> [<to>] --network %s --paratime %s\n", npa.NetworkName, name)
+		fmt.Println("- To move tokens from the ParaTime back to the consensus layer, run:")
+		fmt.Printf("    oasis account withdraw <amount> [<to>] --network %s --paratime %s\n", npa.NetworkName, name)
+	},
+}
+
+func init() {
+	addressCmd.Flags().AddFlagSet(common.SelectorNFlags)
+	addressCmd.Flags().AddFlagSet(common.FormatFlag)
+}
@@ -0,0 +1,227 @@
+package paratime
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+
+	"github.com/oasisprotocol/cli/cmd/common"
+	cliConfig "github.com/oasisprotocol/cli/config"
+)
+
+const (
+	// forwardTimeout is the timeout for delivering a single event to the webhook.
+	forwardTimeout = 10 * time.Second
+	// forwardRetryInterval is the initial interval between delivery retries. It doubles after
+	// each failed attempt.
+	forwardRetryInterval = time.Second
+	// forwardSignatureHeader is the HTTP header containing the HMAC-SHA256 signature of the body.
+	forwardSignatureHeader = "X-Oasis-Signature"
+)
+
+var (
+	forwardURL         string
+	forwardFilters     []string
+	forwardHMACKeyFile string
+	forwardRetries     uint
+
+	eventsCmd = &cobra.Command{
+		Use:   "events",
+		Short: "ParaTime event operations",
+	}
+
+	eventsForwardCmd = &cobra.Command{
+		Use:   "forward --url <url>",
+		Short: "Forward ParaTime events to a webhook",
+		Long: `Watch the events emitted in finalized rounds of the selected ParaTime and POST
+each matching event as decoded JSON to the given webhook URL. Failed deliveries
+are retried. When an HMAC key is given, the hex-encoded HMAC-SHA256 of the body is
+sent in the ` + forwardSignatureHeader + ` header.`,
+		Args: cobra.NoArgs,
+		Run: func(_ *cobra.Command, _ []string) {
+			cfg := cliConfig.Global()
+			npa := common.GetNPASelection(cfg)
+			if npa.ParaTime == nil {
+				cobra.CheckErr("no ParaTime selected")
+			}
+			if forwardURL == "" {
+				cobra.CheckErr("no webhook URL given, pass --url")
+			}
+
+			filter, err := parseEventFilter(forwardFilters)
+			cobra.CheckErr(err)
+
+			var hmacKey []byte
+			if forwardHMACKeyFile != "" {
+				hmacKey, err = os.ReadFile(forwardHMACKeyFile)
+				cobra.CheckErr(err)
+				hmacKey = bytes.TrimSpace(hmacKey)
+			}
+
+			ctx := context.Background()
+			conn, err := common.Connect(ctx, npa.Network)
+			cobra.CheckErr(err)
+
+			rt := conn.Runtime(npa.ParaTime)
+			blkCh, sub, err := rt.WatchBlocks(ctx)
+			cobra.CheckErr(err)
+			defer sub.Close()
+
+			fmt.Printf("Forwarding events of %s on %s to %s...\n", npa.ParaTimeName, npa.PrettyPrintNetwork(), forwardURL)
+
+			for blk := range blkCh {
+				round := blk.Block.Header.Round
+				evs, err := rt.GetEventsRaw(ctx, round)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: failed to query events of round %d: %v\n", round, err)
+					continue
+				}
+
+				for evIndex, ev := range evs {
+					if !filter.matches(ev) {
+						continue
+					}
+					payload := &forwardedEvent{
+						Network:  npa.NetworkName,
+						ParaTime: npa.ParaTimeName,
+						Round:    round,
+						Index:    evIndex,
+						Event:    jsonEventFields(ev),
+					}
+					if err = payload.deliver(ctx, forwardURL, hmacKey); err != nil {
+						fmt.Fprintf(os.Stderr, "Warning: failed to forward event %d of round %d: %v\n", evIndex, round, err)
+						continue
+					}
+					fmt.Printf("Forwarded event %d of round %d (%s/%d).\n", evIndex, round, ev.Module, ev.Code)
+				}
+			}
+			cobra.CheckErr("block subscription closed")
+		},
+	}
+)
+
+// eventFilter matches events by module name and event code. Values of the same key are
+// alternatives, while different keys must all match.
+type eventFilter struct {
+	modules map[string]bool
+	codes   map[uint32]bool
+}
+
+// parseEventFilter parses the given key=value filters.
+func parseEventFilter(filters []string) (*eventFilter, error) {
+	f := &eventFilter{
+		modules: make(map[string]bool),
+		codes:   make(map[uint32]bool),
+	}
+	for _, raw := range filters {
+		key, value, ok := strings.Cut(raw, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("malformed filter '%s', expected key=value", raw)
+		}
+		switch key {
+		case "module":
+			f.modules[value] = true
+		case "code":
+			code, err := strconv.ParseUint(value, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("malformed event code '%s': %w", value, err)
+			}
+			f.codes[uint32(code)] = true
+		default:
+			return nil, fmt.Errorf("unsupported filter key '%s' (supported: module, code)", key)
+		}
+	}
+	return f, nil
+}
+
+// matches returns true iff the given event matches the filter.
+func (f *eventFilter) matches(ev *types.Event) bool {
+	if len(f.modules) > 0 && !f.modules[ev.Module] {
+		return false
+	}
+	if len(f.codes) > 0 && !f.codes[ev.Code] {
+		return false
+	}
+	return true
+}
+
+// forwardedEvent is the webhook payload of a forwarded event.
+type forwardedEvent struct {
+	Network  string                 `json:"network"`
+	ParaTime string                 `json:"paratime"`
+	Round    uint64                 `json:"round"`
+	Index    int                    `json:"index"`
+	Event    map[string]interface{} `json:"event"`
+}
+
+// deliver posts the event as JSON to the given webhook URL, retrying failed deliveries.
+func (e *forwardedEvent) deliver(ctx context.Context, url string, hmacKey []byte) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	var signature string
+	if hmacKey != nil {
+		mac := hmac.New(sha256.New, hmacKey)
+		_, _ = mac.Write(body)
+		signature = hex.EncodeToString(mac.Sum(nil))
+	}
+
+	retryInterval := forwardRetryInterval
+	for attempt := uint(0); ; attempt++ {
+		err = postEvent(ctx, url, body, signature)
+		if err == nil || attempt >= forwardRetries {
+			return err
+		}
+		time.Sleep(retryInterval)
+		retryInterval *= 2
+	}
+}
+
+// postEvent performs a single webhook request with the given body and signature.
+func postEvent(ctx context.Context, url string, body []byte, signature string) error {
+	reqCtx, cancel := context.WithTimeout(ctx, forwardTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if signature != "" {
+		req.Header.Set(forwardSignatureHeader, "sha256="+signature)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("invalid response status: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func init() {
+	eventsForwardCmd.Flags().AddFlagSet(common.SelectorNPFlags)
+	eventsForwardCmd.Flags().StringVar(&forwardURL, "url", "", "webhook URL to POST the events to")
+	eventsForwardCmd.Flags().StringArrayVar(&forwardFilters, "filter", nil, "only forward events matching the given key=value filter (keys: module, code)")
+	eventsForwardCmd.Flags().StringVar(&forwardHMACKeyFile, "hmac-key-file", "", "file containing the key used to sign the webhook requests with HMAC-SHA256")
+	eventsForwardCmd.Flags().UintVar(&forwardRetries, "webhook-retries", 5, "number of delivery retries of a failed webhook request")
+
+	eventsCmd.AddCommand(eventsForwardCmd)
+}
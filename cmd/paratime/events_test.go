@@ -0,0 +1,64 @@
+package paratime
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+func TestEventFilter(t *testing.T) {
+	require := require.New(t)
+
+	f, err := parseEventFilter([]string{"module=rofl", "module=accounts", "code=1"})
+	require.NoError(err)
+	require.True(f.matches(&types.Event{Module: "rofl", Code: 1}))
+	require.True(f.matches(&types.Event{Module: "accounts", Code: 1}))
+	require.False(f.matches(&types.Event{Module: "rofl", Code: 2}))
+	require.False(f.matches(&types.Event{Module: "evm", Code: 1}))
+
+	f, err = parseEventFilter(nil)
+	require.NoError(err)
+	require.True(f.matches(&types.Event{Module: "evm", Code: 1}))
+
+	for _, invalid := range []string{"module", "module=", "code=x", "tx=abc"} {
+		_, err = parseEventFilter([]string{invalid})
+		require.Error(err, invalid)
+	}
+}
+
+func TestForwardedEventDeliver(t *testing.T) {
+	require := require.New(t)
+
+	key := []byte("secret")
+	var attempts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		mac := hmac.New(sha256.New, key)
+		_, _ = mac.Write(body)
+		if r.Header.Get(forwardSignatureHeader) != "sha256="+hex.EncodeToString(mac.Sum(nil)) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer srv.Close()
+
+	forwardRetries = 1
+	ev := &forwardedEvent{Network: "testnet", ParaTime: "sapphire", Round: 1}
+	require.NoError(ev.deliver(context.Background(), srv.URL, key))
+	require.Equal(2, attempts)
+
+	require.Error(ev.deliver(context.Background(), srv.URL, []byte("wrong")))
+}
@@ -0,0 +1,267 @@
+package paratime
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/core"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/evm"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+
+	"github.com/oasisprotocol/cli/cmd/common"
+	cliConfig "github.com/oasisprotocol/cli/config"
+	"github.com/oasisprotocol/cli/table"
+)
+
+const (
+	// methodEncrypted is the pseudo method of encrypted calls.
+	methodEncrypted = "(encrypted)"
+	// selectorNone is the pseudo selector of EVM calls without a function selector.
+	selectorNone = "-"
+	// selectorEncrypted is the pseudo selector of EVM calls with encrypted call data.
+	selectorEncrypted = "(encrypted)"
+)
+
+var (
+	gasReportRounds      string
+	gasReportDepth       uint64
+	gasReportConcurrency uint
+)
+
+// gasReportKey identifies the group of transactions a gas usage statistic is computed for.
+type gasReportKey struct {
+	method   string
+	selector string
+}
+
+// gasReportEntry are the gas usage statistics of a single method (and EVM selector).
+type gasReportEntry struct {
+	Method   string `json:"method"`
+	Selector string `json:"selector,omitempty"`
+	Count    int    `json:"count"`
+	Min      uint64 `json:"min"`
+	Avg      uint64 `json:"avg"`
+	P95      uint64 `json:"p95"`
+	Max      uint64 `json:"max"`
+}
+
+// evmSelector returns the function selector of the given EVM call data. Call data encrypted by
+// confidential ParaTimes has no visible selector.
+func evmSelector(data []byte) string {
+	var call types.Call
+	if err := cbor.Unmarshal(data, &call); err == nil && call.Format != types.CallFormatPlain {
+		return selectorEncrypted
+	}
+	if len(data) < 4 {
+		return selectorNone
+	}
+	return fmt.Sprintf("0x%x", data[:4])
+}
+
+// txGasReportKey returns the method (and EVM selector) of the given transaction.
+func txGasReportKey(tx *client.TransactionWithResults) (gasReportKey, bool) {
+	if len(tx.Tx.AuthProofs) == 1 && tx.Tx.AuthProofs[0].Module == "evm.ethereum.v0" {
+		var ethTx ethTypes.Transaction
+		if err := ethTx.UnmarshalBinary(tx.Tx.Body); err != nil {
+			return gasReportKey{}, false
+		}
+		if ethTx.To() == nil {
+			return gasReportKey{method: "evm.Create", selector: selectorNone}, true
+		}
+		return gasReportKey{method: "evm.Call", selector: evmSelector(ethTx.Data())}, true
+	}
+
+	var t types.Transaction
+	if err := cbor.Unmarshal(tx.Tx.Body, &t); err != nil {
+		return gasReportKey{}, false
+	}
+	key := gasReportKey{method: string(t.Call.Method)}
+	switch {
+	case t.Call.Format != types.CallFormatPlain:
+		// The method of encrypted calls is not visible.
+		key.method = methodEncrypted
+	case key.method == "evm.Call":
+		var body evm.Call
+		if err := cbor.Unmarshal(t.Call.Body, &body); err != nil {
+			key.selector = selectorNone
+			break
+		}
+		key.selector = evmSelector(body.Data)
+	}
+	return key, true
+}
+
+// txGasUsed returns the amount of gas used by the transaction as reported by the core module.
+func txGasUsed(tx *client.TransactionWithResults) (uint64, bool) {
+	for _, ev := range tx.Events {
+		decoded, err := core.DecodeEvent(ev)
+		if err != nil {
+			continue
+		}
+		for _, d := range decoded {
+			if coreEv, ok := d.(*core.Event); ok && coreEv.GasUsed != nil {
+				return coreEv.GasUsed.Amount, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// gasReport aggregates the gas used by transactions per method.
+type gasReport struct {
+	used map[gasReportKey][]uint64
+}
+
+func newGasReport() *gasReport {
+	return &gasReport{used: make(map[gasReportKey][]uint64)}
+}
+
+// add records the gas used by the given transactions.
+func (r *gasReport) add(txs []*client.TransactionWithResults) {
+	for _, tx := range txs {
+		key, ok := txGasReportKey(tx)
+		if !ok {
+			continue
+		}
+		gas, ok := txGasUsed(tx)
+		if !ok {
+			continue
+		}
+		r.used[key] = append(r.used[key], gas)
+	}
+}
+
+// entries returns the gas usage statistics ordered by method and selector.
+func (r *gasReport) entries() []gasReportEntry {
+	entries := make([]gasReportEntry, 0, len(r.used))
+	for key, used := range r.used {
+		sort.Slice(used, func(i, j int) bool { return used[i] < used[j] })
+
+		var total uint64
+		for _, gas := range used {
+			total += gas
+		}
+		// Nearest-rank percentile.
+		p95 := used[(len(used)*95+99)/100-1]
+
+		entries = append(entries, gasReportEntry{
+			Method:   key.method,
+			Selector: key.selector,
+			Count:    len(used),
+			Min:      used[0],
+			Avg:      total / uint64(len(used)),
+			P95:      p95,
+			Max:      used[len(used)-1],
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Method != entries[j].Method {
+			return entries[i].Method < entries[j].Method
+		}
+		return entries[i].Selector < entries[j].Selector
+	})
+	return entries
+}
+
+var gasReportCmd = &cobra.Command{
+	Use:   "gas-report",
+	Short: "Report the gas used by transactions per method",
+	Long: `Scan the given range of rounds and aggregate the gas used by the transactions per ParaTime
+method and, for EVM calls, per function selector. Use the reported percentiles to pick gas limits.`,
+	Args: cobra.NoArgs,
+	Run: func(_ *cobra.Command, _ []string) {
+		cfg := cliConfig.Global()
+		npa := common.GetNPASelection(cfg)
+		if npa.ParaTime == nil {
+			cobra.CheckErr("no ParaTime selected")
+		}
+
+		ctx := context.Background()
+		conn, err := common.Connect(ctx, npa.Network)
+		cobra.CheckErr(err)
+		rt := conn.Runtime(npa.ParaTime)
+
+		first, last, err := resolveRoundRange(ctx, rt, gasReportRounds, gasReportDepth)
+		cobra.CheckErr(err)
+
+		report := newGasReport()
+		total := last - first + 1
+		step := max(total/10, 1)
+		p := common.StartProgress("scan rounds", total, "rounds")
+		progress := func(scanned uint64) {
+			p.Update(scanned)
+			if !common.IsJSONProgress() && (scanned%step == 0 || scanned == total) {
+				fmt.Fprintf(os.Stderr, "Scanned %d/%d rounds...\n", scanned, total)
+			}
+		}
+		visit := func(_ uint64, txs []*client.TransactionWithResults) {
+			report.add(txs)
+		}
+		err = scanRoundRange(ctx, rt, first, last, gasReportConcurrency, visit, progress)
+		cobra.CheckErr(err)
+		p.Finish()
+
+		entries := report.entries()
+		if common.IsJSONOutput() {
+			data, err := common.JSONMarshalOutput(map[string]interface{}{
+				"first_round": first,
+				"last_round":  last,
+				"methods":     entries,
+			})
+			cobra.CheckErr(err)
+			fmt.Printf("%s\n", data)
+			return
+		}
+
+		if len(entries) == 0 {
+			fmt.Printf("No transactions in rounds %d..%d.\n", first, last)
+			return
+		}
+
+		listing := table.NewListing(
+			table.Column{Name: "Method"},
+			table.Column{Name: "Selector"},
+			table.Column{Name: "Count"},
+			table.Column{Name: "Min"},
+			table.Column{Name: "Avg"},
+			table.Column{Name: "P95"},
+			table.Column{Name: "Max"},
+		)
+		for _, e := range entries {
+			selector := e.Selector
+			if selector == "" {
+				selector = selectorNone
+			}
+			listing.Append(
+				e.Method,
+				selector,
+				strconv.Itoa(e.Count),
+				strconv.FormatUint(e.Min, 10),
+				strconv.FormatUint(e.Avg, 10),
+				strconv.FormatUint(e.P95, 10),
+				strconv.FormatUint(e.Max, 10),
+			)
+		}
+		cobra.CheckErr(listing.Render(common.GetListingOptions()))
+	},
+}
+
+func init() {
+	f := flag.NewFlagSet("", flag.ContinueOnError)
+	f.StringVar(&gasReportRounds, "rounds", "", "range of rounds to scan in the <first>..<last> form (default: the last --scan-depth rounds)")
+	f.Uint64Var(&gasReportDepth, "scan-depth", 1000, "number of recent rounds to scan if no range is given")
+	f.UintVar(&gasReportConcurrency, "concurrency", 8, "number of rounds fetched in parallel")
+	gasReportCmd.Flags().AddFlagSet(common.SelectorNPFlags)
+	gasReportCmd.Flags().AddFlagSet(common.FormatFlag)
+	gasReportCmd.Flags().AddFlagSet(common.ListingFlags)
+	gasReportCmd.Flags().AddFlagSet(f)
+}
@@ -0,0 +1,102 @@
+package paratime
+
+import (
+	"math/big"
+	"testing"
+
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/core"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/evm"
+	sdkTesting "github.com/oasisprotocol/oasis-sdk/client-sdk/go/testing"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// gasUsedTx returns a transaction with results calling the given method and using the given
+// amount of gas.
+func gasUsedTx(tx *types.Transaction, gas uint64) *client.TransactionWithResults {
+	tx.AppendAuthSignature(sdkTesting.Alice.SigSpec, 0)
+	return &client.TransactionWithResults{
+		Tx:     *tx.PrepareForSigning().UnverifiedTransaction(),
+		Result: types.CallResult{Ok: cbor.Marshal(nil)},
+		Events: []*types.Event{{
+			Module: core.ModuleName,
+			Code:   core.GasUsedEventCode,
+			Value:  cbor.Marshal([]core.GasUsedEvent{{Amount: gas}}),
+		}},
+	}
+}
+
+func TestGasReport(t *testing.T) {
+	require := require.New(t)
+
+	transfer := func(gas uint64) *client.TransactionWithResults {
+		return gasUsedTx(types.NewTransaction(nil, "accounts.Transfer", nil), gas)
+	}
+	evmCall := func(data []byte, gas uint64) *client.TransactionWithResults {
+		return gasUsedTx(types.NewTransaction(nil, "evm.Call", &evm.Call{Data: data}), gas)
+	}
+
+	report := newGasReport()
+	var txs []*client.TransactionWithResults
+	for gas := uint64(1); gas <= 100; gas++ {
+		txs = append(txs, transfer(gas*1000))
+	}
+	txs = append(txs,
+		evmCall([]byte{0xa9, 0x05, 0x9c, 0xbb, 0x00}, 50_000),
+		evmCall([]byte{0xa9, 0x05, 0x9c, 0xbb, 0x01}, 70_000),
+		evmCall(nil, 21_000),
+	)
+	// Transactions without a gas used event are skipped.
+	noGas := transfer(1)
+	noGas.Events = nil
+	txs = append(txs, noGas)
+	report.add(txs)
+
+	entries := report.entries()
+	require.Len(entries, 3)
+	require.Equal(gasReportEntry{Method: "accounts.Transfer", Count: 100, Min: 1000, Avg: 50500, P95: 95000, Max: 100000}, entries[0])
+	require.Equal(gasReportEntry{Method: "evm.Call", Selector: selectorNone, Count: 1, Min: 21000, Avg: 21000, P95: 21000, Max: 21000}, entries[1])
+	require.Equal(gasReportEntry{Method: "evm.Call", Selector: "0xa9059cbb", Count: 2, Min: 50000, Avg: 60000, P95: 70000, Max: 70000}, entries[2])
+}
+
+func TestTxGasReportKey(t *testing.T) {
+	require := require.New(t)
+
+	// Encrypted SDK call.
+	tx := types.NewTransaction(nil, "evm.Call", nil)
+	tx.Call.Format = types.CallFormatEncryptedX25519DeoxysII
+	key, ok := txGasReportKey(gasUsedTx(tx, 1))
+	require.True(ok)
+	require.Equal(methodEncrypted, key.method)
+
+	// Ethereum transactions.
+	to := ethCommon.HexToAddress("0x90adE3B7065fa715c7a150313877dF1d33e777D5")
+	for _, tc := range []struct {
+		to       *ethCommon.Address
+		data     []byte
+		method   string
+		selector string
+	}{
+		{&to, []byte{0x12, 0x34, 0x56, 0x78}, "evm.Call", "0x12345678"},
+		{&to, cbor.Marshal(types.Call{Format: types.CallFormatEncryptedX25519DeoxysII}), "evm.Call", selectorEncrypted},
+		{nil, []byte{0x60, 0x80}, "evm.Create", selectorNone},
+	} {
+		ethTx := ethTypes.NewTx(&ethTypes.LegacyTx{To: tc.to, Gas: 100_000, GasPrice: big.NewInt(1), Data: tc.data})
+		raw, err := ethTx.MarshalBinary()
+		require.NoError(err)
+		key, ok := txGasReportKey(&client.TransactionWithResults{
+			Tx: types.UnverifiedTransaction{
+				Body:       raw,
+				AuthProofs: []types.AuthProof{{Module: "evm.ethereum.v0"}},
+			},
+		})
+		require.True(ok)
+		require.Equal(tc.method, key.method)
+		require.Equal(tc.selector, key.selector)
+	}
+}
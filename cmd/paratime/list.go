@@ -22,8 +22,13 @@ var listCmd = &cobra.Command{
 	Args:    cobra.NoArgs,
 	Run: func(_ *cobra.Command, _ []string) {
 		cfg := cliConfig.Global()
-		table := table.New()
-		table.SetHeader([]string{"Network", "Paratime", "ID", "Denomination(s)"})
+		listing := table.NewListing(
+			table.Column{Name: "Network"},
+			table.Column{Name: "Paratime"},
+			table.Column{Name: "ID"},
+			table.Column{Name: "Denomination(s)"},
+			table.Column{Name: "Description", Wide: true},
+		)
 
 		var output [][]string
 		for netName, net := range cfg.Networks.All {
@@ -38,6 +43,7 @@ var listCmd = &cobra.Command{
 					displayPtName,
 					pt.ID,
 					formatDenominations(pt.Denominations),
+					pt.Description,
 				})
 			}
 		}
@@ -50,8 +56,11 @@ var listCmd = &cobra.Command{
 			return output[i][1] < output[j][1]
 		})
 
-		table.AppendBulk(output)
-		table.Render()
+		for _, row := range output {
+			listing.Append(row...)
+		}
+		err := listing.Render(common.GetListingOptions())
+		cobra.CheckErr(err)
 	},
 }
 
@@ -70,3 +79,7 @@ func formatDenominations(denominations map[string]*config.DenominationInfo) stri
 	slices.Sort(fmtDenomArray)
 	return strings.Join(fmtDenomArray, "\n")
 }
+
+func init() {
+	listCmd.Flags().AddFlagSet(common.ListingFlags)
+}
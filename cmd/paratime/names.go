@@ -0,0 +1,77 @@
+package paratime
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// addressNameSuffix is the suffix of the fields holding the names of the addresses in the fields
+// without it.
+const addressNameSuffix = "_name"
+
+var (
+	resolveNames bool
+
+	// eventNames are the names of known addresses used to annotate decoded events or nil when the
+	// decoded events should not be annotated.
+	eventNames types.AccountNames
+)
+
+// annotateAddressNames returns the JSON representation of the given value where each object field
+// holding a known address is accompanied by a field of the same name with the addressNameSuffix
+// holding the name of the address in the wallet or the address book.
+func annotateAddressNames(v interface{}, names types.AccountNames) (interface{}, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var generic interface{}
+	if err = dec.Decode(&generic); err != nil {
+		return nil, err
+	}
+	annotateGeneric(generic, names)
+	return generic, nil
+}
+
+// annotateGeneric annotates the known addresses in the given generic JSON value in place.
+func annotateGeneric(v interface{}, names types.AccountNames) {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		resolved := make(map[string]string)
+		for k, e := range vv {
+			if s, ok := e.(string); ok {
+				if name, known := names[s]; known {
+					resolved[k+addressNameSuffix] = name
+				}
+				continue
+			}
+			annotateGeneric(e, names)
+		}
+		for k, name := range resolved {
+			if _, exists := vv[k]; !exists {
+				vv[k] = name
+			}
+		}
+	case []interface{}:
+		for _, e := range vv {
+			annotateGeneric(e, names)
+		}
+	}
+}
+
+// maybeAnnotateEvent annotates the addresses in the given decoded event with their names when
+// name resolution has been requested. The decoded event is returned unchanged otherwise.
+func maybeAnnotateEvent(decoded interface{}) interface{} {
+	if eventNames == nil {
+		return decoded
+	}
+	annotated, err := annotateAddressNames(decoded, eventNames)
+	if err != nil {
+		return decoded
+	}
+	return annotated
+}
@@ -0,0 +1,40 @@
+package paratime
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/accounts"
+	sdkTesting "github.com/oasisprotocol/oasis-sdk/client-sdk/go/testing"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+func TestAnnotateAddressNames(t *testing.T) {
+	require := require.New(t)
+
+	names := types.AccountNames{
+		sdkTesting.Alice.Address.String(): "alice",
+	}
+	ev := []*accounts.Event{{
+		Transfer: &accounts.TransferEvent{
+			From:   sdkTesting.Alice.Address,
+			To:     sdkTesting.Bob.Address,
+			Amount: types.NewBaseUnits(*quantity.NewFromUint64(10), types.NativeDenomination),
+		},
+	}}
+
+	annotated, err := annotateAddressNames(ev, names)
+	require.NoError(err)
+	raw, err := json.Marshal(annotated)
+	require.NoError(err)
+
+	var out []map[string]map[string]interface{}
+	require.NoError(json.Unmarshal(raw, &out))
+	transfer := out[0]["Transfer"]
+	require.Equal("alice", transfer["from_name"])
+	require.NotContains(transfer, "to_name")
+	require.Equal(sdkTesting.Alice.Address.String(), transfer["from"])
+}
@@ -13,12 +13,19 @@ var Cmd = &cobra.Command{
 }
 
 func init() {
+	Cmd.AddCommand(addressCmd)
 	Cmd.AddCommand(listCmd)
 	Cmd.AddCommand(addCmd)
+	Cmd.AddCommand(eventsCmd)
+	Cmd.AddCommand(gasReportCmd)
 	Cmd.AddCommand(registerCmd)
 	Cmd.AddCommand(removeCmd)
 	Cmd.AddCommand(setDefaultCmd)
+	Cmd.AddCommand(setDefaultAccountCmd)
+	Cmd.AddCommand(setWeb3GatewayCmd)
 	Cmd.AddCommand(showCmd)
+	Cmd.AddCommand(queryCmd)
 	Cmd.AddCommand(statsCmd)
+	Cmd.AddCommand(watchCmd)
 	Cmd.AddCommand(denomination.Cmd)
 }
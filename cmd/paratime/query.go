@@ -0,0 +1,73 @@
+package paratime
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+
+	"github.com/oasisprotocol/cli/cmd/common"
+	cliConfig "github.com/oasisprotocol/cli/config"
+)
+
+var queryCmd = &cobra.Command{
+	Use:   "query <method> [<json-args>]",
+	Short: "Perform an arbitrary runtime query",
+	Long: `Perform an arbitrary runtime query (e.g. "core.Parameters" or "rofl.App") and print the
+decoded CBOR response.
+
+Query arguments are given as JSON and encoded into CBOR. Strings starting with
+"0x" or "base64:" are encoded as byte strings, as are Oasis ("oasis1...") and
+ROFL app ("rofl1...") addresses. Byte strings in the response are shown as
+hex with the "0x" prefix.`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(_ *cobra.Command, args []string) {
+		cfg := cliConfig.Global()
+		npa := common.GetNPASelection(cfg)
+		method := types.MethodName(args[0])
+
+		if npa.ParaTime == nil {
+			cobra.CheckErr("no ParaTime selected")
+		}
+
+		var queryArgs interface{}
+		if len(args) > 1 {
+			var err error
+			queryArgs, err = common.ParseCBORJSON(args[1])
+			cobra.CheckErr(err)
+		}
+
+		ctx := context.Background()
+		conn, err := common.Connect(ctx, npa.Network)
+		cobra.CheckErr(err)
+
+		var rsp cbor.RawMessage
+		err = conn.Runtime(npa.ParaTime).Query(ctx, selectedRound, method, queryArgs, &rsp)
+		cobra.CheckErr(err)
+
+		var decoded interface{}
+		if err = cbor.Unmarshal(rsp, &decoded); err != nil {
+			cobra.CheckErr(fmt.Errorf("failed to decode query response: %w", err))
+		}
+
+		var out bytes.Buffer
+		err = json.Indent(&out, common.JSONMarshalUniversalValue(common.HumanizeCBORValue(decoded)), "", "  ")
+		cobra.CheckErr(err)
+		fmt.Println(out.String())
+	},
+}
+
+func init() {
+	roundFlag := flag.NewFlagSet("", flag.ContinueOnError)
+	roundFlag.Uint64Var(&selectedRound, "round", client.RoundLatest, "explicitly set block round to use")
+
+	queryCmd.Flags().AddFlagSet(common.SelectorNPFlags)
+	queryCmd.Flags().AddFlagSet(roundFlag)
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"os"
 
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/connection"
@@ -33,12 +32,12 @@ var registerCmd = &cobra.Command{
 		var conn connection.Connection
 		if !txCfg.Offline {
 			var err error
-			conn, err = connection.Connect(ctx, npa.Network)
+			conn, err = common.Connect(ctx, npa.Network)
 			cobra.CheckErr(err)
 		}
 
 		// Load runtime descriptor.
-		rawDescriptor, err := os.ReadFile(filename)
+		rawDescriptor, err := common.ReadFile(filename)
 		cobra.CheckErr(err)
 
 		// Parse runtime descriptor.
@@ -24,6 +24,7 @@ var removeCmd = &cobra.Command{
 
 		err := net.ParaTimes.Remove(name)
 		cobra.CheckErr(err)
+		cfg.Web3Gateways.Set(network, name, "")
 
 		err = cfg.Save()
 		cobra.CheckErr(err)
@@ -0,0 +1,37 @@
+package paratime
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	cliConfig "github.com/oasisprotocol/cli/config"
+)
+
+var setDefaultAccountCmd = &cobra.Command{
+	Use:   "set-default-account <network> <name> [<account>]",
+	Short: "Sets the default account for the given ParaTime",
+	Long:  "Sets the default account for the given ParaTime which takes precedence over the network and wallet default accounts. Omit the account to clear it.",
+	Args:  cobra.RangeArgs(2, 3),
+	Run: func(_ *cobra.Command, args []string) {
+		cfg := cliConfig.Global()
+		network, name := args[0], args[1]
+		var account string
+		if len(args) > 2 {
+			account = args[2]
+		}
+
+		net, exists := cfg.Networks.All[network]
+		if !exists {
+			cobra.CheckErr(fmt.Errorf("network '%s' does not exist", network))
+		}
+		if _, exists = net.ParaTimes.All[name]; !exists {
+			cobra.CheckErr(fmt.Errorf("ParaTime '%s' does not exist", name))
+		}
+
+		cfg.DefaultAccounts.Set(network, name, account)
+
+		err := cfg.Save()
+		cobra.CheckErr(err)
+	},
+}
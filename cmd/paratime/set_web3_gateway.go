@@ -0,0 +1,39 @@
+package paratime
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	cliConfig "github.com/oasisprotocol/cli/config"
+)
+
+var setWeb3GatewayCmd = &cobra.Command{
+	Use:   "set-web3-gateway <network> <name> [<url>]",
+	Short: "Sets the Web3 gateway URL of the given ParaTime",
+	Long:  "Associate the Web3 gateway URL with the given ParaTime. Omit the URL to remove the association.",
+	Args:  cobra.RangeArgs(2, 3),
+	Run: func(_ *cobra.Command, args []string) {
+		cfg := cliConfig.Global()
+		network, name := args[0], args[1]
+		var url string
+		if len(args) > 2 {
+			url = args[2]
+			cobra.CheckErr(cliConfig.ValidateWeb3GatewayURL(url))
+		}
+
+		net, exists := cfg.Networks.All[network]
+		if !exists {
+			cobra.CheckErr(fmt.Errorf("network '%s' does not exist", network))
+			return // To make staticcheck happy as it doesn't know CheckErr exits.
+		}
+		if _, exists = net.ParaTimes.All[name]; !exists {
+			cobra.CheckErr(fmt.Errorf("paratime '%s' does not exist", name))
+		}
+
+		cfg.Web3Gateways.Set(network, name, url)
+
+		err := cfg.Save()
+		cobra.CheckErr(err)
+	},
+}
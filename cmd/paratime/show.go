@@ -36,6 +36,7 @@ const (
 	selInvalid propertySelector = iota
 	selParameters
 	selEvents
+	selTx
 	selRoundLatest = "latest"
 )
 
@@ -52,11 +53,11 @@ var (
 	selectedRound uint64
 
 	showCmd = &cobra.Command{
-		Use:     "show { <round> [ <tx-index> | <tx-hash> ] | parameters | events }",
+		Use:     "show { <round> [ <tx-index> | <tx-hash> ] | tx <tx-hash> | parameters | events | --address <address> [--rounds <first>..<last>] }",
 		Short:   "Show information about a ParaTime block, its transactions, events or other parameters",
-		Long:    "Show ParaTime-specific information about a given block round, (optionally) its transactions or other information. Use \"latest\" to use the last round.",
+		Long:    "Show ParaTime-specific information about a given block round, (optionally) its transactions or other information. Use \"latest\" to use the last round. Use \"tx\" to look up a transaction by its Oasis or Ethereum hash. Use --address to list the transactions involving the address in a range of rounds.",
 		Aliases: []string{"s"},
-		Args:    cobra.RangeArgs(1, 2),
+		Args:    cobra.RangeArgs(0, 2),
 		Run: func(_ *cobra.Command, args []string) {
 			cfg := cliConfig.Global()
 			npa := common.GetNPASelection(cfg)
@@ -64,6 +65,23 @@ var (
 			if npa.ParaTime == nil {
 				cobra.CheckErr("no ParaTimes to investigate")
 			}
+			if resolveNames {
+				eventNames = common.GenAccountNames()
+			}
+
+			if scanAddress != "" {
+				if len(args) > 0 {
+					cobra.CheckErr("--address cannot be combined with a round or a selector")
+				}
+				ctx := context.Background()
+				conn, err := common.Connect(ctx, npa.Network)
+				cobra.CheckErr(err)
+				showAddressActivity(ctx, npa, conn.Runtime(npa.ParaTime))
+				return
+			}
+			if len(args) == 0 {
+				cobra.CheckErr("missing round or selector")
+			}
 
 			p, err := parseBlockNum(args[0])
 			cobra.CheckErr(err)
@@ -81,15 +99,14 @@ var (
 				txIndex, err = strconv.Atoi(txIndexOrHash)
 				if err != nil {
 					txIndex = -1
-					if err = txHash.UnmarshalHex(txIndexOrHash); err != nil {
-						cobra.CheckErr(fmt.Errorf("malformed tx hash: %w", err))
-					}
+					txHash, err = parseTxHash(txIndexOrHash)
+					cobra.CheckErr(err)
 				}
 			}
 
 			// Establish connection with the target network.
 			ctx := context.Background()
-			conn, err := connection.Connect(ctx, npa.Network)
+			conn, err := common.Connect(ctx, npa.Network)
 			cobra.CheckErr(err)
 
 			if common.OutputFormat() == common.FormatText {
@@ -165,13 +182,7 @@ var (
 
 					// Resolve transaction index if needed.
 					if txIndex == -1 {
-						for i, tx := range txs {
-							if h := tx.Tx.Hash(); h.Equal(&txHash) {
-								txIndex = i
-								break
-							}
-						}
-
+						txIndex = findTxIndex(txs, txHash)
 						if txIndex == -1 {
 							cobra.CheckErr(fmt.Errorf("failed to find transaction with hash %s", txHash))
 						}
@@ -180,84 +191,7 @@ var (
 					if txIndex >= len(txs) {
 						cobra.CheckErr(fmt.Errorf("transaction index %d is out of range", txIndex))
 					}
-					tx := txs[txIndex]
-
-					fmt.Printf("=== Transaction %d ===\n", txIndex)
-
-					if len(tx.Tx.AuthProofs) == 1 && tx.Tx.AuthProofs[0].Module != "" {
-						// Module-specific transaction encoding scheme.
-						scheme := tx.Tx.AuthProofs[0].Module
-
-						switch scheme {
-						case "evm.ethereum.v0":
-							// Ethereum transaction encoding.
-							var ethTx ethTypes.Transaction
-							if err := ethTx.UnmarshalBinary(tx.Tx.Body); err != nil {
-								fmt.Printf("[malformed 'evm.ethereum.v0' transaction: %s]\n", err)
-								break
-							}
-
-							fmt.Printf("Kind:      evm.ethereum.v0\n")
-							fmt.Printf("Hash:      %s\n", tx.Tx.Hash())
-							fmt.Printf("Eth hash:  %s\n", ethTx.Hash())
-							fmt.Printf("Chain ID:  %s\n", ethTx.ChainId())
-							fmt.Printf("Nonce:     %d\n", ethTx.Nonce())
-							fmt.Printf("Type:      %d\n", ethTx.Type())
-							fmt.Printf("To:        %s\n", ethTx.To())
-							fmt.Printf("Value:     %s\n", ethTx.Value())
-							fmt.Printf("Gas limit: %d\n", ethTx.Gas())
-							fmt.Printf("Gas price: %s\n", ethTx.GasPrice())
-							fmt.Printf("Data:\n")
-							if len(ethTx.Data()) > 0 {
-								fmt.Printf("  %s\n", hex.EncodeToString(ethTx.Data()))
-							} else {
-								fmt.Printf("  (none)\n")
-							}
-						default:
-							fmt.Printf("[module-specific transaction encoding scheme: %s]\n", scheme)
-						}
-					} else {
-						// Regular SDK transaction.
-						fmt.Printf("Kind: oasis\n")
-
-						common.PrintTransactionRaw(npa, &tx.Tx)
-					}
-					fmt.Println()
-
-					// Show result.
-					fmt.Printf("=== Result of transaction %d ===\n", txIndex)
-					switch res := tx.Result; {
-					case res.Failed != nil:
-						fmt.Printf("Status:  failed\n")
-						fmt.Printf("Module:  %s\n", res.Failed.Module)
-						fmt.Printf("Code:    %d\n", res.Failed.Code)
-						fmt.Printf("Message: %s\n", res.Failed.Message)
-					case res.Ok != nil:
-						fmt.Printf("Status: ok\n")
-						fmt.Printf("Data:\n")
-						prettyPrintCBOR("  ", "result", res.Ok)
-					case res.Unknown != nil:
-						fmt.Printf("Status: unknown\n")
-						fmt.Printf("Data:\n")
-						prettyPrintCBOR("  ", "result", res.Unknown)
-					default:
-						fmt.Printf("[unsupported result kind]\n")
-					}
-					fmt.Println()
-
-					// Show events.
-					fmt.Printf("=== Events emitted by transaction %d ===\n", txIndex)
-					if numEvents := len(tx.Events); numEvents > 0 {
-						fmt.Printf("Events: %d\n", numEvents)
-						fmt.Println()
-
-						for evIndex, ev := range tx.Events {
-							prettyPrintEvent("  ", evIndex, ev)
-							fmt.Println()
-						}
-					} else {
-						fmt.Println("No events emitted by this transaction.")
-					}
+					printTransactionWithResults(npa, txIndex, txs[txIndex])
 				}
 			case propertySelector:
 				switch v {
@@ -267,6 +201,18 @@ var (
 				case selEvents:
 					showEvents(ctx, selectedRound, rt)
 					return
+				case selTx:
+					if len(args) < 2 || txIndex != -1 {
+						cobra.CheckErr("missing transaction hash")
+					}
+
+					round, txIndex, txs, err := locateTransaction(ctx, rt, txHash)
+					cobra.CheckErr(err)
+
+					fmt.Printf("Round:          %d\n", round)
+					fmt.Println()
+					printTransactionWithResults(npa, txIndex, txs[txIndex])
+					return
 				default:
 					cobra.CheckErr(fmt.Errorf("selector '%s' not found", args[0]))
 				}
@@ -275,6 +221,87 @@ var (
 	}
 )
 
+// printTransactionWithResults prints the given transaction together with its result and emitted
+// events.
+func printTransactionWithResults(npa *common.NPASelection, txIndex int, tx *client.TransactionWithResults) {
+	fmt.Printf("=== Transaction %d ===\n", txIndex)
+
+	if len(tx.Tx.AuthProofs) == 1 && tx.Tx.AuthProofs[0].Module != "" {
+		// Module-specific transaction encoding scheme.
+		scheme := tx.Tx.AuthProofs[0].Module
+
+		switch scheme {
+		case "evm.ethereum.v0":
+			// Ethereum transaction encoding.
+			var ethTx ethTypes.Transaction
+			if err := ethTx.UnmarshalBinary(tx.Tx.Body); err != nil {
+				fmt.Printf("[malformed 'evm.ethereum.v0' transaction: %s]\n", err)
+				break
+			}
+
+			fmt.Printf("Kind:      evm.ethereum.v0\n")
+			fmt.Printf("Hash:      %s\n", tx.Tx.Hash())
+			fmt.Printf("Eth hash:  %s\n", ethTx.Hash())
+			fmt.Printf("Chain ID:  %s\n", ethTx.ChainId())
+			fmt.Printf("Nonce:     %d\n", ethTx.Nonce())
+			fmt.Printf("Type:      %d\n", ethTx.Type())
+			fmt.Printf("To:        %s\n", ethTx.To())
+			fmt.Printf("Value:     %s\n", ethTx.Value())
+			fmt.Printf("Gas limit: %d\n", ethTx.Gas())
+			fmt.Printf("Gas price: %s\n", ethTx.GasPrice())
+			fmt.Printf("Data:\n")
+			if len(ethTx.Data()) > 0 {
+				fmt.Printf("  %s\n", hex.EncodeToString(ethTx.Data()))
+			} else {
+				fmt.Printf("  (none)\n")
+			}
+		default:
+			fmt.Printf("[module-specific transaction encoding scheme: %s]\n", scheme)
+		}
+	} else {
+		// Regular SDK transaction.
+		fmt.Printf("Kind: oasis\n")
+
+		common.PrintTransactionRaw(npa, &tx.Tx)
+	}
+	fmt.Println()
+
+	// Show result.
+	fmt.Printf("=== Result of transaction %d ===\n", txIndex)
+	switch res := tx.Result; {
+	case res.Failed != nil:
+		fmt.Printf("Status:  failed\n")
+		fmt.Printf("Module:  %s\n", res.Failed.Module)
+		fmt.Printf("Code:    %d\n", res.Failed.Code)
+		fmt.Printf("Message: %s\n", res.Failed.Message)
+	case res.Ok != nil:
+		fmt.Printf("Status: ok\n")
+		fmt.Printf("Data:\n")
+		prettyPrintCBOR("  ", "result", res.Ok)
+	case res.Unknown != nil:
+		fmt.Printf("Status: unknown\n")
+		fmt.Printf("Data:\n")
+		prettyPrintCBOR("  ", "result", res.Unknown)
+	default:
+		fmt.Printf("[unsupported result kind]\n")
+	}
+	fmt.Println()
+
+	// Show events.
+	fmt.Printf("=== Events emitted by transaction %d ===\n", txIndex)
+	if numEvents := len(tx.Events); numEvents > 0 {
+		fmt.Printf("Events: %d\n", numEvents)
+		fmt.Println()
+
+		for evIndex, ev := range tx.Events {
+			prettyPrintEvent("  ", evIndex, ev)
+			fmt.Println()
+		}
+	} else {
+		fmt.Println("No events emitted by this transaction.")
+	}
+}
+
 func parseBlockNum(
 	s string,
 ) (interface{}, error) { // TODO: Use `any`
@@ -303,6 +330,9 @@ func selectorFromString(s string) propertySelector {
 	if strings.ToLower(strings.TrimSpace(s)) == "events" {
 		return selEvents
 	}
+	if strings.ToLower(strings.TrimSpace(s)) == "tx" {
+		return selTx
+	}
 	return selInvalid
 }
 
@@ -438,7 +468,7 @@ func prettyPrintEvent(indent string, evIndex int, ev *types.Event) {
 			continue
 		}
 		if decoded != nil {
-			prettyPrintStruct(indent+"  ", "event", ev.Value, decoded)
+			prettyPrintStruct(indent+"  ", "event", ev.Value, maybeAnnotateEvent(decoded))
 			return
 		}
 	}
@@ -446,33 +476,39 @@ func prettyPrintEvent(indent string, evIndex int, ev *types.Event) {
 	prettyPrintCBOR(indent+"  ", "event", ev.Value)
 }
 
-func jsonPrintEvents(evs []*types.Event) {
-	out := []map[string]interface{}{}
+// jsonEventFields returns the JSON fields of the given event including the decoded event, if the
+// event can be decoded.
+func jsonEventFields(ev *types.Event) map[string]interface{} {
+	fields := make(map[string]interface{})
+	fields["module"] = ev.Module
+	fields["code"] = ev.Code
+	if ev.TxHash != nil {
+		fields["tx_hash"] = ev.TxHash.String()
+	}
+	fields["data"] = ev.Value
 
-	for _, ev := range evs {
-		fields := make(map[string]interface{})
-		fields["module"] = ev.Module
-		fields["code"] = ev.Code
-		if ev.TxHash != nil {
-			fields["tx_hash"] = ev.TxHash.String()
+	for _, decoder := range eventDecoders {
+		decoded, err := decoder(ev)
+		if err != nil {
+			continue
 		}
-		fields["data"] = ev.Value
-
-		for _, decoder := range eventDecoders {
-			decoded, err := decoder(ev)
-			if err != nil {
-				continue
-			}
-			if decoded != nil {
-				fields["parsed"] = decoded
+		if decoded != nil {
+			fields["parsed"] = maybeAnnotateEvent(decoded)
 
-				break
-			}
+			break
 		}
-		out = append(out, fields)
+	}
+	return fields
+}
+
+func jsonPrintEvents(evs []*types.Event) {
+	out := []map[string]interface{}{}
+
+	for _, ev := range evs {
+		out = append(out, jsonEventFields(ev))
 	}
 
-	str, err := common.PrettyJSONMarshal(out)
+	str, err := common.JSONMarshalOutput(out)
 	cobra.CheckErr(err)
 	fmt.Printf("%s\n", str)
 }
@@ -490,7 +526,7 @@ func showParameters(ctx context.Context, npa *common.NPASelection, round uint64,
 	doc := make(map[string]interface{})
 
 	doSection := func(name string, params interface{}) {
-		if common.OutputFormat() == common.FormatJSON {
+		if common.IsJSONOutput() {
 			doc[name] = params
 		} else {
 			fmt.Printf("\n=== %s PARAMETERS ===\n", strings.ToUpper(name))
@@ -501,8 +537,8 @@ func showParameters(ctx context.Context, npa *common.NPASelection, round uint64,
 
 	doSection("rofl", stakeThresholds)
 
-	if common.OutputFormat() == common.FormatJSON {
-		pp, err := json.MarshalIndent(doc, "", "  ")
+	if common.IsJSONOutput() {
+		pp, err := common.JSONMarshalOutput(doc)
 		cobra.CheckErr(err)
 		fmt.Printf("%s\n", pp)
 	}
@@ -513,7 +549,7 @@ func showEvents(ctx context.Context, round uint64, rt connection.RuntimeClient)
 	cobra.CheckErr(err)
 
 	if len(evs) == 0 {
-		if common.OutputFormat() == common.FormatJSON {
+		if common.IsJSONOutput() {
 			fmt.Printf("[]\n")
 		} else {
 			fmt.Println("No events emitted in this block.")
@@ -521,7 +557,7 @@ func showEvents(ctx context.Context, round uint64, rt connection.RuntimeClient)
 		return
 	}
 
-	if common.OutputFormat() == common.FormatJSON {
+	if common.IsJSONOutput() {
 		jsonPrintEvents(evs)
 	} else {
 		for evIndex, ev := range evs {
@@ -538,4 +574,10 @@ func init() {
 	showCmd.Flags().AddFlagSet(common.FormatFlag)
 	showCmd.Flags().AddFlagSet(common.SelectorNPFlags)
 	showCmd.Flags().AddFlagSet(roundFlag)
+	showCmd.Flags().Uint64Var(&txScanDepth, "scan-depth", 1000, "number of recent rounds to search for the transaction")
+	showCmd.Flags().StringVar(&web3Gateway, "web3-gateway", "", "Web3 gateway URL used to look up Ethereum transactions")
+	showCmd.Flags().StringVar(&scanAddress, "address", "", "list transactions involving the given address")
+	showCmd.Flags().StringVar(&scanRounds, "rounds", "", "range of rounds to scan for --address in the <first>..<last> form (default: the last --scan-depth rounds)")
+	showCmd.Flags().BoolVar(&resolveNames, "resolve-names", false, "annotate addresses in decoded events with names from the wallet and the address book")
+	showCmd.Flags().UintVar(&scanConcurrency, "concurrency", 8, "number of rounds fetched in parallel when scanning for --address")
 }
@@ -0,0 +1,381 @@
+package paratime
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/spf13/cobra"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/evm"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+
+	"github.com/oasisprotocol/cli/cmd/common"
+)
+
+var (
+	// scanAddress is the address whose activity is looked up in a range of rounds.
+	scanAddress string
+	// scanRounds is the range of rounds to scan in the <first>..<last> form.
+	scanRounds string
+	// scanConcurrency is the number of rounds fetched in parallel when scanning.
+	scanConcurrency uint
+)
+
+// Ways in which an address can be involved in a transaction.
+const (
+	involvementSigner   = "signer"
+	involvementTo       = "to"
+	involvementEvent    = "event"
+	involvementEventLog = "event log topic"
+)
+
+// addressActivity is a transaction involving the scanned address.
+type addressActivity struct {
+	round       uint64
+	index       int
+	tx          *client.TransactionWithResults
+	involvement []string
+}
+
+// parseRoundRange parses a range of rounds in the <first>..<last> form. The last round may be
+// "latest", in which case client.RoundLatest is returned.
+func parseRoundRange(s string) (uint64, uint64, error) {
+	rawFirst, rawLast, ok := strings.Cut(s, "..")
+	if !ok {
+		return 0, 0, fmt.Errorf("malformed round range '%s' (expected <first>..<last>)", s)
+	}
+	first, err := strconv.ParseUint(rawFirst, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("malformed first round: %w", err)
+	}
+	if rawLast == selRoundLatest {
+		return first, client.RoundLatest, nil
+	}
+	last, err := strconv.ParseUint(rawLast, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("malformed last round: %w", err)
+	}
+	if last < first {
+		return 0, 0, fmt.Errorf("last round %d is before the first round %d", last, first)
+	}
+	return first, last, nil
+}
+
+// txInvolvement returns the ways in which the given address is involved in the transaction: as a
+// signer, as the recipient of an Ethereum transaction or in the emitted events.
+func txInvolvement(tx *client.TransactionWithResults, addr types.Address, ethAddr *ethCommon.Address) []string {
+	var involvement []string
+	addInvolvement := func(kind string) {
+		for _, k := range involvement {
+			if k == kind {
+				return
+			}
+		}
+		involvement = append(involvement, kind)
+	}
+
+	if len(tx.Tx.AuthProofs) == 1 && tx.Tx.AuthProofs[0].Module == "evm.ethereum.v0" {
+		var ethTx ethTypes.Transaction
+		if err := ethTx.UnmarshalBinary(tx.Tx.Body); err == nil {
+			if sender, err := ethTypes.Sender(ethTypes.LatestSignerForChainID(ethTx.ChainId()), &ethTx); err == nil {
+				if types.NewAddressRaw(types.AddressV0Secp256k1EthContext, sender[:]).Equal(addr) {
+					addInvolvement(involvementSigner)
+				}
+			}
+			if to := ethTx.To(); to != nil && types.NewAddressRaw(types.AddressV0Secp256k1EthContext, to[:]).Equal(addr) {
+				addInvolvement(involvementTo)
+			}
+		}
+	} else {
+		var t types.Transaction
+		if err := cbor.Unmarshal(tx.Tx.Body, &t); err == nil {
+			for _, si := range t.AuthInfo.SignerInfo {
+				if signer, err := si.AddressSpec.Address(); err == nil && signer.Equal(addr) {
+					addInvolvement(involvementSigner)
+				}
+			}
+		}
+	}
+
+	for _, ev := range tx.Events {
+		for _, decoder := range eventDecoders {
+			decoded, err := decoder(ev)
+			if err != nil || decoded == nil {
+				continue
+			}
+			for _, d := range decoded {
+				if kind := eventInvolvement(d, addr, ethAddr); kind != "" {
+					addInvolvement(kind)
+				}
+			}
+			break
+		}
+	}
+	return involvement
+}
+
+// eventInvolvement returns how the given address is involved in the decoded event or an empty
+// string if it is not.
+func eventInvolvement(ev client.DecodedEvent, addr types.Address, ethAddr *ethCommon.Address) string {
+	if evmEv, ok := ev.(*evm.Event); ok {
+		if len(evmEv.Address) == ethCommon.AddressLength && types.NewAddressRaw(types.AddressV0Secp256k1EthContext, evmEv.Address).Equal(addr) {
+			return involvementEvent
+		}
+		if ethAddr == nil {
+			return ""
+		}
+		// Indexed address arguments (e.g. ERC-20 transfers) are left-padded to 32 bytes.
+		topic := ethCommon.BytesToHash(ethAddr[:])
+		for _, t := range evmEv.Topics {
+			if bytes.Equal(t, topic[:]) {
+				return involvementEventLog
+			}
+		}
+		return ""
+	}
+
+	if containsAddress(reflect.ValueOf(ev), addr) {
+		return involvementEvent
+	}
+	return ""
+}
+
+// containsAddress returns true iff the given value contains the address in any of its fields.
+func containsAddress(v reflect.Value, addr types.Address) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return false
+		}
+		return containsAddress(v.Elem(), addr)
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			if containsAddress(v.Index(i), addr) {
+				return true
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() && containsAddress(v.Field(i), addr) {
+				return true
+			}
+		}
+	case reflect.Array:
+		if v.Type() == reflect.TypeOf(types.Address{}) {
+			return v.Interface().(types.Address).Equal(addr)
+		}
+	}
+	return false
+}
+
+// scanRoundRange fetches the transactions of the given range of rounds concurrently and calls
+// visit for each round. Calls to visit and progress are serialized, but the rounds are visited in
+// no particular order. Progress is reported after each scanned round.
+func scanRoundRange(
+	ctx context.Context,
+	rt client.RuntimeClient,
+	first, last uint64,
+	concurrency uint,
+	visit func(round uint64, txs []*client.TransactionWithResults),
+	progress func(scanned uint64),
+) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		scanned  uint64
+		firstErr error
+	)
+	work := make(chan uint64)
+	go func() {
+		defer close(work)
+		for round := first; round <= last; round++ {
+			select {
+			case work <- round:
+			case <-ctx.Done():
+				return
+			}
+			if round == last {
+				// Prevent overflow in case the last round is the maximum value.
+				return
+			}
+		}
+	}()
+
+	for i := uint(0); i < max(concurrency, 1); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for round := range work {
+				txs, err := rt.GetTransactionsWithResults(ctx, round)
+
+				mu.Lock()
+				if err != nil {
+					if firstErr == nil {
+						firstErr = fmt.Errorf("failed to fetch transactions of round %d: %w", round, err)
+						cancel()
+					}
+					mu.Unlock()
+					continue
+				}
+				visit(round, txs)
+				scanned++
+				if progress != nil {
+					progress(scanned)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// scanAddressActivity fetches the transactions of the given range of rounds concurrently and
+// returns the ones involving the address ordered by round and index. Progress is reported after
+// each scanned round.
+func scanAddressActivity(
+	ctx context.Context,
+	rt client.RuntimeClient,
+	addr types.Address,
+	ethAddr *ethCommon.Address,
+	first, last uint64,
+	concurrency uint,
+	progress func(scanned uint64),
+) ([]*addressActivity, error) {
+	var activities []*addressActivity
+	visit := func(round uint64, txs []*client.TransactionWithResults) {
+		for idx, tx := range txs {
+			if involvement := txInvolvement(tx, addr, ethAddr); len(involvement) > 0 {
+				activities = append(activities, &addressActivity{
+					round:       round,
+					index:       idx,
+					tx:          tx,
+					involvement: involvement,
+				})
+			}
+		}
+	}
+	if err := scanRoundRange(ctx, rt, first, last, concurrency, visit, progress); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(activities, func(i, j int) bool {
+		if activities[i].round != activities[j].round {
+			return activities[i].round < activities[j].round
+		}
+		return activities[i].index < activities[j].index
+	})
+	return activities, nil
+}
+
+// resolveRoundRange resolves the given range of rounds in the <first>..<last> form against the
+// latest round. An empty range selects the given number of most recent rounds.
+func resolveRoundRange(ctx context.Context, rt client.RuntimeClient, rounds string, depth uint64) (uint64, uint64, error) {
+	latest, err := rt.GetBlock(ctx, client.RoundLatest)
+	if err != nil {
+		return 0, 0, err
+	}
+	latestRound := latest.Header.Round
+
+	if rounds == "" {
+		// Default to the recent rounds.
+		if latestRound >= depth && depth > 0 {
+			return latestRound - depth + 1, latestRound, nil
+		}
+		return 0, latestRound, nil
+	}
+
+	first, last, err := parseRoundRange(rounds)
+	if err != nil {
+		return 0, 0, err
+	}
+	if last == client.RoundLatest || last > latestRound {
+		last = latestRound
+	}
+	if first > last {
+		return 0, 0, fmt.Errorf("first round %d is after the latest round %d", first, latestRound)
+	}
+	return first, last, nil
+}
+
+// showAddressActivity scans the selected range of rounds and lists the transactions involving the
+// selected address.
+func showAddressActivity(ctx context.Context, npa *common.NPASelection, rt client.RuntimeClient) {
+	addr, ethAddr, err := common.ResolveLocalAccountOrAddress(npa.Network, scanAddress)
+	cobra.CheckErr(err)
+
+	first, last, err := resolveRoundRange(ctx, rt, scanRounds, txScanDepth)
+	cobra.CheckErr(err)
+
+	total := last - first + 1
+	step := max(total/10, 1)
+	p := common.StartProgress("scan rounds", total, "rounds")
+	progress := func(scanned uint64) {
+		p.Update(scanned)
+		if !common.IsJSONProgress() && (scanned%step == 0 || scanned == total) {
+			fmt.Fprintf(os.Stderr, "Scanned %d/%d rounds...\n", scanned, total)
+		}
+	}
+	activities, err := scanAddressActivity(ctx, rt, *addr, ethAddr, first, last, scanConcurrency, progress)
+	cobra.CheckErr(err)
+	p.Finish()
+
+	if common.IsJSONOutput() {
+		out := []map[string]interface{}{}
+		for _, a := range activities {
+			evs := []map[string]interface{}{}
+			for _, ev := range a.tx.Events {
+				evs = append(evs, jsonEventFields(ev))
+			}
+			out = append(out, map[string]interface{}{
+				"round":       a.round,
+				"index":       a.index,
+				"hash":        a.tx.Tx.Hash().String(),
+				"involvement": a.involvement,
+				"result":      a.tx.Result,
+				"events":      evs,
+			})
+		}
+		data, err := common.JSONMarshalOutput(out)
+		cobra.CheckErr(err)
+		fmt.Printf("%s\n", data)
+		return
+	}
+
+	fmt.Printf("Address:        %s\n", addr)
+	fmt.Printf("Rounds:         %d..%d\n", first, last)
+	fmt.Printf("Transactions:   %d\n", len(activities))
+	for _, a := range activities {
+		fmt.Println()
+		fmt.Printf("=== Round %d, transaction %d ===\n", a.round, a.index)
+		fmt.Printf("Hash:        %s\n", a.tx.Tx.Hash())
+		fmt.Printf("Involvement: %s\n", strings.Join(a.involvement, ", "))
+		switch res := a.tx.Result; {
+		case res.Failed != nil:
+			fmt.Printf("Status:      failed (module: %s, code: %d, message: %s)\n", res.Failed.Module, res.Failed.Code, res.Failed.Message)
+		case res.Ok != nil:
+			fmt.Printf("Status:      ok\n")
+		default:
+			fmt.Printf("Status:      unknown\n")
+		}
+		fmt.Printf("Events:      %d\n", len(a.tx.Events))
+	}
+	if len(activities) > 0 {
+		fmt.Println()
+		fmt.Println("Use 'paratime show <round> <tx-index>' to show the details of a transaction.")
+	}
+}
@@ -0,0 +1,96 @@
+package paratime
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/accounts"
+	sdkTesting "github.com/oasisprotocol/oasis-sdk/client-sdk/go/testing"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// scanRuntimeClient is a runtime client returning a transfer from Alice to Bob in every round
+// divisible by three.
+type scanRuntimeClient struct {
+	client.RuntimeClient
+
+	failRound uint64
+}
+
+func (rc *scanRuntimeClient) GetTransactionsWithResults(_ context.Context, round uint64) ([]*client.TransactionWithResults, error) {
+	if round == rc.failRound {
+		return nil, fmt.Errorf("round not available")
+	}
+	if round%3 != 0 {
+		return nil, nil
+	}
+
+	tx := types.NewTransaction(nil, "accounts.Transfer", nil)
+	tx.AppendAuthSignature(sdkTesting.Alice.SigSpec, 0)
+	ev := &types.Event{
+		Module: accounts.ModuleName,
+		Code:   accounts.TransferEventCode,
+		Value: cbor.Marshal([]accounts.TransferEvent{{
+			From:   sdkTesting.Alice.Address,
+			To:     sdkTesting.Bob.Address,
+			Amount: types.NewBaseUnits(*quantity.NewFromUint64(1), types.NativeDenomination),
+		}}),
+	}
+	return []*client.TransactionWithResults{{
+		Tx:     *tx.PrepareForSigning().UnverifiedTransaction(),
+		Result: types.CallResult{Ok: cbor.Marshal(nil)},
+		Events: []*types.Event{ev},
+	}}, nil
+}
+
+func TestParseRoundRange(t *testing.T) {
+	require := require.New(t)
+
+	first, last, err := parseRoundRange("10..20")
+	require.NoError(err)
+	require.EqualValues(10, first)
+	require.EqualValues(20, last)
+
+	first, last, err = parseRoundRange("10..latest")
+	require.NoError(err)
+	require.EqualValues(10, first)
+	require.EqualValues(client.RoundLatest, last)
+
+	for _, invalid := range []string{"10", "a..20", "10..b", "20..10"} {
+		_, _, err = parseRoundRange(invalid)
+		require.Error(err, invalid)
+	}
+}
+
+func TestScanAddressActivity(t *testing.T) {
+	require := require.New(t)
+
+	rc := &scanRuntimeClient{failRound: 100}
+	var scanned uint64
+	activities, err := scanAddressActivity(context.Background(), rc, sdkTesting.Alice.Address, nil, 1, 10, 4, func(n uint64) { scanned = n })
+	require.NoError(err)
+	require.EqualValues(10, scanned)
+	require.Len(activities, 3)
+	for i, round := range []uint64{3, 6, 9} {
+		require.Equal(round, activities[i].round)
+		require.Equal([]string{involvementSigner, involvementEvent}, activities[i].involvement)
+	}
+
+	activities, err = scanAddressActivity(context.Background(), rc, sdkTesting.Bob.Address, nil, 1, 10, 4, nil)
+	require.NoError(err)
+	require.Len(activities, 3)
+	require.Equal([]string{involvementEvent}, activities[0].involvement)
+
+	activities, err = scanAddressActivity(context.Background(), rc, sdkTesting.Charlie.Address, nil, 1, 10, 4, nil)
+	require.NoError(err)
+	require.Empty(activities)
+
+	_, err = scanAddressActivity(context.Background(), rc, sdkTesting.Alice.Address, nil, 90, 110, 4, nil)
+	require.Error(err)
+}
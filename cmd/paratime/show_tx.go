@@ -0,0 +1,109 @@
+package paratime
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+)
+
+var (
+	// txScanDepth is the maximum number of rounds scanned when looking up a transaction.
+	txScanDepth uint64
+	// web3Gateway is the URL of the Web3 gateway used to look up Ethereum transactions.
+	web3Gateway string
+)
+
+// parseTxHash parses an Oasis or Ethereum transaction hash with an optional 0x prefix.
+func parseTxHash(s string) (hash.Hash, error) {
+	var h hash.Hash
+	if err := h.UnmarshalHex(strings.TrimPrefix(s, "0x")); err != nil {
+		return h, fmt.Errorf("malformed tx hash: %w", err)
+	}
+	return h, nil
+}
+
+// txMatchesHash returns true if either the Oasis hash or, for Ethereum-encoded transactions, the
+// Ethereum hash of the given transaction matches the given hash.
+func txMatchesHash(tx *client.TransactionWithResults, h hash.Hash) bool {
+	if txHash := tx.Tx.Hash(); txHash.Equal(&h) {
+		return true
+	}
+	if len(tx.Tx.AuthProofs) != 1 || tx.Tx.AuthProofs[0].Module != "evm.ethereum.v0" {
+		return false
+	}
+
+	var ethTx ethTypes.Transaction
+	if err := ethTx.UnmarshalBinary(tx.Tx.Body); err != nil {
+		return false
+	}
+	return ethTx.Hash() == ethCommon.Hash(h)
+}
+
+// findTxIndex returns the index of the transaction with the given hash or -1 if not found.
+func findTxIndex(txs []*client.TransactionWithResults, h hash.Hash) int {
+	for i, tx := range txs {
+		if txMatchesHash(tx, h) {
+			return i
+		}
+	}
+	return -1
+}
+
+// web3TxRound queries the Web3 gateway for the round containing the given Ethereum transaction.
+func web3TxRound(ctx context.Context, url string, h hash.Hash) (uint64, error) {
+	ec, err := ethclient.DialContext(ctx, url)
+	if err != nil {
+		return 0, fmt.Errorf("failed to connect to Web3 gateway: %w", err)
+	}
+	defer ec.Close()
+
+	receipt, err := ec.TransactionReceipt(ctx, ethCommon.Hash(h))
+	if err != nil {
+		return 0, fmt.Errorf("failed to query Web3 gateway: %w", err)
+	}
+	return receipt.BlockNumber.Uint64(), nil
+}
+
+// locateTransaction finds the round and index of the transaction with the given hash. When a
+// Web3 gateway is configured it is asked for the round, otherwise the recent rounds are scanned.
+func locateTransaction(ctx context.Context, rt client.RuntimeClient, h hash.Hash) (uint64, int, []*client.TransactionWithResults, error) {
+	if web3Gateway != "" {
+		round, err := web3TxRound(ctx, web3Gateway, h)
+		if err != nil {
+			return 0, 0, nil, err
+		}
+		txs, err := rt.GetTransactionsWithResults(ctx, round)
+		if err != nil {
+			return 0, 0, nil, err
+		}
+		if idx := findTxIndex(txs, h); idx != -1 {
+			return round, idx, txs, nil
+		}
+		return 0, 0, nil, fmt.Errorf("transaction %s not found in round %d reported by the Web3 gateway", h, round)
+	}
+
+	latest, err := rt.GetBlock(ctx, client.RoundLatest)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	for round := latest.Header.Round; latest.Header.Round-round < txScanDepth; round-- {
+		txs, err := rt.GetTransactionsWithResults(ctx, round)
+		if err != nil {
+			return 0, 0, nil, fmt.Errorf("failed to fetch transactions of round %d: %w", round, err)
+		}
+		if idx := findTxIndex(txs, h); idx != -1 {
+			return round, idx, txs, nil
+		}
+		if round == 0 {
+			break
+		}
+	}
+	return 0, 0, nil, fmt.Errorf("transaction %s not found in the last %d rounds, use --scan-depth to search further or --web3-gateway to look it up directly", h, txScanDepth)
+}
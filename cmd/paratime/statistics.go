@@ -18,7 +18,6 @@ import (
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
-	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/connection"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
 
 	"github.com/oasisprotocol/cli/cmd/common"
@@ -26,7 +25,10 @@ import (
 	"github.com/oasisprotocol/cli/metadata"
 )
 
-var fileCSV string
+var (
+	fileCSV      string
+	slaThreshold float64
+)
 
 type runtimeStats struct {
 	// Rounds.
@@ -83,6 +85,41 @@ type entityStats struct {
 	missedPrimary uint64
 	// How many rounds missed committing a block while being a backup worker (and discrepancy detection was invoked).
 	missedBackup uint64
+
+	// Current number of consecutive rounds missed committing a block.
+	missStreak uint64
+	// Longest number of consecutive rounds missed committing a block.
+	maxMissStreak uint64
+}
+
+// recordCommitment updates the miss streaks after a round in which the entity was required to
+// commit.
+func (e *entityStats) recordCommitment(committed bool) {
+	if committed {
+		e.missStreak = 0
+		return
+	}
+	e.missStreak++
+	e.maxMissStreak = max(e.maxMissStreak, e.missStreak)
+}
+
+// roundsRequired returns the number of rounds the entity was required to commit a block.
+func (e *entityStats) roundsRequired() uint64 {
+	return e.roundsPrimary + e.roundsBackupRequired
+}
+
+// roundsCommitted returns the number of rounds the entity committed a block when required.
+func (e *entityStats) roundsCommitted() uint64 {
+	return e.committedGoodBlocksPrimary + e.committedBadBlocksPrimary + e.committedGoodBlocksBackup + e.committedBadBlocksBackup
+}
+
+// availability returns the percentage of rounds the entity committed a block when required.
+func (e *entityStats) availability() float64 {
+	required := e.roundsRequired()
+	if required == 0 {
+		return 100
+	}
+	return 100 * float64(e.roundsCommitted()) / float64(required)
 }
 
 var statsCmd = &cobra.Command{
@@ -100,6 +137,9 @@ var statsCmd = &cobra.Command{
 			cobra.CheckErr("no ParaTimes configured")
 		}
 		runtimeID := npa.ParaTime.Namespace()
+		if slaThreshold < 0 || slaThreshold > 100 {
+			cobra.CheckErr(fmt.Errorf("SLA threshold must be between 0 and 100"))
+		}
 
 		// Parse command line arguments
 		var (
@@ -122,7 +162,7 @@ var statsCmd = &cobra.Command{
 
 		// Establish connection with the target network.
 		ctx := context.Background()
-		conn, err := connection.Connect(ctx, npa.Network)
+		conn, err := common.Connect(ctx, npa.Network)
 		cobra.CheckErr(err)
 
 		consensusConn := conn.Consensus()
@@ -226,8 +266,10 @@ var statsCmd = &cobra.Command{
 			}
 		}
 
+		p := common.StartProgress("scan blocks", uint64(endHeight-startHeight), "blocks")
 		for height := startHeight; height < endHeight; height++ {
-			if height%1000 == 0 {
+			p.Update(uint64(height - startHeight))
+			if height%1000 == 0 && !common.IsJSONProgress() {
 				fmt.Printf("progressed: height: %d\n", height)
 			}
 
@@ -338,10 +380,13 @@ var statsCmd = &cobra.Command{
 
 								if _, ok := good[entity]; ok {
 									stats.entities[entity].committedGoodBlocksPrimary++
+									stats.entities[entity].recordCommitment(true)
 								} else if _, ok := bad[entity]; ok {
 									stats.entities[entity].committedBadBlocksPrimary++
+									stats.entities[entity].recordCommitment(true)
 								} else {
 									stats.entities[entity].missedPrimary++
+									stats.entities[entity].recordCommitment(false)
 								}
 
 								if member.PublicKey == primaryScheduler.PublicKey {
@@ -367,10 +412,13 @@ var statsCmd = &cobra.Command{
 
 								if _, ok := good[entity]; ok {
 									stats.entities[entity].committedGoodBlocksBackup++
+									stats.entities[entity].recordCommitment(true)
 								} else if _, ok := bad[entity]; ok {
 									stats.entities[entity].committedBadBlocksBackup++
+									stats.entities[entity].recordCommitment(true)
 								} else {
 									stats.entities[entity].missedBackup++
+									stats.entities[entity].recordCommitment(false)
 								}
 							case scheduler.RoleInvalid:
 							}
@@ -381,6 +429,8 @@ var statsCmd = &cobra.Command{
 				}
 			}
 		}
+		p.Update(uint64(endHeight - startHeight))
+		p.Finish()
 
 		// Prepare and printout stats.
 		entityMetadataLookup, err := metadata.EntitiesFromRegistry(ctx)
@@ -433,6 +483,13 @@ func (s *runtimeStats) prepareEntitiesOutput(
 		"Prim Proposed",
 		"Bckp Proposed",
 	}
+	if slaThreshold > 0 {
+		s.entitiesHeader = append(s.entitiesHeader,
+			"Availability",
+			"Max Miss Streak",
+			"SLA",
+		)
+	}
 
 	addrToName := func(addr types.Address) string {
 		if metadataLookup != nil {
@@ -463,6 +520,13 @@ func (s *runtimeStats) prepareEntitiesOutput(
 			strconv.FormatUint(stats.roundsPrimaryProposed, 10),
 			strconv.FormatUint(stats.roundsBackupProposed, 10),
 		}
+		if slaThreshold > 0 {
+			line = append(line,
+				fmt.Sprintf("%.2f%%", stats.availability()),
+				strconv.FormatUint(stats.maxMissStreak, 10),
+				slaResult(stats.availability()),
+			)
+		}
 		s.entitiesOutput = append(s.entitiesOutput, line)
 	}
 
@@ -488,6 +552,27 @@ func (s *runtimeStats) printStats() {
 	fmt.Println()
 	fmt.Printf("%-26s %d", "Suspended:", s.suspendedRounds)
 	fmt.Println()
+
+	if slaThreshold > 0 {
+		var passed int
+		for _, stats := range s.entities {
+			if stats.availability() >= slaThreshold {
+				passed++
+			}
+		}
+		fmt.Printf("%-26s %.2f%%", "SLA threshold:", slaThreshold)
+		fmt.Println()
+		fmt.Printf("%-26s %d/%d", "Entities meeting SLA:", passed, len(s.entities))
+		fmt.Println()
+	}
+}
+
+// slaResult returns whether the given availability meets the SLA threshold.
+func slaResult(availability float64) string {
+	if availability >= slaThreshold {
+		return "PASS"
+	}
+	return "FAIL"
 }
 
 func (s *runtimeStats) printEntityStats() {
@@ -503,4 +588,5 @@ func (s *runtimeStats) printEntityStats() {
 func init() {
 	statsCmd.Flags().AddFlagSet(common.SelectorNPFlags)
 	statsCmd.Flags().StringVarP(&fileCSV, "output-file", "o", "", "output statistics into specified CSV file")
+	statsCmd.Flags().Float64Var(&slaThreshold, "sla", 0, "report entity availability against the given SLA threshold in percent (e.g. 99.5)")
 }
@@ -0,0 +1,182 @@
+package paratime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	coreCommon "github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/connection"
+
+	"github.com/oasisprotocol/cli/cmd/common"
+	cliConfig "github.com/oasisprotocol/cli/config"
+)
+
+// maxDiscrepancyScanHeights is the maximum number of consensus blocks scanned for discrepancy
+// events between two consecutive rounds.
+const maxDiscrepancyScanHeights = 100
+
+// watchedRound is a finalized runtime round as reported by the watch command.
+type watchedRound struct {
+	Time          time.Time `json:"time"`
+	Round         uint64    `json:"round"`
+	Height        int64     `json:"height"`
+	HeaderType    string    `json:"header_type"`
+	Txs           int       `json:"txs"`
+	Discrepancies uint64    `json:"discrepancies"`
+	Timeouts      uint64    `json:"discrepancy_timeouts"`
+	Alerts        []string  `json:"alerts,omitempty"`
+}
+
+// collectAlerts returns the human readable alerts for the given round.
+func (r *watchedRound) collectAlerts() []string {
+	var alerts []string
+	switch r.HeaderType {
+	case headerTypeName(block.RoundFailed):
+		alerts = append(alerts, "ROUND FAILED")
+	case headerTypeName(block.EpochTransition):
+		alerts = append(alerts, "EPOCH TRANSITION")
+	case headerTypeName(block.Suspended):
+		alerts = append(alerts, "SUSPENDED")
+	}
+	if r.Discrepancies > 0 {
+		alerts = append(alerts, fmt.Sprintf("DISCREPANCY x%d", r.Discrepancies))
+	}
+	if r.Timeouts > 0 {
+		alerts = append(alerts, fmt.Sprintf("DISCREPANCY TIMEOUT x%d", r.Timeouts))
+	}
+	return alerts
+}
+
+// headerTypeName returns the human readable name of the given block header type.
+func headerTypeName(ht block.HeaderType) string {
+	switch ht {
+	case block.Normal:
+		return "normal"
+	case block.RoundFailed:
+		return "failed"
+	case block.EpochTransition:
+		return "epoch-transition"
+	case block.Suspended:
+		return "suspended"
+	default:
+		return fmt.Sprintf("unknown(%d)", ht)
+	}
+}
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Follow ParaTime rounds in real time",
+	Long: `Follow finalized rounds of the selected ParaTime and print the round number,
+header type and number of transactions. Failed rounds, execution discrepancies
+and epoch transitions are highlighted.`,
+	Args: cobra.NoArgs,
+	Run: func(_ *cobra.Command, _ []string) {
+		cfg := cliConfig.Global()
+		npa := common.GetNPASelection(cfg)
+		if npa.ParaTime == nil {
+			cobra.CheckErr("no ParaTime selected")
+		}
+
+		ctx := context.Background()
+		conn, err := common.Connect(ctx, npa.Network)
+		cobra.CheckErr(err)
+
+		rt := conn.Runtime(npa.ParaTime)
+		blkCh, sub, err := rt.WatchBlocks(ctx)
+		cobra.CheckErr(err)
+		defer sub.Close()
+
+		if !common.IsJSONOutput() {
+			fmt.Printf("Watching rounds of %s on %s...\n", npa.ParaTimeName, npa.PrettyPrintNetwork())
+		}
+
+		runtimeID := npa.ParaTime.Namespace()
+		var lastHeight int64
+		for blk := range blkCh {
+			r := &watchedRound{
+				Time:       time.Unix(int64(blk.Block.Header.Timestamp), 0),
+				Round:      blk.Block.Header.Round,
+				Height:     blk.Height,
+				HeaderType: headerTypeName(blk.Block.Header.HeaderType),
+			}
+
+			if blk.Block.Header.HeaderType == block.Normal {
+				txs, err := rt.GetTransactions(ctx, r.Round)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: failed to query transactions of round %d: %v\n", r.Round, err)
+				}
+				r.Txs = len(txs)
+			}
+
+			// Discrepancy events are emitted in the consensus blocks preceding finalization.
+			startHeight := lastHeight + 1
+			if lastHeight == 0 || blk.Height-startHeight >= maxDiscrepancyScanHeights {
+				startHeight = blk.Height
+			}
+			if err = countDiscrepancies(ctx, conn, runtimeID, startHeight, blk.Height, r); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to query events of round %d: %v\n", r.Round, err)
+			}
+			lastHeight = blk.Height
+
+			printWatchedRound(r)
+		}
+		cobra.CheckErr("block subscription closed")
+	},
+}
+
+// countDiscrepancies counts the execution discrepancy events of the given runtime emitted between
+// the given consensus heights (inclusive).
+func countDiscrepancies(ctx context.Context, conn connection.Connection, runtimeID coreCommon.Namespace, start, end int64, r *watchedRound) error {
+	for height := start; height <= end; height++ {
+		evs, err := conn.Consensus().RootHash().GetEvents(ctx, height)
+		if err != nil {
+			return err
+		}
+		for _, ev := range evs {
+			if ev.RuntimeID != runtimeID || ev.ExecutionDiscrepancyDetected == nil {
+				continue
+			}
+			if ev.ExecutionDiscrepancyDetected.Timeout {
+				r.Timeouts++
+			} else {
+				r.Discrepancies++
+			}
+		}
+	}
+	return nil
+}
+
+// printWatchedRound prints the given round in the selected output format.
+func printWatchedRound(r *watchedRound) {
+	r.Alerts = r.collectAlerts()
+	if common.IsJSONOutput() {
+		data, err := json.Marshal(r)
+		cobra.CheckErr(err)
+		fmt.Println(string(data))
+		return
+	}
+
+	line := fmt.Sprintf("[%s] round %d (height %d) %-16s txs: %d",
+		r.Time.Local().Format(time.DateTime),
+		r.Round,
+		r.Height,
+		r.HeaderType,
+		r.Txs,
+	)
+	if len(r.Alerts) > 0 {
+		line += "  !! " + strings.Join(r.Alerts, ", ")
+	}
+	fmt.Println(line)
+}
+
+func init() {
+	watchCmd.Flags().AddFlagSet(common.SelectorNPFlags)
+	watchCmd.Flags().AddFlagSet(common.FormatFlag)
+}
@@ -20,6 +20,8 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+
+	"github.com/oasisprotocol/cli/cmd/common"
 )
 
 const artifactCacheDir = "build_cache"
@@ -29,6 +31,7 @@ const artifactCacheDir = "build_cache"
 func maybeDownloadArtifact(kind, uri string) string {
 	fmt.Printf("Downloading %s artifact...\n", kind)
 	fmt.Printf("  URI: %s\n", uri)
+	defer common.LogStage(logger, "fetch artifact", "kind", kind, "uri", uri)()
 
 	url, err := url.Parse(uri)
 	if err != nil {
@@ -94,7 +97,9 @@ func maybeDownloadArtifact(kind, uri string) string {
 
 		// Compute the SHA256 hash while downloading the artifact.
 		h := sha256.New()
-		rd := io.TeeReader(res.Body, h)
+		p := common.StartProgress("download "+kind+" artifact", uint64(max(res.ContentLength, 0)), "bytes")
+		defer p.Finish()
+		rd := io.TeeReader(p.Reader(res.Body), h)
 
 		if _, err = io.Copy(f, rd); err != nil {
 			cobra.CheckErr(fmt.Errorf("failed to download %s artifact: %w", kind, err))
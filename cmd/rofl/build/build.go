@@ -17,6 +17,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/runtime/bundle"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/connection"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/rofl"
 
 	buildRofl "github.com/oasisprotocol/cli/build/rofl"
@@ -35,9 +36,15 @@ var (
 	outputFn       string
 	buildMode      string
 	offline        bool
+	noCache        bool
 	doUpdate       bool
 	doVerify       bool
 	deploymentName string
+	refreshRoot    bool
+	rootMaxAge     uint64
+	signWith       string
+
+	logger = common.NewLogger("rofl/build")
 
 	Cmd = &cobra.Command{
 		Use:   "build",
@@ -51,8 +58,24 @@ var (
 			if doVerify && doUpdate {
 				cobra.CheckErr("only one of --verify and --update-manifest may be passed")
 			}
+			if refreshRoot {
+				if offline {
+					cobra.CheckErr("--refresh-trust-root cannot be used in offline mode")
+				}
+				err := roflCommon.RefreshTrustRoot(context.Background(), npa, manifest, deployment, rootMaxAge)
+				cobra.CheckErr(err)
+			}
+
+			// Load the signer upfront to not ask for a passphrase after a long build.
+			var signer signature.Signer
+			if signWith != "" {
+				var err error
+				signer, err = loadBundleSigner(cfg, signWith)
+				cobra.CheckErr(err)
+			}
 
 			fmt.Println("Building a ROFL application...")
+			defer common.LogStage(logger, "build", "deployment", deploymentName, "tee", manifest.TEE, "kind", manifest.Kind)()
 			fmt.Printf("Deployment: %s\n", deploymentName)
 			fmt.Printf("Network:    %s\n", deployment.Network)
 			fmt.Printf("ParaTime:   %s\n", deployment.ParaTime)
@@ -96,7 +119,14 @@ var (
 			os.Setenv("ROFL_DEPLOYMENT_PARATIME", deployment.ParaTime)
 			os.Setenv("ROFL_TMPDIR", tmpDir)
 
-			runScript(manifest, buildRofl.ScriptBuildPre)
+			sctx := &scriptContext{
+				Manifest:   manifest,
+				Deployment: deploymentName,
+				Network:    deployment.Network,
+				ParaTime:   deployment.ParaTime,
+				TmpDir:     tmpDir,
+			}
+			runScript(sctx, buildRofl.ScriptBuildPre)
 
 			switch manifest.TEE {
 			case buildRofl.TEETypeSGX:
@@ -124,27 +154,56 @@ var (
 				return
 			}
 
-			runScript(manifest, buildRofl.ScriptBuildPost)
+			runScript(sctx, buildRofl.ScriptBuildPost)
+
+			if signer != nil {
+				if err = buildRofl.EmbedBundleSigner(bnd, signer.Public()); err != nil {
+					fmt.Printf("%s\n", err)
+					return
+				}
+			}
 
 			// Write the bundle out.
-			outFn := fmt.Sprintf("%s.%s.orc", manifest.Name, deploymentName)
+			outFn := roflCommon.BundleFilename(manifest, deploymentName)
 			if outputFn != "" {
 				outFn = outputFn
 			}
+			done := common.LogStage(logger, "write bundle", "path", outFn)
 			if err = bnd.Write(outFn); err != nil {
 				fmt.Printf("failed to write output bundle: %s\n", err)
 				return
 			}
+			done()
 
 			fmt.Printf("ROFL app built and bundle written to '%s'.\n", outFn)
 
+			if signer != nil {
+				done = common.LogStage(logger, "sign bundle")
+				var sig *buildRofl.BundleSignature
+				sig, err = buildRofl.SignBundle(bnd, signer)
+				if err != nil {
+					fmt.Printf("%s\n", err)
+					return
+				}
+				sigFn := buildRofl.BundleSignatureFileFor(outFn)
+				if err = sig.Save(sigFn); err != nil {
+					fmt.Printf("failed to write bundle signature: %s\n", err)
+					return
+				}
+				done()
+
+				fmt.Printf("Bundle signature written to '%s'.\n", sigFn)
+			}
+
 			fmt.Println("Computing enclave identity...")
 
+			done = common.LogStage(logger, "compute enclave identity")
 			eids, err := roflCommon.ComputeEnclaveIdentity(bnd, "")
 			if err != nil {
 				fmt.Printf("%s\n", err)
 				return
 			}
+			done()
 
 			// Setup some post-bundle environment variables.
 			os.Setenv("ROFL_BUNDLE", outFn)
@@ -153,7 +212,9 @@ var (
 				os.Setenv(fmt.Sprintf("ROFL_ENCLAVE_ID_%d", idx), string(data))
 			}
 
-			runScript(manifest, buildRofl.ScriptBundlePost)
+			sctx.Bundle = outFn
+			sctx.EnclaveIdentities = eids
+			runScript(sctx, buildRofl.ScriptBundlePost)
 
 			buildEnclaves := make(map[sgx.EnclaveIdentity]struct{})
 			for _, eid := range eids {
@@ -193,7 +254,7 @@ var (
 				if !offline {
 					var conn connection.Connection
 					ctx := context.Background()
-					conn, err = connection.Connect(ctx, npa.Network)
+					conn, err = common.Connect(ctx, npa.Network)
 					cobra.CheckErr(err)
 
 					var appID rofl.AppID
@@ -287,7 +348,7 @@ func fetchTrustRoot(npa *common.NPASelection, cfg *buildRofl.TrustRootConfig) (s
 
 		// Establish connection with the target network.
 		ctx := context.Background()
-		conn, err := connection.Connect(ctx, npa.Network)
+		conn, err := common.Connect(ctx, npa.Network)
 		if err != nil {
 			return "", err
 		}
@@ -336,9 +397,13 @@ func init() {
 	buildFlags := flag.NewFlagSet("", flag.ContinueOnError)
 	buildFlags.BoolVar(&offline, "offline", false, "do not perform any operations requiring network access")
 	buildFlags.StringVar(&outputFn, "output", "", "output bundle filename")
+	buildFlags.BoolVar(&noCache, "no-cache", false, "do not reuse cached intermediate build artifacts")
 	buildFlags.BoolVar(&doUpdate, "update-manifest", false, "automatically update the manifest")
 	buildFlags.BoolVar(&doVerify, "verify", false, "verify build against manifest and on-chain state")
 	buildFlags.StringVar(&deploymentName, "deployment", buildRofl.DefaultDeploymentName, "deployment name")
+	buildFlags.BoolVar(&refreshRoot, "refresh-trust-root", false, "update the trust root in the manifest to a recent block before building")
+	buildFlags.StringVar(&signWith, "sign", "", "sign the bundle with the given account or PEM-encoded private key file")
+	buildFlags.Uint64Var(&rootMaxAge, "trust-root-max-age", roflCommon.DefaultTrustRootMaxAge, "warn if the existing trust root is older than the given number of blocks")
 
 	Cmd.Flags().AddFlagSet(buildFlags)
 }
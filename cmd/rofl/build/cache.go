@@ -0,0 +1,289 @@
+package build
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/adrg/xdg"
+)
+
+const (
+	// stage2CacheImageFn is the name of the cached stage 2 image within a cache entry.
+	stage2CacheImageFn = "stage2.img"
+	// stage2CacheMetaFn is the name of the cached stage 2 metadata within a cache entry.
+	stage2CacheMetaFn = "stage2.json"
+	// stage2BaseCacheRootfsDir is the name of the unpacked base rootfs within a base cache entry.
+	stage2BaseCacheRootfsDir = "rootfs"
+	// stage2BaseCacheCompleteFn is the name of the marker file of a fully unpacked base rootfs.
+	stage2BaseCacheCompleteFn = "complete"
+
+	// stage2CacheImagePrefix is the prefix of stage 2 image cache entries.
+	stage2CacheImagePrefix = "stage2-"
+	// stage2CacheBasePrefix is the prefix of stage 2 base rootfs cache entries.
+	stage2CacheBasePrefix = "stage2base-"
+	// stage2CacheMaxImages is the maximum number of cached stage 2 images.
+	stage2CacheMaxImages = 4
+	// stage2CacheMaxBases is the maximum number of cached stage 2 base root filesystems.
+	stage2CacheMaxBases = 2
+	// stage2CacheMaxAge is the maximum time a cache entry is kept since it was last used.
+	stage2CacheMaxAge = 30 * 24 * time.Hour
+)
+
+// stage2CacheMeta is the metadata stored together with a cached stage 2 image.
+type stage2CacheMeta struct {
+	RootHash string `json:"root_hash"`
+	FsSize   int64  `json:"fs_size"`
+}
+
+// hashFile returns the hex-encoded SHA256 hash of the given file.
+func hashFile(fn string) (string, error) {
+	f, err := os.Open(fn)
+	if err != nil {
+		return "", fmt.Errorf("failed to open '%s': %w", fn, err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("failed to hash '%s': %w", fn, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// stage2BaseCacheKey derives the cache key of an unpacked stage 2 base rootfs from the hashes of
+// the template and init artifacts it is made of.
+func stage2BaseCacheKey(templatePath, initPath string) (string, error) {
+	templateHash, err := hashFile(templatePath)
+	if err != nil {
+		return "", err
+	}
+	initHash, err := hashFile(initPath)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	_, _ = fmt.Fprintf(h, "template:%s\ninit:%s\n", templateHash, initHash)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// stage2CacheKey derives the cache key of a stage 2 image from the key of its base rootfs and
+// the hashes of all extra files added on top of it.
+func stage2CacheKey(baseKey string, extraFiles map[string]string) (string, error) {
+	h := sha256.New()
+	_, _ = fmt.Fprintf(h, "base:%s\n", baseKey)
+
+	// Sort extra files by destination so the key does not depend on map iteration order.
+	srcs := make([]string, 0, len(extraFiles))
+	for src := range extraFiles {
+		srcs = append(srcs, src)
+	}
+	slices.SortFunc(srcs, func(a, b string) int {
+		return cmp.Compare(extraFiles[a], extraFiles[b])
+	})
+	for _, src := range srcs {
+		fh, err := hashFile(src)
+		if err != nil {
+			return "", err
+		}
+		_, _ = fmt.Fprintf(h, "%s:%s\n", extraFiles[src], fh)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// stage2CacheRoot returns the root directory of all stage 2 cache entries.
+func stage2CacheRoot() (string, error) {
+	fn, err := xdg.CacheFile(filepath.Join("oasis", artifactCacheDir, stage2CacheMetaFn))
+	if err != nil {
+		return "", fmt.Errorf("failed to create stage 2 cache directory: %w", err)
+	}
+	return filepath.Dir(fn), nil
+}
+
+// stage2CacheDir returns the cache directory for the stage 2 image with the given key.
+func stage2CacheDir(key string) (string, error) {
+	root, err := stage2CacheRoot()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(root, stage2CacheImagePrefix+key)
+	if err = os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create stage 2 cache directory: %w", err)
+	}
+	return dir, nil
+}
+
+// stage2CacheLoad copies the cached stage 2 image with the given key to the given destination
+// file. It returns nil in case there is no usable cache entry.
+func stage2CacheLoad(key, dst string) *tdxStage2 {
+	dir, err := stage2CacheDir(key)
+	if err != nil {
+		return nil
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, stage2CacheMetaFn))
+	if err != nil {
+		return nil
+	}
+	var meta stage2CacheMeta
+	if err = json.Unmarshal(data, &meta); err != nil || meta.RootHash == "" {
+		return nil
+	}
+
+	// The bundled image gets modified in place (e.g. padded for storage), so always use a copy.
+	if err = copyFile(filepath.Join(dir, stage2CacheImageFn), dst, 0o644); err != nil {
+		return nil
+	}
+	stage2CacheTouch(dir)
+
+	return &tdxStage2{
+		fn:       dst,
+		rootHash: meta.RootHash,
+		fsSize:   meta.FsSize,
+	}
+}
+
+// stage2CacheStore stores the given stage 2 image into the cache under the given key.
+func stage2CacheStore(key string, stage2 *tdxStage2) error {
+	dir, err := stage2CacheDir(key)
+	if err != nil {
+		return err
+	}
+
+	if err = copyFile(stage2.fn, filepath.Join(dir, stage2CacheImageFn), 0o644); err != nil {
+		return err
+	}
+
+	// Write metadata last so that partially written entries are never used.
+	data, err := json.Marshal(&stage2CacheMeta{
+		RootHash: stage2.rootHash,
+		FsSize:   stage2.fsSize,
+	})
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(filepath.Join(dir, stage2CacheMetaFn), data, 0o644); err != nil {
+		return err
+	}
+	stage2CacheTouch(dir)
+	return nil
+}
+
+// stage2BaseCacheDir returns the cache directory for the stage 2 base rootfs with the given key.
+func stage2BaseCacheDir(key string) (string, error) {
+	root, err := stage2CacheRoot()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(root, stage2CacheBasePrefix+key), nil
+}
+
+// stage2BaseCacheLoad returns the path to the cached unpacked base rootfs with the given key. It
+// returns an empty string in case there is no complete cache entry.
+func stage2BaseCacheLoad(key string) string {
+	dir, err := stage2BaseCacheDir(key)
+	if err != nil {
+		return ""
+	}
+	if _, err = os.Stat(filepath.Join(dir, stage2BaseCacheCompleteFn)); err != nil {
+		return ""
+	}
+	stage2CacheTouch(dir)
+	return filepath.Join(dir, stage2BaseCacheRootfsDir)
+}
+
+// stage2BaseCachePrepare removes any partial base cache entry with the given key and returns the
+// empty rootfs directory to unpack the base into.
+func stage2BaseCachePrepare(key string) (string, error) {
+	dir, err := stage2BaseCacheDir(key)
+	if err != nil {
+		return "", err
+	}
+	if err = os.RemoveAll(dir); err != nil {
+		return "", fmt.Errorf("failed to remove stale stage 2 base cache: %w", err)
+	}
+	rootfsDir := filepath.Join(dir, stage2BaseCacheRootfsDir)
+	if err = os.MkdirAll(rootfsDir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create stage 2 base cache directory: %w", err)
+	}
+	return rootfsDir, nil
+}
+
+// stage2BaseCacheComplete marks the base cache entry with the given key as fully unpacked.
+func stage2BaseCacheComplete(key string) error {
+	dir, err := stage2BaseCacheDir(key)
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(filepath.Join(dir, stage2BaseCacheCompleteFn), nil, 0o644); err != nil {
+		return err
+	}
+	stage2CacheTouch(dir)
+	return nil
+}
+
+// stage2CacheTouch marks the given cache entry as recently used.
+func stage2CacheTouch(dir string) {
+	now := time.Now()
+	_ = os.Chtimes(dir, now, now)
+}
+
+// stage2CacheEvict removes stage 2 cache entries that have not been used for a long time and
+// limits the number of remaining entries, keeping the most recently used ones.
+func stage2CacheEvict() {
+	root, err := stage2CacheRoot()
+	if err != nil {
+		return
+	}
+	stage2CacheEvictDir(root, time.Now())
+}
+
+// stage2CacheEvictDir performs stage 2 cache eviction in the given cache root directory.
+func stage2CacheEvictDir(root string, now time.Time) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return
+	}
+
+	type cacheEntry struct {
+		path    string
+		lastUse time.Time
+	}
+	evict := func(prefix string, maxEntries int) {
+		var keep []cacheEntry
+		for _, e := range entries {
+			if !e.IsDir() || !strings.HasPrefix(e.Name(), prefix) {
+				continue
+			}
+			info, err := e.Info()
+			if err != nil {
+				continue
+			}
+			path := filepath.Join(root, e.Name())
+			if now.Sub(info.ModTime()) > stage2CacheMaxAge {
+				_ = os.RemoveAll(path)
+				continue
+			}
+			keep = append(keep, cacheEntry{path, info.ModTime()})
+		}
+
+		slices.SortFunc(keep, func(a, b cacheEntry) int {
+			return b.lastUse.Compare(a.lastUse)
+		})
+		for _, e := range keep[min(len(keep), maxEntries):] {
+			_ = os.RemoveAll(e.path)
+		}
+	}
+	evict(stage2CacheImagePrefix, stage2CacheMaxImages)
+	evict(stage2CacheBasePrefix, stage2CacheMaxBases)
+}
@@ -0,0 +1,76 @@
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStage2CacheKey(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	write := func(name, data string) string {
+		fn := filepath.Join(dir, name)
+		require.NoError(os.WriteFile(fn, []byte(data), 0o644))
+		return fn
+	}
+	template := write("template", "template")
+	init := write("init", "init")
+	compose := write("compose.yaml", "services: {}")
+
+	baseKey, err := stage2BaseCacheKey(template, init)
+	require.NoError(err)
+	key, err := stage2CacheKey(baseKey, map[string]string{compose: "etc/compose.yaml"})
+	require.NoError(err)
+
+	// Changing an extra file must only change the image key, not the base key.
+	write("compose.yaml", "services: {app: {}}")
+	baseKey2, err := stage2BaseCacheKey(template, init)
+	require.NoError(err)
+	require.Equal(baseKey, baseKey2)
+	key2, err := stage2CacheKey(baseKey2, map[string]string{compose: "etc/compose.yaml"})
+	require.NoError(err)
+	require.NotEqual(key, key2)
+
+	// Changing the template must change both keys.
+	write("template", "template2")
+	baseKey3, err := stage2BaseCacheKey(template, init)
+	require.NoError(err)
+	require.NotEqual(baseKey, baseKey3)
+	key3, err := stage2CacheKey(baseKey3, map[string]string{compose: "etc/compose.yaml"})
+	require.NoError(err)
+	require.NotEqual(key2, key3)
+}
+
+func TestStage2CacheEvict(t *testing.T) {
+	require := require.New(t)
+
+	root := t.TempDir()
+	now := time.Now()
+	mkEntry := func(name string, age time.Duration) {
+		path := filepath.Join(root, name)
+		require.NoError(os.Mkdir(path, 0o755))
+		ts := now.Add(-age)
+		require.NoError(os.Chtimes(path, ts, ts))
+	}
+	mkEntry("stage2-old", stage2CacheMaxAge+time.Hour)
+	for i := range stage2CacheMaxImages + 1 {
+		mkEntry("stage2-"+string(rune('a'+i)), time.Duration(i)*time.Hour)
+	}
+	mkEntry("stage2base-a", time.Hour)
+	mkEntry("unrelated", stage2CacheMaxAge+time.Hour)
+
+	stage2CacheEvictDir(root, now)
+
+	entries, err := os.ReadDir(root)
+	require.NoError(err)
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	require.ElementsMatch([]string{"stage2-a", "stage2-b", "stage2-c", "stage2-d", "stage2base-a", "unrelated"}, names)
+}
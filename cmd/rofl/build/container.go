@@ -3,6 +3,9 @@ package build
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
 
 	"github.com/compose-spec/compose-go/v2/cli"
 
@@ -10,6 +13,8 @@ import (
 
 	buildRofl "github.com/oasisprotocol/cli/build/rofl"
 	"github.com/oasisprotocol/cli/cmd/common"
+	roflCommon "github.com/oasisprotocol/cli/cmd/rofl/common"
+	cliConfig "github.com/oasisprotocol/cli/config"
 )
 
 // tdxBuildContainer builds a TDX-based container ROFL app.
@@ -27,6 +32,7 @@ func tdxBuildContainer(
 
 	// Validate compose file.
 	fmt.Println("Validating compose file...")
+	done := common.LogStage(logger, "validate compose file")
 	options, err := cli.NewProjectOptions([]string{artifacts[artifactContainerCompose]})
 	if err != nil {
 		return fmt.Errorf("failed to set up compose options: %w", err)
@@ -36,12 +42,24 @@ func tdxBuildContainer(
 		fmt.Println(err)
 		return fmt.Errorf("pre-build compose validation failed")
 	}
+	done()
+
+	digests, err := checkImageDigests(manifest, artifacts[artifactContainerCompose])
+	if err != nil {
+		return err
+	}
+
+	// Pin the images in the compose file which becomes part of the measured stage 2 rootfs.
+	composeFn, err := pinComposeFile(tmpDir, artifacts[artifactContainerCompose], digests)
+	if err != nil {
+		return err
+	}
 
 	// Use the pre-built container runtime.
 	initPath := artifacts[artifactContainerRuntime]
 
 	stage2, err := tdxPrepareStage2(tmpDir, artifacts, initPath, map[string]string{
-		artifacts[artifactContainerCompose]: "etc/oasis/containers/compose.yaml",
+		composeFn: "etc/oasis/containers/compose.yaml",
 	})
 	if err != nil {
 		return err
@@ -66,3 +84,105 @@ func tdxBuildContainer(
 
 	return tdxBundleComponent(manifest, artifacts, bnd, stage2, extraKernelOpts)
 }
+
+// pinComposeFile writes a copy of the given compose file with all images pinned to the given
+// digests into the temporary directory and returns its path.
+func pinComposeFile(tmpDir, composeFn string, digests map[string]string) (string, error) {
+	data, err := os.ReadFile(composeFn)
+	if err != nil {
+		return "", fmt.Errorf("failed to read compose file: %w", err)
+	}
+	pinned, err := buildRofl.PinComposeImages(data, digests)
+	if err != nil {
+		return "", err
+	}
+
+	pinnedFn := filepath.Join(tmpDir, "compose.yaml")
+	if err = os.WriteFile(pinnedFn, pinned, 0o644); err != nil {
+		return "", fmt.Errorf("failed to write pinned compose file: %w", err)
+	}
+	return pinnedFn, nil
+}
+
+// checkImageDigests resolves the container images referenced by the compose file to digests and
+// cross-checks them against the lock file, so that the images which run are the ones which were
+// present when the app was built. Images not yet in the lock file are recorded. It returns the
+// digests the images should be pinned to, keyed by image reference.
+func checkImageDigests(manifest *buildRofl.Manifest, composeFn string) (map[string]string, error) {
+	services, err := buildRofl.LoadComposeServices(composeFn)
+	if err != nil {
+		return nil, err
+	}
+	images := buildRofl.ComposeImages(services)
+	if len(images) == 0 {
+		return nil, nil
+	}
+
+	lockFn := buildRofl.LockFileFor(manifest)
+	lock, err := buildRofl.LoadLock(lockFn)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start with the locked digests, so images that cannot be resolved are still pinned.
+	digests := make(map[string]string)
+	for _, image := range images {
+		if digest, ok := lock.Images[image]; ok {
+			digests[image] = digest
+		}
+	}
+
+	if offline {
+		for _, image := range images {
+			if _, ok := digests[image]; !ok && !buildRofl.IsImagePinned(image) {
+				fmt.Fprintf(os.Stderr, "Warning: Digest of image '%s' cannot be checked in offline mode.\n", image)
+			}
+		}
+		return digests, nil
+	}
+
+	fmt.Println("Resolving container image digests...")
+	done := common.LogStage(logger, "resolve image digests")
+	resolved, failed := buildRofl.ResolveImageDigests(context.Background(), images, roflCommon.RegistryAuth(cliConfig.Global()))
+	done()
+
+	failedImages := make([]string, 0, len(failed))
+	for image := range failed {
+		failedImages = append(failedImages, image)
+	}
+	sort.Strings(failedImages)
+	for _, image := range failedImages {
+		fmt.Fprintf(os.Stderr, "Warning: Failed to resolve digest of image '%s': %s\n", image, failed[image])
+	}
+
+	changed, unlocked := lock.Check(resolved)
+	if len(changed) > 0 {
+		fmt.Printf("Container images resolve to different digests than recorded in '%s':\n", lockFn)
+		for _, c := range changed {
+			fmt.Printf("  - %s\n", c.Image)
+			fmt.Printf("      locked:   %s\n", c.Locked)
+			fmt.Printf("      resolved: %s\n", c.Resolved)
+		}
+		if buildMode == buildModeProduction {
+			return nil, fmt.Errorf("container image digests changed, run `oasis rofl lock update` to accept the new images")
+		}
+		fmt.Fprintf(os.Stderr, "Warning: Continuing with changed images since this is a debug deployment.\n")
+	}
+
+	if len(unlocked) > 0 {
+		for _, image := range unlocked {
+			lock.Images[image] = resolved[image]
+		}
+		if err = lock.Save(); err != nil {
+			return nil, fmt.Errorf("failed to update lock file: %w", err)
+		}
+		fmt.Printf("Recorded digests of %d container image(s) in '%s'.\n", len(unlocked), lockFn)
+	}
+
+	// Pin the images that actually resolved, which only differ from the locked ones in debug
+	// deployments.
+	for image, digest := range resolved {
+		digests[image] = digest
+	}
+	return digests, nil
+}
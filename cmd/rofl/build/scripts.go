@@ -1,32 +1,86 @@
 package build
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 
 	"github.com/spf13/cobra"
 
+	"github.com/oasisprotocol/oasis-core/go/common/sgx"
+
 	buildRofl "github.com/oasisprotocol/cli/build/rofl"
+	"github.com/oasisprotocol/cli/cmd/common"
+)
+
+const (
+	// scriptContextEnv is the environment variable containing the path to the script context file.
+	scriptContextEnv = "ROFL_SCRIPT_CONTEXT"
+	// scriptContextFilename is the name of the script context file in the temporary build directory.
+	scriptContextFilename = "script-context.json"
 )
 
+// scriptContext is the context passed to build scripts as a JSON file.
+type scriptContext struct {
+	// Script is the name of the script being executed.
+	Script string `json:"script"`
+	// Manifest is the ROFL app manifest.
+	Manifest *buildRofl.Manifest `json:"manifest"`
+	// Deployment is the name of the deployment being built.
+	Deployment string `json:"deployment"`
+	// Network is the name of the network of the deployment.
+	Network string `json:"network"`
+	// ParaTime is the name of the ParaTime of the deployment.
+	ParaTime string `json:"paratime"`
+	// TmpDir is the temporary build directory.
+	TmpDir string `json:"tmp_dir"`
+	// Artifacts are the paths of the fetched and built artifacts by kind.
+	Artifacts map[string]string `json:"artifacts,omitempty"`
+	// Bundle is the path of the output bundle once it has been written.
+	Bundle string `json:"bundle,omitempty"`
+	// EnclaveIdentities are the enclave identities of the bundle once they have been computed.
+	EnclaveIdentities []*sgx.EnclaveIdentity `json:"enclave_identities,omitempty"`
+}
+
+// builtArtifacts are the paths of the fetched and built artifacts by kind.
+var builtArtifacts = make(map[string]string)
+
+// recordArtifact records the path of a fetched or built artifact so that it is made available to
+// subsequent build scripts.
+func recordArtifact(kind, path string) {
+	builtArtifacts[kind] = path
+}
+
 // runScripts executes the specified build script using the current build environment.
-func runScript(manifest *buildRofl.Manifest, name string) {
-	script, ok := manifest.Scripts[name]
+func runScript(sctx *scriptContext, name string) {
+	script, ok := sctx.Manifest.Scripts[name]
 	if !ok {
 		return
 	}
 
 	fmt.Printf("Running script '%s'...\n", name)
+	defer common.LogStage(logger, "script", "name", name)()
+
+	sctx.Script = name
+	sctx.Artifacts = builtArtifacts
+	data, err := json.MarshalIndent(sctx, "", "  ")
+	cobra.CheckErr(err)
+	ctxFn := filepath.Join(sctx.TmpDir, scriptContextFilename)
+	if err = os.WriteFile(ctxFn, data, 0o600); err != nil {
+		cobra.CheckErr(fmt.Errorf("failed to write script context: %w", err))
+	}
 
 	cmd := exec.Command( //nolint: gosec
 		os.Getenv("SHELL"),
 		"-c",
 		script,
 	)
+	cmd.Env = append(os.Environ(), fmt.Sprintf("%s=%s", scriptContextEnv, ctxFn))
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	err := cmd.Run()
+	err = cmd.Run()
 	if err != nil {
 		cobra.CheckErr(fmt.Errorf("script '%s' failed to execute: %w", name, err))
 	}
@@ -36,17 +36,21 @@ func sgxBuild(
 
 	// First build for the default target.
 	fmt.Println("Building ELF binary...")
+	done := common.LogStage(logger, "cargo build", "target", "x86_64-unknown-linux-gnu")
 	elfPath, err := cargo.Build(true, "x86_64-unknown-linux-gnu", features)
 	if err != nil {
 		cobra.CheckErr(fmt.Errorf("failed to build ELF binary: %w", err))
 	}
+	done()
 
 	// Then build for the SGX target.
 	fmt.Println("Building SGXS binary...")
+	done = common.LogStage(logger, "cargo build", "target", "x86_64-fortanix-unknown-sgx")
 	elfSgxPath, err := cargo.Build(true, "x86_64-fortanix-unknown-sgx", nil)
 	if err != nil {
 		cobra.CheckErr(fmt.Errorf("failed to build SGXS binary: %w", err))
 	}
+	done()
 
 	sgxThreads := uint64(32)
 	sgxHeapSize := manifest.Resources.Memory * 1024 * 1024
@@ -57,6 +61,8 @@ func sgxBuild(
 	if err != nil {
 		cobra.CheckErr(fmt.Errorf("failed to generate SGXS binary: %w", err))
 	}
+	recordArtifact(artifactRuntime, elfPath)
+	recordArtifact(artifactRuntimeSGXS, sgxsPath)
 
 	// Compute MRENCLAVE.
 	var b []byte
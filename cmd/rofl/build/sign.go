@@ -0,0 +1,59 @@
+package build
+
+import (
+	goEd25519 "crypto/ed25519"
+	"encoding/pem"
+	"fmt"
+	"os"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/ed25519"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/secp256k1"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/sr25519"
+
+	"github.com/oasisprotocol/cli/cmd/common"
+	cliConfig "github.com/oasisprotocol/cli/config"
+)
+
+// loadBundleSigner returns the signer used to sign bundles. The signer is either a PEM-encoded
+// private key file or an account in the wallet.
+func loadBundleSigner(cfg *cliConfig.Config, signer string) (signature.Signer, error) {
+	if _, err := os.Stat(signer); err == nil {
+		return loadKeyFileSigner(signer)
+	}
+	if _, ok := cfg.Wallet.All[signer]; !ok {
+		return nil, fmt.Errorf("'%s' is neither a key file nor an account in the wallet", signer)
+	}
+	accSigner := common.LoadAccount(cfg, signer).Signer()
+	if accSigner == nil {
+		return nil, fmt.Errorf("account '%s' cannot sign bundles as it has no signer (e.g. a multisig account)", signer)
+	}
+	return accSigner, nil
+}
+
+// loadKeyFileSigner loads a signer from the given PEM-encoded private key file.
+func loadKeyFileSigner(fn string) (signature.Signer, error) {
+	rawFile, err := os.ReadFile(fn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+	block, _ := pem.Decode(rawFile)
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode PEM file '%s'", fn)
+	}
+
+	switch block.Type {
+	case "ED25519 PRIVATE KEY":
+		if len(block.Bytes) != goEd25519.PrivateKeySize {
+			return nil, fmt.Errorf("malformed ed25519 private key")
+		}
+		return ed25519.WrapSigner(memory.NewFromRuntime(goEd25519.PrivateKey(block.Bytes))), nil
+	case "EC PRIVATE KEY":
+		return secp256k1.NewSigner(block.Bytes), nil
+	case "SR25519 PRIVATE KEY":
+		return sr25519.NewSigner(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM type: %s", block.Type)
+	}
+}
@@ -23,6 +23,8 @@ const (
 	artifactStage2           = "stage 2 template"
 	artifactContainerRuntime = "rofl-container runtime"
 	artifactContainerCompose = "compose.yaml"
+	artifactRuntime          = "runtime"
+	artifactRuntimeSGXS      = "runtime sgxs"
 )
 
 // tdxBuildRaw builds a TDX-based "raw" ROFL app.
@@ -58,10 +60,13 @@ func tdxBuildRaw(
 	}
 
 	fmt.Println("Building runtime binary...")
+	done := common.LogStage(logger, "cargo build")
 	initPath, err := cargo.Build(true, "", nil)
 	if err != nil {
 		return fmt.Errorf("failed to build runtime binary: %w", err)
 	}
+	done()
+	recordArtifact(artifactRuntime, initPath)
 
 	stage2, err := tdxPrepareStage2(tmpDir, artifacts, initPath, nil)
 	if err != nil {
@@ -111,6 +116,7 @@ func tdxFetchArtifacts(artifacts []*artifact) map[string]string {
 	result := make(map[string]string)
 	for _, ar := range artifacts {
 		result[ar.kind] = maybeDownloadArtifact(ar.kind, ar.uri)
+		recordArtifact(ar.kind, result[ar.kind])
 	}
 	return result
 }
@@ -123,59 +129,147 @@ type tdxStage2 struct {
 
 // tdxPrepareStage2 prepares the stage 2 rootfs.
 func tdxPrepareStage2(tmpDir string, artifacts map[string]string, initPath string, extraFiles map[string]string) (*tdxStage2, error) {
-	// Create temporary directory and unpack stage 2 template into it.
-	fmt.Println("Preparing stage 2 root filesystem...")
-	rootfsDir := filepath.Join(tmpDir, "rootfs")
-	if err := os.Mkdir(rootfsDir, 0o755); err != nil {
-		return nil, fmt.Errorf("failed to create temporary rootfs directory: %w", err)
+	// Reuse a previously built stage 2 image in case none of its inputs changed.
+	baseKey, err := stage2BaseCacheKey(artifacts[artifactStage2], initPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute stage 2 cache key: %w", err)
 	}
-
-	// Unpack template into temporary directory.
-	fmt.Println("Unpacking template...")
-	if err := extractArchive(artifacts[artifactStage2], rootfsDir); err != nil {
-		return nil, fmt.Errorf("failed to extract stage 2 template: %w", err)
+	cacheKey, err := stage2CacheKey(baseKey, extraFiles)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute stage 2 cache key: %w", err)
+	}
+	rootfsImage := filepath.Join(tmpDir, "rootfs.squashfs")
+	if !noCache {
+		if stage2 := stage2CacheLoad(cacheKey, rootfsImage); stage2 != nil {
+			fmt.Println("Using cached stage 2 root filesystem.")
+			logger.Info("using cached stage 2 root filesystem", "cache_key", cacheKey)
+			return stage2, nil
+		}
 	}
 
-	// Add runtime as init.
-	fmt.Println("Adding runtime as init...")
-	if err := copyFile(initPath, filepath.Join(rootfsDir, "init"), 0o755); err != nil {
+	fmt.Println("Preparing stage 2 root filesystem...")
+	defer common.LogStage(logger, "prepare stage 2")()
+
+	// Reuse the unpacked template and init in case only the extra files (e.g. compose.yaml) changed.
+	var rootfsDir string
+	if !noCache {
+		rootfsDir = stage2BaseCacheLoad(baseKey)
+	}
+	if rootfsDir != "" {
+		fmt.Println("Using cached template and runtime.")
+		logger.Info("using cached stage 2 base root filesystem", "cache_key", baseKey)
+	} else if rootfsDir, err = tdxPrepareStage2Base(tmpDir, artifacts, initPath, baseKey); err != nil {
 		return nil, err
 	}
 
-	// Copy any extra files.
+	// Copy any extra files. As the rootfs directory may be cached, they are removed afterwards.
 	fmt.Println("Adding extra files...")
-	for src, dst := range extraFiles {
-		if err := copyFile(src, filepath.Join(rootfsDir, dst), 0o644); err != nil {
-			return nil, err
-		}
+	cleanup, err := addExtraFiles(rootfsDir, extraFiles)
+	defer cleanup()
+	if err != nil {
+		return nil, err
 	}
 
 	// Create the root filesystem.
 	fmt.Println("Creating squashfs filesystem...")
-	rootfsImage := filepath.Join(tmpDir, "rootfs.squashfs")
+	done := common.LogStage(logger, "create squashfs")
 	rootfsSize, err := createSquashFs(rootfsImage, rootfsDir)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create rootfs image: %w", err)
 	}
+	done()
 
 	// Create dm-verity hash tree.
 	fmt.Println("Creating dm-verity hash tree...")
 	hashFile := filepath.Join(tmpDir, "rootfs.hash")
+	done = common.LogStage(logger, "create verity hash tree")
 	rootHash, err := createVerityHashTree(rootfsImage, hashFile)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create verity hash tree: %w", err)
 	}
+	done()
 
 	// Concatenate filesystem and hash tree into one image.
 	if err = concatFiles(rootfsImage, hashFile); err != nil {
 		return nil, fmt.Errorf("failed to concatenate rootfs and hash tree files: %w", err)
 	}
 
-	return &tdxStage2{
+	stage2 := &tdxStage2{
 		fn:       rootfsImage,
 		rootHash: rootHash,
 		fsSize:   rootfsSize,
-	}, nil
+	}
+	if err = stage2CacheStore(cacheKey, stage2); err != nil {
+		fmt.Printf("WARNING: Failed to cache stage 2 root filesystem: %s\n", err)
+	}
+	stage2CacheEvict()
+	return stage2, nil
+}
+
+// tdxPrepareStage2Base unpacks the stage 2 template and adds the runtime as init. Unless caching
+// is disabled, the result is stored in the cache under the given key.
+func tdxPrepareStage2Base(tmpDir string, artifacts map[string]string, initPath, baseKey string) (string, error) {
+	rootfsDir := filepath.Join(tmpDir, "rootfs")
+	if noCache {
+		if err := os.Mkdir(rootfsDir, 0o755); err != nil {
+			return "", fmt.Errorf("failed to create temporary rootfs directory: %w", err)
+		}
+	} else {
+		var err error
+		if rootfsDir, err = stage2BaseCachePrepare(baseKey); err != nil {
+			return "", err
+		}
+	}
+
+	// Unpack template into rootfs directory.
+	fmt.Println("Unpacking template...")
+	if err := extractArchive(artifacts[artifactStage2], rootfsDir); err != nil {
+		return "", fmt.Errorf("failed to extract stage 2 template: %w", err)
+	}
+
+	// Add runtime as init.
+	fmt.Println("Adding runtime as init...")
+	if err := copyFile(initPath, filepath.Join(rootfsDir, "init"), 0o755); err != nil {
+		return "", err
+	}
+
+	if !noCache {
+		if err := stage2BaseCacheComplete(baseKey); err != nil {
+			fmt.Printf("WARNING: Failed to cache stage 2 template: %s\n", err)
+		}
+	}
+	return rootfsDir, nil
+}
+
+// addExtraFiles copies the given extra files into the rootfs directory. The returned function
+// removes all added files and any directories created for them.
+func addExtraFiles(rootfsDir string, extraFiles map[string]string) (func(), error) {
+	var created []string
+	cleanup := func() {
+		for i := len(created) - 1; i >= 0; i-- {
+			_ = os.RemoveAll(created[i])
+		}
+	}
+
+	for src, dst := range extraFiles {
+		dstPath := filepath.Join(rootfsDir, dst)
+
+		// Find the topmost path component that does not yet exist as that is what needs to be
+		// removed during cleanup.
+		top := dstPath
+		for dir := filepath.Dir(dstPath); dir != rootfsDir && strings.HasPrefix(dir, rootfsDir); dir = filepath.Dir(dir) {
+			if _, err := os.Lstat(dir); err == nil {
+				break
+			}
+			top = dir
+		}
+		created = append(created, top)
+
+		if err := copyFile(src, dstPath, 0o644); err != nil {
+			return cleanup, err
+		}
+	}
+	return cleanup, nil
 }
 
 // tdxBundleComponent adds the ROFL component to the given bundle.
@@ -263,6 +357,12 @@ func tdxBundleComponent(
 	// Add extra kernel options.
 	comp.TDX.ExtraKernelOptions = append(comp.TDX.ExtraKernelOptions, extraKernelOpts...)
 
+	// Add custom kernel options from the manifest.
+	if len(manifest.KernelOptions) > 0 {
+		fmt.Println("NOTE: Custom kernel options are part of the enclave measurement. Changing them changes the enclave identity.")
+		comp.TDX.ExtraKernelOptions = append(comp.TDX.ExtraKernelOptions, manifest.KernelOptions...)
+	}
+
 	bnd.Manifest.Components = append(bnd.Manifest.Components, &comp)
 
 	if err := bnd.Manifest.Validate(); err != nil {
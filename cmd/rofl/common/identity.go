@@ -2,10 +2,12 @@ package common
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/oasisprotocol/oasis-core/go/common/sgx"
 	"github.com/oasisprotocol/oasis-core/go/runtime/bundle"
 	"github.com/oasisprotocol/oasis-core/go/runtime/bundle/component"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/rofl"
 
 	"github.com/oasisprotocol/cli/build/measurement"
 )
@@ -55,3 +57,28 @@ func ComputeEnclaveIdentity(bnd *bundle.Bundle, compID string) ([]*sgx.EnclaveId
 		return nil, fmt.Errorf("ROFL app '%s' not found in bundle", compID)
 	}
 }
+
+// SyncEnclaveIdentities replaces the enclave identities in the given policy with the ones
+// computed from the given ORC bundle. Returns true in case the policy has been changed.
+func SyncEnclaveIdentities(policy *rofl.AppAuthPolicy, bundleFn string) (bool, error) {
+	bnd, err := bundle.Open(bundleFn)
+	if err != nil {
+		return false, fmt.Errorf("failed to open bundle '%s': %w", bundleFn, err)
+	}
+	defer bnd.Close()
+
+	eids, err := ComputeEnclaveIdentity(bnd, "")
+	if err != nil {
+		return false, err
+	}
+
+	enclaves := make([]sgx.EnclaveIdentity, 0, len(eids))
+	for _, eid := range eids {
+		enclaves = append(enclaves, *eid)
+	}
+	if slices.Equal(policy.Enclaves, enclaves) {
+		return false, nil
+	}
+	policy.Enclaves = enclaves
+	return true, nil
+}
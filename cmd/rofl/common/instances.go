@@ -0,0 +1,107 @@
+package common
+
+import (
+	"fmt"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	coreCommon "github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/rofl"
+)
+
+// InstanceStatus is the result of checking a registered app instance against the app policy.
+type InstanceStatus struct {
+	// Violations are the ways in which the instance does not conform to the policy.
+	Violations []string
+	// Warnings are issues which need attention, but are not policy violations.
+	Warnings []string
+}
+
+// OK returns true iff the instance conforms to the policy and needs no attention.
+func (s *InstanceStatus) OK() bool {
+	return len(s.Violations) == 0 && len(s.Warnings) == 0
+}
+
+// CheckInstance checks the given registered app instance against the app policy at the given
+// epoch. The descriptor of the endorsing node may be nil in case the node is not registered.
+//
+// Enclave identities are verified by the ParaTime when the instance registers, but they are not
+// part of the registration, so they cannot be checked here.
+func CheckInstance(
+	policy *rofl.AppAuthPolicy,
+	ai *rofl.Registration,
+	nodeDesc *node.Node,
+	runtimeID coreCommon.Namespace,
+	epoch beacon.EpochTime,
+) *InstanceStatus {
+	var status InstanceStatus
+
+	if nodeDesc == nil {
+		status.Warnings = append(status.Warnings, fmt.Sprintf("endorsing node %s is not registered", ai.NodeID))
+	}
+	if !isEndorsementAllowed(policy, ai, nodeDesc, runtimeID) {
+		status.Violations = append(status.Violations, "endorsement is not allowed by the policy")
+	}
+
+	switch {
+	case ai.Expiration <= epoch:
+		status.Violations = append(status.Violations, fmt.Sprintf("registration expired at epoch %d", ai.Expiration))
+	case ai.Expiration > epoch+policy.MaxExpiration:
+		status.Violations = append(status.Violations, fmt.Sprintf(
+			"registration expires at epoch %d, which is more than %d epochs in the future",
+			ai.Expiration, policy.MaxExpiration,
+		))
+	case ai.Expiration == epoch+1:
+		status.Warnings = append(status.Warnings, fmt.Sprintf("registration expires at the next epoch (%d)", ai.Expiration))
+	}
+
+	return &status
+}
+
+// isEndorsementAllowed returns true iff the endorsement of the given app instance matches any of
+// the endorsements allowed by the policy.
+func isEndorsementAllowed(
+	policy *rofl.AppAuthPolicy,
+	ai *rofl.Registration,
+	nodeDesc *node.Node,
+	runtimeID coreCommon.Namespace,
+) bool {
+	hasRole := func(role node.RolesMask) bool {
+		if nodeDesc == nil || !nodeDesc.HasRoles(role) {
+			return false
+		}
+		for _, rt := range nodeDesc.Runtimes {
+			if rt.ID.Equal(&runtimeID) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, e := range policy.Endorsements {
+		switch {
+		case e.Any != nil:
+			return true
+		case e.ComputeRole != nil:
+			if hasRole(node.RoleComputeWorker) {
+				return true
+			}
+		case e.ObserverRole != nil:
+			if hasRole(node.RoleObserver) {
+				return true
+			}
+		case e.Entity != nil:
+			if ai.EntityID != nil && ai.EntityID.Equal(*e.Entity) {
+				return true
+			}
+			if nodeDesc != nil && nodeDesc.EntityID.Equal(*e.Entity) {
+				return true
+			}
+		case e.Node != nil:
+			if ai.NodeID.Equal(*e.Node) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	coreCommon "github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/rofl"
+)
+
+func TestCheckInstance(t *testing.T) {
+	require := require.New(t)
+
+	var runtimeID coreCommon.Namespace
+	require.NoError(runtimeID.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000000"))
+
+	nodeID := signature.NewPublicKey("2eb8eb3db6e3f2e5b7c0e4b98bd1ba3fbd7cb5bd1c11d8ebf5d8da4a23bc1b00")
+	entityID := signature.NewPublicKey("47aadd91516ac548decdb436fde957992610facc09ba2f850da0fe1b2be96119")
+	otherID := signature.NewPublicKey("5a3a4d0e17c1cda2cca8ab4da7f1da8d8bcb5d7c8b26a7e6d08ae0b1f2b0c2a1")
+
+	nodeDesc := &node.Node{
+		ID:       nodeID,
+		EntityID: entityID,
+		Roles:    node.RoleComputeWorker,
+		Runtimes: []*node.Runtime{{ID: runtimeID}},
+	}
+	ai := &rofl.Registration{
+		NodeID:     nodeID,
+		Expiration: 12,
+	}
+
+	policy := &rofl.AppAuthPolicy{
+		Endorsements:  []rofl.AllowedEndorsement{{Any: &struct{}{}}},
+		MaxExpiration: 3,
+	}
+	status := CheckInstance(policy, ai, nodeDesc, runtimeID, 10)
+	require.True(status.OK())
+
+	// Endorsements.
+	for _, tc := range []struct {
+		endorsement rofl.AllowedEndorsement
+		allowed     bool
+	}{
+		{rofl.AllowedEndorsement{ComputeRole: &struct{}{}}, true},
+		{rofl.AllowedEndorsement{ObserverRole: &struct{}{}}, false},
+		{rofl.AllowedEndorsement{Entity: &entityID}, true},
+		{rofl.AllowedEndorsement{Entity: &otherID}, false},
+		{rofl.AllowedEndorsement{Node: &nodeID}, true},
+		{rofl.AllowedEndorsement{Node: &otherID}, false},
+	} {
+		policy.Endorsements = []rofl.AllowedEndorsement{tc.endorsement}
+		status = CheckInstance(policy, ai, nodeDesc, runtimeID, 10)
+		require.Equal(tc.allowed, len(status.Violations) == 0, "endorsement %+v", tc.endorsement)
+	}
+
+	// Role endorsements require a registered node.
+	policy.Endorsements = []rofl.AllowedEndorsement{{ComputeRole: &struct{}{}}}
+	status = CheckInstance(policy, ai, nil, runtimeID, 10)
+	require.Len(status.Violations, 1)
+	require.Len(status.Warnings, 1)
+
+	// Expiration.
+	policy.Endorsements = []rofl.AllowedEndorsement{{Any: &struct{}{}}}
+	status = CheckInstance(policy, ai, nodeDesc, runtimeID, 11)
+	require.Empty(status.Violations)
+	require.Equal([]string{"registration expires at the next epoch (12)"}, status.Warnings)
+
+	status = CheckInstance(policy, ai, nodeDesc, runtimeID, 12)
+	require.Equal([]string{"registration expired at epoch 12"}, status.Violations)
+
+	status = CheckInstance(policy, ai, nodeDesc, runtimeID, 8)
+	require.Len(status.Violations, 1)
+	require.Contains(status.Violations[0], "more than 3 epochs in the future")
+}
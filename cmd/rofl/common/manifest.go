@@ -74,3 +74,8 @@ func MaybeLoadManifestAndSetNPA(cfg *config.Config, npa *common.NPASelection, de
 	}
 	return manifest, d, nil
 }
+
+// BundleFilename returns the default filename of the ORC bundle built for the given deployment.
+func BundleFilename(manifest *rofl.Manifest, deployment string) string {
+	return fmt.Sprintf("%s.%s.orc", manifest.Name, deployment)
+}
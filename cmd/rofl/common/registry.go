@@ -0,0 +1,64 @@
+package common
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/oasisprotocol/cli/build/rofl"
+	cliConfig "github.com/oasisprotocol/cli/config"
+)
+
+// RegistryAuth returns the OCI registry credentials configured in the given configuration.
+// Credentials stored by `rofl registry login` take precedence over the ones in the configuration
+// file which take precedence over the ones in the Docker config.json (if enabled for the registry).
+func RegistryAuth(cfg *cliConfig.Config) rofl.RegistryAuthFunc {
+	stored, err := cliConfig.LoadOCICredentials(cfg.Directory())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", err)
+		stored = &cliConfig.OCICredentials{}
+	}
+
+	return func(domain string) *rofl.RegistryAuth {
+		reg := cfg.OCIRegistries.Lookup(domain)
+		if reg == nil {
+			reg = &cliConfig.OCIRegistry{}
+		}
+		auth := rofl.RegistryAuth{
+			Username: reg.Username,
+			Password: reg.Password,
+			Token:    reg.Token,
+			Headers:  reg.Headers,
+		}
+
+		switch cred := stored.Registries[domain]; {
+		case cred != nil:
+			auth.Username = cred.Username
+			auth.Password = cred.Password
+			auth.Token = cred.Token
+		case auth.Username == "" && auth.Token == "" && reg.DockerConfig:
+			dockerAuth, err := dockerConfigAuth(domain)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %s\n", err)
+				break
+			}
+			if dockerAuth != nil {
+				auth.Username = dockerAuth.Username
+				auth.Password = dockerAuth.Password
+			}
+		}
+
+		if auth.Username == "" && auth.Token == "" && len(auth.Headers) == 0 {
+			return nil
+		}
+		return &auth
+	}
+}
+
+// dockerConfigAuth returns the credentials of the given registry from the Docker config.json.
+func dockerConfigAuth(domain string) (*rofl.RegistryAuth, error) {
+	fn, err := rofl.DockerConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	return rofl.DockerConfigAuth(fn, domain)
+}
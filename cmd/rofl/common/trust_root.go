@@ -0,0 +1,58 @@
+package common
+
+import (
+	"context"
+	"fmt"
+
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+
+	"github.com/oasisprotocol/cli/build/rofl"
+	"github.com/oasisprotocol/cli/cmd/common"
+)
+
+// DefaultTrustRootMaxAge is the default number of consensus blocks after which a trust root is
+// considered stale (roughly a week).
+const DefaultTrustRootMaxAge = 100_000
+
+// FetchLatestTrustRoot returns the trust root at the latest consensus layer height.
+func FetchLatestTrustRoot(ctx context.Context, conn consensus.ClientBackend) (*rofl.TrustRootConfig, int64, error) {
+	height, err := common.GetActualHeight(ctx, conn)
+	if err != nil {
+		return nil, 0, err
+	}
+	blk, err := conn.GetBlock(ctx, height)
+	if err != nil {
+		return nil, 0, err
+	}
+	return &rofl.TrustRootConfig{
+		Height: uint64(height),
+		Hash:   blk.Hash.Hex(),
+	}, height, nil
+}
+
+// RefreshTrustRoot replaces the trust root of the given deployment with the latest one and saves
+// the manifest. It warns when the replaced trust root is older than maxAge blocks.
+func RefreshTrustRoot(ctx context.Context, npa *common.NPASelection, manifest *rofl.Manifest, deployment *rofl.Deployment, maxAge uint64) error {
+	conn, err := common.Connect(ctx, npa.Network)
+	if err != nil {
+		return err
+	}
+	root, height, err := FetchLatestTrustRoot(ctx, conn.Consensus())
+	if err != nil {
+		return fmt.Errorf("failed to fetch trust root: %w", err)
+	}
+
+	if old := deployment.TrustRoot; old != nil && old.Height > 0 && uint64(height) > old.Height {
+		age := uint64(height) - old.Height
+		if age > maxAge {
+			fmt.Printf("WARNING: Trust root at height %d was %d blocks old (more than %d).\n", old.Height, age, maxAge)
+		}
+	}
+
+	deployment.TrustRoot = root
+	if err = manifest.Save(); err != nil {
+		return fmt.Errorf("failed to update manifest: %w", err)
+	}
+	fmt.Printf("Trust root updated to height %d (hash %s).\n", root.Height, root.Hash)
+	return nil
+}
@@ -0,0 +1,132 @@
+package rofl
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	buildRofl "github.com/oasisprotocol/cli/build/rofl"
+	"github.com/oasisprotocol/cli/cmd/common"
+	roflCommon "github.com/oasisprotocol/cli/cmd/rofl/common"
+	cliConfig "github.com/oasisprotocol/cli/config"
+)
+
+var (
+	configCmd = &cobra.Command{
+		Use:   "config",
+		Short: "Plain-text configuration management commands",
+		Long: `Manage non-sensitive configuration values which are stored in plain text in the manifest and
+delivered to the app alongside the secrets.`,
+	}
+
+	configSetCmd = &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Set the given configuration value in the manifest",
+		Args:  cobra.ExactArgs(2),
+		Run: func(_ *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+			npa := common.GetNPASelection(cfg)
+			key, value := args[0], args[1]
+
+			manifest, deployment := roflCommon.LoadManifestAndSetNPA(cfg, npa, deploymentName, false)
+			if deployment.Config == nil {
+				deployment.Config = make(map[string]string)
+			}
+			deployment.Config[key] = value
+			if err := buildRofl.ValidateConfig(deployment.Config, deployment.Secrets); err != nil {
+				cobra.CheckErr(fmt.Errorf("bad configuration value: %w", err))
+			}
+
+			// Update manifest.
+			if err := manifest.Save(); err != nil {
+				cobra.CheckErr(fmt.Errorf("failed to update manifest: %w", err))
+			}
+
+			fmt.Printf("Run `oasis rofl update` to update your ROFL app's on-chain configuration.\n")
+		},
+	}
+
+	configGetCmd = &cobra.Command{
+		Use:   "get <key>",
+		Short: "Show the given configuration value from the manifest",
+		Args:  cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+			npa := common.GetNPASelection(cfg)
+			key := args[0]
+
+			_, deployment := roflCommon.LoadManifestAndSetNPA(cfg, npa, deploymentName, false)
+			value, ok := deployment.Config[key]
+			if !ok {
+				cobra.CheckErr(fmt.Errorf("configuration key '%s' does not exist for deployment '%s'", key, deploymentName))
+			}
+			fmt.Println(value)
+		},
+	}
+
+	configRmCmd = &cobra.Command{
+		Use:   "rm <key>",
+		Short: "Remove the given configuration value from the manifest",
+		Args:  cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+			npa := common.GetNPASelection(cfg)
+			key := args[0]
+
+			manifest, deployment := roflCommon.LoadManifestAndSetNPA(cfg, npa, deploymentName, false)
+			if _, ok := deployment.Config[key]; !ok {
+				cobra.CheckErr(fmt.Errorf("configuration key '%s' does not exist for deployment '%s'", key, deploymentName))
+			}
+			delete(deployment.Config, key)
+			if len(deployment.Config) == 0 {
+				deployment.Config = nil
+			}
+
+			// Update manifest.
+			if err := manifest.Save(); err != nil {
+				cobra.CheckErr(fmt.Errorf("failed to update manifest: %w", err))
+			}
+
+			fmt.Printf("Run `oasis rofl update` to update your ROFL app's on-chain configuration.\n")
+		},
+	}
+
+	configListCmd = &cobra.Command{
+		Use:     "list",
+		Short:   "List the configuration values in the manifest",
+		Aliases: []string{"ls"},
+		Args:    cobra.NoArgs,
+		Run: func(_ *cobra.Command, _ []string) {
+			cfg := cliConfig.Global()
+			npa := common.GetNPASelection(cfg)
+
+			_, deployment := roflCommon.LoadManifestAndSetNPA(cfg, npa, deploymentName, false)
+			if len(deployment.Config) == 0 {
+				fmt.Printf("No configuration values for deployment '%s'.\n", deploymentName)
+				return
+			}
+			fmt.Printf("Configuration for deployment '%s':\n", deploymentName)
+			for _, key := range sortedKeys(deployment.Config) {
+				fmt.Printf("  %s: %s\n", key, deployment.Config[key])
+			}
+		},
+	}
+)
+
+func init() {
+	deploymentFlags := flag.NewFlagSet("", flag.ContinueOnError)
+	deploymentFlags.StringVar(&deploymentName, "deployment", buildRofl.DefaultDeploymentName, "deployment name")
+
+	configSetCmd.Flags().AddFlagSet(deploymentFlags)
+	configCmd.AddCommand(configSetCmd)
+
+	configGetCmd.Flags().AddFlagSet(deploymentFlags)
+	configCmd.AddCommand(configGetCmd)
+
+	configRmCmd.Flags().AddFlagSet(deploymentFlags)
+	configCmd.AddCommand(configRmCmd)
+
+	configListCmd.Flags().AddFlagSet(deploymentFlags)
+	configCmd.AddCommand(configListCmd)
+}
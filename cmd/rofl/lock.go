@@ -0,0 +1,86 @@
+package rofl
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	buildRofl "github.com/oasisprotocol/cli/build/rofl"
+	roflCommon "github.com/oasisprotocol/cli/cmd/rofl/common"
+	cliConfig "github.com/oasisprotocol/cli/config"
+)
+
+var (
+	lockCmd = &cobra.Command{
+		Use:   "lock",
+		Short: "Manage the pinned container image digests",
+	}
+
+	lockUpdateCmd = &cobra.Command{
+		Use:   "update",
+		Short: "Resolve the container images in the compose file and pin their digests",
+		Args:  cobra.NoArgs,
+		Run: func(_ *cobra.Command, _ []string) {
+			manifest, err := buildRofl.LoadManifest()
+			cobra.CheckErr(err)
+			if manifest.Kind != buildRofl.AppKindContainer {
+				cobra.CheckErr(fmt.Errorf("image pinning is only supported for '%s' apps", buildRofl.AppKindContainer))
+			}
+
+			composeFn := buildRofl.LatestContainerArtifacts.Container.Compose
+			if manifest.Artifacts != nil && manifest.Artifacts.Container.Compose != "" {
+				composeFn = manifest.Artifacts.Container.Compose
+			}
+			services, err := buildRofl.LoadComposeServices(composeFn)
+			cobra.CheckErr(err)
+			images := buildRofl.ComposeImages(services)
+
+			lock, err := buildRofl.LoadLock(buildRofl.LockFileFor(manifest))
+			cobra.CheckErr(err)
+
+			resolved, failed := buildRofl.ResolveImageDigests(context.Background(), images, roflCommon.RegistryAuth(cliConfig.Global()))
+			if len(failed) > 0 {
+				for _, image := range images {
+					if err, ok := failed[image]; ok {
+						fmt.Fprintf(os.Stderr, "Failed to resolve digest of image '%s': %s\n", image, err)
+					}
+				}
+				cobra.CheckErr(fmt.Errorf("%d image(s) could not be resolved", len(failed)))
+			}
+
+			changed, unlocked := lock.Check(resolved)
+			for _, c := range changed {
+				fmt.Printf("Updated %s\n", c.Image)
+				fmt.Printf("  from: %s\n", c.Locked)
+				fmt.Printf("  to:   %s\n", c.Resolved)
+			}
+			for _, image := range unlocked {
+				fmt.Printf("Pinned %s\n", image)
+				fmt.Printf("  to:   %s\n", resolved[image])
+			}
+			var removed []string
+			for image := range lock.Images {
+				if _, ok := resolved[image]; !ok {
+					removed = append(removed, image)
+				}
+			}
+			sort.Strings(removed)
+			for _, image := range removed {
+				fmt.Printf("Removed %s\n", image)
+			}
+
+			lock.Images = resolved
+			cobra.CheckErr(lock.Save())
+			if len(changed)+len(unlocked)+len(removed) == 0 {
+				fmt.Printf("All %d image(s) are already pinned.\n", len(resolved))
+			}
+		},
+	}
+)
+
+func init() {
+	lockCmd.AddCommand(lockUpdateCmd)
+}
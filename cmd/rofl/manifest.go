@@ -0,0 +1,90 @@
+package rofl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	buildRofl "github.com/oasisprotocol/cli/build/rofl"
+	cliConfig "github.com/oasisprotocol/cli/config"
+)
+
+var (
+	manifestCmd = &cobra.Command{
+		Use:   "manifest",
+		Short: "ROFL app manifest commands",
+	}
+
+	manifestSchemaCmd = &cobra.Command{
+		Use:   "schema",
+		Short: "Print the JSON Schema of the ROFL app manifest",
+		Long:  "Print the JSON Schema of the ROFL app manifest which can be used by editors for validation and auto-completion of rofl.yaml.",
+		Args:  cobra.NoArgs,
+		Run: func(_ *cobra.Command, _ []string) {
+			data, err := json.MarshalIndent(buildRofl.ManifestSchema(), "", "  ")
+			cobra.CheckErr(err)
+			fmt.Printf("%s\n", data)
+		},
+	}
+
+	manifestValidateCmd = &cobra.Command{
+		Use:   "validate [<file>]",
+		Short: "Validate the ROFL app manifest without network access",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+
+			var fn string
+			switch len(args) {
+			case 0:
+				fn = findManifestFile()
+			default:
+				fn = args[0]
+			}
+
+			manifest, err := buildRofl.ValidateManifestFile(fn)
+			cobra.CheckErr(err)
+
+			// Deployments refer to networks and ParaTimes by their names in the local config.
+			names := make([]string, 0, len(manifest.Deployments))
+			for name := range manifest.Deployments {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				d := manifest.Deployments[name]
+				net, ok := cfg.Networks.All[d.Network]
+				if !ok {
+					fmt.Printf("WARNING: Network '%s' of deployment '%s' is not configured.\n", d.Network, name)
+					continue
+				}
+				if _, ok = net.ParaTimes.All[d.ParaTime]; !ok {
+					fmt.Printf("WARNING: ParaTime '%s' of deployment '%s' is not configured for network '%s'.\n", d.ParaTime, name, d.Network)
+				}
+			}
+
+			fmt.Printf("Manifest '%s' is valid.\n", fn)
+		},
+	}
+)
+
+// findManifestFile returns the name of the manifest file in the current working directory.
+func findManifestFile() string {
+	for _, fn := range buildRofl.ManifestFileNames {
+		if _, err := os.Stat(fn); !errors.Is(err, os.ErrNotExist) {
+			return fn
+		}
+	}
+	cobra.CheckErr(fmt.Errorf("no ROFL app manifest found (tried: %s)", strings.Join(buildRofl.ManifestFileNames, ", ")))
+	return ""
+}
+
+func init() {
+	manifestCmd.AddCommand(manifestSchemaCmd)
+	manifestCmd.AddCommand(manifestValidateCmd)
+}
@@ -0,0 +1,187 @@
+package rofl
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/rofl"
+
+	buildRofl "github.com/oasisprotocol/cli/build/rofl"
+	"github.com/oasisprotocol/cli/cmd/common"
+	roflCommon "github.com/oasisprotocol/cli/cmd/rofl/common"
+	cliConfig "github.com/oasisprotocol/cli/config"
+)
+
+var (
+	metaCmd = &cobra.Command{
+		Use:     "meta",
+		Short:   "Deployment metadata management commands",
+		Aliases: []string{"metadata"},
+	}
+
+	metaSetCmd = &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Set the given metadata key in the manifest",
+		Args:  cobra.ExactArgs(2),
+		Run: func(_ *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+			npa := common.GetNPASelection(cfg)
+			key, value := args[0], args[1]
+
+			if err := buildRofl.ValidateMetadataKey(key); err != nil {
+				cobra.CheckErr(fmt.Errorf("bad metadata key: %w", err))
+			}
+
+			manifest, deployment := roflCommon.LoadManifestAndSetNPA(cfg, npa, deploymentName, false)
+			if deployment.Metadata == nil {
+				deployment.Metadata = make(map[string]string)
+			}
+			deployment.Metadata[key] = value
+
+			// Update manifest.
+			if err := manifest.Save(); err != nil {
+				cobra.CheckErr(fmt.Errorf("failed to update manifest: %w", err))
+			}
+
+			fmt.Printf("Run `oasis rofl update` to update your ROFL app's on-chain configuration.\n")
+		},
+	}
+
+	metaRmCmd = &cobra.Command{
+		Use:   "rm <key>",
+		Short: "Remove the given metadata key from the manifest",
+		Args:  cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+			npa := common.GetNPASelection(cfg)
+			key := args[0]
+
+			manifest, deployment := roflCommon.LoadManifestAndSetNPA(cfg, npa, deploymentName, false)
+			if _, ok := deployment.Metadata[key]; !ok {
+				cobra.CheckErr(fmt.Errorf("metadata key '%s' does not exist for deployment '%s'", key, deploymentName))
+			}
+			delete(deployment.Metadata, key)
+			if len(deployment.Metadata) == 0 {
+				deployment.Metadata = nil
+			}
+
+			// Update manifest.
+			if err := manifest.Save(); err != nil {
+				cobra.CheckErr(fmt.Errorf("failed to update manifest: %w", err))
+			}
+
+			fmt.Printf("Run `oasis rofl update` to update your ROFL app's on-chain configuration.\n")
+		},
+	}
+
+	metaListCmd = &cobra.Command{
+		Use:     "list",
+		Short:   "List metadata in the manifest and show differences with on-chain metadata",
+		Aliases: []string{"ls"},
+		Args:    cobra.NoArgs,
+		Run: func(_ *cobra.Command, _ []string) {
+			cfg := cliConfig.Global()
+			npa := common.GetNPASelection(cfg)
+
+			_, deployment := roflCommon.LoadManifestAndSetNPA(cfg, npa, deploymentName, false)
+
+			if len(deployment.Metadata) == 0 {
+				fmt.Printf("No metadata configured for deployment '%s'.\n", deploymentName)
+			} else {
+				fmt.Printf("Metadata for deployment '%s':\n", deploymentName)
+				for _, key := range sortedKeys(deployment.Metadata) {
+					fmt.Printf("  %s: %s\n", key, deployment.Metadata[key])
+				}
+			}
+
+			if !deployment.HasAppID() {
+				return
+			}
+			var appID rofl.AppID
+			if err := appID.UnmarshalText([]byte(deployment.AppID)); err != nil {
+				cobra.CheckErr(fmt.Errorf("malformed ROFL app ID: %w", err))
+			}
+
+			// Establish connection with the target network.
+			ctx := context.Background()
+			conn, err := common.Connect(ctx, npa.Network)
+			cobra.CheckErr(err)
+
+			appCfg, err := conn.Runtime(npa.ParaTime).ROFL.App(ctx, client.RoundLatest, appID)
+			cobra.CheckErr(err)
+
+			fmt.Println()
+			diff := diffMetadata(appCfg.Metadata, deployment.Metadata)
+			if len(diff) == 0 {
+				fmt.Println("Metadata is in sync with the on-chain configuration.")
+				return
+			}
+			fmt.Println("Differences from the on-chain configuration:")
+			for _, line := range diff {
+				fmt.Printf("  %s\n", line)
+			}
+			fmt.Printf("Run `oasis rofl update` to update your ROFL app's on-chain configuration.\n")
+		},
+	}
+)
+
+// diffMetadata returns a human readable list of differences between the on-chain and the local
+// metadata, sorted by key.
+func diffMetadata(onChain, local map[string]string) []string {
+	keys := make(map[string]struct{})
+	for key := range onChain {
+		keys[key] = struct{}{}
+	}
+	for key := range local {
+		keys[key] = struct{}{}
+	}
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+
+	var diff []string
+	for _, key := range sorted {
+		ocValue, inChain := onChain[key]
+		lValue, inLocal := local[key]
+		switch {
+		case inChain && !inLocal:
+			diff = append(diff, fmt.Sprintf("- %s: %s", key, ocValue))
+		case !inChain && inLocal:
+			diff = append(diff, fmt.Sprintf("+ %s: %s", key, lValue))
+		case ocValue != lValue:
+			diff = append(diff, fmt.Sprintf("~ %s: %s -> %s", key, ocValue, lValue))
+		}
+	}
+	return diff
+}
+
+// sortedKeys returns the keys of the given map in sorted order.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func init() {
+	deploymentFlags := flag.NewFlagSet("", flag.ContinueOnError)
+	deploymentFlags.StringVar(&deploymentName, "deployment", buildRofl.DefaultDeploymentName, "deployment name")
+
+	metaSetCmd.Flags().AddFlagSet(deploymentFlags)
+	metaCmd.AddCommand(metaSetCmd)
+
+	metaRmCmd.Flags().AddFlagSet(deploymentFlags)
+	metaCmd.AddCommand(metaRmCmd)
+
+	metaListCmd.Flags().AddFlagSet(deploymentFlags)
+	metaCmd.AddCommand(metaListCmd)
+}
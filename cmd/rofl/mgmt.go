@@ -3,17 +3,22 @@ package rofl
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
 	"gopkg.in/yaml.v3"
 
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/sgx/pcs"
 	"github.com/oasisprotocol/oasis-core/go/common/sgx/quote"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/connection"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/helpers"
@@ -38,8 +43,13 @@ var (
 
 	appTEE         string
 	appKind        string
+	appImage       string
 	deploymentName string
 	doUpdate       bool
+	syncEnclaves   bool
+
+	trustRootHeight uint64
+	trustRootHash   string
 
 	initCmd = &cobra.Command{
 		Use:   "init [<name>] [--tee TEE] [--kind KIND]",
@@ -56,8 +66,24 @@ var (
 			if npa.ParaTime == nil {
 				cobra.CheckErr("no ParaTime selected")
 			}
-			if txCfg.Offline {
-				cobra.CheckErr("offline mode currently not supported")
+
+			// Use the trust root from flags when given, otherwise it is fetched from the network.
+			var trustRoot *buildRofl.TrustRootConfig
+			switch {
+			case trustRootHash != "":
+				var h hash.Hash
+				if err := h.UnmarshalHex(trustRootHash); err != nil {
+					cobra.CheckErr(fmt.Errorf("malformed trust root hash: %w", err))
+				}
+				if trustRootHeight == 0 {
+					cobra.CheckErr("--trust-root-height must be set together with --trust-root-hash")
+				}
+				trustRoot = &buildRofl.TrustRootConfig{
+					Height: trustRootHeight,
+					Hash:   h.Hex(),
+				}
+			case txCfg.Offline:
+				cobra.CheckErr("offline mode requires --trust-root-height and --trust-root-hash")
 			}
 
 			// Determine the application directory.
@@ -79,22 +105,25 @@ var (
 				cobra.CheckErr("refusing to overwrite existing manifest")
 			}
 
-			ctx := context.Background()
-			conn, err := connection.Connect(ctx, npa.Network)
-			cobra.CheckErr(err)
+			// Determine debug mode. In offline mode, production mode is assumed.
+			var debugMode bool
+			if !txCfg.Offline {
+				ctx := context.Background()
+				conn, err := common.Connect(ctx, npa.Network)
+				cobra.CheckErr(err)
 
-			// Determine latest height for the trust root.
-			height, err := common.GetActualHeight(ctx, conn.Consensus())
-			cobra.CheckErr(err)
+				latestRoot, height, err := roflCommon.FetchLatestTrustRoot(ctx, conn.Consensus())
+				cobra.CheckErr(err)
 
-			blk, err := conn.Consensus().GetBlock(ctx, height)
-			cobra.CheckErr(err)
+				if trustRoot == nil {
+					// Use latest height for the trust root.
+					trustRoot = latestRoot
+				}
 
-			// Determine debug mode.
-			var debugMode bool
-			params, err := conn.Consensus().Registry().ConsensusParameters(ctx, height)
-			if err == nil {
-				debugMode = params.DebugAllowTestRuntimes
+				params, err := conn.Consensus().Registry().ConsensusParameters(ctx, height)
+				if err == nil {
+					debugMode = params.DebugAllowTestRuntimes
+				}
 			}
 
 			// Generate manifest and a default policy which does not accept any enclaves.
@@ -117,10 +146,7 @@ var (
 					Fees:          rofl.FeePolicyEndorsingNodePays,
 					MaxExpiration: 3,
 				},
-				TrustRoot: &buildRofl.TrustRootConfig{
-					Height: uint64(height),
-					Hash:   blk.Hash.Hex(),
-				},
+				TrustRoot: trustRoot,
 			}
 			manifest := buildRofl.Manifest{
 				Name:    appName,
@@ -160,12 +186,7 @@ var (
 					artifacts := buildRofl.LatestBasicArtifacts // Copy.
 					manifest.Artifacts = &artifacts
 				case buildRofl.AppKindContainer:
-					// For container app kind also create an en empty compose.yaml file if it doesn't exist.
-					var f *os.File
-					f, err = os.OpenFile("compose.yaml", os.O_RDONLY|os.O_CREATE, 0o644)
-					if err == nil {
-						f.Close()
-					}
+					scaffoldContainerApp(&manifest, appPath)
 
 					artifacts := buildRofl.LatestContainerArtifacts // Copy.
 					manifest.Artifacts = &artifacts
@@ -218,7 +239,7 @@ var (
 			var conn connection.Connection
 			if !txCfg.Offline {
 				var err error
-				conn, err = connection.Connect(ctx, npa.Network)
+				conn, err = common.Connect(ctx, npa.Network)
 				cobra.CheckErr(err)
 			}
 
@@ -283,20 +304,49 @@ var (
 				policy   *rofl.AppAuthPolicy
 				metadata map[string]string
 				secrets  map[string][]byte
+				config   map[string]string
 			)
 			if len(args) > 0 {
+				if syncEnclaves {
+					cobra.CheckErr("--sync-enclaves requires the app to be configured in the manifest")
+				}
 				rawAppID = args[0]
 				policy = loadPolicy(policyFn)
 			} else {
-				_, deployment := roflCommon.LoadManifestAndSetNPA(cfg, npa, deploymentName, true)
+				manifest, deployment := roflCommon.LoadManifestAndSetNPA(cfg, npa, deploymentName, true)
 				rawAppID = deployment.AppID
 
+				if syncEnclaves {
+					if deployment.Policy == nil {
+						cobra.CheckErr(fmt.Errorf("deployment '%s' has no policy configured", deploymentName))
+					}
+
+					bundleFn := roflCommon.BundleFilename(manifest, deploymentName)
+					changed, err := roflCommon.SyncEnclaveIdentities(deployment.Policy, bundleFn)
+					if err != nil {
+						cobra.CheckErr(fmt.Errorf("failed to sync enclave identities: %w", err))
+					}
+					switch changed {
+					case true:
+						if err = manifest.Save(); err != nil {
+							cobra.CheckErr(fmt.Errorf("failed to update manifest: %w", err))
+						}
+						fmt.Printf("Updated manifest enclave identities from '%s'.\n", bundleFn)
+					case false:
+						fmt.Println("Manifest enclave identities already match the built bundle.")
+					}
+				}
+
 				if adminAddress == "" && deployment.Admin != "" {
 					adminAddress = "self"
 				}
 				policy = deployment.Policy
 				metadata = deployment.Metadata
+				if err := buildRofl.ValidateSecretLimits(deployment.Secrets); err != nil {
+					cobra.CheckErr(err)
+				}
 				secrets = buildRofl.PrepareSecrets(deployment.Secrets)
+				config = deployment.Config
 			}
 			var appID rofl.AppID
 			if err := appID.UnmarshalText([]byte(rawAppID)); err != nil {
@@ -324,10 +374,27 @@ var (
 			var conn connection.Connection
 			if !txCfg.Offline {
 				var err error
-				conn, err = connection.Connect(ctx, npa.Network)
+				conn, err = common.Connect(ctx, npa.Network)
 				cobra.CheckErr(err)
 			}
 
+			// Deliver the plain-text configuration encrypted alongside the secrets.
+			if len(config) > 0 {
+				if txCfg.Offline {
+					cobra.CheckErr("configuration values cannot be encrypted in offline mode")
+				}
+				appCfg, err := conn.Runtime(npa.ParaTime).ROFL.App(ctx, client.RoundLatest, appID)
+				cobra.CheckErr(err)
+				configSecrets, err := buildRofl.EncryptConfig(config, appCfg.SEK)
+				cobra.CheckErr(err)
+				if secrets == nil {
+					secrets = make(map[string][]byte)
+				}
+				for name, value := range buildRofl.PrepareSecrets(configSecrets) {
+					secrets[name] = value
+				}
+			}
+
 			updateBody := rofl.Update{
 				ID:       appID,
 				Policy:   *policy,
@@ -369,8 +436,29 @@ var (
 			if len(args) > 0 {
 				rawAppID = args[0]
 			} else {
-				_, deployment := roflCommon.LoadManifestAndSetNPA(cfg, npa, deploymentName, true)
+				manifest, deployment := roflCommon.LoadManifestAndSetNPA(cfg, npa, deploymentName, true)
 				rawAppID = deployment.AppID
+
+				if syncEnclaves {
+					if deployment.Policy == nil {
+						cobra.CheckErr(fmt.Errorf("deployment '%s' has no policy configured", deploymentName))
+					}
+
+					bundleFn := roflCommon.BundleFilename(manifest, deploymentName)
+					changed, err := roflCommon.SyncEnclaveIdentities(deployment.Policy, bundleFn)
+					if err != nil {
+						cobra.CheckErr(fmt.Errorf("failed to sync enclave identities: %w", err))
+					}
+					switch changed {
+					case true:
+						if err = manifest.Save(); err != nil {
+							cobra.CheckErr(fmt.Errorf("failed to update manifest: %w", err))
+						}
+						fmt.Printf("Updated manifest enclave identities from '%s'.\n", bundleFn)
+					case false:
+						fmt.Println("Manifest enclave identities already match the built bundle.")
+					}
+				}
 			}
 			var appID rofl.AppID
 			if err := appID.UnmarshalText([]byte(rawAppID)); err != nil {
@@ -389,7 +477,7 @@ var (
 			var conn connection.Connection
 			if !txCfg.Offline {
 				var err error
-				conn, err = connection.Connect(ctx, npa.Network)
+				conn, err = common.Connect(ctx, npa.Network)
 				cobra.CheckErr(err)
 			}
 
@@ -418,8 +506,29 @@ var (
 			if len(args) > 0 {
 				rawAppID = args[0]
 			} else {
-				_, deployment := roflCommon.LoadManifestAndSetNPA(cfg, npa, deploymentName, true)
+				manifest, deployment := roflCommon.LoadManifestAndSetNPA(cfg, npa, deploymentName, true)
 				rawAppID = deployment.AppID
+
+				if syncEnclaves {
+					if deployment.Policy == nil {
+						cobra.CheckErr(fmt.Errorf("deployment '%s' has no policy configured", deploymentName))
+					}
+
+					bundleFn := roflCommon.BundleFilename(manifest, deploymentName)
+					changed, err := roflCommon.SyncEnclaveIdentities(deployment.Policy, bundleFn)
+					if err != nil {
+						cobra.CheckErr(fmt.Errorf("failed to sync enclave identities: %w", err))
+					}
+					switch changed {
+					case true:
+						if err = manifest.Save(); err != nil {
+							cobra.CheckErr(fmt.Errorf("failed to update manifest: %w", err))
+						}
+						fmt.Printf("Updated manifest enclave identities from '%s'.\n", bundleFn)
+					case false:
+						fmt.Println("Manifest enclave identities already match the built bundle.")
+					}
+				}
 			}
 			var appID rofl.AppID
 			if err := appID.UnmarshalText([]byte(rawAppID)); err != nil {
@@ -428,7 +537,7 @@ var (
 
 			// Establish connection with the target network.
 			ctx := context.Background()
-			conn, err := connection.Connect(ctx, npa.Network)
+			conn, err := common.Connect(ctx, npa.Network)
 			cobra.CheckErr(err)
 
 			appCfg, err := conn.Runtime(npa.ParaTime).ROFL.App(ctx, client.RoundLatest, appID)
@@ -469,14 +578,52 @@ var (
 			appInstances, err := conn.Runtime(npa.ParaTime).ROFL.AppInstances(ctx, client.RoundLatest, appID)
 			cobra.CheckErr(err)
 
-			if len(appInstances) > 0 {
-				for _, ai := range appInstances {
-					fmt.Printf("- RAK:        %s\n", ai.RAK)
-					fmt.Printf("  Node ID:    %s\n", ai.NodeID)
-					fmt.Printf("  Expiration: %d\n", ai.Expiration)
-				}
-			} else {
+			if len(appInstances) == 0 {
 				fmt.Println("No registered app instances.")
+				return
+			}
+
+			// Cross-check the registered instances against the policy.
+			epoch, err := conn.Consensus().Beacon().GetEpoch(ctx, consensus.HeightLatest)
+			cobra.CheckErr(err)
+			fmt.Printf("Current epoch: %d\n", epoch)
+			if len(appCfg.Policy.Enclaves) == 0 {
+				fmt.Println("WARNING: The policy allows no enclave identities, new instances cannot register.")
+			}
+
+			var violations int
+			for _, ai := range appInstances {
+				nodeDesc, err := conn.Consensus().Registry().GetNode(ctx, &registry.IDQuery{
+					Height: consensus.HeightLatest,
+					ID:     ai.NodeID,
+				})
+				if err != nil && !errors.Is(err, registry.ErrNoSuchNode) {
+					cobra.CheckErr(err)
+				}
+				status := roflCommon.CheckInstance(&appCfg.Policy, ai, nodeDesc, npa.ParaTime.Namespace(), epoch)
+
+				fmt.Printf("- RAK:        %s\n", ai.RAK)
+				fmt.Printf("  Node ID:    %s\n", ai.NodeID)
+				fmt.Printf("  Expiration: %d\n", ai.Expiration)
+				switch {
+				case len(status.Violations) > 0:
+					fmt.Printf("  Status:     policy violation\n")
+					violations++
+				case len(status.Warnings) > 0:
+					fmt.Printf("  Status:     needs attention\n")
+				default:
+					fmt.Printf("  Status:     ok\n")
+				}
+				for _, v := range status.Violations {
+					fmt.Printf("    - %s\n", v)
+				}
+				for _, w := range status.Warnings {
+					fmt.Printf("    - warning: %s\n", w)
+				}
+			}
+			if violations > 0 {
+				fmt.Println()
+				fmt.Printf("%d of %d instances violate the app policy.\n", violations, len(appInstances))
 			}
 		},
 	}
@@ -535,7 +682,7 @@ var (
 
 			// Establish connection with the target network.
 			ctx := context.Background()
-			conn, err := connection.Connect(ctx, npa.Network)
+			conn, err := common.Connect(ctx, npa.Network)
 			cobra.CheckErr(err)
 
 			appCfg, err := conn.Runtime(npa.ParaTime).ROFL.App(ctx, client.RoundLatest, appID)
@@ -554,6 +701,9 @@ var (
 					cobra.CheckErr(fmt.Errorf("failed to read secrets from file: %w", err))
 				}
 			}
+			if len(secretValue) > buildRofl.MaxSecretValueSize {
+				cobra.CheckErr(fmt.Errorf("secret value too large (%d bytes, maximum is %d bytes including encryption overhead)", len(secretValue), buildRofl.MaxSecretValueSize))
+			}
 
 			// Encrypt the secret.
 			encValue, err := buildRofl.EncryptSecret(secretName, secretValue, appCfg.SEK)
@@ -574,6 +724,13 @@ var (
 				}
 			}
 			deployment.Secrets = append(deployment.Secrets, &secretCfg)
+			if err = buildRofl.ValidateSecretLimits(deployment.Secrets); err != nil {
+				cobra.CheckErr(err)
+			}
+			if err = buildRofl.ValidateConfig(deployment.Config, deployment.Secrets); err != nil {
+				cobra.CheckErr(err)
+			}
+			warnSecretsPayloadSize(ctx, npa, conn, deployment)
 
 			// Update manifest.
 			if err = manifest.Save(); err != nil {
@@ -652,6 +809,62 @@ var (
 			}
 		},
 	}
+
+	secretCheckCmd = &cobra.Command{
+		Use:   "check",
+		Short: "Cross-reference secrets in the manifest with the compose file",
+		Args:  cobra.NoArgs,
+		Run: func(_ *cobra.Command, _ []string) {
+			cfg := cliConfig.Global()
+			npa := common.GetNPASelection(cfg)
+
+			manifest, deployment := roflCommon.LoadManifestAndSetNPA(cfg, npa, deploymentName, false)
+			if manifest.Kind != buildRofl.AppKindContainer {
+				cobra.CheckErr(fmt.Errorf("secret check is only supported for '%s' apps", buildRofl.AppKindContainer))
+			}
+
+			composeFn := buildRofl.LatestContainerArtifacts.Container.Compose
+			if manifest.Artifacts != nil && manifest.Artifacts.Container.Compose != "" {
+				composeFn = manifest.Artifacts.Container.Compose
+			}
+			services, err := buildRofl.LoadComposeServices(composeFn)
+			cobra.CheckErr(err)
+
+			fmt.Printf("Environment variables passed to containers:\n")
+			for _, svc := range services {
+				fmt.Printf("  %s:", svc.Name)
+				switch len(svc.Environment) {
+				case 0:
+					fmt.Printf(" (none)\n")
+				default:
+					fmt.Printf(" %s\n", strings.Join(svc.Environment, ", "))
+				}
+			}
+
+			// Configuration values are delivered to the app as secrets.
+			defined := append([]*buildRofl.SecretConfig{}, deployment.Secrets...)
+			for key := range deployment.Config {
+				defined = append(defined, &buildRofl.SecretConfig{Name: key})
+			}
+			result := buildRofl.CheckComposeSecrets(defined, services)
+			if len(result.Unused) > 0 {
+				fmt.Printf("\nSecrets not referenced in '%s':\n", composeFn)
+				for _, name := range result.Unused {
+					fmt.Printf("  - %s\n", name)
+				}
+			}
+			if len(result.Undefined) > 0 {
+				fmt.Printf("\nReferences in '%s' without a secret for deployment '%s':\n", composeFn, deploymentName)
+				for _, name := range result.Undefined {
+					fmt.Printf("  - %s\n", name)
+				}
+				cobra.CheckErr(fmt.Errorf("%d undefined reference(s)", len(result.Undefined)))
+			}
+			if len(result.Unused) == 0 {
+				fmt.Printf("\nAll secrets are referenced and all references are defined.\n")
+			}
+		},
+	}
 )
 
 func loadPolicy(fn string) *rofl.AppAuthPolicy {
@@ -666,6 +879,72 @@ func loadPolicy(fn string) *rofl.AppAuthPolicy {
 	return &policy
 }
 
+// warnSecretsPayloadSize warns when the secrets and metadata of the given deployment take up a
+// large part of the maximum transaction size, as the update transaction would then fail.
+func warnSecretsPayloadSize(ctx context.Context, npa *common.NPASelection, conn connection.Connection, deployment *buildRofl.Deployment) {
+	params, err := conn.Runtime(npa.ParaTime).Core.Parameters(ctx, client.RoundLatest)
+	if err != nil || params.MaxTxSize == 0 {
+		return
+	}
+
+	size := buildRofl.SecretsPayloadSize(deployment.Secrets)
+	for key, value := range deployment.Metadata {
+		size += len(key) + len(value)
+	}
+	for key, value := range deployment.Config {
+		size += len(key) + len(value)
+	}
+	maxSize := int(params.MaxTxSize)
+	switch {
+	case size > maxSize:
+		fmt.Fprintf(os.Stderr, "Warning: Secrets and metadata take up %d bytes, which exceeds the maximum transaction size of %d bytes. The `oasis rofl update` transaction will fail.\n", size, maxSize)
+	case size > maxSize*3/4:
+		fmt.Fprintf(os.Stderr, "Warning: Secrets and metadata take up %d bytes of the maximum transaction size of %d bytes.\n", size, maxSize)
+	}
+}
+
+// scaffoldContainerApp prepares the container app in the given directory. When the app uses a
+// supported toolchain, a Dockerfile and a compose file building it are generated and the image
+// build is wired into the build-pre script. Existing files are never overwritten.
+func scaffoldContainerApp(manifest *buildRofl.Manifest, appPath string) {
+	const (
+		composeFn    = "compose.yaml"
+		dockerfileFn = "Dockerfile"
+	)
+
+	if _, err := os.Stat(dockerfileFn); errors.Is(err, os.ErrNotExist) {
+		if tc := buildRofl.DetectToolchain(appPath); tc != nil {
+			fmt.Printf("Detected %s app, generating '%s'.\n", tc.Name, dockerfileFn)
+			err = os.WriteFile(dockerfileFn, []byte(tc.Dockerfile(appPath, manifest.Name)), 0o644) //nolint: gosec
+			cobra.CheckErr(err)
+		}
+	}
+
+	if _, err := os.Stat(composeFn); !errors.Is(err, os.ErrNotExist) {
+		return
+	}
+
+	// Without a Dockerfile, create an empty compose file.
+	var compose string
+	if _, err := os.Stat(dockerfileFn); err == nil {
+		image := appImage
+		if image == "" {
+			image = buildRofl.ComposeServiceName(manifest.Name) + ":latest"
+		}
+		compose = buildRofl.GenerateCompose(buildRofl.ComposeServiceName(manifest.Name), image)
+
+		if manifest.Scripts == nil {
+			manifest.Scripts = make(map[string]string)
+		}
+		manifest.Scripts[buildRofl.ScriptBuildPre] = buildRofl.ImageBuildScript(image)
+
+		fmt.Printf("Generated '%s' with image '%s' built by `oasis rofl build`.\n", composeFn, image)
+		fmt.Printf("Make sure to push the image to a public registry before deploying the app.\n")
+	}
+	err := os.WriteFile(composeFn, []byte(compose), 0o644) //nolint: gosec
+	cobra.CheckErr(err)
+}
+
 func init() {
 	deploymentFlags := flag.NewFlagSet("", flag.ContinueOnError)
 	deploymentFlags.StringVar(&deploymentName, "deployment", buildRofl.DefaultDeploymentName, "deployment name")
@@ -673,6 +952,7 @@ func init() {
 	updateFlags := flag.NewFlagSet("", flag.ContinueOnError)
 	updateFlags.StringVar(&policyFn, "policy", "", "set the ROFL application policy")
 	updateFlags.StringVar(&adminAddress, "admin", "", "set the administrator address")
+	updateFlags.BoolVar(&syncEnclaves, "sync-enclaves", false, "replace manifest enclave identities with the ones from the built bundle")
 	updateCmd.Flags().AddFlagSet(deploymentFlags)
 
 	initCmd.Flags().AddFlagSet(common.SelectorFlags)
@@ -680,7 +960,10 @@ func init() {
 	initCmd.Flags().AddFlagSet(deploymentFlags)
 	initCmd.Flags().StringVar(&appTEE, "tee", "tdx", "TEE kind [tdx, sgx]")
 	initCmd.Flags().StringVar(&appKind, "kind", "container", "ROFL app kind [container, raw]")
+	initCmd.Flags().StringVar(&appImage, "image", "", "container image reference for generated compose files (default \"<name>:latest\")")
 	initCmd.Flags().StringVar(&scheme, "scheme", "cn", "app ID generation scheme: creator+round+index [cri] or creator+nonce [cn]")
+	initCmd.Flags().Uint64Var(&trustRootHeight, "trust-root-height", 0, "consensus layer height of the trust root (required in offline mode)")
+	initCmd.Flags().StringVar(&trustRootHash, "trust-root-hash", "", "consensus layer block hash of the trust root (required in offline mode)")
 
 	createCmd.Flags().AddFlagSet(common.SelectorFlags)
 	createCmd.Flags().AddFlagSet(common.RuntimeTxFlags)
@@ -709,4 +992,7 @@ func init() {
 
 	secretRmCmd.Flags().AddFlagSet(deploymentFlags)
 	secretCmd.AddCommand(secretRmCmd)
+
+	secretCheckCmd.Flags().AddFlagSet(deploymentFlags)
+	secretCmd.AddCommand(secretCheckCmd)
 }
@@ -0,0 +1,84 @@
+package rofl
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/oasisprotocol/oasis-core/go/runtime/bundle"
+
+	buildRofl "github.com/oasisprotocol/cli/build/rofl"
+	"github.com/oasisprotocol/cli/cmd/common"
+	cliConfig "github.com/oasisprotocol/cli/config"
+)
+
+var (
+	signatureFn    string
+	expectedSigner string
+
+	orcCmd = &cobra.Command{
+		Use:   "orc",
+		Short: "ORC bundle operations",
+	}
+
+	orcVerifySignatureCmd = &cobra.Command{
+		Use:   "verify-signature <app.orc> --signer <account|address>",
+		Short: "Verify the detached signature of an ORC bundle",
+		Long: `Verify that the detached signature of an ORC bundle produced by "rofl build --sign" was
+made by the trusted signer given with --signer. The signature is read from the bundle filename
+with the .sig suffix unless --signature is given.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+			npa := common.GetNPASelection(cfg)
+			bundleFn := args[0]
+
+			// Anyone can re-sign a bundle after embedding their own identity, so the signature
+			// only means something when checked against a trusted signer.
+			if expectedSigner == "" {
+				cobra.CheckErr("no trusted signer given, use --signer to specify the expected signer of the bundle")
+			}
+			expectedAddr, _, err := common.ResolveLocalAccountOrAddress(npa.Network, expectedSigner)
+			cobra.CheckErr(err)
+
+			if signatureFn == "" {
+				signatureFn = buildRofl.BundleSignatureFileFor(bundleFn)
+			}
+
+			bnd, err := bundle.Open(bundleFn)
+			if err != nil {
+				cobra.CheckErr(fmt.Errorf("failed to open bundle: %w", err))
+			}
+			defer bnd.Close()
+
+			sig, err := buildRofl.LoadBundleSignature(signatureFn)
+			cobra.CheckErr(err)
+			if err = sig.Verify(bnd, *expectedAddr); err != nil {
+				cobra.CheckErr(fmt.Errorf("bundle signature verification failed: %w", err))
+			}
+
+			signerAddr, err := sig.Signer.Address()
+			cobra.CheckErr(err)
+
+			fmt.Println("Bundle signature is VALID.")
+			if name, ok := common.GenAccountNames()[signerAddr.String()]; ok {
+				fmt.Printf("Signer:        %s (%s)\n", signerAddr, name)
+			} else {
+				fmt.Printf("Signer:        %s\n", signerAddr)
+			}
+			fmt.Printf("Public key:    %s\n", sig.Signer.PublicKey.String())
+			fmt.Printf("Manifest hash: %s\n", sig.ManifestHash)
+		},
+	}
+)
+
+func init() {
+	verifyFlags := flag.NewFlagSet("", flag.ContinueOnError)
+	verifyFlags.StringVar(&signatureFn, "signature", "", "detached signature filename (default: bundle filename with the .sig suffix)")
+	verifyFlags.StringVar(&expectedSigner, "signer", "", "trusted account or address that must have signed the bundle (required)")
+	orcVerifySignatureCmd.Flags().AddFlagSet(verifyFlags)
+	orcVerifySignatureCmd.Flags().AddFlagSet(common.SelectorNFlags)
+
+	orcCmd.AddCommand(orcVerifySignatureCmd)
+}
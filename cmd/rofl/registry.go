@@ -0,0 +1,103 @@
+package rofl
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/oasisprotocol/cli/cmd/common"
+	cliConfig "github.com/oasisprotocol/cli/config"
+)
+
+var (
+	registryUsername      string
+	registrySecretOnStdin bool
+
+	registryCmd = &cobra.Command{
+		Use:   "registry",
+		Short: "Manage OCI registry credentials",
+	}
+
+	registryLoginCmd = &cobra.Command{
+		Use:   "login <domain>",
+		Short: "Store the credentials of an OCI registry",
+		Long: `Store the credentials used to access the OCI registry with the given domain (e.g. docker.io or
+ghcr.io). With --username the password is used for basic authentication, otherwise the secret is
+used as a bearer token. The credentials are stored in a file only readable by you next to the
+configuration file.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+			domain := args[0]
+
+			var secret string
+			switch registrySecretOnStdin {
+			case true:
+				data, err := io.ReadAll(os.Stdin)
+				cobra.CheckErr(err)
+				secret = strings.TrimRight(string(data), "\r\n")
+			case false:
+				common.CheckInteractive()
+
+				msg := "Token:"
+				if registryUsername != "" {
+					msg = "Password:"
+				}
+				err := survey.AskOne(&survey.Password{Message: msg}, &secret)
+				cobra.CheckErr(err)
+			}
+			if secret == "" {
+				cobra.CheckErr("empty registry secret")
+			}
+
+			cred := &cliConfig.OCICredential{Token: secret}
+			if registryUsername != "" {
+				cred = &cliConfig.OCICredential{Username: registryUsername, Password: secret}
+			}
+
+			creds, err := cliConfig.LoadOCICredentials(cfg.Directory())
+			cobra.CheckErr(err)
+			creds.Registries[domain] = cred
+			err = creds.Save(cfg.Directory())
+			cobra.CheckErr(err)
+
+			fmt.Printf("Stored credentials of registry '%s'.\n", domain)
+		},
+	}
+
+	registryLogoutCmd = &cobra.Command{
+		Use:   "logout <domain>",
+		Short: "Remove the stored credentials of an OCI registry",
+		Args:  cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+			domain := args[0]
+
+			creds, err := cliConfig.LoadOCICredentials(cfg.Directory())
+			cobra.CheckErr(err)
+			if _, ok := creds.Registries[domain]; !ok {
+				cobra.CheckErr(fmt.Errorf("no stored credentials of registry '%s'", domain))
+			}
+			delete(creds.Registries, domain)
+			err = creds.Save(cfg.Directory())
+			cobra.CheckErr(err)
+
+			fmt.Printf("Removed credentials of registry '%s'.\n", domain)
+		},
+	}
+)
+
+func init() {
+	loginFlags := flag.NewFlagSet("", flag.ContinueOnError)
+	loginFlags.StringVar(&registryUsername, "username", "", "username for basic authentication")
+	loginFlags.BoolVar(&registrySecretOnStdin, "secret-stdin", false, "read the password or token from standard input")
+	registryLoginCmd.Flags().AddFlagSet(loginFlags)
+
+	registryCmd.AddCommand(registryLoginCmd)
+	registryCmd.AddCommand(registryLogoutCmd)
+}
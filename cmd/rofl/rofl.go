@@ -18,9 +18,17 @@ func init() {
 	Cmd.AddCommand(updateCmd)
 	Cmd.AddCommand(removeCmd)
 	Cmd.AddCommand(showCmd)
+	Cmd.AddCommand(statusCmd)
 	Cmd.AddCommand(trustRootCmd)
 	Cmd.AddCommand(build.Cmd)
 	Cmd.AddCommand(identityCmd)
+	Cmd.AddCommand(orcCmd)
 	Cmd.AddCommand(secretCmd)
+	Cmd.AddCommand(metaCmd)
+	Cmd.AddCommand(configCmd)
+	Cmd.AddCommand(manifestCmd)
+	Cmd.AddCommand(stakeCmd)
 	Cmd.AddCommand(upgradeCmd)
+	Cmd.AddCommand(lockCmd)
+	Cmd.AddCommand(registryCmd)
 }
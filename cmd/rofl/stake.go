@@ -0,0 +1,107 @@
+package rofl
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/helpers"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/rofl"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+
+	buildRofl "github.com/oasisprotocol/cli/build/rofl"
+	"github.com/oasisprotocol/cli/cmd/common"
+	roflCommon "github.com/oasisprotocol/cli/cmd/rofl/common"
+	cliConfig "github.com/oasisprotocol/cli/config"
+)
+
+var (
+	stakeCmd = &cobra.Command{
+		Use:   "stake",
+		Short: "ROFL app stake management commands",
+	}
+
+	stakeShowCmd = &cobra.Command{
+		Use:   "show [<app-id>]",
+		Short: "Show the ROFL app stake compared to the current stake thresholds",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+			npa := common.GetNPASelection(cfg)
+
+			var rawAppID string
+			if len(args) > 0 {
+				rawAppID = args[0]
+			} else {
+				_, deployment := roflCommon.LoadManifestAndSetNPA(cfg, npa, deploymentName, true)
+				rawAppID = deployment.AppID
+			}
+			var appID rofl.AppID
+			if err := appID.UnmarshalText([]byte(rawAppID)); err != nil {
+				cobra.CheckErr(fmt.Errorf("malformed ROFL app ID: %w", err))
+			}
+
+			// Establish connection with the target network.
+			ctx := context.Background()
+			conn, err := common.Connect(ctx, npa.Network)
+			cobra.CheckErr(err)
+
+			appCfg, err := conn.Runtime(npa.ParaTime).ROFL.App(ctx, client.RoundLatest, appID)
+			cobra.CheckErr(err)
+			thresholds, err := conn.Runtime(npa.ParaTime).ROFL.StakeThresholds(ctx, client.RoundLatest)
+			cobra.CheckErr(err)
+
+			fmt.Printf("App ID:           %s\n", appCfg.ID)
+			fmt.Printf("Staked amount:    %s\n", helpers.FormatParaTimeDenomination(npa.ParaTime, appCfg.Stake))
+			if thresholds.AppCreate == nil {
+				fmt.Printf("Create threshold: none\n")
+				return
+			}
+			fmt.Printf("Create threshold: %s\n", helpers.FormatParaTimeDenomination(npa.ParaTime, *thresholds.AppCreate))
+
+			if appCfg.Stake.Denomination != thresholds.AppCreate.Denomination {
+				fmt.Printf("Status:           staked in a different denomination than the current threshold\n")
+				return
+			}
+			switch diff := stakeDifference(&appCfg.Stake, thresholds.AppCreate); {
+			case diff == nil:
+				fmt.Printf("Status:           matches the current threshold\n")
+			case appCfg.Stake.Amount.Cmp(&thresholds.AppCreate.Amount) > 0:
+				fmt.Printf("Status:           %s above the current threshold\n", helpers.FormatParaTimeDenomination(npa.ParaTime, *diff))
+			default:
+				fmt.Printf("Status:           %s below the current threshold\n", helpers.FormatParaTimeDenomination(npa.ParaTime, *diff))
+			}
+			fmt.Println()
+			fmt.Println("The stake is escrowed when the app is created and refunded to the administrator")
+			fmt.Println("when the app is removed with `oasis rofl remove`. The rofl module does not support")
+			fmt.Println("topping up or partially withdrawing the stake of an existing app.")
+		},
+	}
+)
+
+// stakeDifference returns the absolute difference between the staked amount and the threshold or
+// nil if they are equal. Both amounts must be in the same denomination.
+func stakeDifference(stake, threshold *types.BaseUnits) *types.BaseUnits {
+	if stake.Amount.Cmp(&threshold.Amount) == 0 {
+		return nil
+	}
+	hi, lo := stake.Amount.Clone(), &threshold.Amount
+	if hi.Cmp(lo) < 0 {
+		hi, lo = threshold.Amount.Clone(), &stake.Amount
+	}
+	_ = hi.Sub(lo)
+	diff := types.NewBaseUnits(*hi, stake.Denomination)
+	return &diff
+}
+
+func init() {
+	deploymentFlags := flag.NewFlagSet("", flag.ContinueOnError)
+	deploymentFlags.StringVar(&deploymentName, "deployment", buildRofl.DefaultDeploymentName, "deployment name")
+
+	stakeShowCmd.Flags().AddFlagSet(common.SelectorFlags)
+	stakeShowCmd.Flags().AddFlagSet(deploymentFlags)
+	stakeCmd.AddCommand(stakeShowCmd)
+}
@@ -0,0 +1,434 @@
+package rofl
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/oasisprotocol/oasis-core/go/common/sgx"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/bundle"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/rofl"
+
+	buildRofl "github.com/oasisprotocol/cli/build/rofl"
+	"github.com/oasisprotocol/cli/cmd/common"
+	roflCommon "github.com/oasisprotocol/cli/cmd/rofl/common"
+	cliConfig "github.com/oasisprotocol/cli/config"
+)
+
+// driftKind describes how a local value relates to the corresponding on-chain value.
+type driftKind string
+
+const (
+	driftNone        driftKind = "in-sync"
+	driftModified    driftKind = "modified"
+	driftLocalOnly   driftKind = "local-only"
+	driftOnChainOnly driftKind = "on-chain-only"
+)
+
+// marker returns the marker of the drift kind used in the textual output.
+func (d driftKind) marker() string {
+	switch d {
+	case driftModified:
+		return "~"
+	case driftLocalOnly:
+		return "+"
+	case driftOnChainOnly:
+		return "-"
+	default:
+		return "="
+	}
+}
+
+// statusEntry is the status of a single item of the app configuration.
+type statusEntry struct {
+	// Name is the name of the item.
+	Name string `json:"name"`
+	// Local is the value in the manifest.
+	Local string `json:"local,omitempty"`
+	// OnChain is the value in the on-chain app configuration.
+	OnChain string `json:"on_chain,omitempty"`
+	// Drift describes how the local value relates to the on-chain value.
+	Drift driftKind `json:"drift"`
+	// Note is an optional remark about the item.
+	Note string `json:"note,omitempty"`
+}
+
+// instancesStatus summarizes the registered instances of the app.
+type instancesStatus struct {
+	// Registered is the number of registered instances.
+	Registered int `json:"registered"`
+	// NeedsAttention is the number of instances with warnings.
+	NeedsAttention int `json:"needs_attention"`
+	// Violations is the number of instances violating the on-chain policy.
+	Violations int `json:"violations"`
+}
+
+// appStatus is the status of a deployment compared with its on-chain state.
+type appStatus struct {
+	// AppID is the identifier of the app.
+	AppID string `json:"app_id"`
+	// Deployment is the name of the deployment in the manifest.
+	Deployment string `json:"deployment"`
+	// Admin is the status of the app administrator.
+	Admin statusEntry `json:"admin"`
+	// Policy is the status of the policy fields other than the enclave identities.
+	Policy []statusEntry `json:"policy"`
+	// Enclaves is the status of the allowed enclave identities.
+	Enclaves []statusEntry `json:"enclaves"`
+	// Bundle is the status of the enclave identities of the built bundle compared with the
+	// manifest or empty if no bundle has been built.
+	Bundle driftKind `json:"bundle,omitempty"`
+	// Metadata is the status of the metadata.
+	Metadata []statusEntry `json:"metadata"`
+	// Secrets is the status of the secrets and configuration values.
+	Secrets []statusEntry `json:"secrets"`
+	// Instances is the summary of the registered instances.
+	Instances instancesStatus `json:"instances"`
+}
+
+// drifted returns the number of items whose local value differs from the on-chain one.
+func (s *appStatus) drifted() int {
+	n := 0
+	if s.Admin.Drift != driftNone {
+		n++
+	}
+	for _, entries := range [][]statusEntry{s.Policy, s.Enclaves, s.Metadata, s.Secrets} {
+		for _, e := range entries {
+			if e.Drift != driftNone {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// compareValue compares the given optional local and on-chain values.
+func compareValue(name, local, onChain string) statusEntry {
+	e := statusEntry{Name: name, Local: local, OnChain: onChain}
+	switch {
+	case local == onChain:
+		e.Drift = driftNone
+	case onChain == "":
+		e.Drift = driftLocalOnly
+	case local == "":
+		e.Drift = driftOnChainOnly
+	default:
+		e.Drift = driftModified
+	}
+	return e
+}
+
+// compareMaps compares the given local and on-chain maps key by key.
+func compareMaps(local, onChain map[string]string) []statusEntry {
+	keys := make(map[string]struct{})
+	for k := range local {
+		keys[k] = struct{}{}
+	}
+	for k := range onChain {
+		keys[k] = struct{}{}
+	}
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	entries := make([]statusEntry, 0, len(sorted))
+	for _, k := range sorted {
+		lv, lok := local[k]
+		ov, ook := onChain[k]
+		e := statusEntry{Name: k, Local: lv, OnChain: ov}
+		switch {
+		case lok && ook && lv == ov:
+			e.Drift = driftNone
+		case lok && ook:
+			e.Drift = driftModified
+		case lok:
+			e.Drift = driftLocalOnly
+		default:
+			e.Drift = driftOnChainOnly
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+// comparePolicy compares the fields of the given policies other than the enclave identities which
+// are compared separately.
+func comparePolicy(local, onChain *rofl.AppAuthPolicy) []statusEntry {
+	fields := func(p *rofl.AppAuthPolicy) map[string]string {
+		if p == nil {
+			return nil
+		}
+		cp := *p
+		cp.Enclaves = nil
+		raw, _ := json.Marshal(cp)
+		var m map[string]json.RawMessage
+		_ = json.Unmarshal(raw, &m)
+		delete(m, "enclaves")
+
+		out := make(map[string]string, len(m))
+		for k, v := range m {
+			out[k] = string(v)
+		}
+		return out
+	}
+	return compareMaps(fields(local), fields(onChain))
+}
+
+// compareEnclaves compares the given local and on-chain enclave identities.
+func compareEnclaves(local, onChain []sgx.EnclaveIdentity) []statusEntry {
+	toMap := func(ids []sgx.EnclaveIdentity) map[string]string {
+		m := make(map[string]string, len(ids))
+		for _, id := range ids {
+			text, _ := id.MarshalText()
+			m[string(text)] = string(text)
+		}
+		return m
+	}
+	entries := compareMaps(toMap(local), toMap(onChain))
+	for i := range entries {
+		// The value is the identity itself, so it is only repeated in the name.
+		entries[i].Local, entries[i].OnChain = "", ""
+	}
+	return entries
+}
+
+// secretDigest returns a short digest of the given encrypted secret value.
+func secretDigest(value []byte) string {
+	h := sha256.Sum256(value)
+	return hex.EncodeToString(h[:8])
+}
+
+// compareSecrets compares the secrets and configuration values of the given deployment with the
+// on-chain secrets. Secrets are compared by the digest of their encrypted value. Configuration
+// values are encrypted anew on every update, so only their presence can be compared.
+func compareSecrets(deployment *buildRofl.Deployment, onChain map[string][]byte) []statusEntry {
+	local := make(map[string]string)
+	for name, value := range buildRofl.PrepareSecrets(deployment.Secrets) {
+		local[name] = secretDigest(value)
+	}
+	remote := make(map[string]string, len(onChain))
+	for name, value := range onChain {
+		remote[name] = secretDigest(value)
+	}
+	for key := range deployment.Config {
+		local[key] = "config"
+		if _, ok := remote[key]; ok {
+			remote[key] = "config"
+		}
+	}
+
+	entries := compareMaps(local, remote)
+	for i := range entries {
+		if _, ok := deployment.Config[entries[i].Name]; ok {
+			entries[i].Local, entries[i].OnChain = "", ""
+			entries[i].Note = "configuration value, only presence is compared"
+		}
+	}
+	return entries
+}
+
+// bundleEnclaves returns the enclave identities of the given built bundle or nil if the bundle
+// does not exist.
+func bundleEnclaves(bundleFn string) ([]sgx.EnclaveIdentity, error) {
+	if _, err := os.Stat(bundleFn); errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	bnd, err := bundle.Open(bundleFn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bundle '%s': %w", bundleFn, err)
+	}
+	defer bnd.Close()
+
+	eids, err := roflCommon.ComputeEnclaveIdentity(bnd, "")
+	if err != nil {
+		return nil, err
+	}
+	enclaves := make([]sgx.EnclaveIdentity, 0, len(eids))
+	for _, eid := range eids {
+		enclaves = append(enclaves, *eid)
+	}
+	return enclaves, nil
+}
+
+// printStatusEntries prints the given entries using git-style drift markers.
+func printStatusEntries(title string, entries []statusEntry, showValues bool) {
+	fmt.Printf("%s:\n", title)
+	if len(entries) == 0 {
+		fmt.Printf("  (none)\n")
+		return
+	}
+	for _, e := range entries {
+		fmt.Printf("  %s %s", e.Drift.marker(), e.Name)
+		switch {
+		case e.Drift == driftNone:
+		case e.Drift == driftModified && showValues:
+			fmt.Printf(" (local: %s, on-chain: %s)", e.Local, e.OnChain)
+		default:
+			fmt.Printf(" (%s)", e.Drift)
+		}
+		if e.Note != "" {
+			fmt.Printf(" [%s]", e.Note)
+		}
+		fmt.Println()
+	}
+}
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Compare the manifest deployment with the on-chain state of the ROFL app",
+	Args:  cobra.NoArgs,
+	Run: func(_ *cobra.Command, _ []string) {
+		cfg := cliConfig.Global()
+		npa := common.GetNPASelection(cfg)
+
+		manifest, deployment := roflCommon.LoadManifestAndSetNPA(cfg, npa, deploymentName, true)
+		var appID rofl.AppID
+		if err := appID.UnmarshalText([]byte(deployment.AppID)); err != nil {
+			cobra.CheckErr(fmt.Errorf("malformed ROFL app ID: %w", err))
+		}
+
+		ctx := context.Background()
+		conn, err := common.Connect(ctx, npa.Network)
+		cobra.CheckErr(err)
+
+		appCfg, err := conn.Runtime(npa.ParaTime).ROFL.App(ctx, client.RoundLatest, appID)
+		cobra.CheckErr(err)
+
+		status := appStatus{
+			AppID:      deployment.AppID,
+			Deployment: deploymentName,
+		}
+
+		// Administrator.
+		var localAdmin, onChainAdmin string
+		if deployment.Admin != "" {
+			addr, _, err := common.ResolveLocalAccountOrAddress(npa.Network, deployment.Admin)
+			cobra.CheckErr(err)
+			localAdmin = addr.String()
+		}
+		if appCfg.Admin != nil {
+			onChainAdmin = appCfg.Admin.String()
+		}
+		status.Admin = compareValue("admin", localAdmin, onChainAdmin)
+
+		// Policy and enclave identities.
+		status.Policy = comparePolicy(deployment.Policy, &appCfg.Policy)
+		var localEnclaves []sgx.EnclaveIdentity
+		if deployment.Policy != nil {
+			localEnclaves = deployment.Policy.Enclaves
+		}
+		status.Enclaves = compareEnclaves(localEnclaves, appCfg.Policy.Enclaves)
+
+		bundleIDs, err := bundleEnclaves(roflCommon.BundleFilename(manifest, deploymentName))
+		cobra.CheckErr(err)
+		if bundleIDs != nil {
+			status.Bundle = driftNone
+			for _, e := range compareEnclaves(bundleIDs, localEnclaves) {
+				if e.Drift != driftNone {
+					status.Bundle = driftModified
+					break
+				}
+			}
+		}
+
+		// Metadata and secrets.
+		status.Metadata = compareMaps(deployment.Metadata, appCfg.Metadata)
+		status.Secrets = compareSecrets(deployment, appCfg.Secrets)
+
+		// Running instances.
+		appInstances, err := conn.Runtime(npa.ParaTime).ROFL.AppInstances(ctx, client.RoundLatest, appID)
+		cobra.CheckErr(err)
+		epoch, err := conn.Consensus().Beacon().GetEpoch(ctx, consensus.HeightLatest)
+		cobra.CheckErr(err)
+		status.Instances.Registered = len(appInstances)
+		for _, ai := range appInstances {
+			nodeDesc, err := conn.Consensus().Registry().GetNode(ctx, &registry.IDQuery{
+				Height: consensus.HeightLatest,
+				ID:     ai.NodeID,
+			})
+			if err != nil && !errors.Is(err, registry.ErrNoSuchNode) {
+				cobra.CheckErr(err)
+			}
+			st := roflCommon.CheckInstance(&appCfg.Policy, ai, nodeDesc, npa.ParaTime.Namespace(), epoch)
+			switch {
+			case len(st.Violations) > 0:
+				status.Instances.Violations++
+			case len(st.Warnings) > 0:
+				status.Instances.NeedsAttention++
+			}
+		}
+
+		if common.IsJSONOutput() {
+			data, err := common.JSONMarshalOutput(status)
+			cobra.CheckErr(err)
+			fmt.Printf("%s\n", data)
+			return
+		}
+
+		fmt.Printf("App ID:     %s\n", status.AppID)
+		fmt.Printf("Deployment: %s (%s, %s)\n", status.Deployment, deployment.Network, deployment.ParaTime)
+		fmt.Println()
+		fmt.Printf("Admin: %s", status.Admin.Drift)
+		if status.Admin.Drift != driftNone {
+			fmt.Printf(" (local: %s, on-chain: %s)", orNone(status.Admin.Local), orNone(status.Admin.OnChain))
+		}
+		fmt.Println()
+		printStatusEntries("Policy", status.Policy, false)
+		printStatusEntries("Enclaves", status.Enclaves, false)
+		switch status.Bundle {
+		case "":
+			fmt.Printf("  Bundle not built, enclave identities of the bundle were not checked.\n")
+		case driftModified:
+			fmt.Printf("  The built bundle has different enclave identities than the manifest, run `oasis rofl update --sync-enclaves`.\n")
+		}
+		printStatusEntries("Metadata", status.Metadata, true)
+		printStatusEntries("Secrets", status.Secrets, true)
+		fmt.Printf("Instances: %d registered", status.Instances.Registered)
+		if status.Instances.Violations > 0 {
+			fmt.Printf(", %d violating the policy", status.Instances.Violations)
+		}
+		if status.Instances.NeedsAttention > 0 {
+			fmt.Printf(", %d needing attention", status.Instances.NeedsAttention)
+		}
+		fmt.Println()
+		fmt.Println()
+
+		switch n := status.drifted(); n {
+		case 0:
+			fmt.Printf("The manifest is in sync with the on-chain app configuration.\n")
+		default:
+			fmt.Printf("%d item(s) differ between the manifest and the on-chain app configuration.\n", n)
+			fmt.Printf("Run `oasis rofl update` to deploy the local changes.\n")
+		}
+	},
+}
+
+// orNone returns the given value or "none" if it is empty.
+func orNone(v string) string {
+	if v == "" {
+		return "none"
+	}
+	return v
+}
+
+func init() {
+	deploymentFlags := flag.NewFlagSet("", flag.ContinueOnError)
+	deploymentFlags.StringVar(&deploymentName, "deployment", buildRofl.DefaultDeploymentName, "deployment name")
+
+	statusCmd.Flags().AddFlagSet(common.SelectorNPFlags)
+	statusCmd.Flags().AddFlagSet(common.FormatFlag)
+	statusCmd.Flags().AddFlagSet(deploymentFlags)
+}
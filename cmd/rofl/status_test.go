@@ -0,0 +1,87 @@
+package rofl
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/sgx"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/rofl"
+
+	buildRofl "github.com/oasisprotocol/cli/build/rofl"
+)
+
+func TestCompareMaps(t *testing.T) {
+	require := require.New(t)
+
+	entries := compareMaps(
+		map[string]string{"a": "1", "b": "2", "c": "3"},
+		map[string]string{"a": "1", "b": "x", "d": "4"},
+	)
+	require.Equal([]statusEntry{
+		{Name: "a", Local: "1", OnChain: "1", Drift: driftNone},
+		{Name: "b", Local: "2", OnChain: "x", Drift: driftModified},
+		{Name: "c", Local: "3", Drift: driftLocalOnly},
+		{Name: "d", OnChain: "4", Drift: driftOnChainOnly},
+	}, entries)
+}
+
+func TestComparePolicyAndEnclaves(t *testing.T) {
+	require := require.New(t)
+
+	eid1 := sgx.EnclaveIdentity{MrEnclave: sgx.MrEnclave{1}}
+	eid2 := sgx.EnclaveIdentity{MrEnclave: sgx.MrEnclave{2}}
+	local := &rofl.AppAuthPolicy{Enclaves: []sgx.EnclaveIdentity{eid1}, MaxExpiration: 3}
+	onChain := &rofl.AppAuthPolicy{Enclaves: []sgx.EnclaveIdentity{eid2}, MaxExpiration: 3}
+
+	for _, e := range comparePolicy(local, onChain) {
+		require.Equal(driftNone, e.Drift, e.Name)
+	}
+	onChain.MaxExpiration = 2
+	var modified []string
+	for _, e := range comparePolicy(local, onChain) {
+		if e.Drift != driftNone {
+			modified = append(modified, e.Name)
+		}
+	}
+	require.Equal([]string{"max_expiration"}, modified)
+
+	enclaves := compareEnclaves(local.Enclaves, onChain.Enclaves)
+	require.Len(enclaves, 2)
+	drifts := []driftKind{enclaves[0].Drift, enclaves[1].Drift}
+	require.ElementsMatch([]driftKind{driftLocalOnly, driftOnChainOnly}, drifts)
+}
+
+func TestCompareSecrets(t *testing.T) {
+	require := require.New(t)
+
+	enc := func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) }
+	deployment := &buildRofl.Deployment{
+		Secrets: []*buildRofl.SecretConfig{
+			{Name: "same", Value: enc("v1")},
+			{Name: "changed", Value: enc("v2")},
+			{Name: "private", PublicName: "public", Value: enc("v3")},
+		},
+		Config: map[string]string{"LOG_LEVEL": "debug"},
+	}
+	onChain := map[string][]byte{
+		"same":      []byte("v1"),
+		"changed":   []byte("old"),
+		"public":    []byte("v3"),
+		"LOG_LEVEL": []byte("encrypted"),
+		"removed":   []byte("v4"),
+	}
+
+	drifts := make(map[string]driftKind)
+	for _, e := range compareSecrets(deployment, onChain) {
+		drifts[e.Name] = e.Drift
+	}
+	require.Equal(map[string]driftKind{
+		"LOG_LEVEL": driftNone,
+		"changed":   driftModified,
+		"public":    driftNone,
+		"removed":   driftOnChainOnly,
+		"same":      driftNone,
+	}, drifts)
+}
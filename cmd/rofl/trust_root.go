@@ -5,51 +5,79 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
 
-	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/connection"
-
+	buildRofl "github.com/oasisprotocol/cli/build/rofl"
 	"github.com/oasisprotocol/cli/cmd/common"
+	roflCommon "github.com/oasisprotocol/cli/cmd/rofl/common"
 	cliConfig "github.com/oasisprotocol/cli/config"
 )
 
-var trustRootCmd = &cobra.Command{
-	Use:   "trust-root",
-	Short: "Show a recent trust root for a ROFL application",
-	Args:  cobra.NoArgs,
-	Run: func(_ *cobra.Command, _ []string) {
-		cfg := cliConfig.Global()
-		npa := common.GetNPASelection(cfg)
-
-		if npa.ParaTime == nil {
-			cobra.CheckErr("no ParaTime selected")
-		}
-
-		// Establish connection with the target network.
-		ctx := context.Background()
-		conn, err := connection.Connect(ctx, npa.Network)
-		cobra.CheckErr(err)
-
-		// Fetch latest consensus block.
-		height, err := common.GetActualHeight(
-			ctx,
-			conn.Consensus(),
-		)
-		cobra.CheckErr(err)
-
-		blk, err := conn.Consensus().GetBlock(ctx, height)
-		cobra.CheckErr(err)
-
-		// TODO: Support different output formats.
-		fmt.Printf("TrustRoot {\n")
-		fmt.Printf("    height: %d,\n", height)
-		fmt.Printf("    hash: \"%s\".into(),\n", blk.Hash)
-		fmt.Printf("    runtime_id: \"%s\".into(),\n", npa.ParaTime.ID)
-		fmt.Printf("    chain_context: \"%s\".to_string(),\n", npa.Network.ChainContext)
-		fmt.Printf("}\n")
-	},
-}
+var (
+	trustRootMaxAge uint64
+
+	trustRootCmd = &cobra.Command{
+		Use:   "trust-root",
+		Short: "Show a recent trust root for a ROFL application",
+		Args:  cobra.NoArgs,
+		Run: func(_ *cobra.Command, _ []string) {
+			cfg := cliConfig.Global()
+			npa := common.GetNPASelection(cfg)
+
+			if npa.ParaTime == nil {
+				cobra.CheckErr("no ParaTime selected")
+			}
+
+			// Establish connection with the target network.
+			ctx := context.Background()
+			conn, err := common.Connect(ctx, npa.Network)
+			cobra.CheckErr(err)
+
+			// Fetch latest consensus block.
+			height, err := common.GetActualHeight(
+				ctx,
+				conn.Consensus(),
+			)
+			cobra.CheckErr(err)
+
+			blk, err := conn.Consensus().GetBlock(ctx, height)
+			cobra.CheckErr(err)
+
+			// TODO: Support different output formats.
+			fmt.Printf("TrustRoot {\n")
+			fmt.Printf("    height: %d,\n", height)
+			fmt.Printf("    hash: \"%s\".into(),\n", blk.Hash)
+			fmt.Printf("    runtime_id: \"%s\".into(),\n", npa.ParaTime.ID)
+			fmt.Printf("    chain_context: \"%s\".to_string(),\n", npa.Network.ChainContext)
+			fmt.Printf("}\n")
+		},
+	}
+
+	trustRootUpdateCmd = &cobra.Command{
+		Use:   "update",
+		Short: "Update the trust root of the deployment in the manifest to a recent block",
+		Args:  cobra.NoArgs,
+		Run: func(_ *cobra.Command, _ []string) {
+			cfg := cliConfig.Global()
+			npa := common.GetNPASelection(cfg)
+			manifest, deployment := roflCommon.LoadManifestAndSetNPA(cfg, npa, deploymentName, false)
+
+			err := roflCommon.RefreshTrustRoot(context.Background(), npa, manifest, deployment, trustRootMaxAge)
+			cobra.CheckErr(err)
+
+			fmt.Printf("Run `oasis rofl build --update-manifest` and `oasis rofl update` to deploy an app using the new trust root.\n")
+		},
+	}
+)
 
 func init() {
 	trustRootCmd.Flags().AddFlagSet(common.SelectorNPFlags)
 	trustRootCmd.Flags().AddFlagSet(common.HeightFlag)
+
+	deploymentFlags := flag.NewFlagSet("", flag.ContinueOnError)
+	deploymentFlags.StringVar(&deploymentName, "deployment", buildRofl.DefaultDeploymentName, "deployment name")
+
+	trustRootUpdateCmd.Flags().AddFlagSet(deploymentFlags)
+	trustRootUpdateCmd.Flags().Uint64Var(&trustRootMaxAge, "max-age", roflCommon.DefaultTrustRootMaxAge, "warn if the existing trust root is older than the given number of blocks")
+	trustRootCmd.AddCommand(trustRootUpdateCmd)
 }
@@ -11,14 +11,22 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/oasisprotocol/cli/cmd/account"
+	"github.com/oasisprotocol/cli/cmd/agent"
+	"github.com/oasisprotocol/cli/cmd/backup"
+	"github.com/oasisprotocol/cli/cmd/common"
+	"github.com/oasisprotocol/cli/cmd/debug"
+	"github.com/oasisprotocol/cli/cmd/dev"
+	"github.com/oasisprotocol/cli/cmd/evm"
+	"github.com/oasisprotocol/cli/cmd/explorer"
 	"github.com/oasisprotocol/cli/cmd/network"
 	"github.com/oasisprotocol/cli/cmd/paratime"
 	"github.com/oasisprotocol/cli/cmd/rofl"
 	"github.com/oasisprotocol/cli/cmd/wallet"
 	"github.com/oasisprotocol/cli/config"
 	"github.com/oasisprotocol/cli/version"
-	_ "github.com/oasisprotocol/cli/wallet/file"   // Register file wallet backend.
-	_ "github.com/oasisprotocol/cli/wallet/ledger" // Register ledger wallet backend.
+	_ "github.com/oasisprotocol/cli/wallet/file"     // Register file wallet backend.
+	_ "github.com/oasisprotocol/cli/wallet/ledger"   // Register ledger wallet backend.
+	_ "github.com/oasisprotocol/cli/wallet/multisig" // Register multisig wallet backend.
 )
 
 var (
@@ -28,6 +36,9 @@ var (
 		Use:     "oasis",
 		Short:   "CLI for interacting with the Oasis network",
 		Version: version.Software,
+		PersistentPreRun: func(cmd *cobra.Command, _ []string) {
+			common.CheckReadOnlyCommand(cmd)
+		},
 	}
 )
 
@@ -48,6 +59,10 @@ Go toolchain version: {{ toolchain }}
 `)
 }
 
+func initLogging() {
+	cobra.CheckErr(common.InitLogging())
+}
+
 func initConfig() {
 	v := viper.New()
 
@@ -94,16 +109,29 @@ func initConfig() {
 func init() {
 	initVersions()
 
-	cobra.OnInitialize(initConfig)
+	cobra.OnInitialize(initLogging, initConfig, common.RedirectOutputForExport)
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file to use")
+	rootCmd.PersistentFlags().AddFlagSet(common.NonInteractiveFlag)
+	rootCmd.PersistentFlags().AddFlagSet(common.RequestFlags)
+	rootCmd.PersistentFlags().AddFlagSet(common.LoggingFlags)
+	rootCmd.PersistentFlags().AddFlagSet(common.ProgressFlags)
+	rootCmd.PersistentFlags().AddFlagSet(common.ReadOnlyFlag)
 
 	rootCmd.AddCommand(network.Cmd)
 	rootCmd.AddCommand(paratime.Cmd)
 	rootCmd.AddCommand(wallet.Cmd)
 	rootCmd.AddCommand(account.Cmd)
 	rootCmd.AddCommand(addressBookCmd)
+	rootCmd.AddCommand(addressCmd)
 	rootCmd.AddCommand(contractCmd)
 	rootCmd.AddCommand(txCmd)
 	rootCmd.AddCommand(rofl.Cmd)
+	rootCmd.AddCommand(evm.Cmd)
+	rootCmd.AddCommand(debug.Cmd)
+	rootCmd.AddCommand(dev.Cmd)
+	rootCmd.AddCommand(explorer.Cmd)
+	rootCmd.AddCommand(backup.Cmd)
+	rootCmd.AddCommand(agent.Cmd)
+	rootCmd.AddCommand(updateCmd)
 }
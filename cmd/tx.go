@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"os"
 	"reflect"
 
 	"github.com/spf13/cobra"
@@ -37,10 +36,10 @@ var (
 
 			// Establish connection with the target network.
 			ctx := context.Background()
-			conn, err := connection.Connect(ctx, npa.Network)
+			conn, err := common.Connect(ctx, npa.Network)
 			cobra.CheckErr(err)
 
-			rawTx, err := os.ReadFile(filename)
+			rawTx, err := common.ReadFile(filename)
 			cobra.CheckErr(err)
 
 			tx, err := tryDecodeTx(rawTx)
@@ -83,11 +82,11 @@ var (
 			var conn connection.Connection
 			if !txCfg.Offline {
 				var err error
-				conn, err = connection.Connect(ctx, npa.Network)
+				conn, err = common.Connect(ctx, npa.Network)
 				cobra.CheckErr(err)
 			}
 
-			rawTx, err := os.ReadFile(filename)
+			rawTx, err := common.ReadFile(filename)
 			cobra.CheckErr(err)
 
 			tx, err := tryDecodeTx(rawTx)
@@ -124,7 +123,7 @@ var (
 			npa := common.GetNPASelection(cfg)
 			filename := args[0]
 
-			rawTx, err := os.ReadFile(filename)
+			rawTx, err := common.ReadFile(filename)
 			cobra.CheckErr(err)
 
 			tx, err := tryDecodeTx(rawTx)
@@ -195,5 +194,6 @@ func init() {
 
 	txCmd.AddCommand(txSubmitCmd)
 	txCmd.AddCommand(txSignCmd)
+	txCmd.AddCommand(txSignBatchCmd)
 	txCmd.AddCommand(txShowCmd)
 }
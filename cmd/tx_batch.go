@@ -0,0 +1,282 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	consensusTx "github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/connection"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+
+	"github.com/oasisprotocol/cli/cmd/common"
+	cliConfig "github.com/oasisprotocol/cli/config"
+	"github.com/oasisprotocol/cli/wallet"
+)
+
+const (
+	batchActionRetry = "Retry"
+	batchActionSkip  = "Skip"
+	batchActionAbort = "Abort"
+
+	// batchSignedFileSuffix is appended to the name of the signed transaction files.
+	batchSignedFileSuffix = ".signed"
+)
+
+var (
+	batchOutputDir        string
+	batchSequentialNonces bool
+
+	errBatchSigningAborted = errors.New("signing aborted")
+
+	txSignBatchCmd = &cobra.Command{
+		Use:   "sign-batch <filename.json>...",
+		Short: "Sign several unsigned transactions with the same account",
+		Long: `Sign several unsigned transactions one after another with the same account.
+
+All transactions are decoded before signing. The account is loaded only once, so
+hardware wallets keep the same device session and only need a confirmation for
+each transaction. Each signed transaction is stored next to the original (or in
+--output-dir) with the ` + batchSignedFileSuffix + ` suffix. Transactions which were already signed
+in a previous run are skipped, so an interrupted batch can be resumed by running
+the same command again.`,
+		Args: cobra.MinimumNArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+			npa := common.GetNPASelection(cfg)
+			txCfg := common.GetTransactionConfig()
+
+			// Decode all transactions before touching the signer.
+			queue, err := prepareSigningQueue(args, batchOutputDir)
+			cobra.CheckErr(err)
+
+			var pending int
+			fmt.Printf("Signing queue:\n")
+			for i, item := range queue {
+				status := "pending"
+				if item.done {
+					status = "already signed"
+				} else {
+					pending++
+				}
+				fmt.Printf("  %d. %s (%s): %s\n", i+1, item.filename, item.method(), status)
+			}
+			if pending == 0 {
+				fmt.Printf("All transactions are already signed.\n")
+				return
+			}
+
+			ctx := context.Background()
+			var conn connection.Connection
+			if !txCfg.Offline {
+				conn, err = common.Connect(ctx, npa.Network)
+				cobra.CheckErr(err)
+			}
+
+			acc := common.LoadAccount(cfg, npa.AccountName)
+			if batchSequentialNonces {
+				cobra.CheckErr(assignSequentialNonces(queue, acc))
+			}
+
+			var signed, skipped int
+			for i, item := range queue {
+				if item.done {
+					continue
+				}
+				fmt.Printf("\n=== Transaction %d/%d: %s ===\n", i+1, len(queue), item.filename)
+
+				for {
+					err = item.sign(ctx, npa, acc, conn)
+					if err == nil {
+						signed++
+						fmt.Printf("Signed transaction saved to '%s'.\n", item.outputFn)
+						break
+					}
+
+					action := askBatchFailureAction(item, err)
+					if action == batchActionSkip {
+						skipped++
+						break
+					}
+					if action == batchActionAbort {
+						fmt.Printf("Signed %d transaction(s). Run the same command again to resume.\n", signed)
+						cobra.CheckErr(errBatchSigningAborted)
+					}
+				}
+			}
+
+			fmt.Printf("\nSigned %d transaction(s)", signed)
+			if skipped > 0 {
+				fmt.Printf(", skipped %d. Run the same command again to sign the skipped ones", skipped)
+			}
+			fmt.Printf(".\n")
+		},
+	}
+)
+
+// signingQueueItem is a single unsigned transaction of the signing queue.
+type signingQueueItem struct {
+	filename string
+	outputFn string
+	tx       interface{}
+	done     bool
+}
+
+// method returns the method of the queued transaction.
+func (item *signingQueueItem) method() string {
+	switch tx := item.tx.(type) {
+	case *consensusTx.Transaction:
+		return string(tx.Method)
+	case *types.Transaction:
+		return string(tx.Call.Method)
+	default:
+		return "unknown"
+	}
+}
+
+// sign signs the queued transaction with the given account and saves it.
+func (item *signingQueueItem) sign(ctx context.Context, npa *common.NPASelection, acc wallet.Account, conn connection.Connection) error {
+	var sigTx interface{}
+	var err error
+	switch tx := item.tx.(type) {
+	case *consensusTx.Transaction:
+		sigTx, err = common.SignConsensusTransaction(ctx, npa, acc, conn, tx)
+	case *types.Transaction:
+		sigTx, _, err = common.SignParaTimeTransaction(ctx, npa, acc, conn, tx, nil)
+	}
+	if err != nil {
+		return err
+	}
+	if err = common.ExportTransactionToFile(item.outputFn, sigTx); err != nil {
+		return err
+	}
+	item.done = true
+	return nil
+}
+
+// prepareSigningQueue decodes the unsigned transactions in the given files. Transactions for which
+// the signed output file already exists are marked as done.
+func prepareSigningQueue(filenames []string, outputDir string) ([]*signingQueueItem, error) {
+	queue := make([]*signingQueueItem, 0, len(filenames))
+	outputs := make(map[string]string)
+	for _, fn := range filenames {
+		if fn == common.StdioFilename {
+			return nil, fmt.Errorf("reading transactions from standard input is not supported in batch mode")
+		}
+		rawTx, err := os.ReadFile(fn)
+		if err != nil {
+			return nil, err
+		}
+		tx, err := tryDecodeTx(rawTx)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", fn, err)
+		}
+		switch tx.(type) {
+		case *consensusTx.Transaction, *types.Transaction:
+		default:
+			return nil, fmt.Errorf("%s: transaction is already signed", fn)
+		}
+
+		outputFn := signedFilename(fn, outputDir)
+		if other, ok := outputs[outputFn]; ok {
+			return nil, fmt.Errorf("%s and %s would both be saved to '%s'", other, fn, outputFn)
+		}
+		outputs[outputFn] = fn
+
+		_, err = os.Stat(outputFn)
+		queue = append(queue, &signingQueueItem{
+			filename: fn,
+			outputFn: outputFn,
+			tx:       tx,
+			done:     err == nil,
+		})
+	}
+	return queue, nil
+}
+
+// signedFilename returns the name of the file the signed transaction of the given file is saved to.
+func signedFilename(fn, outputDir string) string {
+	ext := filepath.Ext(fn)
+	name := strings.TrimSuffix(filepath.Base(fn), ext) + batchSignedFileSuffix + ext
+	if outputDir == "" {
+		outputDir = filepath.Dir(fn)
+	}
+	return filepath.Join(outputDir, name)
+}
+
+// assignSequentialNonces assigns consecutive nonces to the queued transactions, starting with the
+// nonce of the first transaction. Consensus and ParaTime transactions are numbered separately.
+func assignSequentialNonces(queue []*signingQueueItem, acc wallet.Account) error {
+	accAddr := acc.Address()
+
+	var consensusNonce, runtimeNonce *uint64
+	for _, item := range queue {
+		switch tx := item.tx.(type) {
+		case *consensusTx.Transaction:
+			if consensusNonce == nil {
+				nonce := tx.Nonce
+				consensusNonce = &nonce
+			}
+			tx.Nonce = *consensusNonce
+			*consensusNonce++
+		case *types.Transaction:
+			var found bool
+			for i, si := range tx.AuthInfo.SignerInfo {
+				addr, err := si.AddressSpec.Address()
+				if err != nil || !addr.Equal(accAddr) {
+					continue
+				}
+				if runtimeNonce == nil {
+					nonce := si.Nonce
+					runtimeNonce = &nonce
+				}
+				tx.AuthInfo.SignerInfo[i].Nonce = *runtimeNonce
+				*runtimeNonce++
+				found = true
+				break
+			}
+			if !found {
+				return fmt.Errorf("%s: transaction has no signer information for the selected account", item.filename)
+			}
+		}
+	}
+	return nil
+}
+
+// askBatchFailureAction reports the signing failure and asks the user whether to retry signing the
+// transaction, skip it or abort.
+func askBatchFailureAction(item *signingQueueItem, err error) string {
+	fmt.Fprintf(os.Stderr, "Failed to sign '%s': %v\n", item.filename, err)
+	if common.GetAnswerYes() {
+		return batchActionAbort
+	}
+	common.CheckInteractive()
+
+	var action string
+	err = survey.AskOne(&survey.Select{
+		Message: "What do you want to do?",
+		Options: []string{batchActionRetry, batchActionSkip, batchActionAbort},
+		Default: batchActionRetry,
+	}, &action)
+	if err != nil {
+		return batchActionAbort
+	}
+	return action
+}
+
+func init() {
+	batchFlags := flag.NewFlagSet("", flag.ContinueOnError)
+	batchFlags.StringVar(&batchOutputDir, "output-dir", "", "directory to store the signed transactions in (default: next to the originals)")
+	batchFlags.BoolVar(&batchSequentialNonces, "sequential-nonces", false, "assign consecutive nonces starting with the nonce of the first transaction")
+
+	txSignBatchCmd.Flags().AddFlagSet(common.SelectorFlags)
+	txSignBatchCmd.Flags().AddFlagSet(common.RuntimeTxFlags)
+	txSignBatchCmd.Flags().AddFlagSet(batchFlags)
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/oasisprotocol/cli/cmd/common"
+	"github.com/oasisprotocol/cli/update"
+	"github.com/oasisprotocol/cli/version"
+)
+
+var (
+	updateCheckOnly bool
+
+	updateCmd = &cobra.Command{
+		Use:   "update",
+		Short: "Update the CLI to the latest release",
+		Long: `Check the latest release of the Oasis CLI and, if it is newer than the running
+one, download the binary for the current platform, verify the signature of the
+release checksums and the checksum of the binary and replace the running
+binary with it.`,
+		Args: cobra.NoArgs,
+		Run: func(_ *cobra.Command, _ []string) {
+			ctx := context.Background()
+
+			release, err := update.LatestRelease(ctx)
+			cobra.CheckErr(err)
+			latest := release.Version()
+
+			fmt.Printf("Current version: %s\n", version.Software)
+			fmt.Printf("Latest version:  %s\n", latest)
+			if !update.IsNewer(version.Software, latest) {
+				fmt.Println("The CLI is up to date.")
+				return
+			}
+			if updateCheckOnly {
+				fmt.Println("A newer version is available. Run 'oasis update' to install it.")
+				return
+			}
+
+			exe, err := os.Executable()
+			cobra.CheckErr(err)
+			exe, err = filepath.EvalSymlinks(exe)
+			cobra.CheckErr(err)
+
+			// Verify the checksums file before trusting any of the checksums.
+			checksumsName := update.ChecksumsName(latest)
+			checksums, err := downloadReleaseAsset(ctx, release, checksumsName)
+			cobra.CheckErr(err)
+			signature, err := downloadReleaseAsset(ctx, release, update.SignatureName(latest))
+			cobra.CheckErr(err)
+			if err = update.VerifySignature(version.ReleaseSigningKey, update.ReleaseSignatureContext, checksums, signature); err != nil {
+				cobra.CheckErr(fmt.Errorf("failed to verify release %s: %w", latest, err))
+			}
+
+			archiveName := update.ArchiveName(latest, runtime.GOOS, runtime.GOARCH)
+			archive, err := downloadReleaseAsset(ctx, release, archiveName)
+			cobra.CheckErr(err)
+			if err = update.VerifyChecksum(checksums, archiveName, archive); err != nil {
+				cobra.CheckErr(fmt.Errorf("failed to verify release %s: %w", latest, err))
+			}
+			fmt.Println("Signature and checksum verified.")
+
+			binary, err := update.ExtractBinary(archiveName, archive)
+			cobra.CheckErr(err)
+
+			common.Confirm(fmt.Sprintf("Replace %s with version %s?", exe, latest), "update aborted")
+
+			err = update.ReplaceExecutable(exe, binary)
+			cobra.CheckErr(err)
+			fmt.Printf("Updated the CLI to version %s.\n", latest)
+		},
+	}
+)
+
+// downloadReleaseAsset downloads the release asset with the given name.
+func downloadReleaseAsset(ctx context.Context, release *update.Release, name string) ([]byte, error) {
+	asset, err := release.Asset(name)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Printf("Downloading %s...\n", asset.Name)
+	return update.Download(ctx, asset.URL)
+}
+
+func init() {
+	updateFlags := flag.NewFlagSet("", flag.ContinueOnError)
+	updateFlags.BoolVar(&updateCheckOnly, "check-only", false, "only check whether a newer version is available")
+
+	updateCmd.Flags().AddFlagSet(updateFlags)
+	updateCmd.Flags().AddFlagSet(common.AnswerYesFlag)
+}
@@ -27,18 +27,24 @@ var createCmd = &cobra.Command{
 		af, err := wallet.Load(accKind)
 		cobra.CheckErr(err)
 
-		// Ask for passphrase to encrypt the wallet with.
-		var passphrase string
-		if af.RequiresPassphrase() {
-			passphrase = common.AskNewPassphrase()
-		}
-
 		accCfg := &config.Account{
 			Kind: accKind,
 		}
 		err = accCfg.SetConfigFromFlags()
 		cobra.CheckErr(err)
 
+		// Validate the custom derivation path before asking for the passphrase.
+		var walletCfg wallet.AccountConfig
+		cobra.CheckErr(walletCfg.UnmarshalMap(accCfg.Config))
+		_, err = walletCfg.GetDerivationPath()
+		cobra.CheckErr(err)
+
+		// Ask for passphrase to encrypt the wallet with.
+		var passphrase string
+		if af.RequiresPassphrase() {
+			passphrase = common.AskNewPassphrase()
+		}
+
 		err = cfg.Wallet.Create(name, passphrase, accCfg)
 		cobra.CheckErr(err)
 
@@ -17,7 +17,7 @@ var exportCmd = &cobra.Command{
 		name := args[0]
 
 		fmt.Printf("WARNING: Exporting the account will expose secret key material!\n")
-		acc := common.LoadAccount(config.Global(), name)
+		acc := common.LoadAccountDirect(config.Global(), name)
 		accCfg, _ := common.LoadAccountConfig(config.Global(), name)
 
 		showPublicWalletInfo(name, acc, accCfg)
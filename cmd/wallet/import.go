@@ -15,9 +15,10 @@ import (
 )
 
 var (
-	algorithm string
-	number    uint32
-	secret    string
+	algorithm      string
+	number         uint32
+	derivationPath string
+	secret         string
 
 	passphrase string
 	accCfg     *config.Account
@@ -40,6 +41,8 @@ var (
 			afCfg := make(map[string]interface{})
 
 			if !common.GetAnswerYes() {
+				common.CheckInteractive()
+
 				// Ask for import kind.
 				var supportedKinds []string
 				for _, kind := range af.SupportedImportKinds() {
@@ -70,6 +73,10 @@ var (
 				}
 			}
 
+			if derivationPath != "" {
+				afCfg["derivation_path"] = derivationPath
+			}
+
 			accCfg = &config.Account{
 				Kind:   af.Kind(),
 				Config: afCfg,
@@ -124,6 +131,10 @@ func init() {
 	numberFlag.Uint32Var(&number, "number", 0, "Key number to use in the key derivation scheme")
 	importCmd.Flags().AddFlagSet(numberFlag)
 
+	derivationPathFlag := flag.NewFlagSet("", flag.ContinueOnError)
+	derivationPathFlag.StringVar(&derivationPath, "derivation-path", "", "Custom derivation path to use instead of the key number (e.g. m/44'/474'/5'/0'/3')")
+	importCmd.Flags().AddFlagSet(derivationPathFlag)
+
 	secretFlag := flag.NewFlagSet("", flag.ContinueOnError)
 	secretFlag.StringVar(&secret, "secret", "", "A secret key or mnemonic to use for this account")
 	importCmd.Flags().AddFlagSet(secretFlag)
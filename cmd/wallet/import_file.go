@@ -1,13 +1,17 @@
 package wallet
 
 import (
+	"crypto/ed25519"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/pem"
+	"errors"
 	"fmt"
 	"os"
 
+	"github.com/AlecAivazis/survey/v2"
 	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh"
 
 	"github.com/oasisprotocol/cli/cmd/common"
 	"github.com/oasisprotocol/cli/config"
@@ -18,7 +22,7 @@ import (
 var importFileCmd = &cobra.Command{
 	Use:   "import-file <name> <entity.pem>",
 	Short: "Import an existing account from file",
-	Long:  "Import the private key from an existing PEM file",
+	Long:  "Import the private key from an existing PEM file or an OpenSSH ed25519 private key file",
 	Args:  cobra.ExactArgs(2),
 	Run: func(_ *cobra.Command, args []string) {
 		cfg := config.Global()
@@ -35,8 +39,23 @@ var importFileCmd = &cobra.Command{
 			cobra.CheckErr(fmt.Errorf("failed to decode PEM file"))
 		}
 
-		algorithm, err := detectAlgorithm(block.Type) //nolint: staticcheck
-		cobra.CheckErr(err)
+		var (
+			algorithm string
+			keyData   string
+		)
+		switch block.Type { //nolint: staticcheck
+		case openSSHPrivateKeyType:
+			rawKey, err := decodeOpenSSHKey(rawFile, askSSHPassphrase)
+			cobra.CheckErr(err)
+
+			algorithm = wallet.AlgorithmEd25519Raw
+			keyData = encodeKeyData(algorithm, rawKey)
+			fmt.Fprintln(os.Stderr, "Warning: Reusing an SSH key for an account means anyone who obtains the SSH key can also sign transactions on behalf of the account. Protect the key file accordingly.")
+		default:
+			algorithm, err = detectAlgorithm(block.Type) //nolint: staticcheck
+			cobra.CheckErr(err)
+			keyData = encodeKeyData(algorithm, block.Bytes) //nolint: staticcheck
+		}
 
 		// Ask for passphrase.
 		passphrase := common.AskNewPassphrase()
@@ -50,7 +69,7 @@ var importFileCmd = &cobra.Command{
 
 		src := &wallet.ImportSource{
 			Kind: wallet.ImportKindPrivateKey,
-			Data: encodeKeyData(algorithm, block.Bytes), //nolint: staticcheck
+			Data: keyData,
 		}
 
 		err = cfg.Wallet.Import(name, passphrase, accCfg, src)
@@ -61,6 +80,44 @@ var importFileCmd = &cobra.Command{
 	},
 }
 
+// openSSHPrivateKeyType is the PEM type of OpenSSH private keys.
+const openSSHPrivateKeyType = "OPENSSH PRIVATE KEY"
+
+// askSSHPassphrase asks the user for the passphrase of an encrypted OpenSSH private key.
+func askSSHPassphrase() (string, error) {
+	common.CheckInteractive()
+
+	var passphrase string
+	err := survey.AskOne(&survey.Password{Message: "SSH key passphrase:"}, &passphrase)
+	return passphrase, err
+}
+
+// decodeOpenSSHKey decodes the raw Ed25519 private key from the given OpenSSH private key file,
+// asking for the passphrase if the key is encrypted.
+func decodeOpenSSHKey(rawFile []byte, askPassphrase func() (string, error)) ([]byte, error) {
+	key, err := ssh.ParseRawPrivateKey(rawFile)
+	var missing *ssh.PassphraseMissingError
+	if errors.As(err, &missing) {
+		var passphrase string
+		if passphrase, err = askPassphrase(); err != nil {
+			return nil, err
+		}
+		key, err = ssh.ParseRawPrivateKeyWithPassphrase(rawFile, []byte(passphrase))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse OpenSSH private key: %w", err)
+	}
+
+	switch k := key.(type) {
+	case *ed25519.PrivateKey:
+		return *k, nil
+	case ed25519.PrivateKey:
+		return k, nil
+	default:
+		return nil, fmt.Errorf("unsupported OpenSSH key type, only ed25519 keys can be imported")
+	}
+}
+
 // detectAlgorithm detects the key type based on the PEM type.
 func detectAlgorithm(pemType string) (string, error) {
 	switch pemType {
@@ -0,0 +1,37 @@
+package wallet
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestDecodeOpenSSHKey(t *testing.T) {
+	require := require.New(t)
+
+	_, sk, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(err)
+	noPassphrase := func() (string, error) {
+		require.Fail("unexpected passphrase prompt")
+		return "", nil
+	}
+
+	block, err := ssh.MarshalPrivateKey(sk, "test")
+	require.NoError(err)
+	key, err := decodeOpenSSHKey(pem.EncodeToMemory(block), noPassphrase)
+	require.NoError(err)
+	require.EqualValues(sk, key)
+
+	block, err = ssh.MarshalPrivateKeyWithPassphrase(sk, "test", []byte("secret"))
+	require.NoError(err)
+	key, err = decodeOpenSSHKey(pem.EncodeToMemory(block), func() (string, error) { return "secret", nil })
+	require.NoError(err)
+	require.EqualValues(sk, key)
+
+	_, err = decodeOpenSSHKey(pem.EncodeToMemory(block), func() (string, error) { return "wrong", nil })
+	require.Error(err)
+}
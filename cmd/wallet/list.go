@@ -1,15 +1,114 @@
 package wallet
 
 import (
+	"context"
+	"errors"
 	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/connection"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/helpers"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+
 	"github.com/oasisprotocol/cli/cmd/common"
 	"github.com/oasisprotocol/cli/config"
 	"github.com/oasisprotocol/cli/table"
 )
 
+var (
+	listVerbose        bool
+	listBalances       bool
+	listBalanceTimeout time.Duration
+)
+
+// accountBalances are the formatted balances of an account.
+type accountBalances struct {
+	consensus string
+	paratime  string
+}
+
+// formatBalanceError formats a failed balance query for display in the listing.
+func formatBalanceError(err error) string {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "(timeout)"
+	}
+	return "(error)"
+}
+
+// fetchBalances concurrently queries the consensus and (if selected) ParaTime balances of the
+// given addresses. Each address is given at most listBalanceTimeout to resolve.
+func fetchBalances(ctx context.Context, npa *common.NPASelection, conn connection.Connection, addresses []string) map[string]*accountBalances {
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		balances = make(map[string]*accountBalances)
+	)
+	for _, address := range addresses {
+		if _, ok := balances[address]; ok {
+			continue
+		}
+		balances[address] = nil
+
+		wg.Add(1)
+		go func(address string) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(ctx, listBalanceTimeout)
+			defer cancel()
+			b := fetchAccountBalances(ctx, npa, conn, address)
+
+			mu.Lock()
+			defer mu.Unlock()
+			balances[address] = b
+		}(address)
+	}
+	wg.Wait()
+	return balances
+}
+
+// fetchAccountBalances queries the consensus and (if selected) ParaTime balances of the given
+// address.
+func fetchAccountBalances(ctx context.Context, npa *common.NPASelection, conn connection.Connection, address string) *accountBalances {
+	b := &accountBalances{consensus: "-", paratime: "-"}
+
+	var addr types.Address
+	if err := addr.UnmarshalText([]byte(address)); err != nil {
+		b.consensus, b.paratime = "(error)", "(error)"
+		return b
+	}
+
+	acc, err := conn.Consensus().Staking().Account(ctx, &staking.OwnerQuery{
+		Height: consensus.HeightLatest,
+		Owner:  addr.ConsensusAddress(),
+	})
+	switch err {
+	case nil:
+		b.consensus = helpers.FormatConsensusDenomination(npa.Network, acc.General.Balance)
+	default:
+		b.consensus = formatBalanceError(err)
+	}
+
+	if npa.ParaTime != nil {
+		rtBalances, err := conn.Runtime(npa.ParaTime).Accounts.Balances(ctx, client.RoundLatest, addr)
+		switch err {
+		case nil:
+			native := rtBalances.Balances[types.NativeDenomination]
+			b.paratime = helpers.FormatParaTimeDenomination(npa.ParaTime, types.NewBaseUnits(native, types.NativeDenomination))
+		default:
+			b.paratime = formatBalanceError(err)
+		}
+	}
+	return b
+}
+
 var listCmd = &cobra.Command{
 	Use:     "list",
 	Aliases: []string{"ls"},
@@ -17,27 +116,88 @@ var listCmd = &cobra.Command{
 	Args:    cobra.NoArgs,
 	Run: func(_ *cobra.Command, _ []string) {
 		cfg := config.Global()
-		table := table.New()
-		table.SetHeader([]string{"Account", "Kind", "Address"})
+		columns := []table.Column{
+			{Name: "Account"},
+			{Name: "Kind"},
+			{Name: "Address"},
+			{Name: "Description", Wide: true},
+			{Name: "Last Used", Wide: true},
+			{Name: "Tx Count", Wide: true},
+			{Name: "Networks", Wide: true},
+		}
+		if listBalances {
+			columns = append(columns,
+				table.Column{Name: "Consensus Balance"},
+				table.Column{Name: "ParaTime Balance"},
+			)
+		}
+		listing := table.NewListing(columns...)
+
+		usage, err := config.LoadUsage(cfg.Directory())
+		cobra.CheckErr(err)
+
+		// Sort output by name.
+		names := make([]string, 0, len(cfg.Wallet.All))
+		for name := range cfg.Wallet.All {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		var balances map[string]*accountBalances
+		if listBalances {
+			npa := common.GetNPASelection(cfg)
+
+			ctx := context.Background()
+			conn, err := common.Connect(ctx, npa.Network)
+			cobra.CheckErr(err)
+
+			addresses := make([]string, 0, len(names))
+			for _, name := range names {
+				addresses = append(addresses, cfg.Wallet.All[name].Address)
+			}
+			balances = fetchBalances(ctx, npa, conn, addresses)
+		}
 
-		var output [][]string
-		for name, acc := range cfg.Wallet.All {
+		for _, name := range names {
+			acc := cfg.Wallet.All[name]
+			displayName := name
 			if cfg.Wallet.Default == name {
-				name += common.DefaultMarker
+				displayName += common.DefaultMarker
 			}
-			output = append(output, []string{
-				name,
+
+			lastUsed, count, networks := "never", "0", ""
+			if au := usage.Accounts[acc.Address]; au != nil {
+				lastUsed = au.LastUsed.Local().Format("2006-01-02 15:04")
+				count = strconv.FormatUint(au.Count, 10)
+				networks = strings.Join(au.Networks, ",")
+			}
+
+			row := []string{
+				displayName,
 				acc.PrettyKind(),
 				acc.Address,
-			})
+				acc.Description,
+				lastUsed,
+				count,
+				networks,
+			}
+			if b := balances[acc.Address]; listBalances {
+				row = append(row, b.consensus, b.paratime)
+			}
+			listing.Append(row...)
 		}
 
-		// Sort output by name.
-		sort.Slice(output, func(i, j int) bool {
-			return output[i][0] < output[j][0]
-		})
-
-		table.AppendBulk(output)
-		table.Render()
+		opts := common.GetListingOptions()
+		opts.Wide = opts.Wide || listVerbose
+		err = listing.Render(opts)
+		cobra.CheckErr(err)
 	},
 }
+
+func init() {
+	listCmd.Flags().BoolVarP(&listVerbose, "verbose", "v", false, "show account usage statistics")
+	listCmd.Flags().AddFlagSet(common.ListingFlags)
+	listCmd.Flags().BoolVar(&listBalances, "balances", false, "show consensus and ParaTime balances of the accounts")
+	listCmd.Flags().DurationVar(&listBalanceTimeout, "balance-timeout", 10*time.Second, "timeout for querying the balances of each account")
+	listCmd.Flags().AddFlagSet(common.SelectorNPFlags)
+}
@@ -0,0 +1,53 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oasisprotocol/cli/cmd/common"
+	"github.com/oasisprotocol/cli/config"
+)
+
+var mergeCmd = &cobra.Command{
+	Use:   "merge <src> <dst>",
+	Short: "Merge an account into another account with the same key",
+	Long: `Merge the source account into the destination account with the same address and remove the
+source account. The default accounts referring to the source account are updated to refer to the
+destination account. The key material of the destination account is kept.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(_ *cobra.Command, args []string) {
+		cfg := config.Global()
+		src, dst := args[0], args[1]
+
+		srcCfg, exists := cfg.Wallet.All[src]
+		if !exists {
+			cobra.CheckErr(fmt.Errorf("account '%s' does not exist", src))
+		}
+		dstCfg, exists := cfg.Wallet.All[dst]
+		if !exists {
+			cobra.CheckErr(fmt.Errorf("account '%s' does not exist", dst))
+		}
+		if srcCfg.Address != dstCfg.Address {
+			cobra.CheckErr(fmt.Errorf("refusing to merge accounts with different keys ('%s' is %s, '%s' is %s)", src, srcCfg.Address, dst, dstCfg.Address))
+		}
+		sameDerivation := srcCfg.SameDerivation(dstCfg)
+
+		fmt.Printf("Merging account '%s' (%s) into '%s' (%s).\n", src, srcCfg.PrettyKind(), dst, dstCfg.PrettyKind())
+		if srcCfg.Kind != dstCfg.Kind {
+			fmt.Printf("WARNING: The key material of '%s' will be ERASED and only the %s account will remain!\n", src, dstCfg.PrettyKind())
+		}
+		common.Confirm("Merge accounts?", "merge aborted")
+
+		err := cfg.Wallet.Merge(src, dst)
+		cobra.CheckErr(err)
+		cfg.DefaultAccounts.MergeAccount(src, dst, sameDerivation)
+
+		err = cfg.Save()
+		cobra.CheckErr(err)
+	},
+}
+
+func init() {
+	mergeCmd.Flags().AddFlagSet(common.AnswerYesFlag)
+}
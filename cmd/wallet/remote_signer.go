@@ -22,6 +22,7 @@ var remoteSignerCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(2),
 	Run: func(_ *cobra.Command, args []string) {
 		name, socketPath := args[0], args[1]
+		cobra.CheckErr(common.CheckReadOnly())
 
 		acc := common.LoadAccount(config.Global(), name)
 
@@ -35,14 +36,16 @@ var remoteSignerCmd = &cobra.Command{
 		// The domain separation is entirely handled on the client side.
 		signature.UnsafeAllowUnregisteredContexts()
 
-		// Suppress oasis-core logging.
-		err := logging.Initialize(
-			nil,
-			logging.FmtLogfmt,
-			logging.LevelInfo,
-			nil,
-		)
-		cobra.CheckErr(err)
+		// Suppress oasis-core logging unless diagnostic logging was requested.
+		if !common.IsLoggingEnabled() {
+			err := logging.Initialize(
+				nil,
+				logging.FmtLogfmt,
+				logging.LevelInfo,
+				nil,
+			)
+			cobra.CheckErr(err)
+		}
 
 		// Setup the gRPC service.
 		srvCfg := &grpc.ServerConfig{
@@ -31,6 +31,7 @@ var rmCmd = &cobra.Command{
 		}
 
 		if !common.GetAnswerYes() {
+			common.CheckInteractive()
 			fmt.Printf("WARNING: Removing the account will ERASE secret key material!\n")
 			fmt.Printf("WARNING: THIS ACTION IS IRREVERSIBLE!\n")
 
@@ -50,6 +51,7 @@ var rmCmd = &cobra.Command{
 		for _, name := range uniqueArgs {
 			err := cfg.Wallet.Remove(name)
 			cobra.CheckErr(err)
+			cfg.DefaultAccounts.RemoveAccount(name)
 
 			err = cfg.Save()
 			cobra.CheckErr(err)
@@ -22,6 +22,7 @@ var renameCmd = &cobra.Command{
 		}
 		err := cfg.Wallet.Rename(oldName, newName)
 		cobra.CheckErr(err)
+		cfg.DefaultAccounts.RenameAccount(oldName, newName)
 
 		err = cfg.Save()
 		cobra.CheckErr(err)
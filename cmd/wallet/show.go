@@ -8,6 +8,7 @@ import (
 	"github.com/oasisprotocol/cli/cmd/common"
 	"github.com/oasisprotocol/cli/config"
 	"github.com/oasisprotocol/cli/wallet"
+	"github.com/oasisprotocol/cli/wallet/multisig"
 )
 
 var showCmd = &cobra.Command{
@@ -21,10 +22,15 @@ var showCmd = &cobra.Command{
 		acc := common.LoadAccount(config.Global(), name)
 		accCfg, _ := common.LoadAccountConfig(config.Global(), name)
 		showPublicWalletInfo(name, acc, accCfg)
+
+		if common.IsQRRequested() {
+			addr := acc.Address()
+			cobra.CheckErr(common.PrintAddressQRs(&addr, acc.EthAddress()))
+		}
 	},
 }
 
-func showPublicWalletInfo(name string, wallet wallet.Account, accCfg *config.Account) {
+func showPublicWalletInfo(name string, acc wallet.Account, accCfg *config.Account) {
 	kind := "<unknown>"
 	if accCfg != nil {
 		kind = accCfg.PrettyKind()
@@ -32,15 +38,24 @@ func showPublicWalletInfo(name string, wallet wallet.Account, accCfg *config.Acc
 
 	fmt.Printf("Name:             %s\n", name)
 	fmt.Printf("Kind:             %s\n", kind)
-	if signer := wallet.Signer(); signer != nil {
+	if signer := acc.Signer(); signer != nil {
 		fmt.Printf("Public Key:       %s\n", signer.Public())
 	}
-	if ethAddr := wallet.EthAddress(); ethAddr != nil {
+	if ethAddr := acc.EthAddress(); ethAddr != nil {
 		fmt.Printf("Ethereum address: %s\n", ethAddr.Hex())
 	}
-	fmt.Printf("Native address:   %s\n", wallet.Address())
+	fmt.Printf("Native address:   %s\n", acc.Address())
+	if msAcc, ok := acc.(wallet.MultisigAccount); ok {
+		msCfg := msAcc.MultisigConfig()
+		fmt.Printf("Threshold:        %d\n", msCfg.Threshold)
+		fmt.Printf("Signers:\n")
+		for i, s := range msCfg.Signers {
+			fmt.Printf("  %d. %s (weight %d)\n", i+1, multisig.SignerAddress(s.PublicKey), s.Weight)
+		}
+	}
 }
 
 func init() {
 	showCmd.Flags().AddFlagSet(common.AnswerYesFlag)
+	showCmd.Flags().AddFlagSet(common.QRFlags)
 }
@@ -0,0 +1,26 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oasisprotocol/cli/cmd/common"
+	"github.com/oasisprotocol/cli/config"
+)
+
+var verifyAddressCmd = &cobra.Command{
+	Use:   "verify-address <name>",
+	Short: "Show the address of a hardware wallet account on the device for verification",
+	Long: `Derive the address of the given hardware wallet account on the device again and
+display it on the device screen. Compare it with the address printed by the CLI
+and confirm it on the device to make sure the address was not substituted on
+the host.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(_ *cobra.Command, args []string) {
+		name := args[0]
+
+		cobra.CheckErr(common.VerifyAccountAddress(config.Global(), name))
+		fmt.Printf("Address of account '%s' verified.\n", name)
+	},
+}
@@ -0,0 +1,110 @@
+package wallet
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/oasisprotocol/cli/cmd/common"
+	"github.com/oasisprotocol/cli/config"
+)
+
+var (
+	verifyBackupWords int
+
+	verifyBackupCmd = &cobra.Command{
+		Use:   "verify-backup <name>",
+		Short: "Verify the backup of an account's recovery phrase",
+		Long: `Ask for randomly selected words of the recovery phrase (mnemonic) of the given
+account to make sure it has been backed up correctly. The recovery phrase is
+never shown. After a successful verification the time of the verification is
+recorded in the wallet and the warning shown when signing transactions with
+the account is no longer displayed.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			cfg := config.Global()
+			name := args[0]
+
+			acfg, ok := cfg.Wallet.All[name]
+			if !ok {
+				cobra.CheckErr(fmt.Errorf("account '%s' does not exist in the wallet", name))
+			}
+			if !common.HasRecoveryPhrase(acfg) {
+				cobra.CheckErr(fmt.Errorf("account '%s' has no recovery phrase", name))
+			}
+			common.CheckInteractive()
+
+			acc := common.LoadAccountDirect(cfg, name)
+			_, mnemonic := acc.UnsafeExport()
+			words := strings.Fields(mnemonic)
+
+			positions, err := pickMnemonicPositions(len(words), verifyBackupWords)
+			cobra.CheckErr(err)
+
+			fmt.Printf("Enter the requested words of the recovery phrase of account '%s'.\n", name)
+			var mistakes int
+			for _, pos := range positions {
+				var answer string
+				err = survey.AskOne(&survey.Password{Message: fmt.Sprintf("Word #%d:", pos+1)}, &answer)
+				cobra.CheckErr(err)
+				if !checkMnemonicWord(words, pos, answer) {
+					mistakes++
+				}
+			}
+			if mistakes > 0 {
+				cobra.CheckErr(fmt.Errorf("%d of %d words do not match the recovery phrase, check your backup", mistakes, len(positions)))
+			}
+
+			acfg.BackupVerified = time.Now().UTC().Format(time.RFC3339)
+			cobra.CheckErr(cfg.Save())
+
+			fmt.Printf("Backup of account '%s' verified.\n", name)
+		},
+	}
+)
+
+// pickMnemonicPositions returns the given number of distinct randomly selected positions of words
+// in a mnemonic with the given number of words in ascending order.
+func pickMnemonicPositions(numWords, count int) ([]int, error) {
+	if count < 1 {
+		return nil, fmt.Errorf("at least one word must be verified")
+	}
+	if numWords == 0 {
+		return nil, fmt.Errorf("empty recovery phrase")
+	}
+	count = min(count, numWords)
+
+	picked := make(map[int]struct{}, count)
+	for len(picked) < count {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(numWords)))
+		if err != nil {
+			return nil, err
+		}
+		picked[int(n.Int64())] = struct{}{}
+	}
+
+	positions := make([]int, 0, count)
+	for pos := range picked {
+		positions = append(positions, pos)
+	}
+	sort.Ints(positions)
+	return positions, nil
+}
+
+// checkMnemonicWord returns true iff the given answer matches the word at the given position.
+func checkMnemonicWord(words []string, pos int, answer string) bool {
+	return strings.EqualFold(strings.TrimSpace(answer), words[pos])
+}
+
+func init() {
+	verifyBackupFlags := flag.NewFlagSet("", flag.ContinueOnError)
+	verifyBackupFlags.IntVar(&verifyBackupWords, "words", 3, "number of words to ask for")
+	verifyBackupCmd.Flags().AddFlagSet(verifyBackupFlags)
+}
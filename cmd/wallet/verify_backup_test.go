@@ -0,0 +1,41 @@
+package wallet
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPickMnemonicPositions(t *testing.T) {
+	require := require.New(t)
+
+	for i := 0; i < 100; i++ {
+		positions, err := pickMnemonicPositions(24, 3)
+		require.NoError(err)
+		require.Len(positions, 3)
+		require.True(sort.IntsAreSorted(positions))
+		require.NotEqual(positions[0], positions[1])
+		require.NotEqual(positions[1], positions[2])
+		require.GreaterOrEqual(positions[0], 0)
+		require.Less(positions[2], 24)
+	}
+
+	positions, err := pickMnemonicPositions(2, 5)
+	require.NoError(err)
+	require.Equal([]int{0, 1}, positions)
+
+	_, err = pickMnemonicPositions(24, 0)
+	require.Error(err)
+	_, err = pickMnemonicPositions(0, 3)
+	require.Error(err)
+}
+
+func TestCheckMnemonicWord(t *testing.T) {
+	require := require.New(t)
+
+	words := []string{"abandon", "ability", "able"}
+	require.True(checkMnemonicWord(words, 1, "ability"))
+	require.True(checkMnemonicWord(words, 1, " Ability\n"))
+	require.False(checkMnemonicWord(words, 1, "able"))
+}
@@ -51,9 +51,12 @@ func init() {
 	Cmd.AddCommand(showCmd)
 	Cmd.AddCommand(rmCmd)
 	Cmd.AddCommand(renameCmd)
+	Cmd.AddCommand(mergeCmd)
 	Cmd.AddCommand(setDefaultCmd)
 	Cmd.AddCommand(importCmd)
 	Cmd.AddCommand(importFileCmd)
 	Cmd.AddCommand(exportCmd)
 	Cmd.AddCommand(remoteSignerCmd)
+	Cmd.AddCommand(verifyAddressCmd)
+	Cmd.AddCommand(verifyBackupCmd)
 }
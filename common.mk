@@ -57,7 +57,8 @@ VERSION := $(or \
 )
 
 # Project's version as the linker's string value definition.
-export GOLDFLAGS_VERSION := -X github.com/oasisprotocol/cli/version.Software=$(VERSION)
+export GOLDFLAGS_VERSION := -X github.com/oasisprotocol/cli/version.Software=$(VERSION) \
+	-X github.com/oasisprotocol/cli/version.ReleaseSigningKey=$(RELEASE_SIGNING_PUBLIC_KEY)
 
 # Go's linker flags.
 export GOLDFLAGS ?= "$(GOLDFLAGS_VERSION)"
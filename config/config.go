@@ -49,6 +49,40 @@ type Config struct {
 	Wallet      Wallet          `mapstructure:"wallets"`
 	AddressBook AddressBook     `mapstructure:"address_book"`
 
+	// DefaultAccounts are the per-network and per-ParaTime default accounts which take precedence
+	// over the wallet default account.
+	DefaultAccounts DefaultAccounts `mapstructure:"default_accounts"`
+
+	// Confirmations is the policy for confirming transactions before signing.
+	Confirmations ConfirmationPolicy `mapstructure:"confirmations"`
+	// ConfirmationThreshold is the amount (in the network or ParaTime denomination) at or above
+	// which transactions are confirmed when using the large-amounts policy.
+	ConfirmationThreshold string `mapstructure:"confirmation_threshold"`
+
+	// Guards are the guard rails checking transaction amounts and destinations before signing.
+	Guards Guards `mapstructure:"guards"`
+
+	// Requests is the timeout and retry policy of network requests.
+	Requests RequestPolicy `mapstructure:"requests"`
+
+	// Explorers are the block explorer URL templates by network name.
+	Explorers Explorers `mapstructure:"explorers"`
+
+	// Faucets are the faucet API URLs by network name.
+	Faucets Faucets `mapstructure:"faucets"`
+
+	// Prices is the optional token price provider used to display approximate fiat values.
+	Prices Prices `mapstructure:"prices"`
+
+	// Web3Gateways are the Web3 gateway URLs by network name and ParaTime name.
+	Web3Gateways Web3Gateways `mapstructure:"web3_gateways"`
+
+	// OCIRegistries are the access configurations of OCI registries by registry domain.
+	OCIRegistries OCIRegistries `mapstructure:"oci_registries"`
+
+	// ReadOnly forbids signing and broadcasting transactions.
+	ReadOnly bool `mapstructure:"read_only"`
+
 	// LastMigration is the last migration version.
 	LastMigration int `mapstructure:"last_migration"`
 }
@@ -62,6 +96,11 @@ func (cfg *Config) Directory() string {
 	return filepath.Dir(cfgFile)
 }
 
+// File returns the path to the used configuration file.
+func (cfg *Config) File() string {
+	return cfg.viper.ConfigFileUsed()
+}
+
 // Load loads the configuration structure from viper.
 func (cfg *Config) Load(v *viper.Viper) error {
 	cfg.viper = v
@@ -107,6 +146,8 @@ func encode(in interface{}) (interface{}, error) {
 			}
 
 			switch {
+			case attributes["omitempty"] && v.Field(i).IsZero():
+				// Omit empty values when requested.
 			case attributes["remain"]:
 				// When remain attribute is set, merge the map.
 				remaining, ok := value.(map[string]interface{})
@@ -267,5 +308,35 @@ func (cfg *Config) Validate() error {
 	if err := cfg.Wallet.Validate(); err != nil {
 		return fmt.Errorf("failed to validate wallet configuration: %w", err)
 	}
+	if err := cfg.DefaultAccounts.Validate(&cfg.Wallet); err != nil {
+		return fmt.Errorf("failed to validate default accounts: %w", err)
+	}
+	if err := cfg.Confirmations.Validate(); err != nil {
+		return fmt.Errorf("failed to validate confirmation policy: %w", err)
+	}
+	if cfg.Confirmations == ConfirmationsLargeAmounts && cfg.ConfirmationThreshold == "" {
+		return fmt.Errorf("failed to validate confirmation policy: %s policy requires a confirmation threshold", ConfirmationsLargeAmounts)
+	}
+	if err := cfg.Guards.Validate(); err != nil {
+		return fmt.Errorf("failed to validate guard rails: %w", err)
+	}
+	if err := cfg.Requests.Validate(); err != nil {
+		return fmt.Errorf("failed to validate request policy: %w", err)
+	}
+	if err := cfg.Explorers.Validate(); err != nil {
+		return fmt.Errorf("failed to validate explorer configuration: %w", err)
+	}
+	if err := cfg.Faucets.Validate(); err != nil {
+		return fmt.Errorf("failed to validate faucet configuration: %w", err)
+	}
+	if err := cfg.Prices.Validate(); err != nil {
+		return fmt.Errorf("failed to validate price configuration: %w", err)
+	}
+	if err := cfg.Web3Gateways.Validate(); err != nil {
+		return fmt.Errorf("failed to validate Web3 gateway configuration: %w", err)
+	}
+	if err := cfg.OCIRegistries.Validate(); err != nil {
+		return fmt.Errorf("failed to validate OCI registry configuration: %w", err)
+	}
 	return nil
 }
@@ -0,0 +1,33 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ConfirmationPolicy is the policy for asking the user to confirm transactions before signing.
+type ConfirmationPolicy string
+
+const (
+	// ConfirmationsAlways asks for confirmation of every transaction.
+	ConfirmationsAlways ConfirmationPolicy = "always"
+	// ConfirmationsLargeAmounts only asks for confirmation of transactions which move an amount
+	// at or above the configured threshold or where the amount cannot be determined.
+	ConfirmationsLargeAmounts ConfirmationPolicy = "large-amounts"
+	// ConfirmationsNever never asks for confirmation of transactions.
+	ConfirmationsNever ConfirmationPolicy = "never"
+)
+
+// Validate validates the confirmation policy.
+func (p ConfirmationPolicy) Validate() error {
+	switch p {
+	case "", ConfirmationsAlways, ConfirmationsLargeAmounts, ConfirmationsNever:
+		return nil
+	default:
+		return fmt.Errorf("unknown confirmation policy '%s' (must be one of: %s)", p, strings.Join([]string{
+			string(ConfirmationsAlways),
+			string(ConfirmationsLargeAmounts),
+			string(ConfirmationsNever),
+		}, ", "))
+	}
+}
@@ -7,7 +7,12 @@ import (
 // Default is the default config that should be used in case no configuration file exists.
 var Default = Config{
 	Networks:      config.DefaultNetworks,
-	LastMigration: latestMigrationVersion,
+	Confirmations: ConfirmationsAlways,
+	Requests: RequestPolicy{
+		Timeout: DefaultRequestTimeout.String(),
+		Retries: DefaultRequestRetries,
+	},
+	LastMigration: LatestMigrationVersion,
 }
 
 // OldNetwork contains information about an old version of a known network configuration.
@@ -0,0 +1,161 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// testAccountPrefix is the prefix of the built-in test account names.
+const testAccountPrefix = "test:"
+
+// NetworkDefaultAccounts contains the default accounts of a network.
+type NetworkDefaultAccounts struct {
+	// Default is the name of the default account of the network.
+	Default string `mapstructure:"default"`
+	// ParaTimes are the names of the default accounts by ParaTime name.
+	ParaTimes map[string]string `mapstructure:"paratimes"`
+}
+
+// DefaultAccounts contains the per-network and per-ParaTime default accounts by network name.
+type DefaultAccounts map[string]*NetworkDefaultAccounts
+
+// Resolve returns the most specific default account for the given network and ParaTime or an
+// empty string if none is configured. The ParaTime name may be empty.
+func (da DefaultAccounts) Resolve(network, paratime string) string {
+	nda := da[network]
+	if nda == nil {
+		return ""
+	}
+	if name := nda.ParaTimes[paratime]; paratime != "" && name != "" {
+		return name
+	}
+	return nda.Default
+}
+
+// Set sets the default account of the given network or, if the ParaTime name is not empty, of the
+// given ParaTime. An empty account name clears the default account.
+func (da *DefaultAccounts) Set(network, paratime, account string) {
+	if *da == nil {
+		*da = make(DefaultAccounts)
+	}
+	nda := (*da)[network]
+	if nda == nil {
+		nda = &NetworkDefaultAccounts{}
+		(*da)[network] = nda
+	}
+
+	switch {
+	case paratime == "":
+		nda.Default = account
+	case account == "":
+		delete(nda.ParaTimes, paratime)
+	default:
+		if nda.ParaTimes == nil {
+			nda.ParaTimes = make(map[string]string)
+		}
+		nda.ParaTimes[paratime] = account
+	}
+
+	if nda.Default == "" && len(nda.ParaTimes) == 0 {
+		delete(*da, network)
+	}
+}
+
+// forEach calls the given function for each configured default account. The function returns the
+// new account name, an empty name removes the default.
+func (da DefaultAccounts) forEach(fn func(account string) string) {
+	for network, nda := range da {
+		if nda == nil {
+			continue
+		}
+		if nda.Default != "" {
+			nda.Default = fn(nda.Default)
+		}
+		for paratime, account := range nda.ParaTimes {
+			switch account = fn(account); account {
+			case "":
+				delete(nda.ParaTimes, paratime)
+			default:
+				nda.ParaTimes[paratime] = account
+			}
+		}
+		if nda.Default == "" && len(nda.ParaTimes) == 0 {
+			delete(da, network)
+		}
+	}
+}
+
+// RenameAccount updates the default accounts after an account has been renamed.
+func (da DefaultAccounts) RenameAccount(old, new string) {
+	da.forEach(func(account string) string {
+		base, index, ok := ParseSubAccountName(account)
+		switch {
+		case base != old:
+			return account
+		case ok:
+			return fmt.Sprintf("%s%s%d", new, SubAccountSeparator, index)
+		default:
+			return new
+		}
+	})
+}
+
+// MergeAccount updates the default accounts after the source account has been merged into the
+// destination account. Sub-accounts of the source account are only moved when both accounts
+// derive the same sub-accounts, otherwise they are cleared.
+func (da DefaultAccounts) MergeAccount(src, dst string, sameDerivation bool) {
+	da.forEach(func(account string) string {
+		base, index, ok := ParseSubAccountName(account)
+		switch {
+		case base != src:
+			return account
+		case !ok:
+			return dst
+		case sameDerivation:
+			return fmt.Sprintf("%s%s%d", dst, SubAccountSeparator, index)
+		default:
+			return ""
+		}
+	})
+}
+
+// RemoveAccount clears the default accounts referring to a removed account.
+func (da DefaultAccounts) RemoveAccount(name string) {
+	da.forEach(func(account string) string {
+		if base, _, _ := ParseSubAccountName(account); base == name {
+			return ""
+		}
+		return account
+	})
+}
+
+// Validate makes sure all default accounts exist in the given wallet.
+func (da DefaultAccounts) Validate(w *Wallet) error {
+	check := func(account string) error {
+		if strings.HasPrefix(account, testAccountPrefix) {
+			return nil
+		}
+		base, _, _ := ParseSubAccountName(account)
+		if _, exists := w.All[base]; !exists {
+			return fmt.Errorf("account '%s' does not exist in the wallet", account)
+		}
+		return nil
+	}
+
+	for network, nda := range da {
+		if nda == nil {
+			continue
+		}
+		if nda.Default != "" {
+			if err := check(nda.Default); err != nil {
+				return fmt.Errorf("network '%s': %w", network, err)
+			}
+		}
+		for paratime, account := range nda.ParaTimes {
+			if err := check(account); err != nil {
+				return fmt.Errorf("network '%s', paratime '%s': %w", network, paratime, err)
+			}
+		}
+	}
+	return nil
+}
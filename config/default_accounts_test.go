@@ -0,0 +1,59 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultAccounts(t *testing.T) {
+	require := require.New(t)
+
+	var da DefaultAccounts
+	require.Equal("", da.Resolve("testnet", "sapphire"))
+
+	da.Set("testnet", "", "dev")
+	da.Set("testnet", "sapphire", "dev:1")
+	da.Set("mainnet", "emerald", "test:alice")
+	require.Equal("dev", da.Resolve("testnet", ""))
+	require.Equal("dev", da.Resolve("testnet", "emerald"))
+	require.Equal("dev:1", da.Resolve("testnet", "sapphire"))
+	require.Equal("", da.Resolve("mainnet", ""))
+	require.Equal("test:alice", da.Resolve("mainnet", "emerald"))
+
+	w := &Wallet{All: map[string]*Account{"dev": {}}}
+	require.NoError(da.Validate(w))
+	da.Set("mainnet", "", "prod")
+	require.Error(da.Validate(w))
+	da.Set("mainnet", "", "")
+	require.NoError(da.Validate(w))
+
+	da.RenameAccount("dev", "staging")
+	require.Equal("staging", da.Resolve("testnet", ""))
+	require.Equal("staging:1", da.Resolve("testnet", "sapphire"))
+
+	da.RemoveAccount("staging")
+	require.Equal("", da.Resolve("testnet", "sapphire"))
+	require.NotContains(da, "testnet")
+
+	da.Set("mainnet", "emerald", "")
+	require.Empty(da)
+}
+
+func TestDefaultAccountsMerge(t *testing.T) {
+	require := require.New(t)
+
+	var da DefaultAccounts
+	da.Set("testnet", "", "old")
+	da.Set("testnet", "sapphire", "old:1")
+	da.Set("mainnet", "", "other")
+
+	da.MergeAccount("old", "new", true)
+	require.Equal("new", da.Resolve("testnet", ""))
+	require.Equal("new:1", da.Resolve("testnet", "sapphire"))
+	require.Equal("other", da.Resolve("mainnet", ""))
+
+	da.MergeAccount("new", "ledger", false)
+	require.Equal("ledger", da.Resolve("testnet", ""))
+	require.Equal("ledger", da.Resolve("testnet", "sapphire"), "sub-account default should be cleared")
+}
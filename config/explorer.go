@@ -0,0 +1,129 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
+)
+
+const (
+	// ExplorerPlaceholder is the placeholder replaced by the transaction hash, address or block
+	// number in explorer URL templates.
+	ExplorerPlaceholder = "{id}"
+
+	// defaultExplorerURL is the base URL of the default block explorer.
+	defaultExplorerURL = "https://explorer.oasis.io"
+)
+
+// defaultExplorerParaTimes are the ParaTimes supported by the default block explorer.
+var defaultExplorerParaTimes = []string{"cipher", "emerald", "sapphire"}
+
+// Explorer contains the block explorer URL templates for a network layer. Each template must
+// contain the {id} placeholder.
+type Explorer struct {
+	// Tx is the URL template of a transaction.
+	Tx string `mapstructure:"tx"`
+	// Address is the URL template of an account.
+	Address string `mapstructure:"address"`
+	// Block is the URL template of a consensus block or ParaTime round.
+	Block string `mapstructure:"block"`
+}
+
+// Validate validates the explorer configuration.
+func (e *Explorer) Validate() error {
+	for name, tmpl := range map[string]string{"tx": e.Tx, "address": e.Address, "block": e.Block} {
+		if tmpl != "" && !strings.Contains(tmpl, ExplorerPlaceholder) {
+			return fmt.Errorf("%s template '%s' does not contain the %s placeholder", name, tmpl, ExplorerPlaceholder)
+		}
+	}
+	return nil
+}
+
+// NetworkExplorer contains the block explorer URL templates of a network.
+type NetworkExplorer struct {
+	// Consensus are the URL templates of the consensus layer.
+	Consensus Explorer `mapstructure:"consensus"`
+	// ParaTimes are the URL templates of the ParaTimes by ParaTime name.
+	ParaTimes map[string]*Explorer `mapstructure:"paratimes"`
+}
+
+// Validate validates the network explorer configuration.
+func (ne *NetworkExplorer) Validate() error {
+	if err := ne.Consensus.Validate(); err != nil {
+		return fmt.Errorf("consensus: %w", err)
+	}
+	for ptName, e := range ne.ParaTimes {
+		if e == nil {
+			continue
+		}
+		if err := e.Validate(); err != nil {
+			return fmt.Errorf("paratime '%s': %w", ptName, err)
+		}
+	}
+	return nil
+}
+
+// Explorers contains the block explorer URL templates of networks by network name.
+type Explorers map[string]*NetworkExplorer
+
+// Validate validates the explorers configuration.
+func (ex Explorers) Validate() error {
+	for netName, ne := range ex {
+		if ne == nil {
+			continue
+		}
+		if err := ne.Validate(); err != nil {
+			return fmt.Errorf("network '%s': %w", netName, err)
+		}
+	}
+	return nil
+}
+
+// Lookup returns the block explorer URL templates for the given network and ParaTime (empty for
+// the consensus layer). Configured templates take precedence over the default block explorer
+// which supports the Mainnet and Testnet networks.
+func (ex Explorers) Lookup(netName string, net *config.Network, ptName string) *Explorer {
+	if ne, ok := ex[netName]; ok && ne != nil {
+		switch ptName {
+		case "":
+			if ne.Consensus != (Explorer{}) {
+				return &ne.Consensus
+			}
+		default:
+			if e, ok := ne.ParaTimes[ptName]; ok && e != nil {
+				return e
+			}
+		}
+	}
+
+	// Fall back to the default block explorer for known networks.
+	var explorerNet string
+	for _, name := range []string{"mainnet", "testnet"} {
+		if known := config.DefaultNetworks.All[name]; known != nil && net != nil && known.ChainContext == net.ChainContext {
+			explorerNet = name
+		}
+	}
+	if explorerNet == "" {
+		return nil
+	}
+
+	layer := "consensus"
+	if ptName != "" {
+		known := false
+		for _, name := range defaultExplorerParaTimes {
+			known = known || name == ptName
+		}
+		if !known {
+			return nil
+		}
+		layer = ptName
+	}
+
+	base := fmt.Sprintf("%s/%s/%s", defaultExplorerURL, explorerNet, layer)
+	return &Explorer{
+		Tx:      base + "/tx/" + ExplorerPlaceholder,
+		Address: base + "/address/" + ExplorerPlaceholder,
+		Block:   base + "/block/" + ExplorerPlaceholder,
+	}
+}
@@ -0,0 +1,41 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
+)
+
+func TestExplorersLookup(t *testing.T) {
+	require := require.New(t)
+
+	mainnet := config.DefaultNetworks.All["mainnet"]
+	custom := &config.Network{ChainContext: "custom"}
+
+	var ex Explorers
+	e := ex.Lookup("mainnet", mainnet, "")
+	require.NotNil(e)
+	require.Equal("https://explorer.oasis.io/mainnet/consensus/tx/{id}", e.Tx)
+	e = ex.Lookup("mainnet", mainnet, "sapphire")
+	require.NotNil(e)
+	require.Equal("https://explorer.oasis.io/mainnet/sapphire/block/{id}", e.Block)
+	require.Nil(ex.Lookup("mainnet", mainnet, "unknown"))
+	require.Nil(ex.Lookup("custom", custom, ""))
+
+	ex = Explorers{
+		"custom": {
+			Consensus: Explorer{Tx: "https://example.com/tx/{id}"},
+			ParaTimes: map[string]*Explorer{
+				"pt": {Address: "https://example.com/pt/address/{id}"},
+			},
+		},
+	}
+	require.NoError(ex.Validate())
+	require.Equal("https://example.com/tx/{id}", ex.Lookup("custom", custom, "").Tx)
+	require.Equal("https://example.com/pt/address/{id}", ex.Lookup("custom", custom, "pt").Address)
+
+	ex["custom"].Consensus.Block = "https://example.com/block"
+	require.Error(ex.Validate())
+}
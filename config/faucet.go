@@ -0,0 +1,40 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
+)
+
+// defaultTestnetFaucetURL is the URL of the Testnet faucet API.
+const defaultTestnetFaucetURL = "https://faucet.testnet.oasis.io/api/v1/fund"
+
+// Faucets contains the faucet API URLs by network name.
+type Faucets map[string]string
+
+// Validate validates the faucets configuration.
+func (f Faucets) Validate() error {
+	for netName, rawURL := range f {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return fmt.Errorf("network '%s': malformed faucet URL: %w", netName, err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("network '%s': unsupported faucet URL scheme '%s'", netName, u.Scheme)
+		}
+	}
+	return nil
+}
+
+// Lookup returns the faucet API URL for the given network or an empty string if there is none.
+// Configured URLs take precedence over the default faucet which supports the Testnet network.
+func (f Faucets) Lookup(netName string, net *config.Network) string {
+	if u, ok := f[netName]; ok && u != "" {
+		return u
+	}
+	if known := config.DefaultNetworks.All["testnet"]; known != nil && net != nil && known.ChainContext == net.ChainContext {
+		return defaultTestnetFaucetURL
+	}
+	return ""
+}
@@ -0,0 +1,29 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
+)
+
+func TestFaucetsLookup(t *testing.T) {
+	require := require.New(t)
+
+	testnet := config.DefaultNetworks.All["testnet"]
+	mainnet := config.DefaultNetworks.All["mainnet"]
+	custom := &config.Network{ChainContext: "custom"}
+
+	var f Faucets
+	require.Equal(defaultTestnetFaucetURL, f.Lookup("testnet", testnet))
+	require.Empty(f.Lookup("mainnet", mainnet))
+	require.Empty(f.Lookup("custom", custom))
+
+	f = Faucets{"custom": "http://localhost:8080/fund"}
+	require.NoError(f.Validate())
+	require.Equal("http://localhost:8080/fund", f.Lookup("custom", custom))
+
+	f["custom"] = "localhost:8080"
+	require.Error(f.Validate())
+}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GuardAction is the action taken when a transaction trips a guard rail.
+type GuardAction string
+
+const (
+	// GuardOff disables the guard rail.
+	GuardOff GuardAction = "off"
+	// GuardWarn prints a warning before signing.
+	GuardWarn GuardAction = "warn"
+	// GuardConfirm requires the user to type the end of the destination address before signing.
+	GuardConfirm GuardAction = "confirm"
+)
+
+// DefaultGuardBalancePercent is the default share of the available balance at or above which
+// the large transfer guard rail trips.
+const DefaultGuardBalancePercent = 50
+
+// Validate validates the guard action.
+func (a GuardAction) Validate() error {
+	switch a {
+	case "", GuardOff, GuardWarn, GuardConfirm:
+		return nil
+	default:
+		return fmt.Errorf("unknown guard action '%s' (must be one of: %s)", a, strings.Join([]string{
+			string(GuardOff),
+			string(GuardWarn),
+			string(GuardConfirm),
+		}, ", "))
+	}
+}
+
+// orDefault returns the given default action when no action is configured.
+func (a GuardAction) orDefault(def GuardAction) GuardAction {
+	if a == "" {
+		return def
+	}
+	return a
+}
+
+// Guards configures the guard rails checking transaction amounts and destinations before signing.
+// Guard rails without a configured action use their default action.
+type Guards struct {
+	// BalancePercent is the share of the available balance (in percent) at or above which the
+	// large transfer guard rail trips. Zero uses the default.
+	BalancePercent uint64 `mapstructure:"balance_percent"`
+	// LargeTransfer is the action when transferring a large share of the available balance.
+	LargeTransfer GuardAction `mapstructure:"large_transfer"`
+	// ReservedAddress is the action when the destination is a reserved or a ParaTime internal
+	// address.
+	ReservedAddress GuardAction `mapstructure:"reserved_address"`
+	// NewAddress is the action when the destination has no history on the network.
+	NewAddress GuardAction `mapstructure:"new_address"`
+	// EthAddress is the action when sending consensus funds to an Ethereum-derived address.
+	EthAddress GuardAction `mapstructure:"eth_address"`
+}
+
+// Percent returns the configured large transfer balance share in percent.
+func (g *Guards) Percent() uint64 {
+	if g.BalancePercent == 0 {
+		return DefaultGuardBalancePercent
+	}
+	return g.BalancePercent
+}
+
+// LargeTransferAction returns the action of the large transfer guard rail.
+func (g *Guards) LargeTransferAction() GuardAction {
+	return g.LargeTransfer.orDefault(GuardWarn)
+}
+
+// ReservedAddressAction returns the action of the reserved address guard rail.
+func (g *Guards) ReservedAddressAction() GuardAction {
+	return g.ReservedAddress.orDefault(GuardConfirm)
+}
+
+// NewAddressAction returns the action of the new address guard rail.
+func (g *Guards) NewAddressAction() GuardAction {
+	return g.NewAddress.orDefault(GuardWarn)
+}
+
+// EthAddressAction returns the action of the Ethereum-derived address guard rail.
+func (g *Guards) EthAddressAction() GuardAction {
+	return g.EthAddress.orDefault(GuardConfirm)
+}
+
+// Validate validates the guard rail configuration.
+func (g *Guards) Validate() error {
+	if g.BalancePercent > 100 {
+		return fmt.Errorf("balance percent must be at most 100")
+	}
+	for name, a := range map[string]GuardAction{
+		"large_transfer":   g.LargeTransfer,
+		"reserved_address": g.ReservedAddress,
+		"new_address":      g.NewAddress,
+		"eth_address":      g.EthAddress,
+	} {
+		if err := a.Validate(); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
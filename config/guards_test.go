@@ -0,0 +1,29 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuardsDefaults(t *testing.T) {
+	require := require.New(t)
+
+	var g Guards
+	require.NoError(g.Validate())
+	require.EqualValues(DefaultGuardBalancePercent, g.Percent())
+	require.Equal(GuardWarn, g.LargeTransferAction())
+	require.Equal(GuardConfirm, g.ReservedAddressAction())
+	require.Equal(GuardWarn, g.NewAddressAction())
+	require.Equal(GuardConfirm, g.EthAddressAction())
+
+	g = Guards{BalancePercent: 90, NewAddress: GuardOff}
+	require.NoError(g.Validate())
+	require.EqualValues(90, g.Percent())
+	require.Equal(GuardOff, g.NewAddressAction())
+
+	g = Guards{BalancePercent: 101}
+	require.Error(g.Validate())
+	g = Guards{EthAddress: "ask"}
+	require.Error(g.Validate())
+}
@@ -6,9 +6,9 @@ import (
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
 )
 
-// latestMigrationVersion is the target migration version. All migrations from prior versions will
+// LatestMigrationVersion is the target migration version. All migrations from prior versions will
 // be executed to reach this target version.
-const latestMigrationVersion = 1
+const LatestMigrationVersion = 2
 
 // migrationFunc is a configuration migration function.
 //
@@ -17,6 +17,7 @@ type migrationFunc func(*Config) (bool, error)
 
 var migrations = map[int]migrationFunc{
 	0: migrateFromV0,
+	1: migrateFromV1,
 }
 
 // migrateFromV0 performs the following migrations:
@@ -88,10 +89,21 @@ func migrateFromV0(cfg *Config) (bool, error) {
 	return true, nil
 }
 
-// migrateVersions applies migrations for all versions up to the latestMigrationVersion.
+// migrateFromV1 performs the following migrations:
+//
+// - The default request timeout and retry policy is configured.
+func migrateFromV1(cfg *Config) (bool, error) {
+	if cfg.Requests != (RequestPolicy{}) {
+		return false, nil
+	}
+	cfg.Requests = Default.Requests
+	return true, nil
+}
+
+// migrateVersions applies migrations for all versions up to the LatestMigrationVersion.
 func (cfg *Config) migrateVersions() (bool, error) {
 	var changes bool
-	for v := cfg.LastMigration; v < latestMigrationVersion; v++ {
+	for v := cfg.LastMigration; v < LatestMigrationVersion; v++ {
 		fn, ok := migrations[v]
 		if !ok {
 			return false, fmt.Errorf("missing migration from version %d", v)
@@ -109,7 +121,7 @@ func (cfg *Config) migrateVersions() (bool, error) {
 
 func init() {
 	// Make sure that it is possible to migrate from zero to the latest migration version.
-	for v := 0; v < latestMigrationVersion; v++ {
+	for v := 0; v < LatestMigrationVersion; v++ {
 		if _, ok := migrations[v]; !ok {
 			panic(fmt.Errorf("missing migration from version %d", v))
 		}
@@ -0,0 +1,121 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ociCredentialsFilename is the name of the file storing OCI registry credentials.
+const ociCredentialsFilename = "oci_credentials.json"
+
+// OCIRegistry is the configuration of access to a single OCI registry.
+type OCIRegistry struct {
+	// Username is the username used for basic authentication.
+	Username string `mapstructure:"username,omitempty"`
+	// Password is the password used for basic authentication.
+	Password string `mapstructure:"password,omitempty"`
+	// Token is the bearer token used for authentication.
+	Token string `mapstructure:"token,omitempty"`
+	// DockerConfig reuses the credentials stored in the Docker config.json.
+	DockerConfig bool `mapstructure:"docker_config,omitempty"`
+	// Headers are custom HTTP headers sent with each registry request.
+	Headers map[string]string `mapstructure:"headers,omitempty"`
+}
+
+// Validate validates the registry configuration.
+func (r *OCIRegistry) Validate() error {
+	if r.Token != "" && (r.Username != "" || r.Password != "") {
+		return fmt.Errorf("only one of token and basic authentication may be configured")
+	}
+	if r.Password != "" && r.Username == "" {
+		return fmt.Errorf("password requires a username")
+	}
+	for name := range r.Headers {
+		if name == "" || strings.ContainsAny(name, " :\r\n") {
+			return fmt.Errorf("malformed header name '%s'", name)
+		}
+		if http.CanonicalHeaderKey(name) == "Authorization" {
+			return fmt.Errorf("use token or basic authentication instead of the Authorization header")
+		}
+	}
+	return nil
+}
+
+// OCIRegistries contains the OCI registry configurations by registry domain (e.g. docker.io or
+// ghcr.io).
+type OCIRegistries map[string]*OCIRegistry
+
+// Validate validates the OCI registries configuration.
+func (r OCIRegistries) Validate() error {
+	for domain, reg := range r {
+		if reg == nil {
+			continue
+		}
+		if err := reg.Validate(); err != nil {
+			return fmt.Errorf("registry '%s': %w", domain, err)
+		}
+	}
+	return nil
+}
+
+// Lookup returns the configuration of the registry with the given domain or nil if there is none.
+func (r OCIRegistries) Lookup(domain string) *OCIRegistry {
+	return r[domain]
+}
+
+// OCICredential are the credentials of a single OCI registry stored by registry login.
+type OCICredential struct {
+	// Username is the username used for basic authentication.
+	Username string `json:"username,omitempty"`
+	// Password is the password used for basic authentication.
+	Password string `json:"password,omitempty"`
+	// Token is the bearer token used for authentication.
+	Token string `json:"token,omitempty"`
+}
+
+// OCICredentials are the stored OCI registry credentials by registry domain. They are kept out of
+// the configuration file in a file only readable by the user.
+type OCICredentials struct {
+	Registries map[string]*OCICredential `json:"registries"`
+}
+
+// LoadOCICredentials loads the OCI registry credentials from the given configuration directory.
+func LoadOCICredentials(dir string) (*OCICredentials, error) {
+	c := OCICredentials{
+		Registries: make(map[string]*OCICredential),
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, ociCredentialsFilename))
+	switch {
+	case err == nil:
+	case errors.Is(err, os.ErrNotExist):
+		return &c, nil
+	default:
+		return nil, fmt.Errorf("failed to read OCI registry credentials: %w", err)
+	}
+
+	if err = json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("malformed OCI registry credentials: %w", err)
+	}
+	if c.Registries == nil {
+		c.Registries = make(map[string]*OCICredential)
+	}
+	return &c, nil
+}
+
+// Save saves the OCI registry credentials into the given configuration directory.
+func (c *OCICredentials) Save(dir string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, ociCredentialsFilename), data, 0o600)
+}
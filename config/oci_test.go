@@ -0,0 +1,50 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOCIRegistriesValidate(t *testing.T) {
+	require := require.New(t)
+
+	r := OCIRegistries{
+		"ghcr.io":   {Username: "user", Password: "pat"},
+		"docker.io": {DockerConfig: true, Headers: map[string]string{"X-Tenant": "demo"}},
+	}
+	require.NoError(r.Validate())
+	require.True(r.Lookup("docker.io").DockerConfig)
+	require.Nil(r.Lookup("quay.io"))
+
+	r["ghcr.io"].Token = "token"
+	require.Error(r.Validate())
+
+	r["ghcr.io"] = &OCIRegistry{Password: "pat"}
+	require.Error(r.Validate())
+
+	r["ghcr.io"] = &OCIRegistry{Headers: map[string]string{"authorization": "Bearer token"}}
+	require.Error(r.Validate())
+}
+
+func TestOCICredentials(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	creds, err := LoadOCICredentials(dir)
+	require.NoError(err)
+	require.Empty(creds.Registries)
+
+	creds.Registries["ghcr.io"] = &OCICredential{Username: "user", Password: "pat"}
+	require.NoError(creds.Save(dir))
+
+	fi, err := os.Stat(filepath.Join(dir, ociCredentialsFilename))
+	require.NoError(err)
+	require.EqualValues(0o600, fi.Mode().Perm())
+
+	creds, err = LoadOCICredentials(dir)
+	require.NoError(err)
+	require.Equal(&OCICredential{Username: "user", Password: "pat"}, creds.Registries["ghcr.io"])
+}
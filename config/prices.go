@@ -0,0 +1,94 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const (
+	// PriceProviderCoinGecko is the price provider querying the CoinGecko API.
+	PriceProviderCoinGecko = "coingecko"
+	// PriceProviderCustom is the price provider querying a custom URL.
+	PriceProviderCustom = "custom"
+
+	// PricePlaceholderSymbol is the placeholder replaced by the token symbol in custom price URLs.
+	PricePlaceholderSymbol = "{symbol}"
+	// PricePlaceholderCurrency is the placeholder replaced by the fiat currency in custom price
+	// URLs.
+	PricePlaceholderCurrency = "{currency}"
+
+	// DefaultPriceCurrency is the default fiat currency of token prices.
+	DefaultPriceCurrency = "usd"
+	// DefaultCoinGeckoURL is the URL of the CoinGecko simple price API.
+	DefaultCoinGeckoURL = "https://api.coingecko.com/api/v3/simple/price"
+)
+
+// defaultCoinGeckoIDs are the CoinGecko coin IDs of the known tokens by token symbol.
+var defaultCoinGeckoIDs = map[string]string{
+	"ROSE": "oasis-network",
+}
+
+// Prices is the configuration of the optional token price provider used to display approximate
+// fiat values. Prices are only fetched when a provider is configured.
+type Prices struct {
+	// Provider is the price provider, either coingecko or custom. Empty disables prices.
+	Provider string `mapstructure:"provider"`
+	// URL is the URL of the price API. For the custom provider it may contain the {symbol} and
+	// {currency} placeholders and must return a JSON object with a price field.
+	URL string `mapstructure:"url"`
+	// Currency is the fiat currency (e.g. "usd" or "eur").
+	Currency string `mapstructure:"currency"`
+	// CoinIDs are the CoinGecko coin IDs by token symbol, overriding the known ones.
+	CoinIDs map[string]string `mapstructure:"coin_ids"`
+}
+
+// Enabled returns true iff a price provider is configured.
+func (p *Prices) Enabled() bool {
+	return p.Provider != ""
+}
+
+// FiatCurrency returns the configured fiat currency in lower case.
+func (p *Prices) FiatCurrency() string {
+	if p.Currency == "" {
+		return DefaultPriceCurrency
+	}
+	return strings.ToLower(p.Currency)
+}
+
+// CoinGeckoID returns the CoinGecko coin ID of the token with the given symbol or an empty string
+// if it is not known.
+func (p *Prices) CoinGeckoID(symbol string) string {
+	symbol = strings.ToUpper(symbol)
+	if id, ok := p.CoinIDs[symbol]; ok {
+		return id
+	}
+	return defaultCoinGeckoIDs[symbol]
+}
+
+// Validate validates the price provider configuration.
+func (p *Prices) Validate() error {
+	switch p.Provider {
+	case "":
+		return nil
+	case PriceProviderCoinGecko:
+		if p.URL == "" {
+			return nil
+		}
+	case PriceProviderCustom:
+		if p.URL == "" {
+			return fmt.Errorf("%s provider requires an URL", PriceProviderCustom)
+		}
+	default:
+		return fmt.Errorf("unsupported price provider '%s'", p.Provider)
+	}
+
+	u, err := url.Parse(p.URL)
+	if err != nil {
+		return fmt.Errorf("malformed price URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported price URL scheme '%s'", u.Scheme)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPricesValidate(t *testing.T) {
+	require := require.New(t)
+
+	var p Prices
+	require.False(p.Enabled())
+	require.NoError(p.Validate())
+	require.Equal(DefaultPriceCurrency, p.FiatCurrency())
+
+	p = Prices{Provider: PriceProviderCoinGecko, Currency: "EUR"}
+	require.True(p.Enabled())
+	require.NoError(p.Validate())
+	require.Equal("eur", p.FiatCurrency())
+
+	p.URL = "ftp://prices.example.com"
+	require.Error(p.Validate())
+
+	p = Prices{Provider: PriceProviderCustom}
+	require.Error(p.Validate(), "custom provider should require an URL")
+	p.URL = "https://prices.example.com/{symbol}/{currency}"
+	require.NoError(p.Validate())
+
+	p = Prices{Provider: "unknown"}
+	require.Error(p.Validate())
+}
+
+func TestPricesCoinGeckoID(t *testing.T) {
+	require := require.New(t)
+
+	var p Prices
+	require.Equal("oasis-network", p.CoinGeckoID("ROSE"))
+	require.Equal("oasis-network", p.CoinGeckoID("rose"))
+	require.Empty(p.CoinGeckoID("TEST"))
+
+	p.CoinIDs = map[string]string{"TEST": "test-token"}
+	require.Equal("test-token", p.CoinGeckoID("TEST"))
+}
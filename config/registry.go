@@ -0,0 +1,243 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
+)
+
+const (
+	// DefaultRegistryURL is the location of the network registry maintained by the Oasis team.
+	DefaultRegistryURL = "https://raw.githubusercontent.com/oasisprotocol/cli/master/registry/networks.json"
+
+	// RegistryVersion is the supported version of the network registry format.
+	RegistryVersion = 1
+)
+
+// Registry is a registry of canonical network and ParaTime definitions. It uses the same format as
+// the networks section of the configuration file.
+type Registry struct {
+	// Version is the version of the registry format.
+	Version int `mapstructure:"version"`
+	// Networks are the canonical network definitions.
+	Networks config.Networks `mapstructure:"networks"`
+}
+
+// ParseRegistry parses and validates the given JSON-encoded network registry.
+func ParseRegistry(data []byte) (*Registry, error) {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("malformed registry: %w", err)
+	}
+	var r Registry
+	if err := mapstructure.Decode(raw, &r); err != nil {
+		return nil, fmt.Errorf("malformed registry: %w", err)
+	}
+	if r.Version != RegistryVersion {
+		return nil, fmt.Errorf("unsupported registry version: %d", r.Version)
+	}
+	if err := r.Networks.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid registry: %w", err)
+	}
+	return &r, nil
+}
+
+// FieldChange is a change of a single configuration field.
+type FieldChange struct {
+	// Name is the name of the field.
+	Name string
+	// Old is the local value.
+	Old string
+	// New is the value in the registry.
+	New string
+}
+
+// RegistryChange is a change of the local configuration proposed by the network registry.
+type RegistryChange struct {
+	// Network is the local name of the network.
+	Network string
+	// ParaTime is the local name of the ParaTime or empty if the change concerns the network.
+	ParaTime string
+	// New is true iff the network or ParaTime does not exist locally.
+	New bool
+	// Fields are the changed fields of an existing network or ParaTime.
+	Fields []FieldChange
+
+	apply func(cfg *Config) error
+}
+
+// Apply applies the change to the given configuration.
+func (c *RegistryChange) Apply(cfg *Config) error {
+	return c.apply(cfg)
+}
+
+// DiffRegistry returns the changes needed to bring the local network configuration in line with
+// the registry. Networks and ParaTimes are matched by name and, failing that, by their chain
+// context or ID so that renamed entries are also updated. Local networks and ParaTimes which are
+// not in the registry are kept.
+func (cfg *Config) DiffRegistry(r *Registry) []*RegistryChange {
+	var changes []*RegistryChange
+	for _, name := range sortedKeys(r.Networks.All) {
+		remote := r.Networks.All[name]
+		localName, local := findNetwork(&cfg.Networks, name, remote.ChainContext)
+		if local == nil {
+			changes = append(changes, &RegistryChange{
+				Network: name,
+				New:     true,
+				apply: func(cfg *Config) error {
+					net := cloneNetwork(remote)
+					return cfg.Networks.Add(name, net)
+				},
+			})
+			continue
+		}
+
+		if fields := diffNetwork(local, remote); len(fields) > 0 {
+			changes = append(changes, &RegistryChange{
+				Network: localName,
+				Fields:  fields,
+				apply: func(cfg *Config) error {
+					net := cfg.Networks.All[localName]
+					net.Description = remote.Description
+					net.ChainContext = remote.ChainContext
+					net.RPC = remote.RPC
+					net.Denomination = remote.Denomination
+					return nil
+				},
+			})
+		}
+
+		for _, ptName := range sortedKeys(remote.ParaTimes.All) {
+			remotePt := remote.ParaTimes.All[ptName]
+			localPtName, localPt := findParaTime(&local.ParaTimes, ptName, remotePt.ID)
+			if localPt == nil {
+				changes = append(changes, &RegistryChange{
+					Network:  localName,
+					ParaTime: ptName,
+					New:      true,
+					apply: func(cfg *Config) error {
+						return cfg.Networks.All[localName].ParaTimes.Add(ptName, cloneParaTime(remotePt))
+					},
+				})
+				continue
+			}
+
+			if fields := diffParaTime(localPt, remotePt); len(fields) > 0 {
+				changes = append(changes, &RegistryChange{
+					Network:  localName,
+					ParaTime: localPtName,
+					Fields:   fields,
+					apply: func(cfg *Config) error {
+						cfg.Networks.All[localName].ParaTimes.All[localPtName] = cloneParaTime(remotePt)
+						return nil
+					},
+				})
+			}
+		}
+	}
+	return changes
+}
+
+// findNetwork finds the local network with the given name or chain context.
+func findNetwork(nets *config.Networks, name, chainContext string) (string, *config.Network) {
+	if net := nets.All[name]; net != nil {
+		return name, net
+	}
+	for _, localName := range sortedKeys(nets.All) {
+		if nets.All[localName].ChainContext == chainContext {
+			return localName, nets.All[localName]
+		}
+	}
+	return "", nil
+}
+
+// findParaTime finds the local ParaTime with the given name or ID.
+func findParaTime(pts *config.ParaTimes, name, id string) (string, *config.ParaTime) {
+	if pt := pts.All[name]; pt != nil {
+		return name, pt
+	}
+	for _, localName := range sortedKeys(pts.All) {
+		if pts.All[localName].ID == id {
+			return localName, pts.All[localName]
+		}
+	}
+	return "", nil
+}
+
+func diffNetwork(local, remote *config.Network) []FieldChange {
+	var fields []FieldChange
+	addField := func(name, old, new string) {
+		if old != new {
+			fields = append(fields, FieldChange{Name: name, Old: old, New: new})
+		}
+	}
+	addField("description", local.Description, remote.Description)
+	addField("chain_context", local.ChainContext, remote.ChainContext)
+	addField("rpc", local.RPC, remote.RPC)
+	addField("denomination", formatDenomination(&local.Denomination), formatDenomination(&remote.Denomination))
+	return fields
+}
+
+func diffParaTime(local, remote *config.ParaTime) []FieldChange {
+	var fields []FieldChange
+	addField := func(name, old, new string) {
+		if old != new {
+			fields = append(fields, FieldChange{Name: name, Old: old, New: new})
+		}
+	}
+	addField("description", local.Description, remote.Description)
+	addField("id", local.ID, remote.ID)
+	if !reflect.DeepEqual(local.Denominations, remote.Denominations) {
+		addField("denominations", formatDenominations(local.Denominations), formatDenominations(remote.Denominations))
+	}
+	addField("consensus_denomination", local.ConsensusDenomination, remote.ConsensusDenomination)
+	return fields
+}
+
+func formatDenomination(di *config.DenominationInfo) string {
+	return fmt.Sprintf("%s (%d decimals)", di.Symbol, di.Decimals)
+}
+
+func formatDenominations(dis map[string]*config.DenominationInfo) string {
+	var s string
+	for i, name := range sortedKeys(dis) {
+		if i > 0 {
+			s += ", "
+		}
+		s += fmt.Sprintf("%s: %s", name, formatDenomination(dis[name]))
+	}
+	return s
+}
+
+func cloneNetwork(net *config.Network) *config.Network {
+	clone := *net
+	clone.ParaTimes.All = make(map[string]*config.ParaTime, len(net.ParaTimes.All))
+	for name, pt := range net.ParaTimes.All {
+		clone.ParaTimes.All[name] = cloneParaTime(pt)
+	}
+	return &clone
+}
+
+func cloneParaTime(pt *config.ParaTime) *config.ParaTime {
+	clone := *pt
+	clone.Denominations = make(map[string]*config.DenominationInfo, len(pt.Denominations))
+	for name, di := range pt.Denominations {
+		d := *di
+		clone.Denominations[name] = &d
+	}
+	return &clone
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,121 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
+)
+
+const testRegistry = `{
+  "version": 1,
+  "networks": {
+    "default": "mainnet",
+    "mainnet": {
+      "description": "Mainnet",
+      "chain_context": "bb3d748def55bdfb797a2ac53ee6ee141e54cd2ab2dc2375f4a0703a178e6e55",
+      "rpc": "grpc.oasis.io:443",
+      "denomination": {"symbol": "ROSE", "decimals": 9},
+      "paratimes": {
+        "default": "sapphire",
+        "sapphire": {
+          "description": "Sapphire",
+          "id": "000000000000000000000000000000000000000000000000f80306c9858e7279",
+          "denominations": {"_": {"symbol": "ROSE", "decimals": 18}},
+          "consensus_denomination": "_"
+        },
+        "newtime": {
+          "description": "New ParaTime",
+          "id": "0000000000000000000000000000000000000000000000000000000000001234",
+          "denominations": {"_": {"symbol": "NEW", "decimals": 18}}
+        }
+      }
+    },
+    "devnet": {
+      "description": "Devnet",
+      "chain_context": "0000000000000000000000000000000000000000000000000000000000000001",
+      "rpc": "devnet.grpc.oasis.io:443",
+      "denomination": {"symbol": "DEV", "decimals": 9},
+      "paratimes": {}
+    }
+  }
+}`
+
+func TestRegistry(t *testing.T) {
+	require := require.New(t)
+
+	_, err := ParseRegistry([]byte(`{"version": 2, "networks": {}}`))
+	require.ErrorContains(err, "unsupported registry version")
+	_, err = ParseRegistry([]byte(`{"version": 1, "networks": {"foo": {"chain_context": "bar"}}}`))
+	require.ErrorContains(err, "invalid registry")
+
+	r, err := ParseRegistry([]byte(testRegistry))
+	require.NoError(err)
+	require.EqualValues(18, r.Networks.All["mainnet"].ParaTimes.All["sapphire"].Denominations["_"].Decimals)
+
+	cfg := Config{
+		Networks: config.Networks{
+			Default: "mine",
+			All: map[string]*config.Network{
+				// Renamed network with a stale RPC endpoint.
+				"mine": {
+					Description:  "Mainnet",
+					ChainContext: "bb3d748def55bdfb797a2ac53ee6ee141e54cd2ab2dc2375f4a0703a178e6e55",
+					RPC:          "grpc.oasis.dev:443",
+					Denomination: config.DenominationInfo{Symbol: "ROSE", Decimals: 9},
+					ParaTimes: config.ParaTimes{
+						Default: "sapphire",
+						All: map[string]*config.ParaTime{
+							"sapphire": {
+								Description: "Sapphire",
+								ID:          "000000000000000000000000000000000000000000000000f80306c9858e7279",
+								Denominations: map[string]*config.DenominationInfo{
+									"_": {Symbol: "ROSE", Decimals: 18},
+								},
+								ConsensusDenomination: "_",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	changes := cfg.DiffRegistry(r)
+	require.Len(changes, 3)
+	require.Equal("devnet", changes[0].Network)
+	require.True(changes[0].New)
+	require.Equal("mine", changes[1].Network)
+	require.Equal([]FieldChange{{Name: "rpc", Old: "grpc.oasis.dev:443", New: "grpc.oasis.io:443"}}, changes[1].Fields)
+	require.Equal("mine", changes[2].Network)
+	require.Equal("newtime", changes[2].ParaTime)
+	require.True(changes[2].New)
+
+	for _, c := range changes {
+		require.NoError(c.Apply(&cfg))
+	}
+	require.NoError(cfg.Networks.Validate())
+	require.Equal("grpc.oasis.io:443", cfg.Networks.All["mine"].RPC)
+	require.Contains(cfg.Networks.All["mine"].ParaTimes.All, "newtime")
+	require.Contains(cfg.Networks.All, "devnet")
+	require.Empty(cfg.DiffRegistry(r))
+
+	// Changes to the registry must not leak into the configuration.
+	r.Networks.All["devnet"].RPC = "changed"
+	require.Equal("devnet.grpc.oasis.io:443", cfg.Networks.All["devnet"].RPC)
+}
+
+func TestRegistryFile(t *testing.T) {
+	require := require.New(t)
+
+	data, err := os.ReadFile("../registry/networks.json")
+	require.NoError(err)
+	r, err := ParseRegistry(data)
+	require.NoError(err)
+
+	// The published registry must not propose changes to the built-in defaults.
+	cfg := Config{Networks: config.DefaultNetworks}
+	require.Empty(cfg.DiffRegistry(r))
+}
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultRequestTimeout is the default timeout of a single network request.
+	DefaultRequestTimeout = 60 * time.Second
+	// DefaultRequestRetries is the default number of times a failed network request is retried.
+	DefaultRequestRetries = 3
+)
+
+// RequestPolicy configures how network requests are performed.
+type RequestPolicy struct {
+	// Timeout is the timeout of a single network request (e.g. "30s"). Zero disables the timeout
+	// and empty uses the default timeout.
+	Timeout string `mapstructure:"timeout"`
+	// Retries is the number of times a request that failed due to an unavailable endpoint or a
+	// timeout is retried.
+	Retries uint `mapstructure:"retries"`
+}
+
+// TimeoutDuration returns the configured request timeout.
+func (p *RequestPolicy) TimeoutDuration() (time.Duration, error) {
+	if p.Timeout == "" {
+		return DefaultRequestTimeout, nil
+	}
+	timeout, err := time.ParseDuration(p.Timeout)
+	if err != nil {
+		return 0, fmt.Errorf("malformed timeout '%s': %w", p.Timeout, err)
+	}
+	if timeout < 0 {
+		return 0, fmt.Errorf("timeout must not be negative")
+	}
+	return timeout, nil
+}
+
+// Validate validates the request policy.
+func (p *RequestPolicy) Validate() error {
+	_, err := p.TimeoutDuration()
+	return err
+}
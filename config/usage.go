@@ -0,0 +1,87 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// UsageFilename is the name of the file in the configuration directory storing local account
+// usage statistics.
+const UsageFilename = "usage.json"
+
+// AccountUsage are the local usage statistics of a single account.
+type AccountUsage struct {
+	// LastUsed is the time the account was last used to sign a transaction.
+	LastUsed time.Time `json:"last_used"`
+	// Count is the number of transactions signed by the account.
+	Count uint64 `json:"count"`
+	// Networks are the names of the networks the account has signed transactions for.
+	Networks []string `json:"networks"`
+}
+
+// UsedOn returns true iff the account has been used on the given network.
+func (u *AccountUsage) UsedOn(network string) bool {
+	return u != nil && slices.Contains(u.Networks, network)
+}
+
+// Usage are the local usage statistics of all accounts, indexed by account address.
+type Usage struct {
+	Accounts map[string]*AccountUsage `json:"accounts"`
+}
+
+// LoadUsage loads the account usage statistics from the given configuration directory.
+func LoadUsage(dir string) (*Usage, error) {
+	u := Usage{
+		Accounts: make(map[string]*AccountUsage),
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, UsageFilename))
+	switch {
+	case err == nil:
+	case errors.Is(err, os.ErrNotExist):
+		return &u, nil
+	default:
+		return nil, fmt.Errorf("failed to read account usage: %w", err)
+	}
+
+	if err = json.Unmarshal(data, &u); err != nil {
+		return nil, fmt.Errorf("malformed account usage: %w", err)
+	}
+	if u.Accounts == nil {
+		u.Accounts = make(map[string]*AccountUsage)
+	}
+	return &u, nil
+}
+
+// Save saves the account usage statistics into the given configuration directory.
+func (u *Usage) Save(dir string) error {
+	data, err := json.MarshalIndent(u, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, UsageFilename), data, 0o600)
+}
+
+// Record records that the account with the given address signed a transaction for the given
+// network at the given time.
+func (u *Usage) Record(address, network string, t time.Time) {
+	au := u.Accounts[address]
+	if au == nil {
+		au = &AccountUsage{}
+		u.Accounts[address] = au
+	}
+	au.LastUsed = t
+	au.Count++
+	if !slices.Contains(au.Networks, network) {
+		au.Networks = append(au.Networks, network)
+		slices.Sort(au.Networks)
+	}
+}
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsage(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	u, err := LoadUsage(dir)
+	require.NoError(err)
+	require.Empty(u.Accounts)
+	require.False(u.Accounts["oasis1foo"].UsedOn("mainnet"))
+
+	now := time.Now().UTC().Truncate(time.Second)
+	u.Record("oasis1foo", "testnet", now.Add(-time.Hour))
+	u.Record("oasis1foo", "mainnet", now)
+	u.Record("oasis1foo", "testnet", now)
+	require.NoError(u.Save(dir))
+
+	u, err = LoadUsage(dir)
+	require.NoError(err)
+	au := u.Accounts["oasis1foo"]
+	require.NotNil(au)
+	require.EqualValues(3, au.Count)
+	require.True(now.Equal(au.LastUsed))
+	require.Equal([]string{"mainnet", "testnet"}, au.Networks)
+	require.True(au.UsedOn("testnet"))
+	require.False(au.UsedOn("localnet"))
+}
@@ -2,11 +2,15 @@ package config
 
 import (
 	"fmt"
+	"maps"
+	"strconv"
+	"strings"
 
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
 
 	"github.com/oasisprotocol/cli/wallet"
+	"github.com/oasisprotocol/cli/wallet/multisig"
 )
 
 // Wallet contains the configuration of the wallet.
@@ -93,15 +97,42 @@ func (w *Wallet) Create(name string, passphrase string, nw *Account) error {
 	return nil
 }
 
-// Load loads the given account.
+// SubAccountSeparator separates the account name from the derivation index of a sub-account.
+const SubAccountSeparator = ":"
+
+// ParseSubAccountName splits the given account name of the form <name>:<number> into the name
+// of the account holding the key material and the derivation index of the sub-account. In case
+// the name does not refer to a sub-account, it is returned unchanged.
+func ParseSubAccountName(name string) (string, uint32, bool) {
+	base, index, ok := strings.Cut(name, SubAccountSeparator)
+	if !ok {
+		return name, 0, false
+	}
+	number, err := strconv.ParseUint(index, 10, 32)
+	if err != nil {
+		return name, 0, false
+	}
+	return base, uint32(number), true
+}
+
+// Load loads the given account. The name may refer to a sub-account using the <name>:<number>
+// syntax in which case the key with the given derivation index is derived on the fly.
 func (w *Wallet) Load(name string, passphrase string) (wallet.Account, error) {
-	cfg, exists := w.All[name]
+	baseName, number, isSubAccount := ParseSubAccountName(name)
+	cfg, exists := w.All[baseName]
 	if !exists {
-		return nil, fmt.Errorf("account '%s' does not exist in the wallet", name)
+		return nil, fmt.Errorf("account '%s' does not exist in the wallet", baseName)
 	}
 
-	if err := config.ValidateIdentifier(name); err != nil {
-		return nil, fmt.Errorf("malformed account name '%s': %w", name, err)
+	if err := config.ValidateIdentifier(baseName); err != nil {
+		return nil, fmt.Errorf("malformed account name '%s': %w", baseName, err)
+	}
+
+	if isSubAccount {
+		var err error
+		if cfg, err = cfg.SubAccount(number); err != nil {
+			return nil, fmt.Errorf("account '%s': %w", baseName, err)
+		}
 	}
 
 	af, err := wallet.Load(cfg.Kind)
@@ -109,12 +140,16 @@ func (w *Wallet) Load(name string, passphrase string) (wallet.Account, error) {
 		return nil, err
 	}
 
-	acc, err := af.Load(name, passphrase, cfg.Config)
+	acc, err := af.Load(baseName, passphrase, cfg.Config)
 	if err != nil {
 		return nil, err
 	}
 
-	// Make sure the address matches what we have in the config.
+	// Make sure the address matches what we have in the config. Sub-account addresses are not
+	// known before the key is derived.
+	if cfg.Address == "" {
+		return acc, nil
+	}
 	if expected, actual := cfg.GetAddress(), acc.Address(); !actual.Equal(expected) {
 		return nil, fmt.Errorf("address mismatch after loading account (expected: %s got: %s)",
 			expected,
@@ -193,6 +228,52 @@ func (w *Wallet) Rename(old, new string) error {
 	return nil
 }
 
+// Merge merges the source account into the destination account and removes the source account.
+// Both accounts must have the same address. The description of the source account is appended to
+// the destination one, but the key material of the destination account is kept.
+func (w *Wallet) Merge(src, dst string) error {
+	srcCfg, exists := w.All[src]
+	if !exists {
+		return fmt.Errorf("account '%s' does not exist in the wallet", src)
+	}
+	dstCfg, exists := w.All[dst]
+	if !exists {
+		return fmt.Errorf("account '%s' does not exist in the wallet", dst)
+	}
+	if src == dst {
+		return fmt.Errorf("cannot merge account '%s' into itself", src)
+	}
+	if srcCfg.Address != dstCfg.Address {
+		return fmt.Errorf("accounts '%s' and '%s' have different keys (addresses %s and %s)", src, dst, srcCfg.Address, dstCfg.Address)
+	}
+
+	switch {
+	case srcCfg.Description == "", srcCfg.Description == dstCfg.Description:
+	case dstCfg.Description == "":
+		dstCfg.Description = srcCfg.Description
+	default:
+		dstCfg.Description += "; " + srcCfg.Description
+	}
+	if srcCfg.Kind == dstCfg.Kind {
+		dstCfg.AddressVerified = dstCfg.AddressVerified || srcCfg.AddressVerified
+	}
+	if dstCfg.BackupVerified == "" && srcCfg.SameDerivation(dstCfg) {
+		dstCfg.BackupVerified = srcCfg.BackupVerified
+	}
+
+	wasDefault := w.Default == src
+	if err := w.Remove(src); err != nil {
+		return err
+	}
+
+	// Update default if set to the source account.
+	if wasDefault {
+		w.Default = dst
+	}
+
+	return nil
+}
+
 // Import imports an existing account.
 func (w *Wallet) Import(name string, passphrase string, nw *Account, src *wallet.ImportSource) error {
 	if _, exists := w.All[name]; exists {
@@ -253,6 +334,18 @@ type Account struct {
 	Kind        string `mapstructure:"kind"`
 	Address     string `mapstructure:"address"`
 
+	// AddressVerified is true iff the address of a hardware wallet account has been confirmed on
+	// the device.
+	AddressVerified bool `mapstructure:"address_verified,omitempty"`
+
+	// BackupVerified is the time in RFC 3339 format at which the backup of the account's recovery
+	// phrase has been verified or empty if it has never been verified.
+	BackupVerified string `mapstructure:"backup_verified,omitempty"`
+
+	// SubAccounts maps the numbers of sub-accounts derived from this account to their addresses
+	// so that they can be resolved without unlocking the account.
+	SubAccounts map[string]string `mapstructure:"sub_accounts,omitempty"`
+
 	// Config contains kind-specific configuration for this wallet.
 	Config map[string]interface{} `mapstructure:",remain"`
 }
@@ -270,14 +363,70 @@ func (a *Account) Validate() error {
 		return fmt.Errorf("malformed address '%s': %w", a.Address, err)
 	}
 
-	// Check the algorithm is not empty.
-	if _, ok := a.Config["algorithm"]; !ok {
+	// Check the algorithm is not empty. Multisig accounts have no key of their own.
+	if _, ok := a.Config["algorithm"]; !ok && a.Kind != multisig.Kind {
 		return fmt.Errorf("algorithm field not defined")
 	}
 
 	return nil
 }
 
+// SubAccount returns the configuration of the sub-account with the given derivation index. The
+// address of the returned account is empty unless it has been recorded using
+// RecordSubAccountAddress as it is only known once the key is derived.
+func (a *Account) SubAccount(number uint32) (*Account, error) {
+	algorithm, _ := a.Config["algorithm"].(string)
+	switch algorithm {
+	case wallet.AlgorithmEd25519Raw, wallet.AlgorithmSecp256k1Raw, wallet.AlgorithmSr25519Raw:
+		return nil, fmt.Errorf("algorithm '%s' does not support key derivation", algorithm)
+	}
+
+	sub := *a
+	sub.Address = a.SubAccounts[strconv.FormatUint(uint64(number), 10)]
+	sub.SubAccounts = nil
+	sub.Config = maps.Clone(a.Config)
+	if sub.Config == nil {
+		sub.Config = make(map[string]interface{})
+	}
+	sub.Config["number"] = number
+
+	// Custom derivation paths take precedence over the number, so the number is substituted into
+	// the path as otherwise the sub-account would derive the key of the parent account.
+	if rawPath, _ := sub.Config["derivation_path"].(string); rawPath != "" {
+		path, err := wallet.SubAccountDerivationPath(algorithm, rawPath, number)
+		if err != nil {
+			return nil, err
+		}
+		sub.Config["derivation_path"] = path
+	}
+	return &sub, nil
+}
+
+// RecordSubAccountAddress records the address of the sub-account with the given derivation index.
+// It returns true iff the recorded address has changed.
+func (a *Account) RecordSubAccountAddress(number uint32, address string) bool {
+	key := strconv.FormatUint(uint64(number), 10)
+	if a.SubAccounts[key] == address {
+		return false
+	}
+	if a.SubAccounts == nil {
+		a.SubAccounts = make(map[string]string)
+	}
+	a.SubAccounts[key] = address
+	return true
+}
+
+// SameDerivation returns true iff the other account derives the same sub-accounts as this one.
+func (a *Account) SameDerivation(other *Account) bool {
+	algorithm, _ := a.Config["algorithm"].(string)
+	otherAlgorithm, _ := other.Config["algorithm"].(string)
+	if algorithm != otherAlgorithm || a.Address != other.Address {
+		return false
+	}
+	_, err := a.SubAccount(0)
+	return err == nil
+}
+
 // GetAddress returns the parsed account address.
 func (a *Account) GetAddress() types.Address {
 	var address types.Address
@@ -317,6 +466,17 @@ func (a *Account) PrettyKind() string {
 	return af.PrettyKind(a.Config)
 }
 
+// HasEthAddress returns true, iff there is an Ethereum address associated with this account.
+func (a *Account) HasEthAddress() bool {
+	algorithm, _ := a.Config["algorithm"].(string)
+	switch algorithm {
+	case wallet.AlgorithmSecp256k1Bip44, wallet.AlgorithmSecp256k1Raw:
+		return true
+	default:
+		return false
+	}
+}
+
 // HasConsensusSigner returns true, iff there is a consensus layer signer associated with this account.
 func (a *Account) HasConsensusSigner() bool {
 	af, err := wallet.Load(a.Kind)
@@ -0,0 +1,141 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/cli/wallet"
+)
+
+func TestParseSubAccountName(t *testing.T) {
+	require := require.New(t)
+
+	for _, tc := range []struct {
+		name         string
+		expectedBase string
+		expectedNum  uint32
+		expectedSub  bool
+	}{
+		{"mywallet", "mywallet", 0, false},
+		{"mywallet:3", "mywallet", 3, true},
+		{"mywallet:0", "mywallet", 0, true},
+		{"test:alice", "test:alice", 0, false},
+		{"mywallet:-1", "mywallet:-1", 0, false},
+		{"mywallet:4294967296", "mywallet:4294967296", 0, false},
+	} {
+		base, number, ok := ParseSubAccountName(tc.name)
+		require.Equal(tc.expectedBase, base, tc.name)
+		require.Equal(tc.expectedNum, number, tc.name)
+		require.Equal(tc.expectedSub, ok, tc.name)
+	}
+}
+
+func TestSubAccount(t *testing.T) {
+	require := require.New(t)
+
+	acc := &Account{
+		Kind:    "file",
+		Address: "oasis1qrvzxld9rz83wv92lvnkpmr30c77kj2tvg0pednz",
+		Config: map[string]interface{}{
+			"algorithm": wallet.AlgorithmSecp256k1Bip44,
+			"number":    uint32(0),
+		},
+	}
+	sub, err := acc.SubAccount(3)
+	require.NoError(err)
+	require.Empty(sub.Address)
+	require.EqualValues(3, sub.Config["number"])
+	require.EqualValues(0, acc.Config["number"], "original config must not be modified")
+
+	acc.Config["derivation_path"] = "m/44'/60'/2'/0/7"
+	sub, err = acc.SubAccount(3)
+	require.NoError(err)
+	require.Equal("m/44'/60'/2'/0/3", sub.Config["derivation_path"])
+	require.Equal("m/44'/60'/2'/0/7", acc.Config["derivation_path"], "original config must not be modified")
+
+	acc.Config["algorithm"] = wallet.AlgorithmEd25519Adr8
+	acc.Config["derivation_path"] = "m/44'/474'/5'/0'/0'"
+	sub, err = acc.SubAccount(1)
+	require.NoError(err)
+	require.Equal("m/44'/474'/5'/0'/1'", sub.Config["derivation_path"])
+
+	require.True(acc.RecordSubAccountAddress(1, "oasis1qz0k5q8vjqvu4s4nwxyj406ylnflkc4vrcjghuwk"))
+	require.False(acc.RecordSubAccountAddress(1, "oasis1qz0k5q8vjqvu4s4nwxyj406ylnflkc4vrcjghuwk"))
+	sub, err = acc.SubAccount(1)
+	require.NoError(err)
+	require.Equal("oasis1qz0k5q8vjqvu4s4nwxyj406ylnflkc4vrcjghuwk", sub.Address)
+	require.Nil(sub.SubAccounts)
+	sub, err = acc.SubAccount(2)
+	require.NoError(err)
+	require.Empty(sub.Address)
+
+	enc, err := encode(acc)
+	require.NoError(err)
+	require.Equal(map[string]interface{}{"1": "oasis1qz0k5q8vjqvu4s4nwxyj406ylnflkc4vrcjghuwk"}, enc.(map[string]interface{})["sub_accounts"])
+
+	acc.Config["algorithm"] = wallet.AlgorithmEd25519Raw
+	_, err = acc.SubAccount(3)
+	require.Error(err)
+}
+
+func TestSameDerivation(t *testing.T) {
+	require := require.New(t)
+
+	newAccount := func(kind, algorithm string) *Account {
+		return &Account{
+			Kind:    kind,
+			Address: "oasis1qrvzxld9rz83wv92lvnkpmr30c77kj2tvg0pednz",
+			Config:  map[string]interface{}{"algorithm": algorithm},
+		}
+	}
+	file := newAccount("file", wallet.AlgorithmEd25519Adr8)
+	require.True(file.SameDerivation(newAccount("ledger", wallet.AlgorithmEd25519Adr8)))
+	require.False(file.SameDerivation(newAccount("file", wallet.AlgorithmEd25519Raw)))
+
+	raw := newAccount("file", wallet.AlgorithmEd25519Raw)
+	require.False(raw.SameDerivation(newAccount("file", wallet.AlgorithmEd25519Raw)), "raw keys have no sub-accounts")
+
+	other := newAccount("file", wallet.AlgorithmEd25519Adr8)
+	other.Address = "oasis1qqxxut9x74dutu587f9nj8787qz4dm0ueu05l88c"
+	require.False(file.SameDerivation(other))
+}
+
+func TestHasEthAddress(t *testing.T) {
+	require := require.New(t)
+
+	acc := &Account{
+		Kind:    "file",
+		Address: "oasis1qrvzxld9rz83wv92lvnkpmr30c77kj2tvg0pednz",
+		Config: map[string]interface{}{
+			"algorithm": wallet.AlgorithmSecp256k1Bip44,
+		},
+	}
+	require.True(acc.HasEthAddress())
+
+	acc.Config["algorithm"] = wallet.AlgorithmSecp256k1Raw
+	require.True(acc.HasEthAddress())
+
+	acc.Config["algorithm"] = wallet.AlgorithmEd25519Adr8
+	require.False(acc.HasEthAddress())
+}
+
+func TestEncodeAddressVerified(t *testing.T) {
+	require := require.New(t)
+
+	acc := &Account{
+		Kind:    "ledger",
+		Address: "oasis1qrvzxld9rz83wv92lvnkpmr30c77kj2tvg0pednz",
+		Config: map[string]interface{}{
+			"algorithm": wallet.AlgorithmEd25519Adr8,
+		},
+	}
+	enc, err := encode(acc)
+	require.NoError(err)
+	require.NotContains(enc, "address_verified", "unverified accounts should not store the flag")
+
+	acc.AddressVerified = true
+	enc, err = encode(acc)
+	require.NoError(err)
+	require.Equal(true, enc.(map[string]interface{})["address_verified"])
+}
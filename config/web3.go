@@ -0,0 +1,83 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
+)
+
+// defaultWeb3Gateways are the URLs of the public Web3 gateways by network and ParaTime name.
+var defaultWeb3Gateways = map[string]map[string]string{
+	"mainnet": {
+		"emerald":  "https://emerald.oasis.io",
+		"sapphire": "https://sapphire.oasis.io",
+	},
+	"testnet": {
+		"emerald":  "https://testnet.emerald.oasis.io",
+		"sapphire": "https://testnet.sapphire.oasis.io",
+	},
+}
+
+// Web3Gateways contains the Web3 gateway URLs of EVM-compatible ParaTimes by network name and
+// ParaTime name.
+type Web3Gateways map[string]map[string]string
+
+// Validate validates the Web3 gateways configuration.
+func (w Web3Gateways) Validate() error {
+	for netName, gateways := range w {
+		for ptName, rawURL := range gateways {
+			if err := ValidateWeb3GatewayURL(rawURL); err != nil {
+				return fmt.Errorf("network '%s', paratime '%s': %w", netName, ptName, err)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateWeb3GatewayURL validates the URL of a Web3 gateway.
+func ValidateWeb3GatewayURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("malformed Web3 gateway URL: %w", err)
+	}
+	switch u.Scheme {
+	case "http", "https", "ws", "wss":
+		return nil
+	default:
+		return fmt.Errorf("unsupported Web3 gateway URL scheme '%s'", u.Scheme)
+	}
+}
+
+// Set associates the Web3 gateway URL with the given ParaTime. An empty URL removes the
+// association.
+func (w *Web3Gateways) Set(netName, ptName, rawURL string) {
+	if rawURL == "" {
+		delete((*w)[netName], ptName)
+		if len((*w)[netName]) == 0 {
+			delete(*w, netName)
+		}
+		return
+	}
+	if *w == nil {
+		*w = make(Web3Gateways)
+	}
+	if (*w)[netName] == nil {
+		(*w)[netName] = make(map[string]string)
+	}
+	(*w)[netName][ptName] = rawURL
+}
+
+// Lookup returns the Web3 gateway URL of the given ParaTime or an empty string if there is none.
+// Configured URLs take precedence over the public gateways of the Mainnet and Testnet ParaTimes.
+func (w Web3Gateways) Lookup(netName string, net *config.Network, ptName string) string {
+	if u, ok := w[netName][ptName]; ok && u != "" {
+		return u
+	}
+	for name, gateways := range defaultWeb3Gateways {
+		if known := config.DefaultNetworks.All[name]; known != nil && net != nil && known.ChainContext == net.ChainContext {
+			return gateways[ptName]
+		}
+	}
+	return ""
+}
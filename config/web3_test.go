@@ -0,0 +1,31 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
+)
+
+func TestWeb3GatewaysLookup(t *testing.T) {
+	require := require.New(t)
+
+	mainnet := config.DefaultNetworks.All["mainnet"]
+	custom := &config.Network{ChainContext: "custom"}
+
+	var w Web3Gateways
+	require.Equal("https://sapphire.oasis.io", w.Lookup("mainnet", mainnet, "sapphire"))
+	require.Empty(w.Lookup("mainnet", mainnet, "cipher"))
+	require.Empty(w.Lookup("custom", custom, "sapphire"))
+
+	w.Set("custom", "sapphire", "http://localhost:8545")
+	require.NoError(w.Validate())
+	require.Equal("http://localhost:8545", w.Lookup("custom", custom, "sapphire"))
+
+	w.Set("custom", "sapphire", "")
+	require.Empty(w)
+
+	w.Set("mainnet", "sapphire", "localhost:8545")
+	require.Error(w.Validate())
+}
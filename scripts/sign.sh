@@ -0,0 +1,19 @@
+#!/bin/bash
+
+# Sign file $3 in the domain separation context $1 with the Ed25519 private key
+# in PEM file $2 and store the raw signature to $4.
+# The signed message is the context, a zero byte and the file content. This
+# must match update.SignedMessage.
+
+set -euo pipefail
+
+CONTEXT=$1
+KEY=$2
+IN=$3
+OUT=$4
+
+MSG=$(mktemp)
+trap 'rm -f "$MSG"' EXIT
+
+{ printf '%s\0' "$CONTEXT"; cat "$IN"; } > "$MSG"
+openssl pkeyutl -sign -rawin -inkey "$KEY" -in "$MSG" -out "$OUT"
@@ -0,0 +1,148 @@
+package table
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// Column describes a column of a listing.
+type Column struct {
+	// Name is the human readable column name shown in the header.
+	Name string
+	// Wide is true iff the column is only shown by default in wide mode.
+	Wide bool
+}
+
+// Key returns the key used to refer to the column in column selection and sorting.
+func (c Column) Key() string {
+	return columnKey(c.Name)
+}
+
+func columnKey(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	return strings.NewReplacer(" ", "-", "_", "-").Replace(name)
+}
+
+// Options are the user-configurable listing rendering options.
+type Options struct {
+	// Columns are the keys of the columns to show, in order. When empty, the default columns are
+	// shown.
+	Columns []string
+	// SortBy is the key of the column to sort by. When empty, the listing order is preserved.
+	// A leading "-" sorts in descending order.
+	SortBy string
+	// NoHeader is true iff the header should be omitted.
+	NoHeader bool
+	// Wide is true iff the wide-only columns should also be shown by default.
+	Wide bool
+}
+
+// Listing is a table with user-selectable columns and sorting.
+type Listing struct {
+	columns []Column
+	rows    [][]string
+}
+
+// NewListing creates a new listing with the given columns.
+func NewListing(columns ...Column) *Listing {
+	return &Listing{columns: columns}
+}
+
+// Append appends a row to the listing. The row must contain a value for each of the columns.
+func (l *Listing) Append(row ...string) {
+	l.rows = append(l.rows, row)
+}
+
+// Rows returns the rows of the listing with the given options applied together with the header.
+func (l *Listing) Rows(opts Options) ([]string, [][]string, error) {
+	// Determine which columns to show.
+	var indices []int
+	switch len(opts.Columns) {
+	case 0:
+		for i, c := range l.columns {
+			if !c.Wide || opts.Wide {
+				indices = append(indices, i)
+			}
+		}
+	default:
+		for _, name := range opts.Columns {
+			idx, err := l.columnIndex(name)
+			if err != nil {
+				return nil, nil, err
+			}
+			indices = append(indices, idx)
+		}
+	}
+
+	rows := make([][]string, len(l.rows))
+	copy(rows, l.rows)
+
+	if opts.SortBy != "" {
+		name, desc := strings.CutPrefix(opts.SortBy, "-")
+		idx, err := l.columnIndex(name)
+		if err != nil {
+			return nil, nil, err
+		}
+		sort.SliceStable(rows, func(i, j int) bool {
+			if desc {
+				return lessValue(rows[j][idx], rows[i][idx])
+			}
+			return lessValue(rows[i][idx], rows[j][idx])
+		})
+	}
+
+	header := make([]string, 0, len(indices))
+	for _, idx := range indices {
+		header = append(header, l.columns[idx].Name)
+	}
+	output := make([][]string, 0, len(rows))
+	for _, row := range rows {
+		selected := make([]string, 0, len(indices))
+		for _, idx := range indices {
+			selected = append(selected, row[idx])
+		}
+		output = append(output, selected)
+	}
+	return header, output, nil
+}
+
+// Render renders the listing to standard output with the given options applied.
+func (l *Listing) Render(opts Options) error {
+	header, rows, err := l.Rows(opts)
+	if err != nil {
+		return err
+	}
+
+	t := New()
+	if !opts.NoHeader {
+		t.SetHeader(header)
+	}
+	t.AppendBulk(rows)
+	t.Render()
+	return nil
+}
+
+func (l *Listing) columnIndex(name string) (int, error) {
+	key := columnKey(name)
+	keys := make([]string, 0, len(l.columns))
+	for i, c := range l.columns {
+		if c.Key() == key {
+			return i, nil
+		}
+		keys = append(keys, c.Key())
+	}
+	return 0, fmt.Errorf("unknown column '%s' (available: %s)", name, strings.Join(keys, ", "))
+}
+
+// lessValue compares two cell values numerically when both are numbers and lexicographically
+// otherwise.
+func lessValue(a, b string) bool {
+	na, okA := new(big.Float).SetString(a)
+	nb, okB := new(big.Float).SetString(b)
+	if okA && okB {
+		return na.Cmp(nb) < 0
+	}
+	return a < b
+}
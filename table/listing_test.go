@@ -0,0 +1,40 @@
+package table
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestListingRows(t *testing.T) {
+	require := require.New(t)
+
+	l := NewListing(
+		Column{Name: "Name"},
+		Column{Name: "Chain Context"},
+		Column{Name: "Count", Wide: true},
+	)
+	l.Append("b", "ctx-b", "10")
+	l.Append("a", "ctx-a", "9")
+	l.Append("c", "ctx-c", "100")
+
+	header, rows, err := l.Rows(Options{})
+	require.NoError(err)
+	require.EqualValues([]string{"Name", "Chain Context"}, header)
+	require.EqualValues([][]string{{"b", "ctx-b"}, {"a", "ctx-a"}, {"c", "ctx-c"}}, rows)
+
+	header, rows, err = l.Rows(Options{Wide: true, SortBy: "count"})
+	require.NoError(err)
+	require.EqualValues([]string{"Name", "Chain Context", "Count"}, header)
+	require.EqualValues([][]string{{"a", "ctx-a", "9"}, {"b", "ctx-b", "10"}, {"c", "ctx-c", "100"}}, rows)
+
+	header, rows, err = l.Rows(Options{Columns: []string{"count", "chain_context"}, SortBy: "-name"})
+	require.NoError(err)
+	require.EqualValues([]string{"Count", "Chain Context"}, header)
+	require.EqualValues([][]string{{"100", "ctx-c"}, {"10", "ctx-b"}, {"9", "ctx-a"}}, rows)
+
+	_, _, err = l.Rows(Options{Columns: []string{"foo"}})
+	require.Error(err)
+	_, _, err = l.Rows(Options{SortBy: "foo"})
+	require.Error(err)
+}
@@ -0,0 +1,341 @@
+// Package update implements updating the CLI binary to the latest release.
+package update
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	// LatestReleaseURL is the GitHub API endpoint returning the latest CLI release.
+	LatestReleaseURL = "https://api.github.com/repos/oasisprotocol/cli/releases/latest"
+
+	// maxDownloadSize is the maximum size of a downloaded release asset.
+	maxDownloadSize = 256 * 1024 * 1024
+)
+
+// Asset is a file attached to a release.
+type Asset struct {
+	// Name is the file name of the asset.
+	Name string `json:"name"`
+	// URL is the download URL of the asset.
+	URL string `json:"browser_download_url"`
+}
+
+// Release is a CLI release.
+type Release struct {
+	// Tag is the git tag of the release (e.g. v0.12.0).
+	Tag string `json:"tag_name"`
+	// Assets are the files attached to the release.
+	Assets []Asset `json:"assets"`
+}
+
+// Version returns the version of the release.
+func (r *Release) Version() string {
+	return strings.TrimPrefix(r.Tag, "v")
+}
+
+// Asset returns the asset with the given name.
+func (r *Release) Asset(name string) (*Asset, error) {
+	for i := range r.Assets {
+		if r.Assets[i].Name == name {
+			return &r.Assets[i], nil
+		}
+	}
+	return nil, fmt.Errorf("release %s has no asset '%s'", r.Tag, name)
+}
+
+// LatestRelease queries the latest CLI release.
+func LatestRelease(ctx context.Context) (*Release, error) {
+	data, err := Download(ctx, LatestReleaseURL)
+	if err != nil {
+		return nil, err
+	}
+	var r Release
+	if err = json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("malformed release information: %w", err)
+	}
+	if r.Tag == "" {
+		return nil, fmt.Errorf("malformed release information: missing tag")
+	}
+	return &r, nil
+}
+
+// Download fetches the given URL.
+func Download(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	rsp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	defer rsp.Body.Close()
+	if rsp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s: %s", url, rsp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(rsp.Body, maxDownloadSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	if len(data) > maxDownloadSize {
+		return nil, fmt.Errorf("failed to download %s: file too large", url)
+	}
+	return data, nil
+}
+
+// ArchiveName returns the name of the release archive for the given platform.
+func ArchiveName(version, goos, goarch string) string {
+	ext := ".tar.gz"
+	switch goos {
+	case "darwin":
+		// macOS releases contain a single universal binary.
+		goarch = "all"
+	case "windows":
+		ext = ".zip"
+	}
+	return fmt.Sprintf("oasis_%s_%s_%s%s", version, goos, goarch, ext)
+}
+
+// ChecksumsName returns the name of the checksums file of the given release version.
+func ChecksumsName(version string) string {
+	return fmt.Sprintf("SHA256SUMS-%s.txt", version)
+}
+
+// SignatureName returns the name of the signature of the checksums file of the given release
+// version.
+func SignatureName(version string) string {
+	return ChecksumsName(version) + ".sig"
+}
+
+// SignatureContext is the domain separation context of a signature made with the release signing
+// key. It ensures that a signature of one kind of file cannot be passed off as another.
+type SignatureContext string
+
+const (
+	// ReleaseSignatureContext is the context of the signatures of release checksum files.
+	ReleaseSignatureContext = SignatureContext("oasis-cli/release: checksums")
+	// RegistrySignatureContext is the context of the signatures of the network registry.
+	RegistrySignatureContext = SignatureContext("oasis-cli/registry: networks")
+)
+
+// SignedMessage returns the message which is signed for the given data in the given context.
+func SignedMessage(context SignatureContext, data []byte) []byte {
+	msg := make([]byte, 0, len(context)+1+len(data))
+	msg = append(msg, context...)
+	msg = append(msg, 0)
+	return append(msg, data...)
+}
+
+// VerifySignature verifies the Ed25519 signature of the given data (e.g. the checksums file) made
+// in the given context using the given Base64-encoded public key.
+func VerifySignature(publicKey string, context SignatureContext, data, signature []byte) error {
+	if publicKey == "" {
+		return fmt.Errorf("no release signing key is embedded in this build")
+	}
+	pk, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil || len(pk) != ed25519.PublicKeySize {
+		return fmt.Errorf("malformed release signing key")
+	}
+	// Accept both raw and Base64-encoded signatures.
+	if len(signature) != ed25519.SignatureSize {
+		if signature, err = base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature))); err != nil {
+			return fmt.Errorf("malformed signature: %w", err)
+		}
+	}
+	if !ed25519.Verify(pk, SignedMessage(context, data), signature) {
+		return fmt.Errorf("invalid signature")
+	}
+	return nil
+}
+
+// VerifyChecksum verifies the SHA256 checksum of the given file against the checksums file.
+func VerifyChecksum(checksums []byte, name string, data []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || fields[1] != name {
+			continue
+		}
+		expected, err := hex.DecodeString(fields[0])
+		if err != nil {
+			return fmt.Errorf("malformed checksum of '%s': %w", name, err)
+		}
+		actual := sha256.Sum256(data)
+		if !bytes.Equal(expected, actual[:]) {
+			return fmt.Errorf("checksum mismatch of '%s'", name)
+		}
+		return nil
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return fmt.Errorf("no checksum of '%s' found", name)
+}
+
+// ExtractBinary extracts the CLI binary from the given release archive.
+func ExtractBinary(archiveName string, data []byte) ([]byte, error) {
+	if strings.HasSuffix(archiveName, ".zip") {
+		return extractZip(data)
+	}
+	return extractTarGz(data)
+}
+
+// isBinaryName returns true iff the given archive path refers to the CLI binary.
+func isBinaryName(name string) bool {
+	switch path.Base(name) {
+	case "oasis", "oasis.exe":
+		return true
+	default:
+		return false
+	}
+}
+
+func extractTarGz(data []byte) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("malformed archive: %w", err)
+	}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		switch {
+		case errors.Is(err, io.EOF):
+			return nil, fmt.Errorf("binary not found in archive")
+		case err != nil:
+			return nil, fmt.Errorf("malformed archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg || !isBinaryName(hdr.Name) {
+			continue
+		}
+		return io.ReadAll(io.LimitReader(tr, maxDownloadSize))
+	}
+}
+
+func extractZip(data []byte) ([]byte, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("malformed archive: %w", err)
+	}
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || !isBinaryName(f.Name) {
+			continue
+		}
+		rd, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("malformed archive: %w", err)
+		}
+		defer rd.Close()
+		return io.ReadAll(io.LimitReader(rd, maxDownloadSize))
+	}
+	return nil, fmt.Errorf("binary not found in archive")
+}
+
+// ReplaceExecutable atomically replaces the executable at the given path with the given binary.
+func ReplaceExecutable(fn string, binary []byte) error {
+	fi, err := os.Stat(fn)
+	if err != nil {
+		return err
+	}
+
+	// Write the new binary next to the old one, so it can be renamed over it.
+	tmp, err := os.CreateTemp(filepath.Dir(fn), "."+filepath.Base(fn)+".new-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	tmpFn := tmp.Name()
+	defer os.Remove(tmpFn)
+
+	if _, err = tmp.Write(binary); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write new binary: %w", err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write new binary: %w", err)
+	}
+	if err = os.Chmod(tmpFn, fi.Mode().Perm()|0o111); err != nil {
+		return err
+	}
+
+	// A running executable cannot be overwritten on Windows, but it can be renamed.
+	oldFn := fn + ".old"
+	_ = os.Remove(oldFn)
+	if err = os.Rename(fn, oldFn); err != nil {
+		return fmt.Errorf("failed to replace binary: %w", err)
+	}
+	if err = os.Rename(tmpFn, fn); err != nil {
+		// Restore the old binary.
+		_ = os.Rename(oldFn, fn)
+		return fmt.Errorf("failed to replace binary: %w", err)
+	}
+	_ = os.Remove(oldFn)
+	return nil
+}
+
+// IsNewer returns true iff the latest version is newer than the current one. Versions are
+// compared as semantic versions, where a pre-release is older than the corresponding release.
+// Unparsable current versions (e.g. development builds) are always considered older.
+func IsNewer(current, latest string) bool {
+	cur, curPre, ok := parseVersion(current)
+	if !ok {
+		return true
+	}
+	lat, latPre, ok := parseVersion(latest)
+	if !ok {
+		return false
+	}
+	for i := range cur {
+		if lat[i] != cur[i] {
+			return lat[i] > cur[i]
+		}
+	}
+	switch {
+	case curPre == latPre:
+		return false
+	case latPre == "":
+		return true
+	case curPre == "":
+		return false
+	default:
+		return latPre > curPre
+	}
+}
+
+// parseVersion parses a MAJOR.MINOR.PATCH[-PRERELEASE] version.
+func parseVersion(v string) ([3]int, string, bool) {
+	var parts [3]int
+	v = strings.TrimPrefix(v, "v")
+	v, _, _ = strings.Cut(v, "+")
+	v, pre, _ := strings.Cut(v, "-")
+	fields := strings.Split(v, ".")
+	if len(fields) != len(parts) {
+		return parts, "", false
+	}
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil || n < 0 {
+			return parts, "", false
+		}
+		parts[i] = n
+	}
+	return parts, pre, true
+}
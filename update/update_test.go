@@ -0,0 +1,132 @@
+package update
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveName(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal("oasis_0.12.0_linux_amd64.tar.gz", ArchiveName("0.12.0", "linux", "amd64"))
+	require.Equal("oasis_0.12.0_darwin_all.tar.gz", ArchiveName("0.12.0", "darwin", "arm64"))
+	require.Equal("oasis_0.12.0_windows_amd64.zip", ArchiveName("0.12.0", "windows", "amd64"))
+	require.Equal("SHA256SUMS-0.12.0.txt.sig", SignatureName("0.12.0"))
+}
+
+func TestIsNewer(t *testing.T) {
+	require := require.New(t)
+
+	for _, tc := range []struct {
+		current string
+		latest  string
+		newer   bool
+	}{
+		{"0.12.0", "0.12.1", true},
+		{"0.12.1", "0.12.1", false},
+		{"0.12.1", "0.12.0", false},
+		{"0.9.0", "0.10.0", true},
+		{"0.13.0-rc1", "0.13.0", true},
+		{"0.13.0", "0.13.0-rc1", false},
+		{"0.13.0-rc1", "0.13.0-rc2", true},
+		{"v0.12.0", "0.12.0", false},
+		{"0.0.0-unset", "0.12.0", true},
+		{"unknown", "0.12.0", true},
+		{"0.12.0", "garbage", false},
+	} {
+		require.Equal(tc.newer, IsNewer(tc.current, tc.latest), "%s -> %s", tc.current, tc.latest)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	require := require.New(t)
+
+	pk, sk, err := ed25519.GenerateKey(nil)
+	require.NoError(err)
+	publicKey := base64.StdEncoding.EncodeToString(pk)
+
+	archive := []byte("archive")
+	sum := sha256.Sum256(archive)
+	checksums := []byte(fmt.Sprintf("%s  other.tar.gz\n%s  oasis_0.12.0_linux_amd64.tar.gz\n", hex.EncodeToString(make([]byte, 32)), hex.EncodeToString(sum[:])))
+	sig := ed25519.Sign(sk, SignedMessage(ReleaseSignatureContext, checksums))
+
+	require.NoError(VerifySignature(publicKey, ReleaseSignatureContext, checksums, sig))
+	require.NoError(VerifySignature(publicKey, ReleaseSignatureContext, checksums, []byte(base64.StdEncoding.EncodeToString(sig)+"\n")))
+	require.ErrorContains(VerifySignature(publicKey, ReleaseSignatureContext, append(checksums, '\n'), sig), "invalid signature")
+	require.ErrorContains(VerifySignature("", ReleaseSignatureContext, checksums, sig), "no release signing key")
+	// Signatures are bound to their context.
+	require.ErrorContains(VerifySignature(publicKey, RegistrySignatureContext, checksums, sig), "invalid signature")
+	require.ErrorContains(VerifySignature(publicKey, ReleaseSignatureContext, checksums, ed25519.Sign(sk, checksums)), "invalid signature")
+
+	require.NoError(VerifyChecksum(checksums, "oasis_0.12.0_linux_amd64.tar.gz", archive))
+	require.ErrorContains(VerifyChecksum(checksums, "other.tar.gz", archive), "checksum mismatch")
+	require.ErrorContains(VerifyChecksum(checksums, "missing.tar.gz", archive), "no checksum")
+}
+
+func TestExtractBinary(t *testing.T) {
+	require := require.New(t)
+
+	var tgz bytes.Buffer
+	gw := gzip.NewWriter(&tgz)
+	tw := tar.NewWriter(gw)
+	for _, f := range []struct{ name, data string }{
+		{"oasis_0.12.0_linux_amd64/README.md", "readme"},
+		{"oasis_0.12.0_linux_amd64/oasis", "binary"},
+	} {
+		require.NoError(tw.WriteHeader(&tar.Header{Name: f.name, Mode: 0o755, Size: int64(len(f.data)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(f.data))
+		require.NoError(err)
+	}
+	require.NoError(tw.Close())
+	require.NoError(gw.Close())
+
+	binary, err := ExtractBinary("oasis_0.12.0_linux_amd64.tar.gz", tgz.Bytes())
+	require.NoError(err)
+	require.Equal([]byte("binary"), binary)
+
+	var zb bytes.Buffer
+	zw := zip.NewWriter(&zb)
+	w, err := zw.Create("oasis_0.12.0_windows_amd64/oasis.exe")
+	require.NoError(err)
+	_, err = w.Write([]byte("binary.exe"))
+	require.NoError(err)
+	require.NoError(zw.Close())
+
+	binary, err = ExtractBinary("oasis_0.12.0_windows_amd64.zip", zb.Bytes())
+	require.NoError(err)
+	require.Equal([]byte("binary.exe"), binary)
+
+	_, err = ExtractBinary("oasis.zip", zb.Bytes()[:10])
+	require.ErrorContains(err, "malformed archive")
+}
+
+func TestReplaceExecutable(t *testing.T) {
+	require := require.New(t)
+
+	fn := filepath.Join(t.TempDir(), "oasis")
+	require.NoError(os.WriteFile(fn, []byte("old"), 0o755))
+	require.NoError(ReplaceExecutable(fn, []byte("new")))
+
+	data, err := os.ReadFile(fn)
+	require.NoError(err)
+	require.Equal([]byte("new"), data)
+	fi, err := os.Stat(fn)
+	require.NoError(err)
+	require.Equal(os.FileMode(0o755), fi.Mode().Perm())
+
+	entries, err := os.ReadDir(filepath.Dir(fn))
+	require.NoError(err)
+	require.Len(entries, 1)
+}
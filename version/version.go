@@ -12,6 +12,10 @@ var (
 	// Software represents the Oasis CLI version and should be set by the linker.
 	Software = "0.0.0-unset"
 
+	// ReleaseSigningKey is the Base64-encoded Ed25519 public key used to verify CLI releases and
+	// should be set by the linker.
+	ReleaseSigningKey = ""
+
 	// Toolchain is the version of the Go compiler/standard library.
 	Toolchain = runtime.Version()
 )
@@ -4,9 +4,12 @@ import (
 	"encoding/base64"
 	"fmt"
 
+	"github.com/tyler-smith/go-bip39"
+
 	"github.com/oasisprotocol/curve25519-voi/primitives/ed25519"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/sakg"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/slip10"
 	sdkSignature "github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
 	ed255192 "github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/ed25519"
 )
@@ -21,6 +24,28 @@ func Ed25519FromMnemonic(mnemonic string, number uint32) (sdkSignature.Signer, [
 	return ed255192.WrapSigner(signer), signer.(signature.UnsafeSigner).UnsafeBytes(), nil
 }
 
+// Ed25519FromMnemonicPath derives a signer using SLIP-10 with the given derivation path from given
+// mnemonic.
+func Ed25519FromMnemonicPath(mnemonic string, path sakg.BIP32Path) (sdkSignature.Signer, []byte, error) {
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, nil, fmt.Errorf("failed to derive key from mnemonic: invalid mnemonic")
+	}
+	seed := bip39.NewSeed(mnemonic, "")
+
+	signer, chainCode, err := slip10.NewMasterKey(seed)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to derive master key: %w", err)
+	}
+	for _, index := range path {
+		signer, chainCode, err = slip10.NewChildKey(signer, chainCode, index)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to derive child key: %w", err)
+		}
+	}
+
+	return ed255192.WrapSigner(signer), signer.(signature.UnsafeSigner).UnsafeBytes(), nil
+}
+
 // ed25519rawSigner is an in-memory signer that allows deserialization of raw ed25519 keys for use
 // in imported accounts that don't use ADR 0008.
 type ed25519rawSigner struct {
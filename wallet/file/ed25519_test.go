@@ -4,6 +4,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/cli/config"
+	"github.com/oasisprotocol/cli/wallet"
 )
 
 func TestEd25519FromMnemonic(t *testing.T) {
@@ -33,3 +36,64 @@ func TestEd25519FromMnemonic(t *testing.T) {
 		}
 	}
 }
+
+func TestEd25519FromMnemonicPath(t *testing.T) {
+	require := require.New(t)
+
+	mnemonic := "equip will roof matter pink blind book anxiety banner elbow sun young"
+
+	// The ADR-8 derivation path must match the key number derivation.
+	path, err := wallet.ParseDerivationPath(wallet.AlgorithmEd25519Adr8, "m/44'/474'/3'")
+	require.NoError(err)
+	signer, _, err := Ed25519FromMnemonicPath(mnemonic, path)
+	require.NoError(err)
+	require.Equal("klSQRiFP20cpv3pu5KO70PRjxHasyTOyx8zghFCavuQ=", signer.Public().String())
+
+	path, err = wallet.ParseDerivationPath(wallet.AlgorithmEd25519Adr8, "m/44'/474'/5'/0'/3'")
+	require.NoError(err)
+	signer, _, err = Ed25519FromMnemonicPath(mnemonic, path)
+	require.NoError(err)
+	require.NotEqual("klSQRiFP20cpv3pu5KO70PRjxHasyTOyx8zghFCavuQ=", signer.Public().String())
+
+	for _, invalid := range []string{"m/44'/474'/5'/0/3", "m/44'/60'/0'", "m/44'", "44'/474'/0'"} {
+		_, err = wallet.ParseDerivationPath(wallet.AlgorithmEd25519Adr8, invalid)
+		require.Error(err, invalid)
+	}
+	_, err = wallet.ParseDerivationPath(wallet.AlgorithmEd25519Raw, "m/44'/474'/0'")
+	require.Error(err)
+}
+
+func TestSubAccountWithDerivationPath(t *testing.T) {
+	require := require.New(t)
+
+	mnemonic := "equip will roof matter pink blind book anxiety banner elbow sun young"
+	acc := &config.Account{
+		Kind: Kind,
+		Config: map[string]interface{}{
+			"algorithm":       wallet.AlgorithmEd25519Adr8,
+			"derivation_path": "m/44'/474'/5'/0'/0'",
+		},
+	}
+
+	derive := func(number uint32) string {
+		sub, err := acc.SubAccount(number)
+		require.NoError(err)
+		var cfg wallet.AccountConfig
+		require.NoError(cfg.UnmarshalMap(sub.Config))
+		signer, _, err := signerFromMnemonic(&cfg, mnemonic)
+		require.NoError(err)
+		return signer.Public().String()
+	}
+
+	parent := func() string {
+		var cfg wallet.AccountConfig
+		require.NoError(cfg.UnmarshalMap(acc.Config))
+		signer, _, err := signerFromMnemonic(&cfg, mnemonic)
+		require.NoError(err)
+		return signer.Public().String()
+	}()
+
+	require.Equal(parent, derive(0))
+	require.NotEqual(derive(0), derive(1))
+	require.NotEqual(parent, derive(1))
+}
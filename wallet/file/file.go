@@ -32,8 +32,9 @@ const (
 	// Kind is the account kind for the file-backed accounts.
 	Kind = "file"
 
-	cfgAlgorithm = "file.algorithm"
-	cfgNumber    = "file.number"
+	cfgAlgorithm      = "file.algorithm"
+	cfgNumber         = "file.number"
+	cfgDerivationPath = "file.derivation-path"
 
 	stateKeySize   = 32
 	stateNonceSize = 32
@@ -182,11 +183,14 @@ func (af *fileAccountFactory) PrettyKind(rawCfg map[string]interface{}) string {
 		return ""
 	}
 
-	// In case of ADR8 or BIP44 show the keypair number.
+	// In case of ADR8 or BIP44 show the keypair number or the custom derivation path.
 	var number string
 	switch cfg.Algorithm {
 	case wallet.AlgorithmEd25519Adr8, wallet.AlgorithmSecp256k1Bip44, wallet.AlgorithmSr25519Adr8:
 		number = fmt.Sprintf(":%d", cfg.Number)
+		if cfg.DerivationPath != "" {
+			number = ":" + cfg.DerivationPath
+		}
 	}
 	return fmt.Sprintf("%s (%s%s)", Kind, cfg.Algorithm, number)
 }
@@ -199,6 +203,9 @@ func (af *fileAccountFactory) GetConfigFromFlags() (map[string]interface{}, erro
 	cfg := make(map[string]interface{})
 	cfg["algorithm"], _ = af.flags.GetString(cfgAlgorithm)
 	cfg["number"], _ = af.flags.GetUint32(cfgNumber)
+	if path, _ := af.flags.GetString(cfgDerivationPath); path != "" {
+		cfg["derivation_path"] = path
+	}
 	return cfg, nil
 }
 
@@ -329,6 +336,9 @@ func (af *fileAccountFactory) Create(name string, passphrase string, rawCfg map[
 	if err := cfg.UnmarshalMap(rawCfg); err != nil {
 		return nil, err
 	}
+	if _, err := cfg.GetDerivationPath(); err != nil {
+		return nil, err
+	}
 
 	// Generate entropy.
 	entropy, err := bip39.NewEntropy(256)
@@ -423,6 +433,9 @@ func (af *fileAccountFactory) Import(name string, passphrase string, rawCfg map[
 	default:
 		return nil, fmt.Errorf("unsupported import kind: %s", src.Kind)
 	}
+	if _, err := cfg.GetDerivationPath(); err != nil {
+		return nil, err
+	}
 
 	state := secretState{
 		Algorithm: cfg.Algorithm,
@@ -451,6 +464,35 @@ func (af *fileAccountFactory) Import(name string, passphrase string, rawCfg map[
 	return acc, nil
 }
 
+// signerFromMnemonic derives the signer and the private key of a mnemonic-based account using the
+// custom derivation path, if configured, or the key number with the default derivation path.
+func signerFromMnemonic(cfg *wallet.AccountConfig, mnemonic string) (signature.Signer, []byte, error) {
+	path, err := cfg.GetDerivationPath()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch cfg.Algorithm {
+	case wallet.AlgorithmEd25519Adr8:
+		if path != nil {
+			return Ed25519FromMnemonicPath(mnemonic, path)
+		}
+		return Ed25519FromMnemonic(mnemonic, cfg.Number)
+	case wallet.AlgorithmSecp256k1Bip44:
+		if path != nil {
+			return Secp256k1FromMnemonicPath(mnemonic, path)
+		}
+		return Secp256k1FromMnemonic(mnemonic, cfg.Number)
+	case wallet.AlgorithmSr25519Adr8:
+		if path != nil {
+			return Sr25519FromMnemonicPath(mnemonic, path)
+		}
+		return Sr25519FromMnemonic(mnemonic, cfg.Number)
+	default:
+		return nil, nil, fmt.Errorf("algorithm '%s' does not support derivation from mnemonic", cfg.Algorithm)
+	}
+}
+
 type fileAccount struct {
 	cfg    *wallet.AccountConfig
 	state  *secretState
@@ -461,7 +503,7 @@ func newAccount(state *secretState, cfg *wallet.AccountConfig) (wallet.Account,
 	switch state.Algorithm {
 	case wallet.AlgorithmEd25519Adr8:
 		// For Ed25519 use the ADR 0008 derivation scheme.
-		signer, _, err := Ed25519FromMnemonic(state.Data, cfg.Number)
+		signer, _, err := signerFromMnemonic(cfg, state.Data)
 		if err != nil {
 			return nil, fmt.Errorf("failed to derive signer: %w", err)
 		}
@@ -485,7 +527,7 @@ func newAccount(state *secretState, cfg *wallet.AccountConfig) (wallet.Account,
 		}, nil
 	case wallet.AlgorithmSecp256k1Bip44:
 		// For Secp256k1-BIP-44 use the BIP-44 derivation scheme.
-		signer, _, err := Secp256k1FromMnemonic(state.Data, cfg.Number)
+		signer, _, err := signerFromMnemonic(cfg, state.Data)
 		if err != nil {
 			return nil, fmt.Errorf("failed to initialize signer: %w", err)
 		}
@@ -508,7 +550,7 @@ func newAccount(state *secretState, cfg *wallet.AccountConfig) (wallet.Account,
 		}, nil
 	case wallet.AlgorithmSr25519Adr8:
 		// For Sr25519 use the ADR 0008 derivation scheme.
-		signer, _, err := Sr25519FromMnemonic(state.Data, cfg.Number)
+		signer, _, err := signerFromMnemonic(cfg, state.Data)
 		if err != nil {
 			return nil, fmt.Errorf("failed to initialize signer: %w", err)
 		}
@@ -595,16 +637,12 @@ func (a *fileAccount) UnsafeExport() (string, string) {
 
 	// For convenience derive the corresponding private key of the mnemonic.
 	key := ""
+	_, sk, _ := signerFromMnemonic(a.cfg, a.state.Data)
 	switch a.cfg.Algorithm {
-	case wallet.AlgorithmEd25519Adr8:
-		_, sk, _ := Ed25519FromMnemonic(a.state.Data, a.cfg.Number)
+	case wallet.AlgorithmEd25519Adr8, wallet.AlgorithmSr25519Adr8:
 		key = base64.StdEncoding.EncodeToString(sk)
 	case wallet.AlgorithmSecp256k1Bip44:
-		_, sk, _ := Secp256k1FromMnemonic(a.state.Data, a.cfg.Number)
 		key = hex.EncodeToString(sk)
-	case wallet.AlgorithmSr25519Adr8:
-		_, sk, _ := Sr25519FromMnemonic(a.state.Data, a.cfg.Number)
-		key = base64.StdEncoding.EncodeToString(sk)
 	}
 
 	return key, mnemonic
@@ -614,6 +652,7 @@ func init() {
 	flags := flag.NewFlagSet("", flag.ContinueOnError)
 	flags.String(cfgAlgorithm, wallet.AlgorithmEd25519Adr8, fmt.Sprintf("Cryptographic algorithm to use for this account [%s, %s, %s]", wallet.AlgorithmEd25519Adr8, wallet.AlgorithmSecp256k1Bip44, wallet.AlgorithmSr25519Adr8))
 	flags.Uint32(cfgNumber, 0, "Key number to use in the key derivation scheme")
+	flags.String(cfgDerivationPath, "", "Custom derivation path to use instead of the key number (e.g. m/44'/474'/5'/0'/3')")
 
 	wallet.Register(&fileAccountFactory{
 		flags: flags,
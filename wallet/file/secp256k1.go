@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/ethereum/go-ethereum/accounts"
 	hdwallet "github.com/miguelmota/go-ethereum-hdwallet"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/sakg"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	sdkSignature "github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/secp256k1"
@@ -21,12 +23,18 @@ const (
 
 // Secp256k1FromMnemonic derives a signer using BIP-44 from given mnemonic.
 func Secp256k1FromMnemonic(mnemonic string, number uint32) (sdkSignature.Signer, []byte, error) {
+	path := hdwallet.MustParseDerivationPath(fmt.Sprintf(Bip44DerivationPath, number))
+	return Secp256k1FromMnemonicPath(mnemonic, sakg.BIP32Path(path))
+}
+
+// Secp256k1FromMnemonicPath derives a signer using BIP-32 with the given derivation path from
+// given mnemonic.
+func Secp256k1FromMnemonicPath(mnemonic string, path sakg.BIP32Path) (sdkSignature.Signer, []byte, error) {
 	wallet, err := hdwallet.NewFromMnemonic(mnemonic)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to parse mnemonic: %w", err)
 	}
-	path := hdwallet.MustParseDerivationPath(fmt.Sprintf(Bip44DerivationPath, number))
-	account, err := wallet.Derive(path, false)
+	account, err := wallet.Derive(accounts.DerivationPath(path), false)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to derive key from mnemonic: %w", err)
 	}
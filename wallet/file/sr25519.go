@@ -27,6 +27,18 @@ func Sr25519FromMnemonic(mnemonic string, number uint32) (sdkSignature.Signer, [
 		)
 	}
 
+	pathStr := fmt.Sprintf("%s/%d'", sakg.BIP32PathPrefix, number)
+	path, err := sakg.NewBIP32Path(pathStr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("sakg: error creating BIP-0032 path %s: %w", pathStr, err)
+	}
+
+	return Sr25519FromMnemonicPath(mnemonic, path)
+}
+
+// Sr25519FromMnemonicPath derives a signer using SLIP-10 with the given derivation path from given
+// mnemonic.
+func Sr25519FromMnemonicPath(mnemonic string, path sakg.BIP32Path) (sdkSignature.Signer, []byte, error) {
 	if !bip39.IsMnemonicValid(mnemonic) {
 		return nil, nil, fmt.Errorf("sakg: invalid mnemonic")
 	}
@@ -38,12 +50,6 @@ func Sr25519FromMnemonic(mnemonic string, number uint32) (sdkSignature.Signer, [
 		return nil, nil, fmt.Errorf("sakg: error deriving master key: %w", err)
 	}
 
-	pathStr := fmt.Sprintf("%s/%d'", sakg.BIP32PathPrefix, number)
-	path, err := sakg.NewBIP32Path(pathStr)
-	if err != nil {
-		return nil, nil, fmt.Errorf("sakg: error creating BIP-0032 path %s: %w", pathStr, err)
-	}
-
 	var signer sdkSignature.Signer
 	for _, index := range path {
 		signer, chainCode, skBinary, skCanBinary, err = newChildKey(skBinary, chainCode, index)
@@ -5,6 +5,10 @@ import (
 	"encoding/binary"
 	"fmt"
 	"io"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/sakg"
+
+	"github.com/oasisprotocol/cli/wallet"
 )
 
 func getAdr0008Path(number uint32) []uint32 {
@@ -19,6 +23,37 @@ func getBip44Path(number uint32) []uint32 {
 	return []uint32{44, 60, 0, 0, number}
 }
 
+// getCustomPath parses and validates the given custom derivation path and returns it with the
+// hardened bits cleared as expected by the serialization functions. It returns nil if no custom
+// derivation path is given.
+func getCustomPath(algorithm, rawPath string) ([]uint32, error) {
+	if rawPath == "" {
+		return nil, nil
+	}
+	bip32Path, err := wallet.ParseDerivationPath(algorithm, rawPath)
+	if err != nil {
+		return nil, err
+	}
+
+	path := make([]uint32, 0, len(bip32Path))
+	for _, c := range bip32Path {
+		path = append(path, c&^sakg.HardenedKeysIndexStart)
+	}
+
+	switch algorithm {
+	case wallet.AlgorithmSecp256k1Bip44:
+		// Only the hardened components are passed to the device.
+		if path[3] != 0 || path[4] != 0 {
+			return nil, fmt.Errorf("ledger: derivation path for %s must end with /0/0", algorithm)
+		}
+	default:
+		if len(path) != 3 && len(path) != 5 {
+			return nil, fmt.Errorf("ledger: derivation path for %s must contain either 3 or 5 components", algorithm)
+		}
+	}
+	return path, nil
+}
+
 func getSerializedPath(path []uint32) ([]byte, error) {
 	message := make([]byte, 4*len(path))
 	switch len(path) {
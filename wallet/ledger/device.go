@@ -197,6 +197,9 @@ func (ld *ledgerDevice) getPublicKeyRaw(pathBytes []byte, ins byte, requireConfi
 
 	response, err := ld.raw.Exchange(message)
 	if err != nil {
+		if requireConfirmation && err.Error() == errMsgRejected {
+			return nil, fmt.Errorf("ledger: address rejected by user")
+		}
 		return nil, fmt.Errorf("ledger: failed to request public key: %w", err)
 	}
 	return response, nil
@@ -22,8 +22,9 @@ const (
 	// Kind is the account kind for the ledger-backed accounts.
 	Kind = "ledger"
 
-	cfgAlgorithm = "ledger.algorithm"
-	cfgNumber    = "ledger.number"
+	cfgAlgorithm      = "ledger.algorithm"
+	cfgNumber         = "ledger.number"
+	cfgDerivationPath = "ledger.derivation-path"
 )
 
 type ledgerAccountFactory struct {
@@ -45,6 +46,9 @@ func (af *ledgerAccountFactory) PrettyKind(rawCfg map[string]interface{}) string
 	if algorithm == "" {
 		algorithm = wallet.AlgorithmEd25519Adr8
 	}
+	if cfg.DerivationPath != "" {
+		return fmt.Sprintf("%s (%s:%s)", af.Kind(), algorithm, cfg.DerivationPath)
+	}
 	return fmt.Sprintf("%s (%s:%d)", af.Kind(), algorithm, cfg.Number)
 }
 
@@ -56,6 +60,9 @@ func (af *ledgerAccountFactory) GetConfigFromFlags() (map[string]interface{}, er
 	cfg := make(map[string]interface{})
 	cfg["algorithm"], _ = af.flags.GetString(cfgAlgorithm)
 	cfg["number"], _ = af.flags.GetUint32(cfgNumber)
+	if path, _ := af.flags.GetString(cfgDerivationPath); path != "" {
+		cfg["derivation_path"] = path
+	}
 	return cfg, nil
 }
 
@@ -152,6 +159,15 @@ type ledgerAccount struct {
 }
 
 func newAccount(cfg *wallet.AccountConfig) (wallet.Account, error) {
+	algorithm := cfg.Algorithm
+	if algorithm == "" {
+		algorithm = wallet.AlgorithmEd25519Adr8
+	}
+	customPath, err := getCustomPath(algorithm, cfg.DerivationPath)
+	if err != nil {
+		return nil, err
+	}
+
 	// Connect to device.
 	dev, err := connectToDevice()
 	if err != nil {
@@ -168,6 +184,9 @@ func newAccount(cfg *wallet.AccountConfig) (wallet.Account, error) {
 		if cfg.Algorithm == wallet.AlgorithmEd25519Legacy {
 			path = getLegacyPath(cfg.Number)
 		}
+		if customPath != nil {
+			path = customPath
+		}
 		rawPk, err := dev.GetPublicKey25519(path, wallet.AlgorithmEd25519Adr8, false)
 		if err != nil {
 			_ = dev.Close()
@@ -189,6 +208,9 @@ func newAccount(cfg *wallet.AccountConfig) (wallet.Account, error) {
 		pk = ed25519pk
 	case wallet.AlgorithmSecp256k1Bip44:
 		path = getBip44Path(cfg.Number)
+		if customPath != nil {
+			path = customPath
+		}
 		rawPk, err := dev.GetPublicKeySecp256k1(path, false)
 		if err != nil {
 			_ = dev.Close()
@@ -201,6 +223,9 @@ func newAccount(cfg *wallet.AccountConfig) (wallet.Account, error) {
 		pk = secp256k1pk
 	case wallet.AlgorithmSr25519Adr8:
 		path = getAdr0008Path(cfg.Number)
+		if customPath != nil {
+			path = customPath
+		}
 		rawPk, err := dev.GetPublicKey25519(path, wallet.AlgorithmSr25519Adr8, false)
 		if err != nil {
 			_ = dev.Close()
@@ -277,10 +302,54 @@ func (a *ledgerAccount) UnsafeExport() (string, string) {
 	return "", ""
 }
 
+func (a *ledgerAccount) VerifyAddress() error {
+	var pk signature.PublicKey
+	switch a.cfg.Algorithm {
+	case "", wallet.AlgorithmEd25519Legacy, wallet.AlgorithmEd25519Adr8:
+		rawPk, err := a.signer.dev.GetPublicKey25519(a.signer.path, wallet.AlgorithmEd25519Adr8, true)
+		if err != nil {
+			return err
+		}
+		var ed25519pk ed25519.PublicKey
+		if err = ed25519pk.UnmarshalBinary(rawPk); err != nil {
+			return fmt.Errorf("ledger: got malformed public key: %w", err)
+		}
+		pk = ed25519pk
+	case wallet.AlgorithmSecp256k1Bip44:
+		rawPk, err := a.signer.dev.GetPublicKeySecp256k1(a.signer.path, true)
+		if err != nil {
+			return err
+		}
+		var secp256k1pk secp256k1.PublicKey
+		if err = secp256k1pk.UnmarshalBinary(rawPk); err != nil {
+			return fmt.Errorf("ledger: got malformed public key: %w", err)
+		}
+		pk = secp256k1pk
+	case wallet.AlgorithmSr25519Adr8:
+		rawPk, err := a.signer.dev.GetPublicKey25519(a.signer.path, wallet.AlgorithmSr25519Adr8, true)
+		if err != nil {
+			return err
+		}
+		var sr25519pk sr25519.PublicKey
+		if err = sr25519pk.UnmarshalBinary(rawPk); err != nil {
+			return fmt.Errorf("ledger: got malformed public key: %w", err)
+		}
+		pk = sr25519pk
+	default:
+		return fmt.Errorf("unsupported algorithm %s", a.cfg.Algorithm)
+	}
+
+	if !pk.Equal(a.signer.pk) {
+		return fmt.Errorf("ledger: device derived a different public key than when the account was loaded")
+	}
+	return nil
+}
+
 func init() {
 	flags := flag.NewFlagSet("", flag.ContinueOnError)
 	flags.String(cfgAlgorithm, wallet.AlgorithmEd25519Legacy, fmt.Sprintf("Cryptographic algorithm to use for this account [%s, %s, %s, %s]", wallet.AlgorithmEd25519Legacy, wallet.AlgorithmEd25519Adr8, wallet.AlgorithmSecp256k1Bip44, wallet.AlgorithmSr25519Adr8))
 	flags.Uint32(cfgNumber, 0, "Key number to use in the derivation scheme")
+	flags.String(cfgDerivationPath, "", "Custom derivation path to use instead of the key number (e.g. m/44'/474'/5'/0'/3')")
 
 	wallet.Register(&ledgerAccountFactory{
 		flags: flags,
@@ -0,0 +1,283 @@
+package multisig
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/mitchellh/mapstructure"
+	flag "github.com/spf13/pflag"
+
+	coreSignature "github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/ed25519"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/secp256k1"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/sr25519"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+
+	"github.com/oasisprotocol/cli/wallet"
+)
+
+const (
+	// Kind is the account kind for the multisig accounts.
+	Kind = "multisig"
+
+	cfgThreshold = "multisig.threshold"
+	cfgSigners   = "multisig.signers"
+)
+
+// SignerConfig is the configuration of a single multisig signer.
+type SignerConfig struct {
+	// PublicKey is the public key of the signer in the <algorithm>:<base64 public key> form.
+	PublicKey string `mapstructure:"public_key"`
+	// Weight is the weight of the signer's signature.
+	Weight uint64 `mapstructure:"weight"`
+}
+
+// AccountConfig is the configuration of a multisig account.
+type AccountConfig struct {
+	// Threshold is the total weight of signatures required to authenticate.
+	Threshold uint64 `mapstructure:"threshold"`
+	// Signers are the signers of the account.
+	Signers []SignerConfig `mapstructure:"signers"`
+}
+
+// UnmarshalMap imports the config map to AccountConfig.
+func (ac *AccountConfig) UnmarshalMap(raw map[string]interface{}) error {
+	if raw == nil {
+		return fmt.Errorf("missing configuration")
+	}
+	return mapstructure.Decode(raw, ac)
+}
+
+// MultisigConfig returns the validated multisig configuration.
+func (ac *AccountConfig) MultisigConfig() (*types.MultisigConfig, error) {
+	cfg := types.MultisigConfig{
+		Threshold: ac.Threshold,
+	}
+	for i, s := range ac.Signers {
+		pk, err := ParsePublicKey(s.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("signer %d: %w", i, err)
+		}
+		cfg.Signers = append(cfg.Signers, types.MultisigSigner{
+			PublicKey: pk,
+			Weight:    s.Weight,
+		})
+	}
+	if err := cfg.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("bad multisig configuration: %w", err)
+	}
+	return &cfg, nil
+}
+
+// ParsePublicKey parses a public key in the <algorithm>:<base64 public key> form, where the
+// algorithm is one of ed25519, secp256k1 or sr25519.
+func ParsePublicKey(raw string) (types.PublicKey, error) {
+	algorithm, data, ok := strings.Cut(raw, ":")
+	if !ok {
+		return types.PublicKey{}, fmt.Errorf("malformed public key '%s' (expected <algorithm>:<public key>)", raw)
+	}
+
+	var pk signature.PublicKey
+	var err error
+	switch algorithm {
+	case "ed25519":
+		var edPk ed25519.PublicKey
+		err = edPk.UnmarshalText([]byte(data))
+		pk = edPk
+	case "secp256k1":
+		var secpPk secp256k1.PublicKey
+		err = secpPk.UnmarshalText([]byte(data))
+		pk = secpPk
+	case "sr25519":
+		var srPk sr25519.PublicKey
+		err = srPk.UnmarshalText([]byte(data))
+		pk = srPk
+	default:
+		return types.PublicKey{}, fmt.Errorf("unsupported public key algorithm '%s'", algorithm)
+	}
+	if err != nil {
+		return types.PublicKey{}, fmt.Errorf("malformed %s public key: %w", algorithm, err)
+	}
+	return types.PublicKey{PublicKey: pk}, nil
+}
+
+// ParseSigner parses a signer in the <algorithm>:<base64 public key>[:<weight>] form. The weight
+// defaults to 1.
+func ParseSigner(raw string) (*SignerConfig, error) {
+	pk, weight := raw, uint64(1)
+	if idx := strings.LastIndex(raw, ":"); idx > strings.Index(raw, ":") {
+		var err error
+		if weight, err = strconv.ParseUint(raw[idx+1:], 10, 64); err != nil {
+			return nil, fmt.Errorf("malformed weight of signer '%s': %w", raw, err)
+		}
+		pk = raw[:idx]
+	}
+	if _, err := ParsePublicKey(pk); err != nil {
+		return nil, err
+	}
+	return &SignerConfig{PublicKey: pk, Weight: weight}, nil
+}
+
+// SignerAddress returns the address of the account with the given signer public key.
+func SignerAddress(pk types.PublicKey) types.Address {
+	switch inner := pk.PublicKey.(type) {
+	case ed25519.PublicKey:
+		return types.NewAddress(types.NewSignatureAddressSpecEd25519(inner))
+	case secp256k1.PublicKey:
+		return types.NewAddress(types.NewSignatureAddressSpecSecp256k1Eth(inner))
+	case sr25519.PublicKey:
+		return types.NewAddress(types.NewSignatureAddressSpecSr25519(inner))
+	default:
+		return types.Address{}
+	}
+}
+
+type multisigAccountFactory struct {
+	flags *flag.FlagSet
+}
+
+func (af *multisigAccountFactory) Kind() string {
+	return Kind
+}
+
+func (af *multisigAccountFactory) PrettyKind(rawCfg map[string]interface{}) string {
+	var cfg AccountConfig
+	if err := cfg.UnmarshalMap(rawCfg); err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%s (threshold %d, %d signers)", af.Kind(), cfg.Threshold, len(cfg.Signers))
+}
+
+func (af *multisigAccountFactory) Flags() *flag.FlagSet {
+	return af.flags
+}
+
+func (af *multisigAccountFactory) GetConfigFromFlags() (map[string]interface{}, error) {
+	threshold, _ := af.flags.GetUint64(cfgThreshold)
+	rawSigners, _ := af.flags.GetStringSlice(cfgSigners)
+
+	signers := make([]map[string]interface{}, 0, len(rawSigners))
+	for _, raw := range rawSigners {
+		s, err := ParseSigner(raw)
+		if err != nil {
+			return nil, err
+		}
+		signers = append(signers, map[string]interface{}{
+			"public_key": s.PublicKey,
+			"weight":     s.Weight,
+		})
+	}
+	return map[string]interface{}{
+		"threshold": threshold,
+		"signers":   signers,
+	}, nil
+}
+
+func (af *multisigAccountFactory) GetConfigFromSurvey(_ *wallet.ImportKind) (map[string]interface{}, error) {
+	return nil, fmt.Errorf("multisig: import not supported")
+}
+
+func (af *multisigAccountFactory) DataPrompt(_ wallet.ImportKind, _ map[string]interface{}) survey.Prompt {
+	return nil
+}
+
+func (af *multisigAccountFactory) DataValidator(_ wallet.ImportKind, _ map[string]interface{}) survey.Validator {
+	return nil
+}
+
+func (af *multisigAccountFactory) RequiresPassphrase() bool {
+	return false
+}
+
+func (af *multisigAccountFactory) SupportedImportKinds() []wallet.ImportKind {
+	return []wallet.ImportKind{}
+}
+
+func (af *multisigAccountFactory) HasConsensusSigner(_ map[string]interface{}) bool {
+	return false
+}
+
+func (af *multisigAccountFactory) Migrate(_ map[string]interface{}) bool {
+	return false
+}
+
+func (af *multisigAccountFactory) Create(_ string, _ string, rawCfg map[string]interface{}) (wallet.Account, error) {
+	return newAccount(rawCfg)
+}
+
+func (af *multisigAccountFactory) Load(_ string, _ string, rawCfg map[string]interface{}) (wallet.Account, error) {
+	return newAccount(rawCfg)
+}
+
+func (af *multisigAccountFactory) Remove(_ string, _ map[string]interface{}) error {
+	return nil
+}
+
+func (af *multisigAccountFactory) Rename(_, _ string, _ map[string]interface{}) error {
+	return nil
+}
+
+func (af *multisigAccountFactory) Import(_ string, _ string, _ map[string]interface{}, _ *wallet.ImportSource) (wallet.Account, error) {
+	return nil, fmt.Errorf("multisig: import not supported")
+}
+
+type multisigAccount struct {
+	cfg *types.MultisigConfig
+}
+
+func newAccount(rawCfg map[string]interface{}) (wallet.Account, error) {
+	var cfg AccountConfig
+	if err := cfg.UnmarshalMap(rawCfg); err != nil {
+		return nil, err
+	}
+	msCfg, err := cfg.MultisigConfig()
+	if err != nil {
+		return nil, err
+	}
+	return &multisigAccount{cfg: msCfg}, nil
+}
+
+func (a *multisigAccount) ConsensusSigner() coreSignature.Signer {
+	// The consensus layer does not support multisig accounts.
+	return nil
+}
+
+func (a *multisigAccount) Signer() signature.Signer {
+	// Signatures are provided by the accounts of the individual signers.
+	return nil
+}
+
+func (a *multisigAccount) Address() types.Address {
+	return types.NewAddressFromMultisig(a.cfg)
+}
+
+func (a *multisigAccount) EthAddress() *ethCommon.Address {
+	return nil
+}
+
+func (a *multisigAccount) SignatureAddressSpec() types.SignatureAddressSpec {
+	return types.SignatureAddressSpec{}
+}
+
+func (a *multisigAccount) UnsafeExport() (string, string) {
+	// There is no secret.
+	return "", ""
+}
+
+func (a *multisigAccount) MultisigConfig() *types.MultisigConfig {
+	return a.cfg
+}
+
+func init() {
+	flags := flag.NewFlagSet("", flag.ContinueOnError)
+	flags.Uint64(cfgThreshold, 1, "Total weight of signatures required to authenticate the multisig account")
+	flags.StringSlice(cfgSigners, nil, "Signers of the multisig account in the <algorithm>:<public key>[:<weight>] form")
+
+	wallet.Register(&multisigAccountFactory{
+		flags: flags,
+	})
+}
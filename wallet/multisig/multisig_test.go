@@ -0,0 +1,74 @@
+package multisig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdkTesting "github.com/oasisprotocol/oasis-sdk/client-sdk/go/testing"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+
+	"github.com/oasisprotocol/cli/wallet"
+)
+
+func TestParseSigner(t *testing.T) {
+	require := require.New(t)
+
+	alicePk := "ed25519:" + sdkTesting.Alice.Signer.Public().String()
+	davePk := "secp256k1:" + sdkTesting.Dave.Signer.Public().String()
+
+	s, err := ParseSigner(alicePk)
+	require.NoError(err)
+	require.Equal(&SignerConfig{PublicKey: alicePk, Weight: 1}, s)
+
+	s, err = ParseSigner(davePk + ":3")
+	require.NoError(err)
+	require.Equal(&SignerConfig{PublicKey: davePk, Weight: 3}, s)
+
+	_, err = ParseSigner(alicePk + ":x")
+	require.ErrorContains(err, "malformed weight")
+	_, err = ParseSigner("rsa:AAAA")
+	require.ErrorContains(err, "unsupported public key algorithm")
+	_, err = ParseSigner("ed25519:AAAA")
+	require.ErrorContains(err, "malformed ed25519 public key")
+	_, err = ParseSigner(sdkTesting.Alice.Signer.Public().String())
+	require.ErrorContains(err, "malformed public key")
+
+	// Signer addresses match the addresses of the corresponding accounts.
+	pk, err := ParsePublicKey(alicePk)
+	require.NoError(err)
+	require.Equal(sdkTesting.Alice.Address, SignerAddress(pk))
+	pk, err = ParsePublicKey(davePk)
+	require.NoError(err)
+	require.Equal(sdkTesting.Dave.Address, SignerAddress(pk))
+}
+
+func TestMultisigAccount(t *testing.T) {
+	require := require.New(t)
+
+	af, err := wallet.Load(Kind)
+	require.NoError(err)
+
+	rawCfg := map[string]interface{}{
+		"threshold": 3,
+		"signers": []map[string]interface{}{
+			{"public_key": "ed25519:" + sdkTesting.Alice.Signer.Public().String(), "weight": 1},
+			{"public_key": "ed25519:" + sdkTesting.Bob.Signer.Public().String(), "weight": 1},
+		},
+	}
+	_, err = af.Load("ms", "", rawCfg)
+	require.ErrorContains(err, "impossible threshold")
+
+	rawCfg["threshold"] = 2
+	acc, err := af.Load("ms", "", rawCfg)
+	require.NoError(err)
+	require.Nil(acc.Signer())
+	require.Nil(acc.ConsensusSigner())
+	require.False(af.HasConsensusSigner(rawCfg))
+	require.Equal("multisig (threshold 2, 2 signers)", af.PrettyKind(rawCfg))
+
+	msAcc, ok := acc.(wallet.MultisigAccount)
+	require.True(ok)
+	require.EqualValues(2, msAcc.MultisigConfig().Threshold)
+	require.Equal(types.NewAddressFromMultisig(msAcc.MultisigConfig()), acc.Address())
+}
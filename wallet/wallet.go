@@ -9,6 +9,7 @@ import (
 	"github.com/mitchellh/mapstructure"
 	flag "github.com/spf13/pflag"
 
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/sakg"
 	coreSignature "github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
@@ -38,6 +39,8 @@ const (
 type AccountConfig struct {
 	Algorithm string `mapstructure:"algorithm"`
 	Number    uint32 `mapstructure:"number,omitempty"`
+	// DerivationPath is the custom derivation path overriding the key number, if set.
+	DerivationPath string `mapstructure:"derivation_path,omitempty"`
 }
 
 // UnmarshalMap imports the config map to AccountConfig.
@@ -52,6 +55,80 @@ func (af *AccountConfig) UnmarshalMap(raw map[string]interface{}) error {
 	return nil
 }
 
+// GetDerivationPath returns the parsed custom derivation path of the account or nil if the key
+// number should be used with the default derivation path of the algorithm.
+func (af *AccountConfig) GetDerivationPath() (sakg.BIP32Path, error) {
+	if af.DerivationPath == "" {
+		return nil, nil
+	}
+	return ParseDerivationPath(af.Algorithm, af.DerivationPath)
+}
+
+// ParseDerivationPath parses the given BIP-32 derivation path and validates that it can be used
+// with the given algorithm.
+//
+// ADR-8 algorithms (SLIP-10) only support hardened components under the m/44'/474' prefix, while
+// BIP-44 requires five components of which the first three are hardened.
+func ParseDerivationPath(algorithm, rawPath string) (sakg.BIP32Path, error) {
+	path, err := sakg.NewBIP32Path(rawPath)
+	if err != nil {
+		return nil, fmt.Errorf("malformed derivation path '%s': %w", rawPath, err)
+	}
+	isHardened := func(c uint32) bool {
+		return c >= sakg.HardenedKeysIndexStart
+	}
+
+	switch algorithm {
+	case AlgorithmEd25519Adr8, AlgorithmEd25519Legacy, AlgorithmSr25519Adr8:
+		if len(path) < 3 || path[0] != 44|sakg.HardenedKeysIndexStart || path[1] != 474|sakg.HardenedKeysIndexStart {
+			return nil, fmt.Errorf("derivation path '%s' for %s must start with %s and contain at least 3 components", rawPath, algorithm, sakg.BIP32PathPrefix)
+		}
+		for _, c := range path {
+			if !isHardened(c) {
+				return nil, fmt.Errorf("derivation path '%s' for %s must only contain hardened components", rawPath, algorithm)
+			}
+		}
+	case AlgorithmSecp256k1Bip44:
+		if len(path) != 5 || path[0] != 44|sakg.HardenedKeysIndexStart {
+			return nil, fmt.Errorf("derivation path '%s' for %s must have the form m/44'/<coin>'/<account>'/<change>/<index>", rawPath, algorithm)
+		}
+		for i, c := range path {
+			if isHardened(c) != (i < 3) {
+				return nil, fmt.Errorf("derivation path '%s' for %s must have exactly the first three components hardened", rawPath, algorithm)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("algorithm '%s' does not support derivation paths", algorithm)
+	}
+	return path, nil
+}
+
+// SubAccountDerivationPath returns the derivation path of the sub-account with the given number
+// of an account using the given custom derivation path. The last component of the path is replaced
+// by the number, keeping its hardened flag.
+func SubAccountDerivationPath(algorithm, rawPath string, number uint32) (string, error) {
+	path, err := ParseDerivationPath(algorithm, rawPath)
+	if err != nil {
+		return "", err
+	}
+	if number >= sakg.HardenedKeysIndexStart {
+		return "", fmt.Errorf("sub-account number %d too large", number)
+	}
+	last := len(path) - 1
+	path[last] = number | (path[last] & sakg.HardenedKeysIndexStart)
+
+	formatted := "m"
+	for _, c := range path {
+		switch {
+		case c >= sakg.HardenedKeysIndexStart:
+			formatted += fmt.Sprintf("/%d'", c-sakg.HardenedKeysIndexStart)
+		default:
+			formatted += fmt.Sprintf("/%d", c)
+		}
+	}
+	return formatted, nil
+}
+
 // Factory is a factory that supports accounts of a specific kind.
 type Factory interface {
 	// Kind returns the kind of accounts this factory will produce.
@@ -154,6 +231,26 @@ type Account interface {
 	UnsafeExport() (string, string)
 }
 
+// AddressVerifier is an account which can display its address on a hardware device for the user to
+// verify.
+type AddressVerifier interface {
+	Account
+
+	// VerifyAddress displays the account address on the device and waits for the user to confirm
+	// it. It fails if the user rejects the address or the device derives a different key.
+	VerifyAddress() error
+}
+
+// MultisigAccount is an account authenticated by signatures of multiple signers.
+//
+// It has no signer of its own, so transactions must be signed by the accounts of its signers.
+type MultisigAccount interface {
+	Account
+
+	// MultisigConfig returns the multisig configuration of the account.
+	MultisigConfig() *types.MultisigConfig
+}
+
 // Register registers a new account type.
 func Register(af Factory) {
 	if _, loaded := registeredFactories.LoadOrStore(af.Kind(), af); loaded {